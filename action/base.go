@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/serialize"
@@ -110,8 +111,8 @@ func ValidateFee(feeOpt *fees.FeeOption, fee Fee) error {
 	if fee.Price.Currency != feeOpt.FeeCurrency.Name {
 		return ErrInvalidFeeCurrency
 	}
-	minFee := feeOpt.MinFee()
-	if minFee.Amount.BigInt().Cmp(fee.Price.Value.BigInt()) > 0 {
+	baseFee := feeOpt.BaseFee()
+	if baseFee.Amount.BigInt().Cmp(fee.Price.Value.BigInt()) > 0 {
 		return ErrInvalidFeePrice
 	}
 	return nil
@@ -148,7 +149,7 @@ func StakingPayerFeeHandling(ctx *Context, feePayer keys.Address, signedTx Signe
 	if err != nil {
 		return false, Response{Log: errors.Wrap(err, "charge fee").Error()}
 	}
-	err = ctx.FeePool.AddToPool(charge)
+	err = distributeFee(ctx, charge, used)
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
@@ -172,7 +173,7 @@ func ContractFeeHandling(ctx *Context, signedTx SignedTx, gasUsed Gas, start Gas
 
 	charge := signedTx.Fee.Price.ToCoin(ctx.Currencies).MultiplyInt(int(gasUsed))
 
-	err := ctx.FeePool.AddToPool(charge)
+	err := distributeFee(ctx, charge, int64(gasUsed))
 	if err != nil {
 		return false, Response{Log: ErrInvalidVmExecution.Wrap(err).Marshal(), GasWanted: signedTx.Fee.Gas}
 	}
@@ -204,13 +205,60 @@ func BasicFeeHandling(ctx *Context, signedTx SignedTx, start Gas, size Gas, sign
 	if err != nil {
 		return false, Response{Log: errors.Wrap(err, "charge fee").Error()}
 	}
-	err = ctx.FeePool.AddToPool(charge)
+	err = distributeFee(ctx, charge, used)
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
 	return true, Response{GasWanted: signedTx.Fee.Gas, GasUsed: used}
 }
 
+// distributeFee pays out the fee charged for the gas used by a transaction. The base fee of the gas
+// goes to the fee pool, or is burned when governance says so, and the tip over it goes to the block
+// proposer. All of it goes to the fee pool while the base fee market is off
+func distributeFee(ctx *Context, charge balance.Coin, used int64) error {
+	opt := ctx.FeePool.GetOpt()
+	if !opt.BaseFeeMarket.Enabled() {
+		return ctx.FeePool.AddToPool(charge)
+	}
+
+	base := charge.Currency.NewCoinFromAmount(*opt.BaseFee().Amount).MultiplyInt64(used)
+	if charge.Amount.BigInt().Cmp(base.Amount.BigInt()) < 0 {
+		base.Amount = charge.Amount
+	}
+	tip, err := charge.Minus(base)
+	if err != nil {
+		return err
+	}
+
+	if !opt.BaseFeeMarket.Burn {
+		err = ctx.FeePool.AddToPool(base)
+		if err != nil {
+			return err
+		}
+	}
+	if tip.Amount.BigInt().Sign() == 0 {
+		return nil
+	}
+	proposer, ok := blockProposer(ctx)
+	if !ok {
+		return ctx.FeePool.AddToPool(tip)
+	}
+	return ctx.FeePool.AddToAddress(proposer, tip)
+}
+
+// blockProposer returns the stake address of the validator proposing the block
+func blockProposer(ctx *Context) (keys.Address, bool) {
+	if ctx.Validators == nil || ctx.Header == nil || len(ctx.Header.ProposerAddress) == 0 {
+		return nil, false
+	}
+	addr := ctx.Validators.ResolveConsensusAddress(keys.Address(ctx.Header.ProposerAddress))
+	validator, err := ctx.Validators.Get(addr)
+	if err != nil {
+		return nil, false
+	}
+	return validator.StakeAddress, true
+}
+
 func GetEvent(pairs kv.Pairs, eventType string) []types.Event {
 	var eventList []types.Event
 	event := types.Event{
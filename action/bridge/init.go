@@ -0,0 +1,33 @@
+package bridge
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(Pause), "bridge_pause")
+	serialize.RegisterConcrete(new(RotateWitness), "bridge_rotate_witness")
+}
+
+func EnableBridge(r action.Router) error {
+	err := r.AddHandler(action.BRIDGE_PAUSE, pauseTx{})
+	if err != nil {
+		return errors.Wrap(err, "pauseTx")
+	}
+	err = r.AddHandler(action.BRIDGE_ROTATE_WITNESS, rotateWitnessTx{})
+	if err != nil {
+		return errors.Wrap(err, "rotateWitnessTx")
+	}
+	return nil
+}
+
+func EnableInternalBridge(r action.Router) error {
+	err := r.AddHandler(action.BRIDGE_ROTATE_WITNESS, rotateWitnessTx{})
+	if err != nil {
+		return errors.Wrap(err, "rotateWitnessTx")
+	}
+	return nil
+}
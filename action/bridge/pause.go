@@ -0,0 +1,156 @@
+package bridge
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &Pause{}
+
+// Pause is the vote of a witness to trip the circuit breaker of the bridge of a chain,
+// validators vote for the bitcoin bridge
+type Pause struct {
+	Witness keys.Address
+	Chain   chain.Type
+	Reason  string
+}
+
+func (p Pause) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *Pause) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, p)
+}
+
+func (p Pause) Signers() []action.Address {
+	return []action.Address{p.Witness.Bytes()}
+}
+
+func (p Pause) Type() action.Type {
+	return action.BRIDGE_PAUSE
+}
+
+func (p Pause) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(p.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.witness"),
+		Value: p.Witness.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.chain"),
+		Value: []byte(p.Chain.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = pauseTx{}
+
+type pauseTx struct{}
+
+func (pauseTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	p := &Pause{}
+	err := p.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(tx.RawBytes(), p.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if err := p.Witness.Err(); err != nil {
+		return false, err
+	}
+
+	if p.Chain != chain.BITCOIN && p.Chain != chain.ETHEREUM && !p.Chain.IsEVM() {
+		return false, errors.Errorf("%s has no bridge", p.Chain)
+	}
+
+	return true, nil
+}
+
+func (pauseTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing 'bridge_pause' transaction for ProcessCheck", tx)
+	return runPause(ctx, tx)
+}
+
+func (pauseTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing 'bridge_pause' transaction for ProcessDeliver", tx)
+	return runPause(ctx, tx)
+}
+
+func (pauseTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	p := &Pause{}
+	err := p.Unmarshal(signedTx.Data)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "failed to unmarshal").Error()}
+	}
+	return action.StakingPayerFeeHandling(ctx, p.Witness, signedTx, start, size, 1)
+}
+
+func runPause(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	p := &Pause{}
+	err := p.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, p.Tags(), err)
+	}
+
+	voters, err := pauseVoters(ctx, p.Chain)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrNotPauseVoter, p.Tags(), err)
+	}
+	if !isVoter(voters, p.Witness) {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrNotPauseVoter, p.Tags(),
+			errors.Errorf("%s for %s", p.Witness.String(), p.Chain))
+	}
+
+	pause, err := ctx.Bridge.VotePause(p.Chain, p.Witness, voters, ctx.Header.Height, p.Reason)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrUnableToSetPause, p.Tags(), err)
+	}
+	if pause.Paused {
+		ctx.Logger.Info("Bridge paused", p.Chain, "at height", pause.Height, pause.Reason)
+	}
+
+	return helpers.LogAndReturnTrue(ctx.Logger, p.Tags(), "bridge_pause")
+}
+
+// pauseVoters returns who can vote to pause the bridge of a chain, the validators signing
+// the bitcoin trackers or the witnesses of an evm chain
+func pauseVoters(ctx *action.Context, c chain.Type) ([]keys.Address, error) {
+	if c == chain.BITCOIN {
+		return ctx.Validators.GetValidatorsAddress()
+	}
+	return ctx.Witnesses.GetWitnessAddresses(c)
+}
+
+func isVoter(voters []keys.Address, addr keys.Address) bool {
+	for _, v := range voters {
+		if v.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package bridge
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &RotateWitness{}
+
+// RotateWitness is the vote of a witness that the bridge contracts of a chain took the witness
+// set of the pending rotation, it is broadcast by the witness nodes once they see it on chain
+type RotateWitness struct {
+	Witness keys.Address
+	Chain   chain.Type
+	Epoch   int64
+	Round   int64
+}
+
+func (r RotateWitness) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *RotateWitness) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func (r RotateWitness) Signers() []action.Address {
+	return []action.Address{r.Witness.Bytes()}
+}
+
+func (r RotateWitness) Type() action.Type {
+	return action.BRIDGE_ROTATE_WITNESS
+}
+
+func (r RotateWitness) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(r.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.witness"),
+		Value: r.Witness.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.chain"),
+		Value: []byte(r.Chain.String()),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.epoch"),
+		Value: []byte(strconv.FormatInt(r.Epoch, 10)),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = rotateWitnessTx{}
+
+type rotateWitnessTx struct{}
+
+func (rotateWitnessTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	r := &RotateWitness{}
+	err := r.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(tx.RawBytes(), r.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Witness.Err(); err != nil {
+		return false, err
+	}
+
+	if r.Chain != chain.ETHEREUM && !r.Chain.IsEVM() {
+		return false, errors.Errorf("%s has no witnesses", r.Chain)
+	}
+	if r.Epoch <= 0 || r.Round < 0 {
+		return false, action.ErrMissingData
+	}
+
+	return true, nil
+}
+
+func (rotateWitnessTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing 'bridge_rotate_witness' transaction for ProcessCheck", tx)
+	return runRotateWitness(ctx, tx)
+}
+
+func (rotateWitnessTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing 'bridge_rotate_witness' transaction for ProcessDeliver", tx)
+	return runRotateWitness(ctx, tx)
+}
+
+// the votes are broadcast by the witness nodes themselves, as the finality reports
+func (rotateWitnessTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	ctx.State.ConsumeVerifySigGas(1)
+	ctx.State.ConsumeStorageGas(size)
+	return true, action.Response{}
+}
+
+func runRotateWitness(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	r := &RotateWitness{}
+	err := r.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, r.Tags(), err)
+	}
+
+	rotation, err := ctx.Witnesses.GetPendingRotation(r.Chain)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrUnableToRotate, r.Tags(), err)
+	}
+	if rotation == nil || rotation.Epoch != r.Epoch || rotation.Round != r.Round {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrUnableToRotate, r.Tags(),
+			errors.Errorf("no rotation to epoch %d round %d of %s pending", r.Epoch, r.Round, r.Chain))
+	}
+	if !rotation.IsSigner(r.Witness) {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrNotRotationVoter, r.Tags(),
+			errors.Errorf("%s for %s", r.Witness.String(), r.Chain))
+	}
+
+	rotation, err = ctx.Witnesses.VoteRotation(r.Chain, r.Witness, r.Epoch, r.Round, ctx.Header.Height)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrUnableToRotate, r.Tags(), err)
+	}
+	if !rotation.IsPending() {
+		ctx.Logger.Info("Witnesses of", r.Chain, "rotated to epoch", rotation.Epoch, "at height", rotation.Confirmed)
+	}
+
+	return helpers.LogAndReturnTrue(ctx.Logger, r.Tags(), "bridge_rotate_witness")
+}
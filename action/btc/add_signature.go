@@ -12,12 +12,12 @@ import (
 	"github.com/tendermint/tendermint/libs/kv"
 
 	"github.com/btcsuite/btcd/btcec"
-	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/action"
+	bitcoin2 "github.com/Oneledger/protocol/chains/bitcoin"
 	"github.com/Oneledger/protocol/data/bitcoin"
 )
 
@@ -176,7 +176,7 @@ func runAddSignature(ctx *action.Context, tx action.RawTx) (bool, action.Respons
 			return false, action.Response{Log: errors.Wrap(err, "cannot find lockscript").Error()}
 		}
 
-		hash, err := txscript.CalcSignatureHash(sc, txscript.SigHashAll, btcTx, 0)
+		hash, err := bitcoin2.LockSigHash(btcTx, sc, tracker.CurrentLockScriptAddress, tracker.CurrentBalance)
 		if err != nil {
 			return false, action.Response{Log: errors.Wrap(err, "failed to calc signature hash").Error()}
 		}
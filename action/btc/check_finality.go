@@ -13,6 +13,8 @@ import (
 	"github.com/Oneledger/protocol/action"
 	bitcoin2 "github.com/Oneledger/protocol/chains/bitcoin"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/pkg/errors"
@@ -207,12 +209,21 @@ func runReportFinalityMint(ctx *action.Context, tx action.RawTx) (bool, action.R
 		if err != nil {
 			return false, action.Response{Log: "tracker not ready for finalizing"}
 		}
+		err = recordHistory(ctx, tracker, bridgelib.EventVoteYes, f.ValidatorAddress)
+		if err != nil {
+			return false, action.Response{Log: "failed to record tracker history"}
+		}
 
 		return true, action.Response{
 			Events: action.GetEvent(f.Tags(), "btc_check_finality_pending"),
 		}
 	}
 
+	err = ctx.Bridge.Settle(chain.BITCOIN, tracker.Name)
+	if err != nil {
+		return false, action.Response{Log: "failed to settle bridge flow err:" + err.Error()}
+	}
+
 	// if type is lock, then mint the oBTC
 	if tracker.ProcessType == bitcoin.ProcessTypeLock {
 
@@ -240,13 +251,25 @@ func runReportFinalityMint(ctx *action.Context, tx action.RawTx) (bool, action.R
 		ctx.Logger.Info("btc coin minted to ", f.OwnerAddress)
 	}
 
+	processType := "lock"
+	switch tracker.ProcessType {
+	case bitcoin.ProcessTypeRedeem:
+		processType = "redeem"
+	case bitcoin.ProcessTypeMigrate:
+		processType = "migrate"
+	}
+
 	// set the tracker to the new state
 
 	opt := ctx.BTCTrackers.GetConfig()
 	validatorPubKeys, err := ctx.Validators.GetBitcoinKeys(opt.BTCParams)
 	m := (len(validatorPubKeys) * 2 / 3) + 1
 
-	lockScript, lockScriptAddress, addressList, err := bitcoin2.CreateMultiSigAddress(m, validatorPubKeys,
+	createMultiSigAddress := bitcoin2.CreateMultiSigAddress
+	if ctx.BTCTrackers.GetOption().SegWit {
+		createMultiSigAddress = bitcoin2.CreateWitnessMultiSigAddress
+	}
+	lockScript, lockScriptAddress, addressList, err := createMultiSigAddress(m, validatorPubKeys,
 		f.RandomBytes, opt.BTCParams)
 
 	// do final reset changes
@@ -284,10 +307,9 @@ func runReportFinalityMint(ctx *action.Context, tx action.RawTx) (bool, action.R
 	if err != nil {
 		return false, action.Response{Log: "error resetting tracker, try again"}
 	}
-
-	processType := "lock"
-	if tracker.ProcessType == bitcoin.ProcessTypeRedeem {
-		processType = "redeem"
+	err = recordHistory(ctx, tracker, bridgelib.EventVoteYes, f.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: "failed to record tracker history"}
 	}
 
 	return true, action.Response{
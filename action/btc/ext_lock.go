@@ -18,6 +18,7 @@ import (
 	"github.com/Oneledger/protocol/action"
 	bitcoin2 "github.com/Oneledger/protocol/chains/bitcoin"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 )
 
 type Lock struct {
@@ -126,8 +127,13 @@ func (btcLockTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 		return false, errors.New("err in ext lock txn")
 	}
 
+	backend, err := opt.Backend()
+	if err != nil {
+		return false, errors.Wrap(err, "err getting bitcoin backend")
+	}
+
 	isFirstLock := tracker.CurrentTxId == nil
-	if !bitcoin2.ValidateLock(tx, opt.BlockCypherToken, opt.BlockCypherChainType, tracker.ProcessLockScriptAddress,
+	if !bitcoin2.ValidateLock(tx, backend, tracker.ProcessLockScriptAddress,
 		tracker.CurrentBalance, lock.LockAmount, isFirstLock) {
 
 		return false, errors.New("txn doesn't match tracker")
@@ -204,6 +210,10 @@ func runBTCLock(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 	if !balCoin.Plus(lockCoin).LessThanEqualCoin(totalSupplyCoin) {
 		return false, action.Response{Log: fmt.Sprintf("btc lock exceeded limit", lock.TrackerName)}
 	}
+	err = admit(ctx, tracker, bridgelib.Inflow, lock.LockAmount)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "bridge rejected lock").Error()}
+	}
 
 	tracker.ProcessType = bitcoin.ProcessTypeLock
 	tracker.ProcessOwner = lock.Locker
@@ -217,6 +227,10 @@ func runBTCLock(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 	if err != nil {
 		return false, action.Response{Log: "failed to update tracker"}
 	}
+	err = openHistory(ctx, tracker)
+	if err != nil {
+		return false, action.Response{Log: "failed to open tracker history err:" + err.Error()}
+	}
 
 	return true, action.Response{
 		Events: action.GetEvent(lock.Tags(), "btc_lock"),
@@ -17,6 +17,7 @@ import (
 	"github.com/Oneledger/protocol/action"
 	bitcoin2 "github.com/Oneledger/protocol/chains/bitcoin"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 )
 
 type Redeem struct {
@@ -121,7 +122,11 @@ func (btcRedeemTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool
 	}
 
 	opt := ctx.BTCTrackers.GetConfig()
-	if !bitcoin2.ValidateRedeem(tx, opt.BlockCypherToken, opt.BlockCypherChainType, tracker.CurrentTxId,
+	backend, err := opt.Backend()
+	if err != nil {
+		return false, errors.Wrap(err, "err getting bitcoin backend")
+	}
+	if !bitcoin2.ValidateRedeem(tx, backend, tracker.CurrentTxId,
 		tracker.ProcessLockScriptAddress, tracker.CurrentBalance, redeem.RedeemAmount) {
 
 		return false, errors.New("txn doesn't match tracker")
@@ -185,6 +190,11 @@ func runExtRedeem(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		return false, action.Response{Log: fmt.Sprintf("err incorrect btc lock address ", redeem.TrackerName)}
 	}
 
+	err = admit(ctx, tracker, bridgelib.Outflow, redeem.RedeemAmount)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "bridge rejected redeem").Error()}
+	}
+
 	tracker.ProcessType = bitcoin.ProcessTypeRedeem
 	tracker.ProcessOwner = redeem.Redeemer
 
@@ -203,6 +213,10 @@ func runExtRedeem(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 	if err != nil {
 		return false, action.Response{Log: "failed to update tracker err:" + err.Error()}
 	}
+	err = openHistory(ctx, tracker)
+	if err != nil {
+		return false, action.Response{Log: "failed to open tracker history err:" + err.Error()}
+	}
 
 	btcCurr, ok := ctx.Currencies.GetCurrencyByName("BTC")
 	if !ok {
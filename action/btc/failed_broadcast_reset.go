@@ -13,6 +13,9 @@ import (
 
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/pkg/errors"
 )
@@ -174,12 +177,22 @@ func runBroadcastFailureReset(ctx *action.Context, tx action.RawTx) (bool, actio
 		if err != nil {
 			return false, action.Response{Log: "failed to save tracker"}
 		}
+		err = recordHistory(ctx, tracker, bridgelib.EventVoteNo, fbr.ValidatorAddress)
+		if err != nil {
+			return false, action.Response{Log: "failed to record tracker history"}
+		}
 
 		return true, action.Response{
 			Events: action.GetEvent(fbr.Tags(), "btc_broadcast_reset_pending"),
 		}
 	}
 
+	// the lock or redeem never went through, its flow is given back to the bridge limits
+	err = ctx.Bridge.Release(chain.BITCOIN, tracker.Name)
+	if err != nil {
+		return false, action.Response{Log: "failed to release bridge flow err:" + err.Error()}
+	}
+
 	// if the process is redeem return the user oBTC
 	if tracker.ProcessType == bitcoin.ProcessTypeRedeem {
 		amount := tracker.CurrentBalance - tracker.ProcessBalance
@@ -202,6 +215,26 @@ func runBroadcastFailureReset(ctx *action.Context, tx action.RawTx) (bool, actio
 		}
 	}
 
+	// if the process is a migration sweep give the burnt fee back to the fee pool
+	if tracker.ProcessType == bitcoin.ProcessTypeMigrate {
+		btcCurr, ok := ctx.Currencies.GetCurrencyByName("BTC")
+		if !ok {
+			return false, action.Response{Log: "failed to find currency BTC"}
+		}
+		coin := btcCurr.NewCoinFromUnit(tracker.CurrentBalance - tracker.ProcessBalance)
+
+		err = ctx.Balances.AddToAddress(keys.Address(fees.POOL_KEY), coin)
+		if err != nil {
+			return false, action.Response{Log: "failed to add currency err:" + err.Error()}
+		}
+
+		tally := action.Address(ctx.BTCTrackers.GetOption().TotalSupplyAddr)
+		err = ctx.Balances.AddToAddress(tally, coin)
+		if err != nil {
+			return false, action.Response{Log: "failed to add currency err:" + err.Error()}
+		}
+	}
+
 	tracker.Multisig.Msg = nil
 	tracker.Multisig.Signatures = []keys.BTCSignature{}
 
@@ -220,6 +253,10 @@ func runBroadcastFailureReset(ctx *action.Context, tx action.RawTx) (bool, actio
 	if err != nil {
 		return false, action.Response{Log: "failed to save tracker"}
 	}
+	err = recordHistory(ctx, tracker, bridgelib.EventReset, fbr.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: "failed to record tracker history"}
+	}
 
 	return true, action.Response{Events: action.GetEvent(fbr.Tags(), "btc_broadcast_reset_complete")}
 }
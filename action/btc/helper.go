@@ -6,13 +6,59 @@ package btc
 
 import (
 	"bytes"
+	"math/big"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/pkg/errors"
 
+	"github.com/Oneledger/protocol/action"
+	bitcoin2 "github.com/Oneledger/protocol/chains/bitcoin"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
 )
 
+// admit lets a new lock or redeem of satoshis through the circuit breaker and the flow limits
+// of the bitcoin bridge, the amount counts against them until the tracker is reset
+func admit(ctx *action.Context, tracker *bitcoin.Tracker, dir bridgelib.Direction, amount int64) error {
+	opt, err := ctx.GovernanceStore.GetBridgeOptions()
+	if err != nil {
+		return errors.Wrap(err, "unable to get bridge options")
+	}
+	return ctx.Bridge.Admit(opt, chain.BITCOIN, tracker.Name, "BTC", dir, ctx.Header.Height, big.NewInt(amount))
+}
+
+// processTypeName names the process a tracker is busy with in its history
+func processTypeName(processType int) string {
+	switch processType {
+	case bitcoin.ProcessTypeLock:
+		return "lock"
+	case bitcoin.ProcessTypeRedeem:
+		return "redeem"
+	case bitcoin.ProcessTypeMigrate:
+		return "migrate"
+	}
+	return "none"
+}
+
+// openHistory records a new lock or redeem taken by a tracker in its history
+func openHistory(ctx *action.Context, tracker *bitcoin.Tracker) error {
+	return ctx.Bridge.OpenHistory(chain.BITCOIN, tracker.Name, processTypeName(tracker.ProcessType),
+		tracker.ProcessOwner, tracker.State.String(), ctx.Header.Height)
+}
+
+// recordHistory adds a step taken by a validator to the history of a tracker
+func recordHistory(ctx *action.Context, tracker *bitcoin.Tracker, event string, validator keys.Address) error {
+	return ctx.Bridge.RecordHistory(chain.BITCOIN, tracker.Name, bridgelib.HistoryEntry{
+		Height:  ctx.Header.Height,
+		Event:   event,
+		State:   tracker.State.String(),
+		Witness: validator,
+	})
+}
+
 func ValidateExtLockStructure(tracker *bitcoin.Tracker, tx *wire.MsgTx, params *chaincfg.Params) bool {
 
 	// Validate outputs
@@ -55,7 +101,7 @@ func ValidateExtLockStructure(tracker *bitcoin.Tracker, tx *wire.MsgTx, params *
 				continue
 			}
 
-			if len(tx.TxIn[i].SignatureScript) == 0 {
+			if !bitcoin2.InputSigned(tx.TxIn[i]) {
 				return false
 			}
 		}
@@ -69,7 +115,7 @@ func ValidateExtLockStructure(tracker *bitcoin.Tracker, tx *wire.MsgTx, params *
 
 		// all user inputs must be signed
 		for i := range tx.TxIn {
-			if len(tx.TxIn[i].SignatureScript) == 0 {
+			if !bitcoin2.InputSigned(tx.TxIn[i]) {
 				return false
 			}
 		}
@@ -10,6 +10,7 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/evidence"
@@ -40,6 +41,8 @@ type Context struct {
 	Witnesses           *identity.WitnessStore
 	BTCTrackers         *bitcoin.TrackerStore
 	ETHTrackers         *ethereum.TrackerStore
+	NFTs                *ethereum.NFTStore
+	Bridge              *bridge.Store
 	Logger              *log.Logger
 	JobStore            *jobs.JobStore
 	LockScriptStore     *bitcoin.LockScriptStore
@@ -58,7 +61,7 @@ func NewContext(r Router, header *abci.Header, state *storage.State,
 	currencies *balance.CurrencySet, feePool *fees.Store,
 	validators *identity.ValidatorStore, witnesses *identity.WitnessStore,
 	domains *ons.DomainStore, delegators *delegation.DelegationStore, netwkDelegators *netwkDeleg.MasterStore, evidenceStore *evidence.EvidenceStore,
-	btcTrackers *bitcoin.TrackerStore, ethTrackers *ethereum.TrackerStore, jobStore *jobs.JobStore,
+	btcTrackers *bitcoin.TrackerStore, ethTrackers *ethereum.TrackerStore, nfts *ethereum.NFTStore, bridgeStore *bridge.Store, jobStore *jobs.JobStore,
 	lockScriptStore *bitcoin.LockScriptStore, logger *log.Logger, proposalmaster *governance.ProposalMasterStore,
 	rewardmaster *rewards.RewardMasterStore, govern *governance.Store, extStores data.Router, govUpdate *GovernaceUpdateAndValidate,
 	stateDB *vm.CommitStateDB,
@@ -79,6 +82,8 @@ func NewContext(r Router, header *abci.Header, state *storage.State,
 		Witnesses:           witnesses,
 		BTCTrackers:         btcTrackers,
 		ETHTrackers:         ethTrackers,
+		NFTs:                nfts,
+		Bridge:              bridgeStore,
 		Logger:              logger,
 		JobStore:            jobStore,
 		LockScriptStore:     lockScriptStore,
@@ -31,4 +31,6 @@ var (
 	ErrInvalidValidatorAddr = codes.ProtocolError{codes.GovErrInvalidValidatorAddr, "invalid validator address"}
 	ErrStakeAddressInUse    = codes.ProtocolError{codes.DelgErrStakeAddressInUse, "current stake address is in use"}
 	ErrStakeAddressMismatch = codes.ProtocolError{codes.DelgErrStakeAddressMismatch, "stake address does not match"}
+	ErrInvalidKeyRotation   = codes.ProtocolError{codes.DelgErrInvalidKeyRotation, "invalid key rotation"}
+	ErrInvalidProfile       = codes.ProtocolError{codes.DelgErrInvalidProfile, "invalid validator profile"}
 )
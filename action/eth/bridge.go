@@ -0,0 +1,85 @@
+package eth
+
+import (
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	ethchaindriver "github.com/Oneledger/protocol/chains/ethereum"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	trackerlib "github.com/Oneledger/protocol/data/ethereum"
+	gov "github.com/Oneledger/protocol/data/governance"
+)
+
+// evmBridge is what the bridge transactions need to know of the evm chain they target
+type evmBridge struct {
+	ChainID   int64
+	ChainType chain.Type
+	Option    *ethchaindriver.ChainDriverOption
+	Currency  string
+	Trackers  *trackerlib.TrackerStore
+}
+
+// getBridge resolves an evm chain of the registry, chain id 0 is the default ethereum chain
+func getBridge(ctx *action.Context, chainID int64) (*evmBridge, error) {
+	if chainID == 0 {
+		opt, err := ctx.GovernanceStore.GetETHChainDriverOption()
+		if err != nil {
+			return nil, gov.ErrGetEthOptions
+		}
+		return &evmBridge{
+			ChainType: chain.ETHEREUM,
+			Option:    opt,
+			Currency:  "ETH",
+			Trackers:  ctx.ETHTrackers,
+		}, nil
+	}
+
+	registry, err := ctx.GovernanceStore.GetEVMChainRegistry()
+	if err != nil {
+		return nil, errors.Wrap(gov.ErrGetEthOptions, err.Error())
+	}
+	c, ok := registry.Get(chainID)
+	if !ok {
+		return nil, errors.Errorf("evm chain %d is not in the bridge registry", chainID)
+	}
+	return &evmBridge{
+		ChainID:   chainID,
+		ChainType: chain.EVMType(chainID),
+		Option:    &c.Option,
+		Currency:  c.Currency,
+		Trackers:  ctx.ETHTrackers.WithChain(chainID),
+	}, nil
+}
+
+// checkChainID makes sure a transaction for a registry chain is signed for it,
+// so the same transaction can't be replayed on the bridge of another chain
+func (b *evmBridge) checkChainID(ethTx *types.Transaction) error {
+	if b.ChainID == 0 {
+		return nil
+	}
+	if !ethTx.Protected() || ethTx.ChainId().Int64() != b.ChainID {
+		return errors.Errorf("transaction is not signed for evm chain %d", b.ChainID)
+	}
+	return nil
+}
+
+// openHistory starts the history of a new tracker of the bridge
+func (b *evmBridge) openHistory(ctx *action.Context, tracker *trackerlib.Tracker) error {
+	return ctx.Bridge.OpenHistory(b.ChainType, tracker.TrackerName.Hex(), tracker.Type.String(),
+		tracker.ProcessOwner, tracker.State.String(), ctx.Header.Height)
+}
+
+// admit lets a new lock or redeem of an asset through the circuit breaker and the flow limits
+// of the bridge, the amount counts against the limits of the asset until the tracker fails
+func (b *evmBridge) admit(ctx *action.Context, name ethcommon.Hash, asset string, dir bridgelib.Direction, amount *big.Int) error {
+	opt, err := ctx.GovernanceStore.GetBridgeOptions()
+	if err != nil {
+		return errors.Wrap(err, "unable to get bridge options")
+	}
+	return ctx.Bridge.Admit(opt, b.ChainType, name.Hex(), asset, dir, ctx.Header.Height, amount)
+}
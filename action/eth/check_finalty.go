@@ -12,8 +12,8 @@ import (
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/data/balance"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 	trackerlib "github.com/Oneledger/protocol/data/ethereum"
-	gov "github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
 )
 
@@ -23,6 +23,10 @@ type ReportFinality struct {
 	ValidatorAddress action.Address
 	VoteIndex        int64
 	Success          bool
+	// ChainID is the evm chain of the registry the tracker is for, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
+	// Reason tells why the witness voted no, it is kept in the history of the tracker
+	Reason string `json:"Reason,omitempty"`
 }
 
 var _ action.Msg = &ReportFinality{}
@@ -113,7 +117,11 @@ func runCheckFinality(ctx *action.Context, tx action.RawTx) (bool, action.Respon
 		return false, action.Response{Log: "wrong tx type"}
 	}
 
-	tracker, err := ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Get(f.TrackerName)
+	bridge, err := getBridge(ctx, f.ChainID)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "err getting bridge").Error()}
+	}
+	tracker, err := bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Get(f.TrackerName)
 	if err != nil {
 
 		return false, action.Response{Log: errors.Wrap(err, "err getting tracker").Error()}
@@ -129,6 +137,7 @@ func runCheckFinality(ctx *action.Context, tx action.RawTx) (bool, action.Respon
 		return true, action.Response{Log: "Tracker already Failed"}
 	}
 	//Add validator Vote
+	event := bridgelib.EventVoteYes
 	if f.Success == true {
 		err = tracker.AddVote(f.ValidatorAddress, f.VoteIndex, true)
 		if err != nil {
@@ -139,61 +148,102 @@ func runCheckFinality(ctx *action.Context, tx action.RawTx) (bool, action.Respon
 		if err != nil {
 			return false, action.Response{Log: errors.Wrap(err, "failed to add vote").Error()}
 		}
+		event = bridgelib.EventVoteNo
+	}
+	err = ctx.Bridge.RecordHistory(bridge.ChainType, tracker.TrackerName.Hex(), bridgelib.HistoryEntry{
+		Height:  ctx.Header.Height,
+		Event:   event,
+		State:   tracker.State.String(),
+		Witness: keys.Address(f.ValidatorAddress),
+		Reason:  f.Reason,
+	})
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "failed to record vote").Error()}
 	}
 	//Handle when tracker has 67% Yes votes
 	if tracker.Finalized() {
+		err = ctx.Bridge.Settle(bridge.ChainType, tracker.TrackerName.Hex())
+		if err != nil {
+			return false, action.Response{Log: errors.Wrap(err, "unable to settle bridge flow").Error()}
+		}
 
 		if tracker.Type == trackerlib.ProcessTypeLock {
-			err := mintTokens(ctx, tracker, *f)
+			err := mintTokens(ctx, bridge, tracker, *f)
 			if err != nil {
 				return false, action.Response{Log: errors.Wrap(err, "unable to mint tokens").Error()}
 			}
 			return true, action.Response{Log: "Lock Operation successful"}
 		}
 		if tracker.Type == trackerlib.ProcessTypeRedeem {
-			err := burnTokens(ctx, tracker, *f)
+			err := burnTokens(ctx, bridge, tracker, *f)
 			if err != nil {
 				return false, action.Response{Log: errors.Wrap(err, "unable to burn tokens").Error()}
 			}
 			return true, action.Response{Log: "Redeem Operation successful"}
 		}
 		if tracker.Type == trackerlib.ProcessTypeLockERC {
-			err := mintERC20tokens(ctx, tracker, *f)
+			err := mintERC20tokens(ctx, bridge, tracker, *f)
 			if err != nil {
 				return false, action.Response{Log: errors.Wrap(err, "unable to mint tokens").Error()}
 			}
 			return true, action.Response{Log: "Lock ERC Operation successful"}
 		}
 		if tracker.Type == trackerlib.ProcessTypeRedeemERC {
-			err := burnERC20Tokens(ctx, tracker, *f)
+			err := burnERC20Tokens(ctx, bridge, tracker, *f)
 			if err != nil {
 				return false, action.Response{Log: errors.Wrap(err, "unable to burn tokens").Error()}
 			}
 			return true, action.Response{Log: "Redeem ERC Operation successful"}
 		}
+		if tracker.Type == trackerlib.ProcessTypeLockNFT {
+			err := mintNFT(ctx, bridge, tracker, *f)
+			if err != nil {
+				return false, action.Response{Log: errors.Wrap(err, "unable to mint nft").Error()}
+			}
+			return true, action.Response{Log: "Lock NFT Operation successful"}
+		}
+		if tracker.Type == trackerlib.ProcessTypeRedeemNFT {
+			err := burnNFT(ctx, bridge, tracker, *f)
+			if err != nil {
+				return false, action.Response{Log: errors.Wrap(err, "unable to burn nft").Error()}
+			}
+			return true, action.Response{Log: "Redeem NFT Operation successful"}
+		}
 		return true, action.Response{Log: "Tracker has enough votes to be Finalized , Tracker Type Unknown"}
 	}
 
 	//Handle when tracker has 67% No votes
 	if tracker.Failed() {
-		if tracker.Type == trackerlib.ProcessTypeLock {
-			err := failedLock(ctx, tracker, *f)
+		// nothing moved through the bridge, the flow it was admitted with is given back
+		err = ctx.Bridge.Release(bridge.ChainType, tracker.TrackerName.Hex())
+		if err != nil {
+			return false, action.Response{Log: errors.Wrap(err, "unable to release bridge flow").Error()}
+		}
+		if tracker.Type == trackerlib.ProcessTypeLock || tracker.Type == trackerlib.ProcessTypeLockNFT {
+			err := failedLock(ctx, bridge, tracker, *f)
 			if err != nil {
 				return false, action.Response{Log: errors.Wrap(err, "unable to finalize lock TX").Error()}
 			}
 			return true, action.Response{Log: "Lock Tracker Failed"}
 		}
 		if tracker.Type == trackerlib.ProcessTypeRedeem {
-			err := refundTokens(ctx, tracker, *f)
+			err := refundTokens(ctx, bridge, tracker, *f)
 			if err != nil {
 				return false, action.Response{Log: errors.Wrap(err, "unable to refund tokens").Error()}
 			}
 			return true, action.Response{Log: "Redeem Tracker Failed"}
 		}
+		if tracker.Type == trackerlib.ProcessTypeRedeemNFT {
+			err := refundNFT(ctx, bridge, tracker, *f)
+			if err != nil {
+				return false, action.Response{Log: errors.Wrap(err, "unable to refund nft").Error()}
+			}
+			return true, action.Response{Log: "Redeem NFT Tracker Failed"}
+		}
 		return true, action.Response{Log: "Tracker has enough votes to be Failed , Tracker Type Unknown"}
 	}
 
-	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err = bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		ctx.Logger.Error("Unable to save the tracker", err)
 		return false, action.Response{Log: errors.Wrap(err, "unable to save the tracker").Error()}
@@ -215,10 +265,10 @@ func (reportFinalityMintTx) ProcessFee(ctx *action.Context, signedTx action.Sign
 }
 
 // Set Lock Tracker to failed
-func failedLock(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+func failedLock(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
 	ctx.Logger.Info("Failing Tracker  | Process Type : ", tracker.Type.String())
 	tracker.State = trackerlib.Failed
-	err := ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err := bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		return errors.Wrap(err, "unable to Fail tracker")
 	}
@@ -226,21 +276,18 @@ func failedLock(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFi
 }
 
 //Process oeth Refund if Validators could not sign
-func refundTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+func refundTokens(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
 	ctx.Logger.Info("Failing Tracker  [ OETH Refund ]| Process Type : ", tracker.Type.String())
 	tracker.State = trackerlib.Failed
-	err := ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err := bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		return errors.Wrap(err, "unable to Fail tracker")
 	}
-	c, ok := ctx.Currencies.GetCurrencyByName("ETH")
+	c, ok := ctx.Currencies.GetCurrencyByName(bridge.Currency)
 	if !ok {
-		return errors.New("ETH not registered")
-	}
-	ethOpt, err := ctx.GovernanceStore.GetETHChainDriverOption()
-	if err != nil {
-		return gov.ErrGetEthOptions
+		return errors.Errorf("%s not registered", bridge.Currency)
 	}
+	ethOpt := bridge.Option
 	req, err := ethereum.ParseRedeem(tracker.SignedETHTx, ethOpt.ContractABI)
 	oEthRefundCoin := c.NewCoinFromAmount(*balance.NewAmountFromBigInt(req.Amount))
 	if err != nil {
@@ -260,20 +307,17 @@ func refundTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx Report
 }
 
 // Mint oeth After Ether lock is confirmed
-func mintTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+func mintTokens(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
 	ctx.Logger.Info("Finalizing Tracker [ Minting Ether ]  | Process Type : ", tracker.Type.String())
-	curr, ok := ctx.Currencies.GetCurrencyByName("ETH")
+	curr, ok := ctx.Currencies.GetCurrencyByName(bridge.Currency)
 	if !ok {
-		return errors.New("ETH currency not allowed")
+		return errors.Errorf("%s currency not allowed", bridge.Currency)
 	}
 	lockAmount, err := ethereum.ParseLock(tracker.SignedETHTx)
 	if err != nil {
 		return err
 	}
-	ethOpt, err := ctx.GovernanceStore.GetETHChainDriverOption()
-	if err != nil {
-		return gov.ErrGetEthOptions
-	}
+	ethOpt := bridge.Option
 	oEthCoin := curr.NewCoinFromAmount(*balance.NewAmountFromBigInt(lockAmount.Amount))
 	err = ctx.Balances.AddToAddress(oltTx.Locker, oEthCoin)
 	if err != nil {
@@ -288,7 +332,7 @@ func mintTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFi
 	}
 
 	tracker.State = trackerlib.Released
-	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err = bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		return err
 	}
@@ -296,11 +340,11 @@ func mintTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFi
 }
 
 // Save tracker Oeth burn already done
-func burnTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+func burnTokens(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
 	ctx.Logger.Info("Finalizing Tracker [ Burning Ether ]  | Process Type : ", tracker.Type.String())
 
 	tracker.State = trackerlib.Released
-	err := ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err := bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		return err
 	}
@@ -308,16 +352,13 @@ func burnTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFi
 	return nil
 }
 
-func burnERC20Tokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+func burnERC20Tokens(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
 	ethTx, err := ethereum.DecodeTransaction(tracker.SignedETHTx)
 	if err != nil {
 		return err
 	}
 
-	ethOpt, err := ctx.GovernanceStore.GetETHChainDriverOption()
-	if err != nil {
-		return gov.ErrGetEthOptions
-	}
+	ethOpt := bridge.Option
 	token, err := ethereum.GetToken(ethOpt.TokenList, *ethTx.To())
 	if err != nil {
 		return err
@@ -326,7 +367,7 @@ func burnERC20Tokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx Rep
 	ctx.Logger.Info("Finalizing Tracker [ Burning Tokens :", token.TokName, "]  | Process Type : ", tracker.Type.String())
 	tracker.State = trackerlib.Released
 
-	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err = bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		return err
 	}
@@ -334,17 +375,14 @@ func burnERC20Tokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx Rep
 	return nil
 }
 
-func mintERC20tokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+func mintERC20tokens(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
 
 	ethTx, err := ethereum.DecodeTransaction(tracker.SignedETHTx)
 	if err != nil {
 		return err
 	}
 
-	ethOpt, err := ctx.GovernanceStore.GetETHChainDriverOption()
-	if err != nil {
-		return gov.ErrGetEthOptions
-	}
+	ethOpt := bridge.Option
 	token, err := ethereum.GetToken(ethOpt.TokenList, *ethTx.To())
 	if err != nil {
 		return err
@@ -374,9 +412,70 @@ func mintERC20tokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx Rep
 	}
 
 	tracker.State = trackerlib.Released
-	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err = bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// Create the OneLedger record of an ERC721 token after its lock is confirmed
+func mintNFT(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+	ctx.Logger.Info("Finalizing Tracker [ Minting NFT ]  | Process Type : ", tracker.Type.String())
+	req, err := ethereum.ParseERC721Lock(tracker.SignedETHTx, bridge.Option.ERC721ContractABI)
+	if err != nil {
+		return err
+	}
+	if ctx.NFTs.Exists(tracker.ChainID, req.Token, req.TokenID.String()) {
+		return trackerlib.ErrNFTExists
+	}
+	err = ctx.NFTs.Set(&trackerlib.NFT{
+		ChainID:  tracker.ChainID,
+		Contract: req.Token,
+		TokenID:  req.TokenID.String(),
+		TokenURI: tracker.TokenURI,
+		Owner:    tracker.ProcessOwner,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Unable to mint nft")
+	}
+
+	tracker.State = trackerlib.Released
+	return bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+}
+
+// Remove the record of an ERC721 token once it is released on the evm chain
+func burnNFT(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+	ctx.Logger.Info("Finalizing Tracker [ Burning NFT ]  | Process Type : ", tracker.Type.String())
+	req, err := ethereum.ParseERC721Redeem(tracker.SignedETHTx, bridge.Option.ERC721ContractABI)
+	if err != nil {
+		return err
+	}
+	_, err = ctx.NFTs.Delete(tracker.ChainID, req.Token, req.TokenID.String())
+	if err != nil {
+		return errors.Wrap(err, "Unable to burn nft")
+	}
+
+	tracker.State = trackerlib.Released
+	return bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+}
+
+// Hand the record of an ERC721 token back to its owner if Validators could not sign the redeem
+func refundNFT(ctx *action.Context, bridge *evmBridge, tracker *trackerlib.Tracker, oltTx ReportFinality) error {
+	ctx.Logger.Info("Failing Tracker  [ NFT Refund ]| Process Type : ", tracker.Type.String())
+	tracker.State = trackerlib.Failed
+	err := bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	if err != nil {
+		return errors.Wrap(err, "unable to Fail tracker")
+	}
+	req, err := ethereum.ParseERC721Redeem(tracker.SignedETHTx, bridge.Option.ERC721ContractABI)
+	if err != nil {
+		return errors.Wrap(action.ErrInvalidExtTx, err.Error())
+	}
+	nft, err := ctx.NFTs.Get(tracker.ChainID, req.Token, req.TokenID.String())
+	if err != nil {
+		return err
+	}
+	nft.Redeeming = false
+	return ctx.NFTs.Set(nft)
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/action/helpers"
 	ethchaindriver "github.com/Oneledger/protocol/chains/ethereum"
-	"github.com/Oneledger/protocol/data/chain"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/ethereum"
 	gov "github.com/Oneledger/protocol/data/governance"
 
@@ -22,6 +22,8 @@ import (
 type ERC20Lock struct {
 	Locker action.Address
 	ETHTxn []byte // Raw Transaction for Locking Tokens
+	// ChainID selects an evm chain of the bridge registry, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
 }
 
 var _ action.Msg = &ERC20Lock{}
@@ -135,10 +137,15 @@ func runERC20Lock(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		}
 	}
 
-	ethOptions, err := ctx.GovernanceStore.GetETHChainDriverOption()
+	bridge, err := getBridge(ctx, erc20lock.ChainID)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, gov.ErrGetEthOptions, erc20lock.Tags(), err)
 	}
+	err = bridge.checkChainID(ethTx)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc20lock.Tags(), err)
+	}
+	ethOptions := bridge.Option
 	token, err := ethchaindriver.GetToken(ethOptions.TokenList, *ethTx.To())
 	if err != nil {
 		return false, action.Response{
@@ -161,7 +168,7 @@ func runERC20Lock(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		}
 	}
 
-	witnesses, err := ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
 	if err != nil {
 		ctx.Logger.Error("err in getting witness address", err)
 		return false, action.Response{Log: "error in getting validator addresses" + err.Error()}
@@ -192,6 +199,10 @@ func runERC20Lock(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 	if !balCoin.Plus(lockToken).LessThanEqualCoin(totalSupplyToken) {
 		return false, action.Response{Log: fmt.Sprintf("Token lock exceeded limit ,for Token : %s ", token.TokName)}
 	}
+	err = bridge.admit(ctx, ethcommon.BytesToHash(erc20lock.ETHTxn), token.TokName, bridgelib.Inflow, erc20Params.TokenAmount)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "bridge rejected lock").Error()}
+	}
 
 	tracker := ethereum.NewTracker(
 		ethereum.ProcessTypeLockERC,
@@ -200,12 +211,17 @@ func runERC20Lock(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		ethcommon.BytesToHash(erc20lock.ETHTxn),
 		witnesses,
 	)
+	tracker.ChainID = erc20lock.ChainID
 
-	err = ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Set(tracker)
+	err = bridge.Trackers.WithPrefixType(ethereum.PrefixOngoing).Set(tracker)
 	if err != nil {
 		ctx.Logger.Error("error saving eth tracker", err)
 		return false, action.Response{Log: "error saving eth tracker: " + err.Error()}
 	}
+	err = bridge.openHistory(ctx, tracker)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "error opening tracker history").Error()}
+	}
 
 	return true, action.Response{
 		Events: action.GetEvent(erc20lock.Tags(), "erc20_lock"),
@@ -14,7 +14,7 @@ import (
 	"github.com/Oneledger/protocol/action/helpers"
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/data/balance"
-	"github.com/Oneledger/protocol/data/chain"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 	trackerlib "github.com/Oneledger/protocol/data/ethereum"
 	gov "github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
@@ -27,6 +27,8 @@ type ERC20Redeem struct {
 	Owner  action.Address    //User Oneledger address
 	To     ethcommon.Address //User Ethereum address
 	ETHTxn []byte
+	// ChainID selects an evm chain of the bridge registry, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
 }
 
 //Signers return the Address of the owner who created the transaction
@@ -126,10 +128,19 @@ func runERC20Reddem(ctx *action.Context, tx action.RawTx) (bool, action.Response
 		return false, action.Response{Log: action.ErrUnserializable.Error()}
 	}
 
-	ethOptions, err := ctx.GovernanceStore.GetETHChainDriverOption()
+	bridge, err := getBridge(ctx, erc20redeem.ChainID)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, gov.ErrGetEthOptions, erc20redeem.Tags(), err)
 	}
+	ethTx, err := ethereum.DecodeTransaction(erc20redeem.ETHTxn)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc20redeem.Tags(), err)
+	}
+	err = bridge.checkChainID(ethTx)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc20redeem.Tags(), err)
+	}
+	ethOptions := bridge.Option
 	redeemParams, err := ethereum.ParseERC20RedeemParams(erc20redeem.ETHTxn, ethOptions.ERCContractABI)
 	if err != nil {
 		ctx.Logger.Error(err)
@@ -147,6 +158,11 @@ func runERC20Reddem(ctx *action.Context, tx action.RawTx) (bool, action.Response
 		return false, action.Response{Log: "Token not registered "}
 	}
 
+	err = bridge.admit(ctx, ethcommon.BytesToHash(erc20redeem.ETHTxn), token.TokName, bridgelib.Outflow, redeemParams.Amount)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "bridge rejected redeem").Error()}
+	}
+
 	coin := c.NewCoinFromAmount(*balance.NewAmountFromBigInt(redeemParams.Amount))
 	err = ctx.Balances.MinusFromAddress(erc20redeem.Owner, coin)
 	if err != nil {
@@ -161,12 +177,13 @@ func runERC20Reddem(ctx *action.Context, tx action.RawTx) (bool, action.Response
 		return false, action.Response{Log: action.ErrNotEnoughFund.Error()}
 	}
 
-	witnesses, err := ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
 	if err != nil {
 		return false, action.Response{Log: "error in getting validator addresses" + err.Error()}
 	}
 	name := ethcommon.BytesToHash(erc20redeem.ETHTxn)
-	if ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Exists(name) || ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixPassed).Exists(name) {
+	trackers := bridge.Trackers
+	if trackers.WithPrefixType(trackerlib.PrefixOngoing).Exists(name) || trackers.WithPrefixType(trackerlib.PrefixPassed).Exists(name) {
 		return false, action.Response{
 			Log: "Tracker already exists",
 		}
@@ -184,9 +201,17 @@ func runERC20Reddem(ctx *action.Context, tx action.RawTx) (bool, action.Response
 	tracker.ProcessOwner = erc20redeem.Owner
 	tracker.SignedETHTx = erc20redeem.ETHTxn
 	tracker.To = erc20redeem.To.Bytes()
+	tracker.ChainID = erc20redeem.ChainID
 
 	// Save eth Tracker
-	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err = trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, erc20redeem.Tags(), err)
+	}
+	err = bridge.openHistory(ctx, tracker)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, erc20redeem.Tags(), err)
+	}
 	return true, action.Response{
 		Data:      nil,
 		Log:       "",
@@ -0,0 +1,198 @@
+//Package for transactions related to Etheruem
+package eth
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	ethchaindriver "github.com/Oneledger/protocol/chains/ethereum"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/ethereum"
+	gov "github.com/Oneledger/protocol/data/governance"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ERC721Lock is a struct for one-Ledger transaction for ERC721 Lock
+type ERC721Lock struct {
+	Locker action.Address
+	ETHTxn []byte // Raw Transaction for Locking the token
+	// TokenURI is the metadata uri of the token, the witnesses check it against the token contract
+	TokenURI string
+	// ChainID selects an evm chain of the bridge registry, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
+}
+
+var _ action.Msg = &ERC721Lock{}
+
+//Signers return the Address of the owner who created the transaction
+func (E ERC721Lock) Signers() []action.Address {
+	return []action.Address{E.Locker}
+}
+
+// Type returns the type of current action
+func (E ERC721Lock) Type() action.Type {
+	return action.ERC721_LOCK
+}
+
+// Tags creates the tags to associate with the transaction
+func (E ERC721Lock) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(E.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.locker"),
+		Value: E.Locker.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.tracker"),
+		Value: ethcommon.BytesToHash(E.ETHTxn).Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+//Marshal ERC721Lock to byte array
+func (E ERC721Lock) Marshal() ([]byte, error) {
+	return json.Marshal(E)
+}
+
+func (E *ERC721Lock) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, E)
+}
+
+type ethERC721LockTx struct {
+}
+
+var _ action.Tx = ethERC721LockTx{}
+
+// Validate provides basic validation for transaction Type and Fee
+func (e ethERC721LockTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool, error) {
+	erclock := &ERC721Lock{}
+
+	err := erclock.Unmarshal(signedTx.Data)
+	if err != nil {
+		ctx.Logger.Error("error unmarshalling Data field of ERC721 LOCK trasaction")
+		return false, errors.Wrap(err, action.ErrWrongTxType.Msg)
+	}
+
+	err = action.ValidateBasic(signedTx.RawBytes(), erclock.Signers(), signedTx.Signatures)
+	if err != nil {
+		ctx.Logger.Error("validate basic failed", err)
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	if err != nil {
+		ctx.Logger.Error("validate fee failed", err)
+		return false, err
+	}
+
+	if erclock.ETHTxn == nil {
+		return false, action.ErrMissingData
+	}
+	return true, nil
+}
+
+// ProcessCheck runs checks on the transaction without commiting it .
+func (e ethERC721LockTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runERC721Lock(ctx, tx)
+}
+
+// ProcessDeliver run checks on transaction and commits it to a new block
+func (e ethERC721LockTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runERC721Lock(ctx, tx)
+}
+
+// ProcessFee process the transaction Fee in OLT
+func (e ethERC721LockTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	ctx.State.ConsumeUpfront(237600)
+
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+// runERC721Lock has the common functionality for ProcessCheck and ProcessDeliver
+// Provides security checks for transaction
+func runERC721Lock(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	erc721lock := &ERC721Lock{}
+
+	err := erc721lock.Unmarshal(tx.Data)
+	if err != nil {
+		ctx.Logger.Error("wrong tx type", err)
+		return false, action.Response{Log: "wrong tx type"}
+	}
+
+	ethTx, err := ethchaindriver.DecodeTransaction(erc721lock.ETHTxn)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721lock.Tags(), err)
+	}
+
+	bridge, err := getBridge(ctx, erc721lock.ChainID)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, gov.ErrGetEthOptions, erc721lock.Tags(), err)
+	}
+	err = bridge.checkChainID(ethTx)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721lock.Tags(), err)
+	}
+	if ctx.Bridge.IsPaused(bridge.ChainType) {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrPaused, erc721lock.Tags(), errors.Errorf("%s bridge", bridge.ChainType))
+	}
+	ethOptions := bridge.Option
+
+	req, err := ethchaindriver.VerifyERC721Lock(erc721lock.ETHTxn, ethOptions.ERC721ContractABI, ethOptions.ERC721ContractAddress)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721lock.Tags(), err)
+	}
+	if !ethchaindriver.IsNFTContract(ethOptions.NFTContracts, req.Token) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrTokenNotSupported, erc721lock.Tags(),
+			errors.Errorf("token contract %s", req.Token.Hex()))
+	}
+	if ctx.NFTs.Exists(erc721lock.ChainID, req.Token, req.TokenID.String()) {
+		return helpers.LogAndReturnFalse(ctx.Logger, ethereum.ErrNFTExists, erc721lock.Tags(), nil)
+	}
+
+	name := ethcommon.BytesToHash(erc721lock.ETHTxn)
+	trackers := bridge.Trackers
+	if trackers.WithPrefixType(ethereum.PrefixOngoing).Exists(name) || trackers.WithPrefixType(ethereum.PrefixPassed).Exists(name) {
+		return helpers.LogAndReturnFalse(ctx.Logger, ethereum.ErrETHTrackerExists, erc721lock.Tags(), nil)
+	}
+
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
+	if err != nil {
+		ctx.Logger.Error("err in getting witness address", err)
+		return false, action.Response{Log: "error in getting validator addresses" + err.Error()}
+	}
+
+	tracker := ethereum.NewTracker(
+		ethereum.ProcessTypeLockNFT,
+		erc721lock.Locker,
+		erc721lock.ETHTxn,
+		name,
+		witnesses,
+	)
+	tracker.TokenURI = erc721lock.TokenURI
+	tracker.ChainID = erc721lock.ChainID
+
+	err = trackers.WithPrefixType(ethereum.PrefixOngoing).Set(tracker)
+	if err != nil {
+		ctx.Logger.Error("error saving eth tracker", err)
+		return false, action.Response{Log: "error saving eth tracker: " + err.Error()}
+	}
+	err = bridge.openHistory(ctx, tracker)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "error opening tracker history").Error()}
+	}
+
+	return true, action.Response{
+		Events: action.GetEvent(erc721lock.Tags(), "erc721_lock"),
+	}
+}
@@ -0,0 +1,218 @@
+//Package for transactions related to Etheruem
+package eth
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/libs/kv"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/chains/ethereum"
+	trackerlib "github.com/Oneledger/protocol/data/ethereum"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	gov "github.com/Oneledger/protocol/data/governance"
+)
+
+var _ action.Msg = &ERC721Redeem{}
+
+// ERC721Redeem is a struct for one-Ledger transaction for ERC721 Redeem
+type ERC721Redeem struct {
+	Owner  action.Address    //User Oneledger address
+	To     ethcommon.Address //User Ethereum address, the sender of the redeem call
+	ETHTxn []byte
+	// ChainID selects an evm chain of the bridge registry, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
+}
+
+//Signers return the Address of the owner who created the transaction
+func (E ERC721Redeem) Signers() []action.Address {
+	return []action.Address{E.Owner}
+}
+
+// Type returns the type of current action
+func (E ERC721Redeem) Type() action.Type {
+	return action.ERC721_REDEEM
+}
+
+// Tags creates the tags to associate with the transaction
+func (E ERC721Redeem) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(E.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: E.Owner,
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.tracker"),
+		Value: ethcommon.BytesToHash(E.ETHTxn).Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+//Marshal ERC721Redeem to byte array
+func (E ERC721Redeem) Marshal() ([]byte, error) {
+	return json.Marshal(E)
+}
+
+func (E *ERC721Redeem) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, E)
+}
+
+var _ action.Tx = ethERC721RedeemTx{}
+
+type ethERC721RedeemTx struct {
+}
+
+// Validate provides basic validation for transaction Type and Fee
+func (e ethERC721RedeemTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool, error) {
+	erc721redeem := &ERC721Redeem{}
+	err := erc721redeem.Unmarshal(signedTx.Data)
+	if err != nil {
+		return false, errors.Wrap(err, action.ErrWrongTxType.Error())
+	}
+
+	err = action.ValidateBasic(signedTx.RawBytes(), erc721redeem.Signers(), signedTx.Signatures)
+	if err != nil {
+		ctx.Logger.Error("validate basic failed", err)
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	if err != nil {
+		ctx.Logger.Error("validate fee failed", err)
+		return false, err
+	}
+
+	if erc721redeem.ETHTxn == nil {
+		ctx.Logger.Error("eth txn is nil")
+		return false, action.ErrMissingData
+	}
+	return true, nil
+}
+
+// ProcessCheck runs checks on the transaction without commiting it .
+func (e ethERC721RedeemTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runERC721Redeem(ctx, tx)
+}
+
+// ProcessDeliver run checks on transaction and commits it to a new block
+func (e ethERC721RedeemTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runERC721Redeem(ctx, tx)
+}
+
+// ProcessFee process the transaction Fee in OLT
+func (e ethERC721RedeemTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	ctx.State.ConsumeUpfront(250400)
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+// runERC721Redeem has the common functionality for ProcessCheck and ProcessDeliver
+// Provides security checks for transaction
+func runERC721Redeem(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	erc721redeem := &ERC721Redeem{}
+	err := erc721redeem.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: action.ErrUnserializable.Error()}
+	}
+
+	bridge, err := getBridge(ctx, erc721redeem.ChainID)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, gov.ErrGetEthOptions, erc721redeem.Tags(), err)
+	}
+	ethTx, err := ethereum.DecodeTransaction(erc721redeem.ETHTxn)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721redeem.Tags(), err)
+	}
+	err = bridge.checkChainID(ethTx)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721redeem.Tags(), err)
+	}
+	if ctx.Bridge.IsPaused(bridge.ChainType) {
+		return helpers.LogAndReturnFalse(ctx.Logger, bridgelib.ErrPaused, erc721redeem.Tags(), errors.Errorf("%s bridge", bridge.ChainType))
+	}
+	ethOptions := bridge.Option
+	if ethTx.To() == nil || *ethTx.To() != ethOptions.ERC721ContractAddress {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721redeem.Tags(),
+			errors.New("transaction is not sent to the ERC721 bridge contract"))
+	}
+	req, err := ethereum.ParseERC721Redeem(erc721redeem.ETHTxn, ethOptions.ERC721ContractABI)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721redeem.Tags(), err)
+	}
+
+	// the bridge contract releases the token to the sender of the redeem call
+	recipient, err := ethereum.ERC721RedeemRecipient(erc721redeem.ETHTxn)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, erc721redeem.Tags(), err)
+	}
+	if recipient != erc721redeem.To {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidAddress, erc721redeem.Tags(),
+			errors.Errorf("redeem is sent by %s", recipient.Hex()))
+	}
+
+	nfts := ctx.NFTs
+	nft, err := nfts.Get(erc721redeem.ChainID, req.Token, req.TokenID.String())
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNFTNotFound, erc721redeem.Tags(), err)
+	}
+	if !nft.Owner.Equal(erc721redeem.Owner) {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNFTNotOwner, erc721redeem.Tags(), nil)
+	}
+	if nft.Redeeming {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNFTRedeeming, erc721redeem.Tags(), nil)
+	}
+
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
+	if err != nil {
+		return false, action.Response{Log: "error in getting validator addresses" + err.Error()}
+	}
+	name := ethcommon.BytesToHash(erc721redeem.ETHTxn)
+	trackers := bridge.Trackers
+	if trackers.WithPrefixType(trackerlib.PrefixOngoing).Exists(name) || trackers.WithPrefixType(trackerlib.PrefixPassed).Exists(name) {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerExists, erc721redeem.Tags(), nil)
+	}
+
+	// the record stays locked until the redeem is released or fails
+	nft.Redeeming = true
+	err = nfts.Set(nft)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "unable to save nft").Error()}
+	}
+
+	tracker := trackerlib.NewTracker(
+		trackerlib.ProcessTypeRedeemNFT,
+		erc721redeem.Owner,
+		erc721redeem.ETHTxn,
+		name,
+		witnesses,
+	)
+
+	tracker.State = trackerlib.New
+	tracker.ProcessOwner = erc721redeem.Owner
+	tracker.SignedETHTx = erc721redeem.ETHTxn
+	tracker.To = erc721redeem.To.Bytes()
+	tracker.ChainID = erc721redeem.ChainID
+
+	err = trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, erc721redeem.Tags(), err)
+	}
+	err = bridge.openHistory(ctx, tracker)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, erc721redeem.Tags(), err)
+	}
+	return true, action.Response{
+		Info:   "Transaction received ,Redeem in progress",
+		Events: action.GetEvent(erc721redeem.Tags(), "erc721_redeem"),
+	}
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/action/helpers"
 	ethchaindriver "github.com/Oneledger/protocol/chains/ethereum"
-	"github.com/Oneledger/protocol/data/chain"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/ethereum"
 	gov "github.com/Oneledger/protocol/data/governance"
 )
@@ -23,6 +23,8 @@ import (
 type Lock struct {
 	Locker action.Address
 	ETHTxn []byte
+	// ChainID selects an evm chain of the bridge registry, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
 }
 
 var _ action.Msg = &Lock{}
@@ -154,10 +156,15 @@ func runLock(ctx *action.Context, lock *Lock) (bool, action.Response) {
 		}
 	}
 
-	ethOptions, err := ctx.GovernanceStore.GetETHChainDriverOption()
+	bridge, err := getBridge(ctx, lock.ChainID)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, gov.ErrGetEthOptions, lock.Tags(), err)
 	}
+	err = bridge.checkChainID(ethTx)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, lock.Tags(), err)
+	}
+	ethOptions := bridge.Option
 
 	ok, err := ethchaindriver.VerifyLock(ethTx, ethOptions.ContractABI)
 	if err != nil {
@@ -179,16 +186,16 @@ func runLock(ctx *action.Context, lock *Lock) (bool, action.Response) {
 		}
 	}
 
-	witnesses, err := ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
 	if err != nil {
 
 		ctx.Logger.Error("err in getting validator address", err)
 		return false, action.Response{Log: "error in getting validator addresses" + err.Error()}
 	}
 
-	curr, ok := ctx.Currencies.GetCurrencyByName("ETH")
+	curr, ok := ctx.Currencies.GetCurrencyByName(bridge.Currency)
 	if !ok {
-		return false, action.Response{Log: fmt.Sprintf("ETH currency not available", lock.Locker)}
+		return false, action.Response{Log: fmt.Sprintf("%s currency not available", bridge.Currency)}
 	}
 	lockCoin := curr.NewCoinFromString(ethTx.Value().String())
 	// Adding lock amount to common address to maintain count of total oEth minted
@@ -204,13 +211,18 @@ func runLock(ctx *action.Context, lock *Lock) (bool, action.Response) {
 		return false, action.Response{Log: fmt.Sprintf("Eth lock exceeded limit", lock.Locker)}
 	}
 	name := ethcommon.BytesToHash(lock.ETHTxn)
-	if ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Exists(name) || ctx.ETHTrackers.WithPrefixType(ethereum.PrefixPassed).Exists(name) {
+	err = bridge.admit(ctx, name, bridge.Currency, bridgelib.Inflow, ethTx.Value())
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "bridge rejected lock").Error()}
+	}
+	trackers := bridge.Trackers
+	if trackers.WithPrefixType(ethereum.PrefixOngoing).Exists(name) || trackers.WithPrefixType(ethereum.PrefixPassed).Exists(name) {
 		return false, action.Response{
 			Log: "Tracker already exists / Lock for this ETHTX in progress or has completed successfully",
 		}
 	}
-	if ctx.ETHTrackers.WithPrefixType(ethereum.PrefixFailed).Exists(name) {
-		res, err := ctx.ETHTrackers.WithPrefixType(ethereum.PrefixFailed).Delete(name)
+	if trackers.WithPrefixType(ethereum.PrefixFailed).Exists(name) {
+		res, err := trackers.WithPrefixType(ethereum.PrefixFailed).Delete(name)
 		if err != nil || !res {
 			return false, action.Response{
 				Log: "Error deleting tracker from store",
@@ -229,12 +241,17 @@ func runLock(ctx *action.Context, lock *Lock) (bool, action.Response) {
 	tracker.State = ethereum.New
 	tracker.ProcessOwner = lock.Locker
 	tracker.SignedETHTx = lock.ETHTxn
+	tracker.ChainID = lock.ChainID
 	// Save eth Tracker
-	err = ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Set(tracker)
+	err = trackers.WithPrefixType(ethereum.PrefixOngoing).Set(tracker)
 	if err != nil {
 		ctx.Logger.Error("error saving eth tracker", err)
 		return false, action.Response{Log: "error saving eth tracker: " + err.Error()}
 	}
+	err = bridge.openHistory(ctx, tracker)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "error opening tracker history").Error()}
+	}
 	return true, action.Response{
 		Events: action.GetEvent(lock.Tags(), "eth_lock"),
 	}
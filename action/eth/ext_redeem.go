@@ -13,7 +13,7 @@ import (
 	"github.com/Oneledger/protocol/action/helpers"
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/data/balance"
-	"github.com/Oneledger/protocol/data/chain"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
 	trackerlib "github.com/Oneledger/protocol/data/ethereum"
 	gov "github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
@@ -26,6 +26,8 @@ type Redeem struct {
 	Owner  action.Address    //User Oneledger address
 	To     ethcommon.Address //User Ethereum address
 	ETHTxn []byte
+	// ChainID selects an evm chain of the bridge registry, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
 }
 
 //Signers return the Address of the owner who created the transaction
@@ -124,20 +126,34 @@ func runRedeem(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 		ctx.Logger.Error("")
 		return false, action.Response{Log: errors.Wrap(action.ErrUnserializable, err.Error()).Error()}
 	}
-	ethOptions, err := ctx.GovernanceStore.GetETHChainDriverOption()
+	bridge, err := getBridge(ctx, redeem.ChainID)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, gov.ErrGetEthOptions, redeem.Tags(), err)
 	}
+	ethOptions := bridge.Option
 	req, err := ethereum.ParseRedeem(redeem.ETHTxn, ethOptions.ContractABI)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, redeem.Tags(), err)
 	}
+	ethTx, err := ethereum.DecodeTransaction(redeem.ETHTxn)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, redeem.Tags(), err)
+	}
+	err = bridge.checkChainID(ethTx)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidExtTx, redeem.Tags(), err)
+	}
 
-	c, ok := ctx.Currencies.GetCurrencyByName("ETH")
+	c, ok := ctx.Currencies.GetCurrencyByName(bridge.Currency)
 	if !ok {
 		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrInvalidCurrency, redeem.Tags(), err)
 	}
 
+	err = bridge.admit(ctx, ethcommon.BytesToHash(redeem.ETHTxn), bridge.Currency, bridgelib.Outflow, req.Amount)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "bridge rejected redeem").Error()}
+	}
+
 	coin := c.NewCoinFromAmount(*balance.NewAmountFromBigInt(req.Amount))
 	err = ctx.Balances.MinusFromAddress(redeem.Owner, coin)
 	if err != nil {
@@ -150,12 +166,13 @@ func runRedeem(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorMinusFailed, redeem.Tags(), err)
 	}
 
-	witnesses, err := ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrGettingWitnessList, redeem.Tags(), err)
 	}
 	name := ethcommon.BytesToHash(redeem.ETHTxn)
-	if ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Exists(name) || ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixFailed).Exists(name) || ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixPassed).Exists(name) {
+	trackers := bridge.Trackers
+	if trackers.WithPrefixType(trackerlib.PrefixOngoing).Exists(name) || trackers.WithPrefixType(trackerlib.PrefixFailed).Exists(name) || trackers.WithPrefixType(trackerlib.PrefixPassed).Exists(name) {
 		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerExists, redeem.Tags(), errors.New("Tracker with same TXHASH already exists"))
 	}
 
@@ -171,9 +188,14 @@ func runRedeem(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 	tracker.ProcessOwner = redeem.Owner
 	tracker.SignedETHTx = redeem.ETHTxn
 	tracker.To = redeem.To.Bytes()
+	tracker.ChainID = redeem.ChainID
 
 	// Save eth Tracker
-	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	err = trackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, redeem.Tags(), err)
+	}
+	err = bridge.openHistory(ctx, tracker)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, redeem.Tags(), err)
 	}
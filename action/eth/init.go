@@ -32,6 +32,26 @@ func EnableETH(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "ERC20Redeem)")
 	}
+
+	err = r.AddHandler(action.ERC721_LOCK, ethERC721LockTx{})
+	if err != nil {
+		return errors.Wrap(err, "ERC721LockTx")
+	}
+
+	err = r.AddHandler(action.ERC721_REDEEM, ethERC721RedeemTx{})
+	if err != nil {
+		return errors.Wrap(err, "ERC721RedeemTx")
+	}
+
+	err = r.AddHandler(action.NFT_TRANSFER, nftTransferTx{})
+	if err != nil {
+		return errors.Wrap(err, "nftTransferTx")
+	}
+
+	err = r.AddHandler(action.ETH_RETRY, retryTx{})
+	if err != nil {
+		return errors.Wrap(err, "retryTx")
+	}
 	return nil
 }
 
@@ -0,0 +1,136 @@
+//Package for transactions related to Etheruem
+package eth
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/libs/kv"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	trackerlib "github.com/Oneledger/protocol/data/ethereum"
+)
+
+var _ action.Msg = &NFTTransfer{}
+
+// NFTTransfer moves a bridged ERC721 record to another OneLedger address
+type NFTTransfer struct {
+	From     action.Address    `json:"from"`
+	To       action.Address    `json:"to"`
+	ChainID  int64             `json:"chainId,omitempty"`
+	Contract ethcommon.Address `json:"contract"`
+	TokenID  string            `json:"tokenId"`
+}
+
+func (s NFTTransfer) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *NFTTransfer) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+func (s NFTTransfer) Signers() []action.Address {
+	return []action.Address{s.From.Bytes()}
+}
+
+func (s NFTTransfer) Type() action.Type {
+	return action.NFT_TRANSFER
+}
+
+func (s NFTTransfer) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(s.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: s.From.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.to"),
+		Value: s.To.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.nft"),
+		Value: []byte(s.Contract.Hex() + "/" + s.TokenID),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = nftTransferTx{}
+
+type nftTransferTx struct {
+}
+
+func (nftTransferTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	transfer := &NFTTransfer{}
+	err := transfer.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(tx.RawBytes(), transfer.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if transfer.From.Err() != nil || transfer.To.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	if transfer.TokenID == "" {
+		return false, action.ErrMissingData
+	}
+	return true, nil
+}
+
+func (nftTransferTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runNFTTransfer(ctx, tx)
+}
+
+func (nftTransferTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runNFTTransfer(ctx, tx)
+}
+
+func (nftTransferTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runNFTTransfer(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	transfer := &NFTTransfer{}
+	err := transfer.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	nft, err := ctx.NFTs.Get(transfer.ChainID, transfer.Contract, transfer.TokenID)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNFTNotFound, transfer.Tags(), err)
+	}
+	if !nft.Owner.Equal(transfer.From) {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNFTNotOwner, transfer.Tags(), nil)
+	}
+	if nft.Redeeming {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNFTRedeeming, transfer.Tags(), nil)
+	}
+
+	nft.Owner = transfer.To
+	err = ctx.NFTs.Set(nft)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "unable to save nft").Error()}
+	}
+
+	return helpers.LogAndReturnTrue(ctx.Logger, transfer.Tags(), "nft_transfer")
+}
@@ -0,0 +1,164 @@
+package eth
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/chains/ethereum"
+	bridgelib "github.com/Oneledger/protocol/data/bridge"
+	trackerlib "github.com/Oneledger/protocol/data/ethereum"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &Retry{}
+
+// Retry is the vote of a witness to step a stuck tracker back, so the witnesses run its last step
+// again once two thirds of them voted
+type Retry struct {
+	Witness     keys.Address
+	TrackerName ethereum.TrackerName
+	// ChainID is the evm chain of the registry the tracker is for, 0 for the default ethereum chain
+	ChainID int64 `json:"ChainID,omitempty"`
+	Reason  string
+}
+
+func (r Retry) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *Retry) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func (r Retry) Signers() []action.Address {
+	return []action.Address{r.Witness.Bytes()}
+}
+
+func (r Retry) Type() action.Type {
+	return action.ETH_RETRY
+}
+
+func (r Retry) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(r.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.witness"),
+		Value: r.Witness.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.tracker_name"),
+		Value: []byte(r.TrackerName.Hex()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = retryTx{}
+
+type retryTx struct{}
+
+func (retryTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	r := &Retry{}
+	err := r.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(tx.RawBytes(), r.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Witness.Err(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (retryTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing 'eth_retry' transaction for ProcessCheck", tx)
+	return runRetry(ctx, tx)
+}
+
+func (retryTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing 'eth_retry' transaction for ProcessDeliver", tx)
+	return runRetry(ctx, tx)
+}
+
+func (retryTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	r := &Retry{}
+	err := r.Unmarshal(signedTx.Data)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "failed to unmarshal").Error()}
+	}
+	return action.StakingPayerFeeHandling(ctx, r.Witness, signedTx, start, size, 1)
+}
+
+func runRetry(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	r := &Retry{}
+	err := r.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, r.Tags(), err)
+	}
+
+	bridge, err := getBridge(ctx, r.ChainID)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "err getting bridge").Error()}
+	}
+	if !ctx.Witnesses.Exists(bridge.ChainType, r.Witness) {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrNotTrackerWitness, r.Tags(),
+			errors.Errorf("%s for %s", r.Witness.String(), bridge.ChainType))
+	}
+
+	trackers := bridge.Trackers.WithPrefixType(trackerlib.PrefixOngoing)
+	tracker, err := trackers.Get(r.TrackerName)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "err getting tracker").Error()}
+	}
+
+	// the witnesses may have changed since the tracker got stuck, the ones of now vote on the retry
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(bridge.ChainType)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "error in getting witness addresses").Error()}
+	}
+	retried, err := tracker.VoteRetry(r.Witness, witnesses)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrTrackerNotRetriable, r.Tags(), err)
+	}
+
+	err = trackers.Set(tracker)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, trackerlib.ErrETHTrackerUnableToSet, r.Tags(), err)
+	}
+	err = ctx.Bridge.RecordHistory(bridge.ChainType, r.TrackerName.Hex(), bridgelib.HistoryEntry{
+		Height:  ctx.Header.Height,
+		Event:   bridgelib.EventRetry,
+		State:   tracker.State.String(),
+		Witness: r.Witness,
+		Reason:  r.Reason,
+	})
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "failed to record retry").Error()}
+	}
+
+	if retried {
+		ctx.Logger.Info("Tracker stepped back", r.TrackerName.Hex(), "to", tracker.State.String())
+	}
+
+	return helpers.LogAndReturnTrue(ctx.Logger, r.Tags(), "eth_retry")
+}
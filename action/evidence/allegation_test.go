@@ -128,9 +128,9 @@ func assemblyCtxData(currencyName string, setCoin int64) *action.Context {
 	ctx.FeePool.SetupOpt(ctx.FeeOpt)
 	ctx.GovernanceStore = governance.NewStore("tg", cs)
 	ctx.Delegators = delegation.NewDelegationStore("tst", cs)
-	ctx.Validators = identity.NewValidatorStore("tv", "purged", cs)
+	ctx.Validators = identity.NewValidatorStore("tv", "purged", "rotation", "profile", cs)
 	ctx.EvidenceStore = evidence.NewEvidenceStore("tes", cs)
-	ctx.GovernanceStore.SetFeeOption(*ctx.FeeOpt)
+	ctx.GovernanceStore.SetFeeOption(ctx.FeeOpt)
 	ctx.GovernanceStore.SetEvidenceOptions(evidenceOption)
 	ctx.GovernanceStore.WithHeight(0).SetAllLUH()
 	validator := identity.NewValidator(
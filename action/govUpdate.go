@@ -4,11 +4,24 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 
+	btcchain "github.com/Oneledger/protocol/chains/bitcoin"
+	ethchain "github.com/Oneledger/protocol/chains/ethereum"
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/rewards"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/serialize"
 )
 
 type FunctionBehaviour int
@@ -33,6 +46,8 @@ func NewGovUpdate() *GovernaceUpdateAndValidate {
 
 func (g GovernaceUpdateAndValidate) inititalizize() {
 	g.GovernanceUpdateFunction["feeOption.minFeeDecimal"] = feeOptionminFeeDecimal
+	// Base fee market as targetGas,changeDenominator,burn, a zero target gas turns it off
+	g.GovernanceUpdateFunction["feeOption.baseFeeMarket"] = feeOptionbaseFeeMarket
 	g.GovernanceUpdateFunction["onsOptions.perBlockFees"] = onsOptionsperBlockFees
 	g.GovernanceUpdateFunction["onsOptions.baseDomainPrice"] = onsOptionsbaseDomainPrice
 	g.GovernanceUpdateFunction["stakingOptions.minSelfDelegationAmount"] = stakingOptionsminSelfDelegationAmount
@@ -63,6 +78,31 @@ func (g GovernaceUpdateAndValidate) inititalizize() {
 	g.GovernanceUpdateFunction["evidenceOptions.minVotesRequired"] = evidenceOptionsminVotesRequired
 	g.GovernanceUpdateFunction["evidenceOptions.blockVotesDiff"] = evidenceOptionsblockVotesDiff
 	g.GovernanceUpdateFunction["evidenceOptions.penaltyBasePercentage"] = evidenceOptionspenaltyBasePercentage
+	// Year shares are given as a comma separated list of amounts
+	g.GovernanceUpdateFunction["rewardOptions.yearBlockRewardShares"] = rewardOptionsyearBlockRewardShares
+	g.GovernanceUpdateFunction["rewardOptions.burnoutRate"] = rewardOptionsburnoutRate
+	g.GovernanceUpdateFunction["rewardOptions.blockSpeedCalculateCycle"] = rewardOptionsblockSpeedCalculateCycle
+	// New evm chain as name,chainId,currency,lockRedeemAddress,ercLockRedeemAddress,blockConfirmation,totalSupply
+	g.GovernanceUpdateFunction["evmChains.addChain"] = evmChainsaddChain
+	// Confirmations of an evm chain as chainId,blockConfirmation
+	g.GovernanceUpdateFunction["evmChains.blockConfirmation"] = evmChainsblockConfirmation
+	// New token of an evm chain as chainId,currency,tokenAddress,totalSupply
+	g.GovernanceUpdateFunction["evmChains.addToken"] = evmChainsaddToken
+	// ERC721 bridge contract of an evm chain as chainId,bridgeAddress, chain id 0 is ethereum
+	g.GovernanceUpdateFunction["evmChains.nftBridge"] = evmChainsnftBridge
+	// Token contract allowed in the ERC721 bridge of an evm chain as chainId,tokenAddress
+	g.GovernanceUpdateFunction["evmChains.addNFTContract"] = evmChainsaddNFTContract
+	// P2WSH lock addresses for btc trackers, turning it on sweeps the P2SH balances to P2WSH
+	g.GovernanceUpdateFunction["btcOptions.segWit"] = btcOptionssegWit
+	// Flow limit of a bridged asset as asset,maxTx,inflow,outflow,window, zero amounts leave a cap out
+	g.GovernanceUpdateFunction["bridgeOptions.limit"] = bridgeOptionslimit
+	// Circuit breaker of the bridge of a chain, given by its name as Bitcoin, Ethereum or EVM-<chainId>
+	g.GovernanceUpdateFunction["bridgeOptions.pause"] = bridgeOptionspause
+	g.GovernanceUpdateFunction["bridgeOptions.resume"] = bridgeOptionsresume
+	// New witness set of an evm chain as <chain>,<validator address>,..., it takes over once the
+	// current witnesses updated the bridge contracts to it, it supersedes a pending rotation
+	g.GovernanceUpdateFunction["bridgeOptions.rotateWitnesses"] = bridgeOptionsrotateWitnesses
+	g.GovernanceUpdateFunction["bridgeOptions.cancelRotation"] = bridgeOptionscancelRotation
 	//g.GovernanceUpdateFunction["evidenceOptions.penaltyPercentage"] = evidenceOptionspenaltyPercentage
 
 	//MinVotesRequired: 2, // should be atleast 70% or greater of block votes diff
@@ -1084,11 +1124,15 @@ func feeOptionminFeeDecimal(value interface{}, ctx *Context, validationOnly Func
 	if validationOnly == ValidateOnly {
 		return true, nil
 	}
-	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetFeeOption(*feeOptions)
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetFeeOption(feeOptions)
 	if err != nil {
 		return false, errors.Wrap(err, "Setup Fee Options")
 	}
 	ctx.FeePool.SetupOpt(feeOptions)
+	err = ctx.FeePool.LoadBaseFee()
+	if err != nil {
+		return false, errors.Wrap(err, "Load base fee")
+	}
 	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_FEE)
 	if err != nil {
 		return false, errors.Wrap(err, "Unable to set last Update height ")
@@ -1097,6 +1141,897 @@ func feeOptionminFeeDecimal(value interface{}, ctx *Context, validationOnly Func
 	return true, nil
 }
 
+func feeOptionbaseFeeMarket(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	feeOptions, err := ctx.GovernanceStore.GetFeeOption()
+	if err != nil {
+		return false, err
+	}
+	fields, err := getNewValueList(value, 3)
+	if err != nil {
+		return false, err
+	}
+	targetGas, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return false, errors.Wrap(err, "target gas")
+	}
+	denominator, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return false, errors.Wrap(err, "change denominator")
+	}
+	burn, err := strconv.ParseBool(fields[2])
+	if err != nil {
+		return false, errors.Wrap(err, "burn")
+	}
+	feeOptions.BaseFeeMarket = fees.BaseFeeOption{
+		TargetGas:         targetGas,
+		ChangeDenominator: denominator,
+		Burn:              burn,
+	}
+
+	ok, err := ctx.GovernanceStore.ValidateFee(feeOptions)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetFeeOption(feeOptions)
+	if err != nil {
+		return false, errors.Wrap(err, "Setup Fee Options")
+	}
+	ctx.FeePool.SetupOpt(feeOptions)
+	err = ctx.FeePool.LoadBaseFee()
+	if err != nil {
+		return false, errors.Wrap(err, "Load base fee")
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_FEE)
+	if err != nil {
+		return false, errors.Wrap(err, "Unable to set last Update height ")
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| feeOption.baseFeeMarket :", feeOptions.BaseFeeMarket)
+	return true, nil
+}
+
+func rewardOptionsyearBlockRewardShares(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	oldOptions, err := ctx.GovernanceStore.GetRewardOptions()
+	if err != nil {
+		return false, err
+	}
+	Options, err := ctx.GovernanceStore.GetRewardOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewAmountList(value)
+	if err != nil {
+		return false, err
+	}
+	Options.YearBlockRewardShares = newValue
+
+	ok, err := ctx.GovernanceStore.ValidateRewards(Options)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	err = validateRewardSchedule(ctx, oldOptions, Options)
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = setRewardOptions(ctx, Options)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| rewardOptions.yearBlockRewardShares :", newValue)
+	return true, nil
+}
+
+func rewardOptionsburnoutRate(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	oldOptions, err := ctx.GovernanceStore.GetRewardOptions()
+	if err != nil {
+		return false, err
+	}
+	Options, err := ctx.GovernanceStore.GetRewardOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewBigInt(value)
+	if err != nil {
+		return false, err
+	}
+	Options.BurnoutRate = *balance.NewAmountFromBigInt(newValue)
+
+	ok, err := ctx.GovernanceStore.ValidateRewards(Options)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	err = validateRewardSchedule(ctx, oldOptions, Options)
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = setRewardOptions(ctx, Options)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| rewardOptions.burnoutRate :", newValue)
+	return true, nil
+}
+
+func rewardOptionsblockSpeedCalculateCycle(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	oldOptions, err := ctx.GovernanceStore.GetRewardOptions()
+	if err != nil {
+		return false, err
+	}
+	Options, err := ctx.GovernanceStore.GetRewardOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewValueInt64(value)
+	if err != nil {
+		return false, err
+	}
+	Options.BlockSpeedCalculateCycle = newValue
+
+	ok, err := ctx.GovernanceStore.ValidateRewards(Options)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	err = validateRewardSchedule(ctx, oldOptions, Options)
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = setRewardOptions(ctx, Options)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| rewardOptions.blockSpeedCalculateCycle :", newValue)
+	return true, nil
+}
+
+func evmChainsaddChain(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	fields, err := getNewValueList(value, 7)
+	if err != nil {
+		return false, err
+	}
+	chainID, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return false, err
+	}
+	confirmation, err := strconv.ParseInt(fields[5], 10, 64)
+	if err != nil {
+		return false, err
+	}
+	if !ethcommon.IsHexAddress(fields[3]) || !ethcommon.IsHexAddress(fields[4]) {
+		return false, errors.New("invalid contract address")
+	}
+	if _, err := getNewBigInt(fields[6]); err != nil {
+		return false, errors.Wrap(err, "total supply")
+	}
+	err = checkNewCurrency(ctx, fields[2])
+	if err != nil {
+		return false, err
+	}
+
+	ethOpt, err := ctx.GovernanceStore.GetETHChainDriverOption()
+	if err != nil {
+		return false, err
+	}
+	registry, err := ctx.GovernanceStore.GetEVMChainRegistry()
+	if err != nil {
+		return false, err
+	}
+	// the contracts on every chain are the ones deployed on ethereum
+	err = registry.Add(ethchain.EVMChain{
+		Name:     fields[0],
+		ChainID:  chainID,
+		Currency: fields[2],
+		Option: ethchain.ChainDriverOption{
+			ContractABI:        ethOpt.ContractABI,
+			ContractAddress:    ethcommon.HexToAddress(fields[3]),
+			TokenList:          []ethchain.ERC20Token{},
+			ERCContractABI:     ethOpt.ERCContractABI,
+			ERCContractAddress: ethcommon.HexToAddress(fields[4]),
+			TotalSupply:        fields[6],
+			TotalSupplyAddr:    ethOpt.TotalSupplyAddr,
+			BlockConfirmation:  confirmation,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	ok, err := ctx.GovernanceStore.ValidateEVMChains(registry)
+	if err != nil || !ok {
+		return false, errors.Wrap(err, "Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+
+	err = addEVMCurrency(ctx, fields[2], chainID)
+	if err != nil {
+		return false, err
+	}
+	// witnesses of the new chain start as the ethereum ones, the set of each chain moves
+	// along with the rotations of its own contracts after that
+	witnesses, err := ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	if err != nil {
+		return false, err
+	}
+	for _, addr := range witnesses {
+		witness, err := ctx.Witnesses.Get(chain.ETHEREUM, addr)
+		if err != nil {
+			return false, err
+		}
+		err = ctx.Witnesses.AddWitness(chain.EVMType(chainID), identity.Stake{
+			ValidatorAddress: witness.Address,
+			Pubkey:           witness.PubKey,
+			ECDSAPubKey:      witness.ECDSAPubKey,
+			Name:             witness.Name,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	err = setEVMChainRegistry(ctx, registry)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| evmChains.addChain :", fields[0], chainID)
+	return true, nil
+}
+
+func evmChainsblockConfirmation(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	fields, err := getNewValueList(value, 2)
+	if err != nil {
+		return false, err
+	}
+	chainID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return false, err
+	}
+	confirmation, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return false, err
+	}
+	registry, err := ctx.GovernanceStore.GetEVMChainRegistry()
+	if err != nil {
+		return false, err
+	}
+	evmChain, ok := registry.Get(chainID)
+	if !ok {
+		return false, errors.Errorf("evm chain %d is not in the registry", chainID)
+	}
+	evmChain.Option.BlockConfirmation = confirmation
+	ok, err = ctx.GovernanceStore.ValidateEVMChains(registry)
+	if err != nil || !ok {
+		return false, errors.Wrap(err, "Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = setEVMChainRegistry(ctx, registry)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| evmChains.blockConfirmation :", chainID, confirmation)
+	return true, nil
+}
+
+func evmChainsaddToken(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	fields, err := getNewValueList(value, 4)
+	if err != nil {
+		return false, err
+	}
+	chainID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return false, err
+	}
+	if !ethcommon.IsHexAddress(fields[2]) {
+		return false, errors.New("invalid token address")
+	}
+	if _, err := getNewBigInt(fields[3]); err != nil {
+		return false, errors.Wrap(err, "total supply")
+	}
+	err = checkNewCurrency(ctx, fields[1])
+	if err != nil {
+		return false, err
+	}
+	registry, err := ctx.GovernanceStore.GetEVMChainRegistry()
+	if err != nil {
+		return false, err
+	}
+	evmChain, ok := registry.Get(chainID)
+	if !ok {
+		return false, errors.Errorf("evm chain %d is not in the registry", chainID)
+	}
+	tokenAddress := ethcommon.HexToAddress(fields[2])
+	if _, err := ethchain.GetToken(evmChain.Option.TokenList, tokenAddress); err == nil {
+		return false, errors.Errorf("token %s already exists on evm chain %d", fields[2], chainID)
+	}
+	evmChain.Option.TokenList = append(evmChain.Option.TokenList, ethchain.ERC20Token{
+		TokName:        fields[1],
+		TokAddr:        tokenAddress,
+		TokAbi:         contract.ERC20BasicABI,
+		TokTotalSupply: fields[3],
+	})
+	ok, err = ctx.GovernanceStore.ValidateEVMChains(registry)
+	if err != nil || !ok {
+		return false, errors.Wrap(err, "Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+
+	err = addEVMCurrency(ctx, fields[1], chainID)
+	if err != nil {
+		return false, err
+	}
+	err = setEVMChainRegistry(ctx, registry)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| evmChains.addToken :", chainID, fields[1])
+	return true, nil
+}
+
+func evmChainsnftBridge(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	fields, err := getNewValueList(value, 2)
+	if err != nil {
+		return false, err
+	}
+	if !ethcommon.IsHexAddress(fields[1]) {
+		return false, errors.New("invalid contract address")
+	}
+	return updateNFTBridge(ctx, fields[0], validationOnly, func(opt *ethchain.ChainDriverOption) error {
+		opt.ERC721ContractABI = contract.LockRedeemERC721ABI
+		opt.ERC721ContractAddress = ethcommon.HexToAddress(fields[1])
+		return nil
+	})
+}
+
+func evmChainsaddNFTContract(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	fields, err := getNewValueList(value, 2)
+	if err != nil {
+		return false, err
+	}
+	if !ethcommon.IsHexAddress(fields[1]) {
+		return false, errors.New("invalid token address")
+	}
+	token := ethcommon.HexToAddress(fields[1])
+	return updateNFTBridge(ctx, fields[0], validationOnly, func(opt *ethchain.ChainDriverOption) error {
+		if ethchain.IsNFTContract(opt.NFTContracts, token) {
+			return errors.Errorf("token %s already allowed", fields[1])
+		}
+		opt.NFTContracts = append(opt.NFTContracts, token)
+		return nil
+	})
+}
+
+// updateNFTBridge applies a change to the ERC721 bridge options of ethereum or of a registry chain
+func updateNFTBridge(ctx *Context, chainIDField string, validationOnly FunctionBehaviour, update func(opt *ethchain.ChainDriverOption) error) (bool, error) {
+	chainID, err := strconv.ParseInt(chainIDField, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	if chainID == 0 {
+		ethOpt, err := ctx.GovernanceStore.GetETHChainDriverOption()
+		if err != nil {
+			return false, err
+		}
+		err = update(ethOpt)
+		if err != nil {
+			return false, err
+		}
+		if validationOnly == ValidateOnly {
+			return true, nil
+		}
+		err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetETHChainDriverOption(*ethOpt)
+		if err != nil {
+			return false, errors.Wrap(err, "Setup ETH Options")
+		}
+		ctx.ETHTrackers.SetupOption(ethOpt)
+		err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_ETH)
+		if err != nil {
+			return false, errors.Wrap(err, "Unable to set last Update height ")
+		}
+		ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| ethereum nft bridge")
+		return true, nil
+	}
+
+	registry, err := ctx.GovernanceStore.GetEVMChainRegistry()
+	if err != nil {
+		return false, err
+	}
+	evmChain, ok := registry.Get(chainID)
+	if !ok {
+		return false, errors.Errorf("evm chain %d is not in the registry", chainID)
+	}
+	err = update(&evmChain.Option)
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = setEVMChainRegistry(ctx, registry)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| evm chain nft bridge :", chainID)
+	return true, nil
+}
+
+func setEVMChainRegistry(ctx *Context, registry *ethchain.EVMChainRegistry) error {
+	err := ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetEVMChainRegistry(*registry)
+	if err != nil {
+		return errors.Wrap(err, "Setup EVM Chains")
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_EVM_CHAINS)
+	if err != nil {
+		return errors.Wrap(err, "Unable to set last Update height ")
+	}
+	return nil
+}
+
+// addEVMCurrency registers the currency minted for the assets locked on an evm chain, it only goes to
+// the governance store, the node loads the currencies and the registry of the store once the block commits
+func addEVMCurrency(ctx *Context, name string, chainID int64) error {
+	currencies, err := ctx.GovernanceStore.GetCurrencies()
+	if err != nil {
+		return err
+	}
+	nextID := int64(0)
+	for _, c := range currencies {
+		if c.Name == name {
+			return errors.Errorf("currency %s already exists", name)
+		}
+		if c.Id >= nextID {
+			nextID = c.Id + 1
+		}
+	}
+	currency := balance.Currency{
+		Id:      nextID,
+		Name:    name,
+		Chain:   chain.EVMType(chainID),
+		Decimal: 18,
+		Unit:    "wei",
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetCurrencies(append(currencies, currency))
+	if err != nil {
+		return err
+	}
+	return ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_CURRENCY)
+}
+
+// checkNewCurrency makes sure a currency is neither loaded nor added earlier in the block
+func checkNewCurrency(ctx *Context, name string) error {
+	if _, ok := ctx.Currencies.GetCurrencyByName(name); ok {
+		return errors.Errorf("currency %s already exists", name)
+	}
+	currencies, err := ctx.GovernanceStore.GetCurrencies()
+	if err != nil {
+		return err
+	}
+	if _, ok := currencies.GetCurrencySet().GetCurrencyByName(name); ok {
+		return errors.Errorf("currency %s already exists", name)
+	}
+	return nil
+}
+
+func btcOptionssegWit(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	Options, err := ctx.GovernanceStore.GetBTCChainDriverOption()
+	if err != nil {
+		return false, err
+	}
+	str, ok := value.(string)
+	if !ok {
+		return false, errors.New("Type assertion failed")
+	}
+	newValue, err := strconv.ParseBool(str)
+	if err != nil {
+		return false, err
+	}
+	migrate := newValue && !Options.SegWit
+	var migration *btcMigration
+	if migrate {
+		migration, err = prepareBTCMigration(ctx)
+		if err != nil {
+			return false, errors.Wrap(err, "Migrate BTC trackers")
+		}
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+
+	Options.SegWit = newValue
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetBTCChainDriverOption(*Options)
+	if err != nil {
+		return false, errors.Wrap(err, "Setup BTC Options")
+	}
+	ctx.BTCTrackers.SetOption(*Options)
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_BTC)
+	if err != nil {
+		return false, errors.Wrap(err, "Unable to set last Update height ")
+	}
+	if migrate {
+		err = migration.apply(ctx)
+		if err != nil {
+			return false, errors.Wrap(err, "Migrate BTC trackers")
+		}
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| btcOptions.segWit :", newValue)
+	return true, nil
+}
+
+// btcMigration moves the available trackers to a P2WSH lock address of the validators
+type btcMigration struct {
+	lockScript        []byte
+	lockScriptAddress []byte
+	trackers          []*bitcoin.Tracker
+	// sweepFee is paid in BTC by the sweeps of the trackers still holding a P2SH utxo
+	sweepFee int64
+}
+
+// prepareBTCMigration checks that the trackers can move to P2WSH addresses and plans the moves. A tracker
+// holding a P2SH utxo gets a sweep of its balance to sign and broadcast, busy trackers move with their next
+// lock or redeem. The sweeps leave less BTC locked than oBTC minted, the fee pool has to hold the oBTC
+// burnt to make up for their fees
+func prepareBTCMigration(ctx *Context) (*btcMigration, error) {
+	if ctx.LockScriptStore == nil {
+		return nil, errors.New("no lock script store to keep the new lock scripts")
+	}
+	opt := ctx.BTCTrackers.GetConfig()
+	if opt.BTCParams == nil || opt.BTCParams.Bech32HRPSegwit == "" {
+		return nil, errors.New("bitcoin network params without segwit support")
+	}
+	validatorPubKeys, err := ctx.Validators.GetBitcoinKeys(opt.BTCParams)
+	if err != nil {
+		return nil, err
+	}
+	nValidators := 0
+	ctx.Validators.Iterate(func(key keys.Address, validator *identity.Validator) bool {
+		nValidators++
+		return false
+	})
+	if len(validatorPubKeys) == 0 || len(validatorPubKeys) != nValidators {
+		return nil, errors.Errorf("bitcoin keys of %d validators out of %d", len(validatorPubKeys), nValidators)
+	}
+	m := (len(validatorPubKeys) * 2 / 3) + 1
+
+	migration := &btcMigration{trackers: make([]*bitcoin.Tracker, 0)}
+	migration.lockScript, migration.lockScriptAddress, _, err = btcchain.CreateWitnessMultiSigAddress(m, validatorPubKeys, nil, opt.BTCParams)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.BTCTrackers.Iterate(func(k, v []byte) bool {
+		t := &bitcoin.Tracker{}
+		err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(v, t)
+		if err == nil && t.IsAvailable() {
+			migration.trackers = append(migration.trackers, t)
+		}
+		return false
+	})
+
+	for _, tracker := range migration.trackers {
+		tracker.ProcessLockScriptAddress = migration.lockScriptAddress
+		if tracker.CurrentTxId == nil || btcchain.IsWitnessLock(tracker.CurrentLockScriptAddress) {
+			continue
+		}
+		txBytes, balance, err := btcchain.PrepareSweep(tracker.CurrentTxId, 0, tracker.CurrentBalance,
+			tracker.CurrentLockScriptAddress, migration.lockScriptAddress, btcchain.DefaultFeeRate)
+		if err != nil {
+			ctx.Logger.Error("skipping sweep of tracker", tracker.Name, err)
+			continue
+		}
+		tracker.ProcessType = bitcoin.ProcessTypeMigrate
+		tracker.ProcessBalance = balance
+		tracker.ProcessUnsignedTx = txBytes
+		tracker.Multisig.Msg = txBytes
+		tracker.Multisig.Signatures = []keys.BTCSignature{}
+		tracker.State = bitcoin.Requested
+		migration.sweepFee += tracker.CurrentBalance - balance
+	}
+
+	if migration.sweepFee > 0 {
+		btcCurr, ok := ctx.Currencies.GetCurrencyByName("BTC")
+		if !ok {
+			return nil, errors.New("failed to find currency BTC")
+		}
+		pool, err := ctx.Balances.GetBalanceForCurr(keys.Address(fees.POOL_KEY), &btcCurr)
+		if err != nil {
+			return nil, err
+		}
+		fee := btcCurr.NewCoinFromUnit(migration.sweepFee)
+		if !fee.LessThanEqualCoin(pool) {
+			return nil, errors.Errorf("fee pool holds %s, the sweep fees need %s", pool.String(), fee.String())
+		}
+	}
+	return migration, nil
+}
+
+// apply saves the migrated trackers and burns the oBTC matching the sweep fees from the fee pool
+func (migration *btcMigration) apply(ctx *Context) error {
+	err := ctx.LockScriptStore.SaveLockScript(migration.lockScriptAddress, migration.lockScript)
+	if err != nil {
+		return err
+	}
+	for _, tracker := range migration.trackers {
+		err = ctx.BTCTrackers.SetTracker(tracker.Name, tracker)
+		if err != nil {
+			return err
+		}
+	}
+	if migration.sweepFee == 0 {
+		return nil
+	}
+
+	btcCurr, ok := ctx.Currencies.GetCurrencyByName("BTC")
+	if !ok {
+		return errors.New("failed to find currency BTC")
+	}
+	fee := btcCurr.NewCoinFromUnit(migration.sweepFee)
+	err = ctx.Balances.MinusFromAddress(keys.Address(fees.POOL_KEY), fee)
+	if err != nil {
+		return errors.Wrap(err, "failed to burn the sweep fees from the fee pool")
+	}
+	tally := keys.Address(ctx.BTCTrackers.GetOption().TotalSupplyAddr)
+	err = ctx.Balances.MinusFromAddress(tally, fee)
+	if err != nil {
+		return errors.Wrap(err, "failed to burn the sweep fees from the btc supply")
+	}
+	return nil
+}
+
+func bridgeOptionslimit(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	fields, err := getNewValueList(value, 5)
+	if err != nil {
+		return false, err
+	}
+	amounts := make([]balance.Amount, 3)
+	for i := range amounts {
+		amount, err := getNewBigInt(fields[i+1])
+		if err != nil {
+			return false, err
+		}
+		amounts[i] = *balance.NewAmountFromBigInt(amount)
+	}
+	window, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return false, err
+	}
+	options, err := ctx.GovernanceStore.GetBridgeOptions()
+	if err != nil {
+		return false, err
+	}
+	options.SetLimit(bridge.Limit{
+		Asset:   fields[0],
+		MaxTx:   amounts[0],
+		Inflow:  amounts[1],
+		Outflow: amounts[2],
+		Window:  window,
+	})
+	ok, err := ctx.GovernanceStore.ValidateBridge(options)
+	if err != nil || !ok {
+		return false, errors.Wrap(err, "Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetBridgeOptions(*options)
+	if err != nil {
+		return false, errors.Wrap(err, "Setup Bridge Options")
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_BRIDGE)
+	if err != nil {
+		return false, errors.Wrap(err, "Unable to set last Update height ")
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| bridgeOptions.limit :", fields[0])
+	return true, nil
+}
+
+func bridgeOptionspause(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	chainType, err := getBridgeChain(value)
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	pause, err := ctx.Bridge.GetPause(chainType)
+	if err != nil {
+		return false, err
+	}
+	pause.Paused = true
+	pause.Height = ctx.Header.Height
+	pause.Reason = "governance"
+	err = ctx.Bridge.SetPause(pause)
+	if err != nil {
+		return false, bridge.ErrUnableToSetPause.Wrap(err)
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| bridgeOptions.pause :", chainType)
+	return true, nil
+}
+
+func bridgeOptionsresume(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	chainType, err := getBridgeChain(value)
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.Bridge.Resume(chainType)
+	if err != nil {
+		return false, bridge.ErrUnableToSetPause.Wrap(err)
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| bridgeOptions.resume :", chainType)
+	return true, nil
+}
+
+func bridgeOptionsrotateWitnesses(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	str, ok := value.(string)
+	if !ok {
+		return false, errors.New("Type assertion failed")
+	}
+	fields := strings.Split(str, ",")
+	if len(fields) < 2 {
+		return false, errors.New("expected a chain and the validator addresses of the new witnesses")
+	}
+	chainType, err := getBridgeChain(fields[0])
+	if err != nil {
+		return false, err
+	}
+	if chainType != chain.ETHEREUM && !chainType.IsEVM() {
+		return false, errors.Errorf("%s has no witnesses", chainType)
+	}
+	witnesses := make([]identity.Witness, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		addr := keys.Address{}
+		err := addr.UnmarshalText([]byte(strings.TrimSpace(field)))
+		if err != nil {
+			return false, err
+		}
+		validator, err := ctx.Validators.Get(addr)
+		if err != nil {
+			return false, errors.Wrapf(err, "new witness %s is not a validator", addr.String())
+		}
+		witnesses = append(witnesses, identity.Witness{
+			Address:     validator.Address,
+			PubKey:      validator.PubKey,
+			ECDSAPubKey: validator.ECDSAPubKey,
+			Name:        validator.Name,
+		})
+	}
+	_, err = (&identity.WitnessRotation{Witnesses: witnesses}).ETHAddresses()
+	if err != nil {
+		return false, err
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	rotation, err := ctx.Witnesses.StartRotation(chainType, witnesses, ctx.Header.Height, "governance")
+	if err != nil {
+		return false, bridge.ErrUnableToRotate.Wrap(err)
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| bridgeOptions.rotateWitnesses :", chainType, "epoch", rotation.Epoch, "round", rotation.Round)
+	return true, nil
+}
+
+func bridgeOptionscancelRotation(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	chainType, err := getBridgeChain(value)
+	if err != nil {
+		return false, err
+	}
+	pending, err := ctx.Witnesses.GetPendingRotation(chainType)
+	if err != nil {
+		return false, err
+	}
+	if pending == nil {
+		return false, errors.Errorf("no witness rotation of %s pending", chainType)
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	_, err = ctx.Witnesses.CancelRotation(chainType, ctx.Header.Height, "governance")
+	if err != nil {
+		return false, bridge.ErrUnableToRotate.Wrap(err)
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| bridgeOptions.cancelRotation :", chainType, "epoch", pending.Epoch)
+	return true, nil
+}
+
+// getBridgeChain returns the bridged chain named by a governance value
+func getBridgeChain(value interface{}) (chain.Type, error) {
+	str, ok := value.(string)
+	if !ok {
+		return chain.Type(-1), errors.New("Type assertion failed")
+	}
+	chainType, err := chain.TypeFromName(strings.TrimSpace(str))
+	if err != nil {
+		return chain.Type(-1), err
+	}
+	if chainType != chain.BITCOIN && chainType != chain.ETHEREUM && !chainType.IsEVM() {
+		return chain.Type(-1), errors.Errorf("%s has no bridge", chainType)
+	}
+	return chainType, nil
+}
+
+func getNewValueList(value interface{}, n int) ([]string, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, errors.New("Type assertion failed")
+	}
+	fields := strings.Split(str, ",")
+	if len(fields) != n {
+		return nil, errors.Errorf("expected %d comma separated values, got %d", n, len(fields))
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields, nil
+}
+
+// validateRewardSchedule makes sure the new schedule does not issue more than what is left in the reward pool
+func validateRewardSchedule(ctx *Context, oldOptions *rewards.Options, newOptions *rewards.Options) error {
+	curr, ok := ctx.Currencies.GetCurrencyByName(newOptions.RewardCurrency)
+	if !ok {
+		return errors.New("reward currency not found")
+	}
+	pool, err := ctx.Balances.GetBalanceForCurr(keys.Address(newOptions.RewardPoolAddress), &curr)
+	if err != nil {
+		return errors.Wrap(err, "failed to get reward pool balance")
+	}
+	return ctx.RewardMasterStore.RewardCm.ValidateScheduleUpdate(oldOptions, newOptions, *pool.Amount, ctx.Header.Time)
+}
+
+func setRewardOptions(ctx *Context, options *rewards.Options) error {
+	err := ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetRewardOptions(*options)
+	if err != nil {
+		return errors.Wrap(err, "Setup Reward Options")
+	}
+	ctx.RewardMasterStore.SetOptions(options)
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_REWARDS)
+	if err != nil {
+		return errors.Wrap(err, "Unable to set last Update height ")
+	}
+	return nil
+}
+
+func getNewAmountList(value interface{}) ([]balance.Amount, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, errors.New("Type assertion failed")
+	}
+	amounts := make([]balance.Amount, 0)
+	for _, s := range strings.Split(str, ",") {
+		n, err := getNewBigInt(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, *balance.NewAmountFromBigInt(n))
+	}
+	return amounts, nil
+}
+
 func getNewValueInt64(value interface{}) (int64, error) {
 	newValue, ok := value.(string)
 	if !ok {
@@ -0,0 +1,185 @@
+package action
+
+import (
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	db "github.com/tendermint/tm-db"
+
+	btcchain "github.com/Oneledger/protocol/chains/bitcoin"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const testTotalSupplyAddr = "btcSupply"
+
+// setupBTCMigration builds a context with four validators and a legacy, a segwit and an empty tracker
+func setupBTCMigration(t *testing.T) (*Context, *storage.State) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	cfg := config.DefaultServerConfig()
+
+	ctx := &Context{
+		Header:          &abci.Header{Height: 2},
+		State:           state,
+		Balances:        balance.NewStore("b", state),
+		Currencies:      balance.NewCurrencySet(),
+		Validators:      identity.NewValidatorStore("v", "purged", "rotation", "profile", state),
+		BTCTrackers:     bitcoin.NewTrackerStore("btct", state),
+		LockScriptStore: bitcoin.NewLockScriptStore(*cfg, t.TempDir()),
+		GovernanceStore: governance.NewStore("g", state),
+		Logger:          log.NewLoggerWithPrefix(os.Stdout, "action"),
+	}
+	require.NoError(t, ctx.Currencies.Register(balance.Currency{Id: 1, Name: "BTC", Chain: chain.BITCOIN, Decimal: 8, Unit: "satoshi"}))
+
+	option := btcchain.ChainDriverOption{ChainType: "testnet3", TotalSupply: "1000000000", TotalSupplyAddr: testTotalSupplyAddr, BlockConfirmation: 6}
+	require.NoError(t, ctx.GovernanceStore.WithHeight(1).SetBTCChainDriverOption(option))
+	require.NoError(t, ctx.GovernanceStore.WithHeight(1).SetAllLUH())
+	ctx.BTCTrackers.SetOption(option)
+	ctx.BTCTrackers.SetConfig(bitcoin.BTCConfig{BTCParams: &chaincfg.TestNet3Params})
+
+	signers := make([]keys.Address, 0, 4)
+	for i := 0; i < 4; i++ {
+		pubKey := ed25519.GenPrivKey().PubKey()
+		btcKey, err := btcec.NewPrivateKey(btcec.S256())
+		require.NoError(t, err)
+		ecdsaKey, err := keys.GetPrivateKeyFromBytes(btcKey.Serialize(), keys.BTCECSECP)
+		require.NoError(t, err)
+		h, err := ecdsaKey.GetHandler()
+		require.NoError(t, err)
+		addr := keys.Address(pubKey.Address())
+		validator := identity.NewValidator(addr, addr, keys.PublicKey{KeyType: keys.ED25519, Data: pubKey.Bytes()[5:]},
+			h.PubKey(), *balance.NewAmountFromInt(1), "validator")
+		require.NoError(t, ctx.Validators.Set(*validator))
+		signers = append(signers, addr)
+	}
+	state.Commit()
+
+	validatorPubKeys, err := ctx.Validators.GetBitcoinKeys(&chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	legacyScript, legacyAddress, _, err := btcchain.CreateMultiSigAddress(3, validatorPubKeys, nil, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	witnessScript, witnessAddress, _, err := btcchain.CreateWitnessMultiSigAddress(3, validatorPubKeys, nil, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	require.NoError(t, ctx.LockScriptStore.SaveLockScript(legacyAddress, legacyScript))
+	require.NoError(t, ctx.LockScriptStore.SaveLockScript(witnessAddress, witnessScript))
+
+	for _, tracker := range []struct {
+		name        string
+		lockAddress []byte
+		balance     int64
+	}{
+		{"tracker_legacy", legacyAddress, 100000},
+		{"tracker_segwit", witnessAddress, 200000},
+		{"tracker_empty", nil, 0},
+	} {
+		tr, err := bitcoin.NewTracker(legacyAddress, 3, signers)
+		require.NoError(t, err)
+		tr.Name = tracker.name
+		if tracker.lockAddress != nil {
+			tr.CurrentTxId = &chainhash.Hash{1}
+			tr.CurrentLockScriptAddress = tracker.lockAddress
+			tr.CurrentBalance = tracker.balance
+		}
+		require.NoError(t, ctx.BTCTrackers.SetTracker(tr.Name, tr))
+	}
+
+	btcCurr, _ := ctx.Currencies.GetCurrencyByName("BTC")
+	require.NoError(t, ctx.Balances.AddToAddress(keys.Address(testTotalSupplyAddr), btcCurr.NewCoinFromUnit(300000)))
+	state.Commit()
+	return ctx, state
+}
+
+func TestBTCOptionsSegWit_Migration(t *testing.T) {
+	ctx, state := setupBTCMigration(t)
+	btcCurr, _ := ctx.Currencies.GetCurrencyByName("BTC")
+	pool := keys.Address(fees.POOL_KEY)
+
+	// the fee pool has no oBTC to burn for the sweep fee yet
+	ok, err := btcOptionssegWit("true", ctx, ValidateOnly)
+	assert.False(t, ok)
+	assert.Error(t, err)
+
+	require.NoError(t, ctx.Balances.AddToAddress(pool, btcCurr.NewCoinFromUnit(100000)))
+	state.Commit()
+	ok, err = btcOptionssegWit("true", ctx, ValidateOnly)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	ok, err = btcOptionssegWit("true", ctx, ValidateAndUpdate)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	state.Commit()
+	assert.True(t, ctx.BTCTrackers.GetOption().SegWit)
+
+	// the legacy tracker sweeps its balance to the witness address
+	legacy, err := ctx.BTCTrackers.Get("tracker_legacy")
+	require.NoError(t, err)
+	assert.Equal(t, bitcoin.ProcessTypeMigrate, legacy.ProcessType)
+	assert.Equal(t, bitcoin.Requested, legacy.State)
+	assert.True(t, btcchain.IsWitnessLock(legacy.ProcessLockScriptAddress))
+	assert.NotEmpty(t, legacy.ProcessUnsignedTx)
+	sweepFee := legacy.CurrentBalance - legacy.ProcessBalance
+	assert.True(t, sweepFee > 0)
+
+	// the segwit and the empty trackers only move their next lock address
+	for _, name := range []string{"tracker_segwit", "tracker_empty"} {
+		tracker, err := ctx.BTCTrackers.Get(name)
+		require.NoError(t, err)
+		assert.Equal(t, bitcoin.ProcessTypeNone, tracker.ProcessType)
+		assert.True(t, tracker.IsAvailable())
+		assert.True(t, btcchain.IsWitnessLock(tracker.ProcessLockScriptAddress))
+	}
+	_, err = ctx.LockScriptStore.GetLockScript(legacy.ProcessLockScriptAddress)
+	assert.NoError(t, err)
+
+	// the oBTC matching the sweep fee is burnt from the fee pool and the supply
+	poolCoin, err := ctx.Balances.GetBalanceForCurr(pool, &btcCurr)
+	require.NoError(t, err)
+	assert.Equal(t, btcCurr.NewCoinFromUnit(100000-sweepFee).String(), poolCoin.String())
+	supply, err := ctx.Balances.GetBalanceForCurr(keys.Address(testTotalSupplyAddr), &btcCurr)
+	require.NoError(t, err)
+	assert.Equal(t, btcCurr.NewCoinFromUnit(300000-sweepFee).String(), supply.String())
+}
+
+func TestBTCOptionsSegWit_MigrationChecks(t *testing.T) {
+	t.Run("without lock script store", func(t *testing.T) {
+		ctx, _ := setupBTCMigration(t)
+		ctx.LockScriptStore = nil
+		ok, err := btcOptionssegWit("true", ctx, ValidateOnly)
+		assert.False(t, ok)
+		assert.Error(t, err)
+	})
+	t.Run("without segwit params", func(t *testing.T) {
+		ctx, _ := setupBTCMigration(t)
+		ctx.BTCTrackers.SetConfig(bitcoin.BTCConfig{})
+		ok, err := btcOptionssegWit("true", ctx, ValidateOnly)
+		assert.False(t, ok)
+		assert.Error(t, err)
+	})
+	t.Run("validator without bitcoin key", func(t *testing.T) {
+		ctx, state := setupBTCMigration(t)
+		pubKey := ed25519.GenPrivKey().PubKey()
+		addr := keys.Address(pubKey.Address())
+		key := keys.PublicKey{KeyType: keys.ED25519, Data: pubKey.Bytes()[5:]}
+		require.NoError(t, ctx.Validators.Set(*identity.NewValidator(addr, addr, key, key, *balance.NewAmountFromInt(1), "validator")))
+		state.Commit()
+		ok, err := btcOptionssegWit("true", ctx, ValidateOnly)
+		assert.False(t, ok)
+		assert.Error(t, err)
+	})
+}
@@ -18,21 +18,28 @@ const (
 	SENDPOOL Type = 0x02
 
 	//staking related transaction
-	STAKE    Type = 0x11
-	UNSTAKE  Type = 0x12
-	WITHDRAW Type = 0x13
+	STAKE          Type = 0x11
+	UNSTAKE        Type = 0x12
+	WITHDRAW       Type = 0x13
+	ROTATE_KEY     Type = 0x14
+	EDIT_VALIDATOR Type = 0x15
 
 	//network network_delegation
 	ADD_NETWORK_DELEGATE              Type = 0x51
 	NETWORK_UNDELEGATE                Type = 0x52
 	REWARDS_WITHDRAW_NETWORK_DELEGATE Type = 0x53
 	REWARDS_REINVEST_NETWORK_DELEGATE Type = 0x54
+	AUTO_COMPOUND_NETWORK_DELEGATE    Type = 0x55
 
 	//Evidence
 	ALLEGATION      Type = 0x61
 	ALLEGATION_VOTE Type = 0x62
 	RELEASE         Type = 0x63
 
+	//Bridge
+	BRIDGE_PAUSE          Type = 0x71
+	BRIDGE_ROTATE_WITNESS Type = 0x72
+
 	//ons related transaction
 	DOMAIN_CREATE     Type = 0x21
 	DOMAIN_UPDATE     Type = 0x22
@@ -56,6 +63,10 @@ const (
 	ETH_REDEEM               Type = 0x93
 	ERC20_LOCK               Type = 0x94
 	ERC20_REDEEM             Type = 0x95
+	ERC721_LOCK              Type = 0x96
+	ERC721_REDEEM            Type = 0x97
+	NFT_TRANSFER             Type = 0x98
+	ETH_RETRY                Type = 0x99
 
 	//Governance Action
 	PROPOSAL_CREATE         Type = 0x30
@@ -92,6 +103,8 @@ func init() {
 	RegisterTxType(STAKE, "STAKE")
 	RegisterTxType(UNSTAKE, "UNSTAKE")
 	RegisterTxType(WITHDRAW, "WITHDRAW")
+	RegisterTxType(ROTATE_KEY, "ROTATE_KEY")
+	RegisterTxType(EDIT_VALIDATOR, "EDIT_VALIDATOR")
 
 	RegisterTxType(DOMAIN_CREATE, "DOMAIN_CREATE")
 	RegisterTxType(DOMAIN_UPDATE, "DOMAIN_UPDATE")
@@ -114,6 +127,10 @@ func init() {
 	RegisterTxType(ETH_REDEEM, "ETH_REDEEM")
 	RegisterTxType(ERC20_LOCK, "ERC20_LOCK")
 	RegisterTxType(ERC20_REDEEM, "ERC20_REDEEM")
+	RegisterTxType(ERC721_LOCK, "ERC721_LOCK")
+	RegisterTxType(ERC721_REDEEM, "ERC721_REDEEM")
+	RegisterTxType(NFT_TRANSFER, "NFT_TRANSFER")
+	RegisterTxType(ETH_RETRY, "ETH_RETRY")
 
 	RegisterTxType(PROPOSAL_CREATE, "PROPOSAL_CREATE")
 	RegisterTxType(PROPOSAL_CANCEL, "PROPOSAL_CANCEL")
@@ -129,11 +146,15 @@ func init() {
 	RegisterTxType(NETWORK_UNDELEGATE, "NETWORK_UNDELEGATE")
 	RegisterTxType(REWARDS_WITHDRAW_NETWORK_DELEGATE, "REWARDS_WITHDRAW_NETWORK_DELEGATE")
 	RegisterTxType(REWARDS_REINVEST_NETWORK_DELEGATE, "REWARDS_REINVEST_NETWORK_DELEGATE")
+	RegisterTxType(AUTO_COMPOUND_NETWORK_DELEGATE, "AUTO_COMPOUND_NETWORK_DELEGATE")
 
 	RegisterTxType(ALLEGATION, "ALLEGATION")
 	RegisterTxType(ALLEGATION_VOTE, "ALLEGATION_VOTE")
 	RegisterTxType(RELEASE, "RELEASE")
 
+	RegisterTxType(BRIDGE_PAUSE, "BRIDGE_PAUSE")
+	RegisterTxType(BRIDGE_ROTATE_WITNESS, "BRIDGE_ROTATE_WITNESS")
+
 	RegisterTxType(OLVM, "OLVM")
 }
 
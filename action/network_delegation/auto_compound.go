@@ -0,0 +1,144 @@
+package network_delegation
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/data/balance"
+	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
+)
+
+// AutoCompound turns automatic reinvestment of rewards on or off for a delegator,
+// once enabled the rewards balance moves to active delegation when it reaches MinAmount
+type AutoCompound struct {
+	Delegator action.Address `json:"delegator"`
+	Enable    bool           `json:"enable"`
+	MinAmount action.Amount  `json:"minAmount"`
+}
+
+func (ac AutoCompound) Signers() []action.Address {
+	return []action.Address{ac.Delegator}
+}
+
+func (ac AutoCompound) Type() action.Type {
+	return action.AUTO_COMPOUND_NETWORK_DELEGATE
+}
+
+func (ac AutoCompound) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(ac.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.Delegator"),
+		Value: ac.Delegator.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.Enable"),
+		Value: []byte(strconv.FormatBool(ac.Enable)),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.MinAmount"),
+		Value: []byte(ac.MinAmount.String()),
+	}
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+func (ac AutoCompound) Marshal() ([]byte, error) {
+	return json.Marshal(ac)
+}
+
+func (ac *AutoCompound) Unmarshal(bytes []byte) error {
+	return json.Unmarshal(bytes, ac)
+}
+
+type delegAutoCompoundTx struct {
+}
+
+func (delegAutoCompoundTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool, error) {
+	compound := AutoCompound{}
+	err := compound.Unmarshal(signedTx.Data)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateBasic(signedTx.RawBytes(), compound.Signers(), signedTx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	err = compound.Delegator.Err()
+	if err != nil {
+		return false, errors.Wrap(action.ErrInvalidAddress, err.Error())
+	}
+
+	// threshold is only needed to opt in
+	if !compound.Enable {
+		return true, nil
+	}
+
+	currency, ok := ctx.Currencies.GetCurrencyByName("OLT")
+	if !ok {
+		return false, errors.Wrap(action.ErrInvalidCurrency, compound.MinAmount.Currency)
+	}
+	if currency.Name != compound.MinAmount.Currency {
+		return false, errors.Wrap(action.ErrInvalidAmount, compound.MinAmount.String())
+	}
+
+	minAmount, err := balance.NewAmountFromString(netwkDeleg.MIN_AUTO_COMPOUND_AMOUNT, 10)
+	if err != nil {
+		return false, err
+	}
+	if compound.MinAmount.Value.LessThan(*minAmount) {
+		return false, errors.Wrapf(action.ErrInvalidAmount, "auto compound threshold should be at least %s", minAmount.String())
+	}
+	return true, nil
+}
+
+func (delegAutoCompoundTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runAutoCompound(ctx, tx)
+}
+
+func (delegAutoCompoundTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runAutoCompound(ctx, tx)
+}
+
+func (delegAutoCompoundTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+var _ action.Msg = &AutoCompound{}
+var _ action.Tx = &delegAutoCompoundTx{}
+
+func runAutoCompound(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	compound := AutoCompound{}
+	err := compound.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, compound.Tags(), err)
+	}
+
+	if compound.Enable {
+		err = ctx.NetwkDelegators.Rewards.SetAutoCompound(compound.Delegator, &compound.MinAmount.Value)
+	} else {
+		err = ctx.NetwkDelegators.Rewards.DisableAutoCompound(compound.Delegator)
+	}
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, netwkDeleg.ErrAutoCompound, compound.Tags(), err)
+	}
+
+	ctx.Logger.Debugf("Successfully updated auto compound, delegator= %s, enable= %v", compound.Delegator.String(), compound.Enable)
+	return helpers.LogAndReturnTrue(ctx.Logger, compound.Tags(), "Success")
+}
@@ -29,6 +29,10 @@ func EnableNetworkDelegation(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "ReinvestRewardsTx")
 	}
+	err = r.AddHandler(action.AUTO_COMPOUND_NETWORK_DELEGATE, delegAutoCompoundTx{})
+	if err != nil {
+		return errors.Wrap(err, "AutoCompoundTx")
+	}
 	//err = r.AddHandler(action.REWARDS_FINALIZE_NETWORK_DELEGATE, finalizeWithdrawRewardsTx{})
 	//if err != nil {
 	//	return errors.Wrap(err, "WithdrawRewardsTx")
@@ -42,9 +42,11 @@ type Transaction struct {
 	Amount  action.Amount   `json:"amount"`
 	Data    []byte          `json:"data"`
 	ChainID *big.Int        `json:"chainID"`
-	// for future
+	// EIP-2718 envelope type, legacy, access list (EIP-2930) or dynamic fee (EIP-1559)
 	TxType     int64                `json:"type"`
 	AccessList *ethtypes.AccessList `json:"accessList"`
+	// tip over the base fee of a dynamic fee transaction, the fee price of the raw tx is its fee cap
+	GasTipCap *big.Int `json:"gasTipCap,omitempty"`
 }
 
 func (tx Transaction) Marshal() ([]byte, error) {
@@ -103,25 +105,77 @@ func (tx Transaction) isSendTx() bool {
 	return true
 }
 
-func (tx *Transaction) tmToEthTx(ctx *action.Context, raw action.RawTx) *ethtypes.Transaction {
+func (tx *Transaction) tmToEthTx(ctx *action.Context, raw action.RawTx) (*ethtypes.Transaction, error) {
 	var to *ethcmn.Address
 	if tx.To != nil {
 		to = new(ethcmn.Address)
 		*to = ethcmn.BytesToAddress(tx.To.Bytes())
 	}
-	ethTx := ethtypes.NewTx(&ethtypes.LegacyTx{
-		Nonce:    tx.Nonce,
-		To:       to,
-		Value:    tx.Amount.Value.BigInt(),
-		Gas:      uint64(raw.Fee.Gas),
-		GasPrice: raw.Fee.Price.Value.BigInt(),
-		Data:     tx.Data,
-	})
-	return ethTx
+	var accessList ethtypes.AccessList
+	if tx.AccessList != nil {
+		accessList = *tx.AccessList
+	}
+	switch tx.TxType {
+	case ethtypes.LegacyTxType:
+		return ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce:    tx.Nonce,
+			To:       to,
+			Value:    tx.Amount.Value.BigInt(),
+			Gas:      uint64(raw.Fee.Gas),
+			GasPrice: raw.Fee.Price.Value.BigInt(),
+			Data:     tx.Data,
+		}), nil
+	case ethtypes.AccessListTxType:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    tx.ChainID,
+			Nonce:      tx.Nonce,
+			To:         to,
+			Value:      tx.Amount.Value.BigInt(),
+			Gas:        uint64(raw.Fee.Gas),
+			GasPrice:   raw.Fee.Price.Value.BigInt(),
+			Data:       tx.Data,
+			AccessList: accessList,
+		}), nil
+	case ethtypes.DynamicFeeTxType:
+		if tx.GasTipCap == nil {
+			return nil, errors.New("missing gas tip cap")
+		}
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    tx.ChainID,
+			Nonce:      tx.Nonce,
+			To:         to,
+			Value:      tx.Amount.Value.BigInt(),
+			Gas:        uint64(raw.Fee.Gas),
+			GasTipCap:  tx.GasTipCap,
+			GasFeeCap:  raw.Fee.Price.Value.BigInt(),
+			Data:       tx.Data,
+			AccessList: accessList,
+		}), nil
+	}
+	return nil, ethtypes.ErrTxTypeNotSupported
 }
 
 func (tx *Transaction) getEthSigner(ctx *action.Context) ethtypes.Signer {
-	return ethtypes.NewEIP155Signer(utils.HashToBigInt(ctx.Header.ChainID))
+	return ethtypes.NewLondonSigner(utils.HashToBigInt(ctx.Header.ChainID))
+}
+
+// getBaseFee returns the price per gas every transaction of the block pays at least
+func getBaseFee(ctx *action.Context) *big.Int {
+	return ctx.FeePool.GetOpt().BaseFee().Amount.BigInt()
+}
+
+// effectiveGasPrice returns the price per gas the sender pays, a dynamic fee transaction pays the
+// base fee and its tip up to its fee cap, the others their gas price
+func (tx *Transaction) effectiveGasPrice(fee action.Fee, baseFee *big.Int) *big.Int {
+	price := fee.Price.Value.BigInt()
+	if tx.TxType != ethtypes.DynamicFeeTxType || tx.GasTipCap == nil {
+		return price
+	}
+	tip := new(big.Int).Add(baseFee, tx.GasTipCap)
+	if tip.Cmp(price) < 0 {
+		return tip
+	}
+	return price
 }
 
 func (tx *Transaction) validateSigner(ctx *action.Context, signedTx action.SignedTx) error {
@@ -131,8 +185,11 @@ func (tx *Transaction) validateSigner(ctx *action.Context, signedTx action.Signe
 
 	//validate basic signature
 	signer := tx.getEthSigner(ctx)
-	ethTx := tx.tmToEthTx(ctx, signedTx.RawTx)
-	ethTx, err := ethTx.WithSignature(signer, signedTx.Signatures[0].Signed)
+	ethTx, err := tx.tmToEthTx(ctx, signedTx.RawTx)
+	if err != nil {
+		return err
+	}
+	ethTx, err = ethTx.WithSignature(signer, signedTx.Signatures[0].Signed)
 	if err != nil {
 		return err
 	}
@@ -155,8 +212,10 @@ func (tx *Transaction) validateSigner(ctx *action.Context, signedTx action.Signe
 // validateEthTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (tx *Transaction) validateEthTx(keeper balance.AccountKeeper, ethTx *ethtypes.Transaction, minFee *big.Int, local bool) error {
-	// Accept only legacy transactions until EIP-2718/2930 activates.
-	if ethTx.Type() != ethtypes.LegacyTxType {
+	// Accept legacy, access list and dynamic fee transactions only
+	switch ethTx.Type() {
+	case ethtypes.LegacyTxType, ethtypes.AccessListTxType, ethtypes.DynamicFeeTxType:
+	default:
 		return ethtypes.ErrTxTypeNotSupported
 	}
 	// Reject transactions over defined size to prevent DOS attacks
@@ -173,6 +232,10 @@ func (tx *Transaction) validateEthTx(keeper balance.AccountKeeper, ethTx *ethtyp
 	if vm.SimulationBlockGasLimit < ethTx.Gas() {
 		return ethcore.ErrGasLimit
 	}
+	// Ensure the tip of a dynamic fee transaction stays under its fee cap
+	if ethTx.GasFeeCap().Cmp(ethTx.GasTipCap()) < 0 {
+		return ethcore.ErrTipAboveFeeCap
+	}
 	// Drop non-local transactions under our own minimal accepted gas price
 	if !local && ethTx.GasPrice().Cmp(minFee) < 0 {
 		return ethcore.ErrUnderpriced
@@ -238,9 +301,14 @@ func (otx olvmTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 		return false, action.ErrInvalidAddress
 	}
 
+	ethTx, err := tx.tmToEthTx(ctx, signedTx.RawTx)
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.validateEthTx(
 		ctx.StateDB.GetAccountKeeper(),
-		tx.tmToEthTx(ctx, signedTx.RawTx),
+		ethTx,
 		ctx.FeePool.GetOpt().MinFee().Amount.BigInt(),
 		true,
 	)
@@ -273,6 +341,12 @@ func (otx olvmTx) ProcessDeliver(ctx *action.Context, rawTx action.RawTx) (ok bo
 }
 
 func (otx olvmTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (ok bool, result action.Response) {
+	// charge the price the vm bought the gas at
+	tx := &Transaction{}
+	if err := tx.Unmarshal(signedTx.Data); err == nil {
+		price := tx.effectiveGasPrice(signedTx.Fee, getBaseFee(ctx))
+		signedTx.Fee.Price = action.Amount{Currency: signedTx.Fee.Price.Currency, Value: *balance.NewAmountFromBigInt(price)}
+	}
 	ok, result = action.ContractFeeHandling(ctx, signedTx, gasUsed, start)
 	ctx.Logger.Detailf("Processing OLVM Transaction for BasicFeeHandling: status - %t\n", ok)
 	return ok, result
@@ -285,6 +359,7 @@ func runOLVM(ctx *action.Context, rawTx action.RawTx) (bool, action.Response) {
 		return ResponseFailed(tx.Tags(), err, action.WrongFee)
 	}
 
+	gasPrice := tx.effectiveGasPrice(rawTx.Fee, getBaseFee(ctx))
 	evmTx := vm.NewEVMTransaction(
 		ctx.StateDB,
 		new(ethcore.GasPool).AddGas(ctx.StateDB.GetAvailableGas()),
@@ -296,13 +371,16 @@ func runOLVM(ctx *action.Context, rawTx action.RawTx) (bool, action.Response) {
 		tx.Data,
 		tx.AccessList,
 		uint64(rawTx.Fee.Gas),
-		rawTx.Fee.Price.Value.BigInt(),
+		gasPrice,
 		false,
 	)
 
 	evmTx.SetVMDebug(enableVMDebug)
 
-	tags := tx.Tags()
+	tags := append(tx.Tags(), kv.Pair{
+		Key:   []byte("tx.gasPrice"),
+		Value: gasPrice.Bytes(),
+	})
 
 	if execResult, err := evmTx.Apply(); err != nil {
 		ctx.Logger.Detailf("OLVM TX: Execution apply VM got err: %s\n", err.Error())
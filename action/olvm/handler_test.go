@@ -27,6 +27,7 @@ import (
 	"github.com/Oneledger/protocol/utils"
 	"github.com/Oneledger/protocol/vm"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
@@ -144,7 +145,7 @@ func assemblyCtxData(currencyName string, currencyDecimal int, setStore bool, se
 		RewardPoolAddress: "rewardspool",
 	}
 	ctx.RewardMasterStore.SetOptions(&rewardOptions)
-	ctx.GovernanceStore.WithHeight(0).SetFeeOption(*ctx.FeeOpt)
+	ctx.GovernanceStore.WithHeight(0).SetFeeOption(ctx.FeeOpt)
 	ctx.GovernanceStore.WithHeight(0).SetProposalOptions(pOpt)
 	ctx.GovernanceStore.WithHeight(0).SetRewardOptions(rewardOptions)
 	ctx.GovernanceStore.WithHeight(0).SetAllLUH()
@@ -839,3 +840,230 @@ func TestRunner_BaseSmartContract(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+func assemblyTypedData(from keys.Address, chainID *big.Int, fromPrikey *ecdsa.PrivateKey, txData ethtypes.TxData) action.SignedTx {
+	signer := ethtypes.NewLondonSigner(chainID)
+	tx, err := ethtypes.SignNewTx(fromPrikey, signer, txData)
+	if err != nil {
+		panic(err)
+	}
+
+	// typed transactions carry the bare recovery id
+	V, R, S := tx.RawSignatureValues()
+	V = new(big.Int).Add(V, big.NewInt(27))
+
+	pub, err := utils.RecoverPlain(signer.Hash(tx), R, S, V, true)
+	if err != nil {
+		panic(err)
+	}
+	pubKey, err := keys.GetPublicKeyFromBytes(ethcrypto.CompressPubkey(pub), keys.ETHSECP)
+	if err != nil {
+		panic(err)
+	}
+
+	accessList := tx.AccessList()
+	av := &Transaction{
+		From:       from,
+		Amount:     action.Amount{Currency: "OLT", Value: *balance.NewAmountFromBigInt(tx.Value())},
+		Data:       tx.Data(),
+		Nonce:      tx.Nonce(),
+		ChainID:    chainID,
+		TxType:     int64(tx.Type()),
+		AccessList: &accessList,
+	}
+	if tx.To() != nil {
+		to := keys.Address(tx.To().Bytes())
+		av.To = &to
+	}
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		av.GasTipCap = tx.GasTipCap()
+	}
+	data, _ := av.Marshal()
+	return action.SignedTx{
+		RawTx: action.RawTx{
+			Type: av.Type(),
+			Data: data,
+			Fee: action.Fee{
+				Price: action.Amount{Currency: "OLT", Value: *balance.NewAmountFromBigInt(tx.GasFeeCap())},
+				Gas:   int64(tx.Gas()),
+			},
+			Memo: strconv.FormatUint(tx.Nonce(), 10),
+		},
+		Signatures: []action.Signature{
+			{
+				Signer: pubKey,
+				Signed: utils.ToUncompressedSig(R, S, V),
+			},
+		},
+	}
+}
+
+func TestRunner_TypedTx(t *testing.T) {
+	ctx := assemblyCtxData("OLT", 18, true, false, false, nil)
+	chainID := utils.HashToBigInt(ctx.Header.ChainID)
+	txHash := ethcmn.BytesToHash(utils.SHA2([]byte("test")))
+	value := big.NewInt(100)
+
+	t.Run("test access list transaction and it is OK", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		sender := newAcc(ctx, from, 10000)
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+
+		stx := &olvmTx{}
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      getNonce(ctx, from),
+			To:         &ethTo,
+			Value:      value,
+			Gas:        30000,
+			GasPrice:   vm.DefaultGasPrice,
+			AccessList: ethtypes.AccessList{{Address: ethTo, StorageKeys: []ethcmn.Hash{}}},
+		})
+
+		ok, err := stx.Validate(ctx, tx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		// the access list is paid for with the intrinsic gas
+		assert.Equal(t, int64(vm.TxGas+2400), resp.GasUsed)
+
+		cost := new(big.Int).Add(value, new(big.Int).Mul(big.NewInt(resp.GasUsed), vm.DefaultGasPrice))
+		assert.Equal(t, new(big.Int).Sub(sender.Coins.Amount.BigInt(), cost), ctx.StateDB.GetBalance(ethcmn.BytesToAddress(from.Bytes())))
+		assert.Equal(t, value, ctx.StateDB.GetBalance(ethTo))
+	})
+
+	t.Run("test dynamic fee transaction pays base fee and tip and it is OK", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		sender := newAcc(ctx, from, 10000)
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+
+		tip := big.NewInt(1_000_000_000)
+		feeCap := new(big.Int).Mul(vm.DefaultGasPrice, big.NewInt(3))
+		effectivePrice := new(big.Int).Add(ctx.FeePool.GetOpt().MinFee().Amount.BigInt(), tip)
+
+		stx := &olvmTx{}
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     getNonce(ctx, from),
+			To:        &ethTo,
+			Value:     value,
+			Gas:       vm.TxGas,
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+		})
+
+		ok, err := stx.Validate(ctx, tx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		poolBefore, err := ctx.FeePool.Get([]byte(fees.POOL_KEY))
+		assert.NoError(t, err)
+
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+
+		cost := new(big.Int).Add(value, new(big.Int).Mul(big.NewInt(resp.GasUsed), effectivePrice))
+		assert.Equal(t, new(big.Int).Sub(sender.Coins.Amount.BigInt(), cost), ctx.StateDB.GetBalance(ethcmn.BytesToAddress(from.Bytes())))
+
+		ok, _ = stx.ProcessFee(ctx, tx, 0, 0, action.Gas(resp.GasUsed))
+		assert.True(t, ok)
+		poolAfter, err := ctx.FeePool.Get([]byte(fees.POOL_KEY))
+		assert.NoError(t, err)
+		charge := new(big.Int).Sub(poolAfter.Amount.BigInt(), poolBefore.Amount.BigInt())
+		assert.Equal(t, new(big.Int).Mul(big.NewInt(resp.GasUsed), effectivePrice), charge)
+	})
+
+	t.Run("test dynamic fee transaction burns base fee and pays tip and it is OK", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		sender := newAcc(ctx, from, 10000)
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+
+		opt := ctx.FeePool.GetOpt()
+		opt.BaseFeeMarket = fees.BaseFeeOption{TargetGas: 1_000_000, ChangeDenominator: 8, Burn: true}
+		baseFee := new(big.Int).Mul(vm.DefaultGasPrice, big.NewInt(2))
+		opt.SetBaseFee(*balance.NewAmountFromBigInt(baseFee))
+		defer func() {
+			opt.BaseFeeMarket = fees.BaseFeeOption{}
+		}()
+
+		tip := big.NewInt(1_000_000_000)
+		stx := &olvmTx{}
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     getNonce(ctx, from),
+			To:        &ethTo,
+			Value:     value,
+			Gas:       vm.TxGas,
+			GasTipCap: tip,
+			GasFeeCap: new(big.Int).Mul(vm.DefaultGasPrice, big.NewInt(5)),
+		})
+
+		ok, err := stx.Validate(ctx, tx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		poolBefore, err := ctx.FeePool.Get([]byte(fees.POOL_KEY))
+		assert.NoError(t, err)
+
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+
+		cost := new(big.Int).Add(value, new(big.Int).Mul(big.NewInt(resp.GasUsed), new(big.Int).Add(baseFee, tip)))
+		assert.Equal(t, new(big.Int).Sub(sender.Coins.Amount.BigInt(), cost), ctx.StateDB.GetBalance(ethcmn.BytesToAddress(from.Bytes())))
+
+		// the base fee is burned, the tip goes to the pool without a proposer
+		ok, _ = stx.ProcessFee(ctx, tx, 0, 0, action.Gas(resp.GasUsed))
+		assert.True(t, ok)
+		poolAfter, err := ctx.FeePool.Get([]byte(fees.POOL_KEY))
+		assert.NoError(t, err)
+		charge := new(big.Int).Sub(poolAfter.Amount.BigInt(), poolBefore.Amount.BigInt())
+		assert.Equal(t, new(big.Int).Mul(big.NewInt(resp.GasUsed), tip), charge)
+	})
+
+	t.Run("test legacy transaction under base fee and it is error", func(t *testing.T) {
+		from, fromPubKey, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		opt := ctx.FeePool.GetOpt()
+		opt.BaseFeeMarket = fees.BaseFeeOption{TargetGas: 1_000_000, ChangeDenominator: 8}
+		opt.SetBaseFee(*balance.NewAmountFromBigInt(new(big.Int).Mul(vm.DefaultGasPrice, big.NewInt(2))))
+		defer func() {
+			opt.BaseFeeMarket = fees.BaseFeeOption{}
+		}()
+
+		stx := &olvmTx{}
+		tx := assemblyExecuteData(from, &to, getNonce(ctx, from), value, chainID, fromPubKey, fromPrikey, make([]byte, 0), vm.TxGas)
+
+		ok, err := stx.Validate(ctx, tx)
+		assert.Equal(t, action.ErrInvalidFeePrice, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("test dynamic fee transaction with tip above fee cap and it is error", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+
+		stx := &olvmTx{}
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     getNonce(ctx, from),
+			To:        &ethTo,
+			Value:     value,
+			Gas:       vm.TxGas,
+			GasTipCap: new(big.Int).Mul(vm.DefaultGasPrice, big.NewInt(2)),
+			GasFeeCap: vm.DefaultGasPrice,
+		})
+
+		ok, err := stx.Validate(ctx, tx)
+		assert.Equal(t, ethcore.ErrTipAboveFeeCap, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,135 @@
+package staking
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+)
+
+var _ action.Msg = &EditValidator{}
+
+// EditValidator replaces the name and profile of a validator, the whole profile is overwritten
+type EditValidator struct {
+	ValidatorAddress keys.Address
+	StakeAddress     keys.Address
+	Name             string
+	Profile          identity.ValidatorProfile
+}
+
+func (ev EditValidator) Marshal() ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+func (ev *EditValidator) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, ev)
+}
+
+func (ev EditValidator) Signers() []action.Address {
+	return []action.Address{ev.StakeAddress.Bytes()}
+}
+
+func (ev EditValidator) Type() action.Type {
+	return action.EDIT_VALIDATOR
+}
+
+func (ev EditValidator) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(ev.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: ev.ValidatorAddress.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.delegator"),
+		Value: ev.StakeAddress.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = editValidatorTx{}
+
+type editValidatorTx struct{}
+
+func (e editValidatorTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	ev := &EditValidator{}
+	err := ev.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+	err = action.ValidateBasic(tx.RawBytes(), ev.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if err := ev.StakeAddress.Err(); err != nil {
+		return false, err
+	}
+
+	if err := ev.ValidatorAddress.Err(); err != nil {
+		return false, err
+	}
+
+	if err := identity.ValidateValidatorName(ev.Name); err != nil {
+		return false, action.ErrInvalidProfile.Wrap(err)
+	}
+
+	if err := ev.Profile.Validate(); err != nil {
+		return false, action.ErrInvalidProfile.Wrap(err)
+	}
+
+	return true, nil
+}
+
+func (e editValidatorTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing EditValidator Transaction for CheckTx", tx)
+	return runEditValidator(ctx, tx)
+}
+
+func (e editValidatorTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing EditValidator Transaction for DeliverTx", tx)
+	return runEditValidator(ctx, tx)
+}
+
+func (e editValidatorTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runEditValidator(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ev := &EditValidator{}
+	err := ev.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	validator, err := ctx.Validators.Get(ev.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: action.ErrInvalidValidatorAddr.Wrap(err).Marshal()}
+	}
+
+	if !validator.StakeAddress.Equal(ev.StakeAddress) {
+		return false, action.Response{Log: action.ErrStakeAddressMismatch.Marshal()}
+	}
+
+	err = ctx.Validators.HandleEditValidator(ev.ValidatorAddress, ev.Name, ev.Profile, ctx.Header.Height)
+	if err != nil {
+		return false, action.Response{Log: action.ErrInvalidProfile.Wrap(err).Marshal()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(ev.Tags(), "edit_validator")}
+}
@@ -0,0 +1,79 @@
+package staking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+)
+
+func assemblyEditValidatorData(name string, profile identity.ValidatorProfile, feeAmt int64) action.SignedTx {
+	av := &EditValidator{
+		ValidatorAddress: from.Bytes(),
+		StakeAddress:     from.Bytes(),
+		Name:             name,
+		Profile:          profile,
+	}
+	fee := action.Fee{
+		Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(feeAmt)},
+		Gas:   10,
+	}
+	data, _ := av.Marshal()
+	tx := action.RawTx{
+		Type: av.Type(),
+		Data: data,
+		Fee:  fee,
+		Memo: "test_memo",
+	}
+	signature, _ := fromPrikey.Sign(tx.RawBytes())
+	signed := action.SignedTx{
+		RawTx: tx,
+		Signatures: []action.Signature{
+			{
+				Signer: keys.PublicKey{KeyType: keys.ED25519, Data: fromPubkey.Bytes()[5:]},
+				Signed: signature,
+			},
+		},
+	}
+	return signed
+}
+
+func TestEditValidatorTx_ProcessDeliver(t *testing.T) {
+	ev := &editValidatorTx{}
+
+	t.Run("edit profile, should return ok", func(t *testing.T) {
+		testDB := setup()
+		defer teardown(testDB)
+
+		ctx := assemblyCtxData("OLT", 0, true, true, 10)
+		profile := identity.ValidatorProfile{Website: "https://oneledger.io", Contact: "validator@oneledger.io"}
+		tx := assemblyEditValidatorData("new_name", profile, 10000000000)
+
+		ok, err := ev.Validate(ctx, tx)
+		assert.True(t, ok, err)
+
+		ok, resp := ev.ProcessDeliver(ctx, tx.RawTx)
+		assert.True(t, ok, resp)
+
+		validator, _ := ctx.Validators.Get(from.Bytes())
+		assert.Equal(t, "new_name", validator.Name)
+		assert.Equal(t, profile, validator.Profile)
+	})
+
+	t.Run("edit with oversized details, should return error", func(t *testing.T) {
+		testDB := setup()
+		defer teardown(testDB)
+
+		ctx := assemblyCtxData("OLT", 0, true, true, 10)
+		profile := identity.ValidatorProfile{Details: strings.Repeat("a", identity.MaxValidatorDetailsLength+1)}
+		tx := assemblyEditValidatorData("new_name", profile, 10000000000)
+
+		ok, _ := ev.Validate(ctx, tx)
+		assert.False(t, ok)
+	})
+}
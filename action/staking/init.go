@@ -11,6 +11,8 @@ func init() {
 	serialize.RegisterConcrete(new(Stake), "stake")
 	serialize.RegisterConcrete(new(Unstake), "unstake")
 	serialize.RegisterConcrete(new(Withdraw), "withdraw")
+	serialize.RegisterConcrete(new(RotateKey), "rotate_key")
+	serialize.RegisterConcrete(new(EditValidator), "edit_validator")
 }
 
 func EnableStaking(r action.Router) error {
@@ -29,5 +31,15 @@ func EnableStaking(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "withdrawTx")
 	}
+
+	err = r.AddHandler(action.ROTATE_KEY, rotateKeyTx{})
+	if err != nil {
+		return errors.Wrap(err, "rotateKeyTx")
+	}
+
+	err = r.AddHandler(action.EDIT_VALIDATOR, editValidatorTx{})
+	if err != nil {
+		return errors.Wrap(err, "editValidatorTx")
+	}
 	return nil
 }
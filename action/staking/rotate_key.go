@@ -0,0 +1,195 @@
+package staking
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/evidence"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &RotateKey{}
+
+// RotateKey replaces the consensus and/or the ECDSA key of a validator, a key left empty is not rotated.
+// The new consensus key is reported to tendermint in the end block update of the next block,
+// the node should switch to the new key once that update has been applied.
+type RotateKey struct {
+	ValidatorAddress keys.Address
+	StakeAddress     keys.Address
+	NewPubKey        keys.PublicKey
+	NewECDSAPubKey   keys.PublicKey
+}
+
+func (rk RotateKey) Marshal() ([]byte, error) {
+	return json.Marshal(rk)
+}
+
+func (rk *RotateKey) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, rk)
+}
+
+func (rk RotateKey) Signers() []action.Address {
+	return []action.Address{rk.StakeAddress.Bytes()}
+}
+
+func (rk RotateKey) Type() action.Type {
+	return action.ROTATE_KEY
+}
+
+func (rk RotateKey) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(rk.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: rk.ValidatorAddress.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.delegator"),
+		Value: rk.StakeAddress.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (rk RotateKey) rotateConsensusKey() bool {
+	return len(rk.NewPubKey.Data) > 0
+}
+
+func (rk RotateKey) rotateECDSAKey() bool {
+	return len(rk.NewECDSAPubKey.Data) > 0
+}
+
+var _ action.Tx = rotateKeyTx{}
+
+type rotateKeyTx struct{}
+
+func (r rotateKeyTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	rk := &RotateKey{}
+	err := rk.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+	err = action.ValidateBasic(tx.RawBytes(), rk.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if err := rk.StakeAddress.Err(); err != nil {
+		return false, err
+	}
+
+	if err := rk.ValidatorAddress.Err(); err != nil {
+		return false, err
+	}
+
+	if !rk.rotateConsensusKey() && !rk.rotateECDSAKey() {
+		return false, errors.Wrap(action.ErrInvalidKeyRotation, "no key to rotate")
+	}
+
+	if rk.rotateConsensusKey() {
+		if rk.NewPubKey.KeyType != keys.ED25519 {
+			return false, errors.Wrap(action.ErrInvalidKeyRotation, "consensus key must be ed25519")
+		}
+		if _, err := rk.NewPubKey.GetHandler(); err != nil {
+			return false, action.ErrInvalidPubkey
+		}
+	}
+
+	if rk.rotateECDSAKey() {
+		if rk.NewECDSAPubKey.KeyType != keys.SECP256K1 {
+			return false, errors.Wrap(action.ErrInvalidKeyRotation, "ecdsa key must be secp256k1")
+		}
+		if _, err := rk.NewECDSAPubKey.GetHandler(); err != nil {
+			return false, action.ErrInvalidPubkey
+		}
+	}
+
+	return true, nil
+}
+
+func (r rotateKeyTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing RotateKey Transaction for CheckTx", tx)
+	return runRotateKey(ctx, tx)
+}
+
+func (r rotateKeyTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing RotateKey Transaction for DeliverTx", tx)
+	return runRotateKey(ctx, tx)
+}
+
+func (r rotateKeyTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runRotateKey(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	rk := &RotateKey{}
+	err := rk.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	validator, err := ctx.Validators.Get(rk.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: action.ErrInvalidValidatorAddr.Wrap(err).Marshal()}
+	}
+
+	if !validator.StakeAddress.Equal(rk.StakeAddress) {
+		return false, action.Response{Log: action.ErrStakeAddressMismatch.Marshal()}
+	}
+
+	if ctx.EvidenceStore.IsFrozenValidator(rk.ValidatorAddress) {
+		return false, action.Response{Log: evidence.ErrFrozenValidator.Error()}
+	}
+
+	if rk.rotateConsensusKey() {
+		err = ctx.Validators.HandleKeyRotation(rk.ValidatorAddress, rk.NewPubKey, ctx.Header.Height)
+		if err != nil {
+			return false, action.Response{Log: action.ErrInvalidKeyRotation.Wrap(err).Marshal()}
+		}
+	}
+
+	if rk.rotateECDSAKey() {
+		err = ctx.Validators.HandleECDSAKeyRotation(rk.ValidatorAddress, rk.NewECDSAPubKey)
+		if err != nil {
+			return false, action.Response{Log: action.ErrInvalidKeyRotation.Wrap(err).Marshal()}
+		}
+	}
+
+	// keep the witnesses of every bridged evm chain in sync with the validator keys
+	if ctx.Witnesses != nil && rk.rotateConsensusKey() {
+		chains, err := witnessChains(ctx)
+		if err != nil {
+			return false, action.Response{Log: action.ErrInvalidKeyRotation.Wrap(err).Marshal()}
+		}
+		for _, c := range chains {
+			if !ctx.Witnesses.Exists(c, rk.ValidatorAddress) {
+				continue
+			}
+			err = ctx.Witnesses.UpdatePubKey(c, rk.ValidatorAddress, rk.NewPubKey)
+			if err != nil {
+				return false, action.Response{Log: action.ErrInvalidKeyRotation.Wrap(err).Marshal()}
+			}
+		}
+	}
+	if ctx.Witnesses != nil && rk.rotateECDSAKey() {
+		err = rotateWitnessECDSAKey(ctx, rk.ValidatorAddress, rk.NewECDSAPubKey)
+		if err != nil {
+			return false, action.Response{Log: action.ErrInvalidKeyRotation.Wrap(err).Marshal()}
+		}
+	}
+
+	return true, action.Response{Events: action.GetEvent(rk.Tags(), "rotate_key")}
+}
@@ -0,0 +1,108 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+func assemblyRotateKeyData(newPubKey keys.PublicKey, newECDSAPubKey keys.PublicKey, feeAmt int64) action.SignedTx {
+	av := &RotateKey{
+		ValidatorAddress: from.Bytes(),
+		StakeAddress:     from.Bytes(),
+		NewPubKey:        newPubKey,
+		NewECDSAPubKey:   newECDSAPubKey,
+	}
+	fee := action.Fee{
+		Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(feeAmt)},
+		Gas:   10,
+	}
+	data, _ := av.Marshal()
+	tx := action.RawTx{
+		Type: av.Type(),
+		Data: data,
+		Fee:  fee,
+		Memo: "test_memo",
+	}
+	signature, _ := fromPrikey.Sign(tx.RawBytes())
+	signed := action.SignedTx{
+		RawTx: tx,
+		Signatures: []action.Signature{
+			{
+				Signer: keys.PublicKey{KeyType: keys.ED25519, Data: fromPubkey.Bytes()[5:]},
+				Signed: signature,
+			},
+		},
+	}
+	return signed
+}
+
+func TestRotateKeyTx_ProcessDeliver_OK(t *testing.T) {
+	rk := &rotateKeyTx{}
+
+	t.Run("rotate consensus key, should return ok", func(t *testing.T) {
+		testDB := setup()
+		defer teardown(testDB)
+
+		ctx := assemblyCtxData("OLT", 0, true, true, 10)
+		newPub := ed25519.GenPrivKey().PubKey()
+		newPubKey := keys.PublicKey{KeyType: keys.ED25519, Data: newPub.Bytes()[5:]}
+		tx := assemblyRotateKeyData(newPubKey, keys.PublicKey{}, 10000000000)
+
+		ok, err := rk.Validate(ctx, tx)
+		assert.True(t, ok, err)
+
+		ok, resp := rk.ProcessDeliver(ctx, tx.RawTx)
+		assert.True(t, ok, resp)
+
+		validator, _ := ctx.Validators.Get(from.Bytes())
+		assert.True(t, validator.PubKey.Equal(newPubKey))
+		assert.Equal(t, keys.Address(from.Bytes()), ctx.Validators.ResolveConsensusAddress(newPub.Address().Bytes()))
+	})
+}
+
+func TestRotateKeyTx_ProcessDeliver_Error(t *testing.T) {
+	rk := &rotateKeyTx{}
+
+	t.Run("rotate without any key, should return error", func(t *testing.T) {
+		testDB := setup()
+		defer teardown(testDB)
+
+		ctx := assemblyCtxData("OLT", 0, true, true, 10)
+		tx := assemblyRotateKeyData(keys.PublicKey{}, keys.PublicKey{}, 10000000000)
+
+		ok, _ := rk.Validate(ctx, tx)
+		assert.False(t, ok)
+	})
+
+	t.Run("rotate ecdsa key with a wrong key type, should return error", func(t *testing.T) {
+		testDB := setup()
+		defer teardown(testDB)
+
+		ctx := assemblyCtxData("OLT", 0, true, true, 10)
+		newPub := ed25519.GenPrivKey().PubKey()
+		tx := assemblyRotateKeyData(keys.PublicKey{}, keys.PublicKey{KeyType: keys.ED25519, Data: newPub.Bytes()[5:]}, 10000000000)
+
+		ok, _ := rk.Validate(ctx, tx)
+		assert.False(t, ok)
+	})
+
+	t.Run("rotate to the current consensus key, should return error", func(t *testing.T) {
+		testDB := setup()
+		defer teardown(testDB)
+
+		ctx := assemblyCtxData("OLT", 0, true, true, 10)
+		tx := assemblyRotateKeyData(keys.PublicKey{KeyType: keys.ED25519, Data: fromPubkey.Bytes()[5:]}, keys.PublicKey{}, 10000000000)
+
+		ok, _ := rk.Validate(ctx, tx)
+		assert.True(t, ok)
+
+		ok, _ = rk.ProcessDeliver(ctx, tx.RawTx)
+		assert.False(t, ok)
+	})
+}
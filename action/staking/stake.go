@@ -180,10 +180,6 @@ func runCheckStake(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		return false, action.Response{Log: err.Error()}
 	}
 
-	if ctx.EvidenceStore.IsFrozenValidator(st.ValidatorAddress) {
-		return false, action.Response{Log: evidence.ErrFrozenValidator.Error()}
-	}
-
 	stake := identity.Stake{
 		ValidatorAddress: st.ValidatorAddress,
 		StakeAddress:     st.StakeAddress,
@@ -192,45 +188,70 @@ func runCheckStake(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		Name:             st.NodeName,
 		Amount:           st.Stake.Value,
 	}
+	err = ApplyStake(ctx, stake, func() error {
+		return ctx.Balances.MinusFromAddress(st.StakeAddress, st.Stake.ToCoinWithBase(ctx.Currencies))
+	})
+	if errors.Cause(err) == action.ErrStakeAddressInUse {
+		return false, action.Response{Log: action.ErrStakeAddressInUse.Marshal()}
+	}
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(st.Tags(), "apply_stake")}
+}
+
+// ApplyStake adds the amount of the stake to the validator, taking it with debit from the stake
+// address. The stake tx and the staking native contract both stake through it
+func ApplyStake(ctx *action.Context, stake identity.Stake, debit func() error) error {
+	if ctx.EvidenceStore.IsFrozenValidator(stake.ValidatorAddress) {
+		return evidence.ErrFrozenValidator
+	}
 
 	// trying to update existing validator's stake address
 	updateStakeAddress := false
-	if ctx.Validators.Exists(st.ValidatorAddress) {
-		validator, err := ctx.Validators.Get(st.ValidatorAddress)
+	if ctx.Validators.Exists(stake.ValidatorAddress) {
+		validator, err := ctx.Validators.Get(stake.ValidatorAddress)
 		if err != nil {
-			return false, action.Response{Log: errors.Wrap(err, st.StakeAddress.String()).Error()}
+			return errors.Wrap(err, stake.StakeAddress.String())
 		}
 
 		clean, err := isStakeAddressClean(ctx, validator)
 		if err != nil {
-			return false, action.Response{Log: errors.Wrap(err, st.StakeAddress.String()).Error()}
+			return errors.Wrap(err, stake.StakeAddress.String())
 		}
 
 		// update not allowed if existing stake address not cleaned up
-		if !validator.StakeAddress.Equal(st.StakeAddress) {
+		if !validator.StakeAddress.Equal(stake.StakeAddress) {
 			if !clean {
-				return false, action.Response{Log: action.ErrStakeAddressInUse.Marshal()}
+				return action.ErrStakeAddressInUse
 			}
 			updateStakeAddress = true
 		}
 	}
 
-	coin := st.Stake.ToCoinWithBase(ctx.Currencies)
-
-	err = ctx.Balances.MinusFromAddress(st.StakeAddress, coin)
+	err := debit()
 	if err != nil {
-		return false, action.Response{Log: errors.Wrap(err, st.StakeAddress.String()).Error()}
+		return errors.Wrap(err, stake.StakeAddress.String())
 	}
 
-	err = ctx.Delegators.Stake(st.ValidatorAddress, st.StakeAddress, st.Stake.Value)
+	err = ctx.Delegators.Stake(stake.ValidatorAddress, stake.StakeAddress, stake.Amount)
 	if err != nil {
-		return false, action.Response{Log: errors.Wrap(err, st.StakeAddress.String()).Error()}
+		return errors.Wrap(err, stake.StakeAddress.String())
 	}
 
 	err = ctx.Validators.HandleStake(stake, updateStakeAddress, ctx.Header.Height)
 	if err != nil {
-		return false, action.Response{Log: err.Error()}
+		return err
 	}
 
-	return true, action.Response{Events: action.GetEvent(st.Tags(), "apply_stake")}
+	// a new validator joins the witnesses once the current ones handed the contracts over to it
+	if ctx.Witnesses != nil {
+		validator, err := ctx.Validators.Get(stake.ValidatorAddress)
+		if err != nil {
+			return errors.Wrap(err, stake.StakeAddress.String())
+		}
+		return joinWitnesses(ctx, validator)
+	}
+	return nil
 }
@@ -103,9 +103,9 @@ func assemblyCtxData(currencyName string, currencyDecimal int, setStore bool, se
 	ctx.FeePool.SetupOpt(ctx.FeeOpt)
 	ctx.GovernanceStore = governance.NewStore("tg", cs)
 	ctx.Delegators = delegation.NewDelegationStore("tst", cs)
-	ctx.Validators = identity.NewValidatorStore("tv", "purged", cs)
+	ctx.Validators = identity.NewValidatorStore("tv", "purged", "rotation", "profile", cs)
 	ctx.EvidenceStore = evidence.NewEvidenceStore("tes", cs)
-	ctx.GovernanceStore.SetFeeOption(*ctx.FeeOpt)
+	ctx.GovernanceStore.SetFeeOption(ctx.FeeOpt)
 	validator := identity.NewValidator(
 		from.Bytes(),
 		from.Bytes(),
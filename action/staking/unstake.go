@@ -157,5 +157,19 @@ func runCheckUnstake(ctx *action.Context, tx action.RawTx) (bool, action.Respons
 		return false, action.Response{Log: err.Error()}
 	}
 
+	// a validator left without stake leaves the witnesses once the contracts dropped it
+	if ctx.Witnesses != nil {
+		validator, err := ctx.Validators.Get(ust.ValidatorAddress)
+		if err != nil {
+			return false, action.Response{Log: err.Error()}
+		}
+		if validator.Staking.BigInt().Sign() == 0 {
+			err = leaveWitnesses(ctx, ust.ValidatorAddress)
+			if err != nil {
+				return false, action.Response{Log: err.Error()}
+			}
+		}
+	}
+
 	return true, action.Response{Events: action.GetEvent(ust.Tags(), "unstake")}
 }
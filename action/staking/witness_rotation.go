@@ -0,0 +1,108 @@
+package staking
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+)
+
+// rotateWitnessECDSAKey starts a witness rotation on the chains the validator witnesses, the bridge
+// contracts only know its old ecdsa key and it keeps signing with it until they took the new one.
+// The new key is folded into the set of a pending rotation, which the new rotation supersedes
+func rotateWitnessECDSAKey(ctx *action.Context, addr keys.Address, ecdsaPubKey keys.PublicKey) error {
+	return rotateWitnesses(ctx, "ecdsa key rotation of "+addr.String(), func(witnesses []identity.Witness) ([]identity.Witness, bool) {
+		for i := range witnesses {
+			if witnesses[i].Address.Equal(addr) {
+				witnesses[i].ECDSAPubKey = ecdsaPubKey
+				return witnesses, true
+			}
+		}
+		return witnesses, false
+	})
+}
+
+// joinWitnesses starts a witness rotation adding a staked validator to the witnesses of every chain,
+// validators without an ecdsa key cannot sign on the contracts and stay out
+func joinWitnesses(ctx *action.Context, validator *identity.Validator) error {
+	joining := identity.Witness{
+		Address:     validator.Address,
+		PubKey:      validator.PubKey,
+		ECDSAPubKey: validator.ECDSAPubKey,
+		Name:        validator.Name,
+	}
+	if _, err := (&identity.WitnessRotation{Witnesses: []identity.Witness{joining}}).ETHAddresses(); err != nil {
+		return nil
+	}
+	return rotateWitnesses(ctx, "stake of "+validator.Address.String(), func(witnesses []identity.Witness) ([]identity.Witness, bool) {
+		for _, w := range witnesses {
+			if w.Address.Equal(validator.Address) {
+				return witnesses, false
+			}
+		}
+		return append(witnesses, joining), true
+	})
+}
+
+// leaveWitnesses starts a witness rotation dropping a validator that unstaked all it had from the
+// witnesses of every chain
+func leaveWitnesses(ctx *action.Context, addr keys.Address) error {
+	return rotateWitnesses(ctx, "unstake of "+addr.String(), func(witnesses []identity.Witness) ([]identity.Witness, bool) {
+		for i, w := range witnesses {
+			if w.Address.Equal(addr) {
+				return append(witnesses[:i:i], witnesses[i+1:]...), len(witnesses) > 1
+			}
+		}
+		return witnesses, false
+	})
+}
+
+// rotateWitnesses applies a change to the witness set every chain moves to, and starts a rotation
+// on the chains it changed
+func rotateWitnesses(ctx *action.Context, reason string, change func([]identity.Witness) ([]identity.Witness, bool)) error {
+	chains, err := witnessChains(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range chains {
+		// a chain without witnesses has no contracts to hand over
+		current, err := ctx.Witnesses.GetWitnessAddresses(c)
+		if err != nil {
+			return err
+		}
+		if len(current) == 0 {
+			continue
+		}
+		witnesses, err := ctx.Witnesses.PendingWitnesses(c)
+		if err != nil {
+			return err
+		}
+		witnesses, changed := change(witnesses)
+		if !changed {
+			continue
+		}
+		_, err = ctx.Witnesses.StartRotation(c, witnesses, ctx.Header.Height, reason)
+		if err != nil {
+			return errors.Wrapf(err, "failed to rotate the witnesses of %s", c)
+		}
+	}
+	return nil
+}
+
+// witnessChains lists ethereum and the chains of the evm registry, each of them keeps its own witnesses
+func witnessChains(ctx *action.Context) ([]chain.Type, error) {
+	chains := []chain.Type{chain.ETHEREUM}
+	if ctx.GovernanceStore == nil {
+		return chains, nil
+	}
+	registry, err := ctx.GovernanceStore.GetEVMChainRegistry()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range registry.Chains {
+		chains = append(chains, chain.EVMType(c.ChainID))
+	}
+	return chains, nil
+}
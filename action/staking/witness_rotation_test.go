@@ -0,0 +1,136 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+)
+
+func newECDSAPubKey() keys.PublicKey {
+	pub := secp256k1.GenPrivKey().PubKey().(secp256k1.PubKeySecp256k1)
+	return keys.PublicKey{KeyType: keys.SECP256K1, Data: pub[:]}
+}
+
+// assemblyWitnessCtx adds ethereum witnesses to the staking context, with the test validator among
+// them when asked
+func assemblyWitnessCtx(t *testing.T, withFrom bool) (*action.Context, keys.Address) {
+	ctx := assemblyCtxData("OLT", 0, true, true, 10)
+	state := ctx.Balances.State
+	ctx.Witnesses = identity.NewWitnessStore("tw", state)
+
+	other := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	stakes := []identity.Stake{{ValidatorAddress: other, ECDSAPubKey: newECDSAPubKey(), Name: "other_node"}}
+	if withFrom {
+		stakes = append(stakes, identity.Stake{
+			ValidatorAddress: from.Bytes(),
+			Pubkey:           keys.PublicKey{KeyType: keys.ED25519, Data: fromPubkey.Bytes()[5:]},
+			ECDSAPubKey:      newECDSAPubKey(),
+			Name:             "test_node",
+		})
+	}
+	for _, stake := range stakes {
+		require.NoError(t, ctx.Witnesses.AddWitness(chain.ETHEREUM, stake))
+	}
+	state.Commit()
+	return ctx, other
+}
+
+func pendingWitness(rotation *identity.WitnessRotation, addr keys.Address) *identity.Witness {
+	for i := range rotation.Witnesses {
+		if rotation.Witnesses[i].Address.Equal(addr) {
+			return &rotation.Witnesses[i]
+		}
+	}
+	return nil
+}
+
+func TestRotateKeyTx_ECDSAKeyWhileRotationPending(t *testing.T) {
+	testDB := setup()
+	defer teardown(testDB)
+
+	rk := &rotateKeyTx{}
+	ctx, other := assemblyWitnessCtx(t, true)
+	otherWitness, err := ctx.Witnesses.Get(chain.ETHEREUM, other)
+	require.NoError(t, err)
+
+	first := newECDSAPubKey()
+	ok, resp := rk.ProcessDeliver(ctx, assemblyRotateKeyData(keys.PublicKey{}, first, 10000000000).RawTx)
+	require.True(t, ok, resp)
+	ctx.Balances.State.Commit()
+
+	// the second rotation supersedes the pending one, the other witness keeps its key
+	second := newECDSAPubKey()
+	ok, resp = rk.ProcessDeliver(ctx, assemblyRotateKeyData(keys.PublicKey{}, second, 10000000000).RawTx)
+	require.True(t, ok, resp)
+	ctx.Balances.State.Commit()
+
+	rotation, err := ctx.Witnesses.GetPendingRotation(chain.ETHEREUM)
+	require.NoError(t, err)
+	require.NotNil(t, rotation)
+	assert.Equal(t, int64(1), rotation.Epoch)
+	assert.Equal(t, int64(1), rotation.Round)
+	assert.Len(t, rotation.Witnesses, 2)
+	assert.Equal(t, second, pendingWitness(rotation, from.Bytes()).ECDSAPubKey)
+	assert.Equal(t, otherWitness.ECDSAPubKey, pendingWitness(rotation, other).ECDSAPubKey)
+}
+
+func TestStakeTx_WitnessRotation(t *testing.T) {
+	testDB := setup()
+	defer teardown(testDB)
+
+	ctx, other := assemblyWitnessCtx(t, false)
+	validatorPubKey := ed25519.GenPrivKey().PubKey()
+	validator := keys.Address(validatorPubKey.Address())
+	ecdsaPubKey := newECDSAPubKey()
+	st := &Stake{
+		StakeAddress:         from.Bytes(),
+		Stake:                action.Amount{Currency: "OLT", Value: *balance.NewAmountFromInt(1)},
+		NodeName:             "new_node",
+		ValidatorAddress:     validator,
+		ValidatorPubKey:      keys.PublicKey{KeyType: keys.ED25519, Data: validatorPubKey.Bytes()[5:]},
+		ValidatorECDSAPubKey: ecdsaPubKey,
+	}
+	data, err := st.Marshal()
+	require.NoError(t, err)
+
+	// the staked validator joins the witnesses in a rotation
+	ok, resp := (&stakeTx{}).ProcessDeliver(ctx, action.RawTx{Type: st.Type(), Data: data})
+	require.True(t, ok, resp)
+	ctx.Balances.State.Commit()
+
+	rotation, err := ctx.Witnesses.GetPendingRotation(chain.ETHEREUM)
+	require.NoError(t, err)
+	require.NotNil(t, rotation)
+	assert.Equal(t, []keys.Address{other}, rotation.Signers)
+	require.NotNil(t, pendingWitness(rotation, validator))
+	assert.Equal(t, ecdsaPubKey, pendingWitness(rotation, validator).ECDSAPubKey)
+	assert.False(t, ctx.Witnesses.Exists(chain.ETHEREUM, validator))
+
+	// unstaking all it has drops it again, superseding the pending rotation
+	ust := &Unstake{
+		StakeAddress:     from.Bytes(),
+		ValidatorAddress: validator,
+		Stake:            action.Amount{Currency: "OLT", Value: *balance.NewAmountFromInt(1)},
+	}
+	data, err = ust.Marshal()
+	require.NoError(t, err)
+	ok, resp = (&unstakeTx{}).ProcessDeliver(ctx, action.RawTx{Type: ust.Type(), Data: data})
+	require.True(t, ok, resp)
+	ctx.Balances.State.Commit()
+
+	rotation, err = ctx.Witnesses.GetPendingRotation(chain.ETHEREUM)
+	require.NoError(t, err)
+	require.NotNil(t, rotation)
+	assert.Equal(t, int64(1), rotation.Round)
+	assert.Len(t, rotation.Witnesses, 1)
+	assert.Nil(t, pendingWitness(rotation, validator))
+}
@@ -174,7 +174,7 @@ func assemblyCtxData(currencyName string, currencyDecimal int, setStore bool, se
 		RewardPoolAddress: "rewardspool",
 	}
 	ctx.RewardMasterStore.SetOptions(&rewardOptions)
-	ctx.GovernanceStore.WithHeight(0).SetFeeOption(*ctx.FeeOpt)
+	ctx.GovernanceStore.WithHeight(0).SetFeeOption(ctx.FeeOpt)
 	ctx.GovernanceStore.WithHeight(0).SetProposalOptions(pOpt)
 	ctx.GovernanceStore.WithHeight(0).SetRewardOptions(rewardOptions)
 	ctx.GovernanceStore.WithHeight(0).SetAllLUH()
@@ -13,11 +13,13 @@ import (
 	"github.com/tendermint/tendermint/store"
 
 	"github.com/Oneledger/protocol/app/node"
+	ethchain "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/consensus"
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/ons"
@@ -26,6 +28,7 @@ import (
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
 )
 
 // Ensure this App struct can control the underlying ABCI app
@@ -138,10 +141,42 @@ func (app *App) setupState(stateBytes []byte) error {
 	}
 	app.Context.ethTrackers.SetupOption(&initial.Governance.ETHCDOption)
 
+	evmChains := initial.Governance.EVMChains
+	if evmChains.Chains == nil {
+		evmChains.Chains = []ethchain.EVMChain{}
+	}
+	err = evmChains.Validate()
+	if err != nil {
+		return errors.Wrap(err, "Setup EVM Chains")
+	}
+	for _, c := range evmChains.Chains {
+		if _, ok := initial.Currencies.GetCurrencySet().GetCurrencyByName(c.Currency); !ok {
+			return errors.Errorf("currency %s of evm chain %s is not in genesis", c.Currency, c.Name)
+		}
+	}
+	err = app.Context.govern.WithHeight(app.header.Height).SetEVMChainRegistry(evmChains)
+	if err != nil {
+		return errors.Wrap(err, "Setup EVM Chains")
+	}
+	app.Context.ethTrackers.SetupRegistry(&evmChains)
+
 	err = app.Context.govern.WithHeight(app.header.Height).SetBTCChainDriverOption(initial.Governance.BTCCDOption)
 	if err != nil {
 		return errors.Wrap(err, "Setup BTC Options")
 	}
+
+	bridgeOpt := initial.Governance.BridgeOptions
+	if bridgeOpt.Limits == nil {
+		bridgeOpt.Limits = []bridge.Limit{}
+	}
+	err = bridgeOpt.Validate()
+	if err != nil {
+		return errors.Wrap(err, "Setup Bridge Options")
+	}
+	err = app.Context.govern.WithHeight(app.header.Height).SetBridgeOptions(bridgeOpt)
+	if err != nil {
+		return errors.Wrap(err, "Setup Bridge Options")
+	}
 	balanceCtx := app.Context.Balances()
 
 	app.Context.btcTrackers.SetConfig(bitcoin.NewBTCConfig(app.Context.cfg.ChainDriver, initial.Governance.BTCCDOption.ChainType))
@@ -167,7 +202,7 @@ func (app *App) setupState(stateBytes []byte) error {
 		}
 	}
 
-	err = app.Context.govern.WithHeight(app.header.Height).SetFeeOption(initial.Governance.FeeOption)
+	err = app.Context.govern.WithHeight(app.header.Height).SetFeeOption(&initial.Governance.FeeOption)
 	if err != nil {
 		return errors.Wrap(err, "Setup FeeOptions Options")
 	}
@@ -206,6 +241,13 @@ func (app *App) setupState(stateBytes []byte) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to add initial ethereum witness")
 		}
+		// the chains of the evm registry start with the ethereum witnesses
+		for _, c := range evmChains.Chains {
+			err = app.Context.witnesses.WithState(app.Context.deliver).AddWitness(chain.EVMType(c.ChainID), identity.Stake(stake))
+			if err != nil {
+				return errors.Wrapf(err, "failed to add initial witness of evm chain %s", c.Name)
+			}
+		}
 	}
 	for _, stake := range initial.Staking {
 		err := app.Context.delegators.WithState(app.Context.deliver).Stake(stake.ValidatorAddress, stake.StakeAddress, identity.Stake(stake).Amount)
@@ -263,6 +305,7 @@ func (app *App) setupState(stateBytes []byte) error {
 			ProcessOwner:  tracker.ProcessOwner,
 			FinalityVotes: make([]ethereum.Vote, len(tracker.Witnesses)),
 			To:            tracker.To,
+			TokenURI:      tracker.TokenURI,
 		}
 		switch tracker.State {
 		case ethereum.Released:
@@ -278,6 +321,14 @@ func (app *App) setupState(stateBytes []byte) error {
 		}
 	}
 
+	for _, nft := range initial.NFTs {
+		nft := nft
+		err = app.Context.nfts.WithState(app.Context.deliver).Set(&nft)
+		if err != nil {
+			return errors.Wrap(err, "failed to setup initial NFTs")
+		}
+	}
+
 	//Setup Proposals
 	err = app.Context.proposalMaster.WithState(app.Context.deliver).LoadProposals(initial.Proposals)
 	if err != nil {
@@ -344,6 +395,30 @@ func (app *App) setupValidators(req RequestInitChain, currencies *balance.Curren
 	return vu, err
 }
 
+// loadEVMChains picks up the currencies and the evm chains added by governance from the committed state
+func (app *App) loadEVMChains() error {
+	currencies, err := app.Context.govern.WithHeight(app.header.Height).GetCurrencies()
+	if err != nil {
+		return err
+	}
+	for _, currency := range currencies {
+		if _, ok := app.Context.currencies.GetCurrencyByName(currency.Name); ok {
+			continue
+		}
+		err := app.Context.currencies.Register(currency)
+		if err != nil {
+			return errors.Wrapf(err, "failed to register currency %s", currency.Name)
+		}
+	}
+
+	evmChains, err := app.Context.govern.WithHeight(app.header.Height).GetEVMChainRegistry()
+	if err != nil {
+		return err
+	}
+	app.Context.ethTrackers.SetupRegistry(evmChains)
+	return nil
+}
+
 func (app *App) Prepare() error {
 	testEnv := os.Getenv("OLTEST")
 
@@ -399,6 +474,10 @@ func (app *App) Prepare() error {
 		}
 
 		app.Context.feePool.SetupOpt(feeOpt)
+		err = app.Context.feePool.LoadBaseFee()
+		if err != nil {
+			return err
+		}
 
 		cdOpt, err := app.Context.govern.WithHeight(app.header.Height).GetETHChainDriverOption()
 		if err != nil {
@@ -406,6 +485,12 @@ func (app *App) Prepare() error {
 		}
 		app.Context.ethTrackers.SetupOption(cdOpt)
 
+		evmChains, err := app.Context.govern.WithHeight(app.header.Height).GetEVMChainRegistry()
+		if err != nil {
+			return err
+		}
+		app.Context.ethTrackers.SetupRegistry(evmChains)
+
 		btcOption, err := app.Context.govern.WithHeight(app.header.Height).GetBTCChainDriverOption()
 		if err != nil {
 			return err
@@ -413,6 +498,7 @@ func (app *App) Prepare() error {
 		btcConfig := bitcoin.NewBTCConfig(app.Context.cfg.ChainDriver, btcOption.ChainType)
 
 		app.Context.btcTrackers.SetConfig(btcConfig)
+		app.Context.btcTrackers.SetOption(*btcOption)
 
 		propOpt, err := app.Context.govern.WithHeight(app.header.Height).GetProposalOptions()
 		if err != nil {
@@ -435,6 +521,7 @@ func (app *App) Prepare() error {
 		return errors.Wrap(err, "failed get genesisDoc")
 	}
 	app.genesisDoc = genesisDoc
+	vm.SetNativeBlock(genesisDoc.ForkParams.NativeBlock)
 
 	blockStoreChan := make(chan *store.BlockStore)
 	var wg sync.WaitGroup
@@ -445,7 +532,7 @@ func (app *App) Prepare() error {
 	}()
 
 	// Init witness store after genesis witnesses loaded in above NewNode
-	app.Context.witnesses.Init(chain.ETHEREUM, app.Context.node.ValidatorAddress())
+	app.Context.witnesses.Init(chain.ETHEREUM, app.Context.validators.ResolveConsensusAddress(app.Context.node.ValidatorAddress()))
 
 	// set up dirty block store when ready to prevent issues during node sync
 	app.logger.Debug("awaiting to set up dirty block store for context, rewards and state db")
@@ -459,6 +546,14 @@ func (app *App) Prepare() error {
 		return errors.Wrap(err, "failed to create new consensus.Node")
 	}
 
+	// a witness proves every bridge transaction against the header chain, refuse to start when it can't build one
+	if app.Context.witnesses.IsChainWitness(chain.ETHEREUM) {
+		err = ethchain.ValidateHeaderConfig(app.Context.cfg.EthChainDriver)
+		if err != nil {
+			return errors.Wrap(err, "invalid ethereum chain driver config")
+		}
+	}
+
 	// set up loaded block store for context and state db
 	app.logger.Debug("set up loaded block store for context, rewards and state db")
 	app.Context.SetBlockStore(app.node.BlockStore())
@@ -498,7 +593,11 @@ func (app *App) Start() error {
 		return err
 	}
 
-	web3Services := app.Context.Web3Services(app.node)
+	web3Services, err := app.Context.Web3Services(app.node)
+	if err != nil {
+		app.logger.Error("Failed to prepare web3 services, details", err)
+		return err
+	}
 
 	// Starting new (web3) RPC
 	err = app.Context.web3.StartHTTP(web3Services)
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -11,9 +12,13 @@ import (
 	"github.com/Oneledger/protocol/external_apps"
 	"github.com/Oneledger/protocol/external_apps/common"
 	"github.com/Oneledger/protocol/vm"
+	"github.com/Oneledger/protocol/vm/native"
 	"github.com/Oneledger/protocol/web3"
+	"github.com/Oneledger/protocol/web3/bloombits"
 	web3types "github.com/Oneledger/protocol/web3/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 
+	tmrpccore "github.com/tendermint/tendermint/rpc/core"
 	"github.com/tendermint/tendermint/store"
 	tmdb "github.com/tendermint/tm-db"
 
@@ -26,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/action"
+	action_bridge "github.com/Oneledger/protocol/action/bridge"
 	"github.com/Oneledger/protocol/action/eth"
 	action_pen "github.com/Oneledger/protocol/action/evidence"
 	action_gov "github.com/Oneledger/protocol/action/governance"
@@ -41,6 +47,7 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/evidence"
@@ -80,6 +87,8 @@ type context struct {
 	govern      *governance.Store
 	btcTrackers *bitcoin.TrackerStore  // tracker for bitcoin balance UTXO
 	ethTrackers *ethereum.TrackerStore // Tracker store for ongoing ethereum trackers
+	nfts        *ethereum.NFTStore     // Records of the ERC721 tokens bridged from evm chains
+	bridge      *bridge.Store          // Circuit breakers and flow limits of the bridges
 	currencies  *balance.CurrencySet
 	//storage which is not a chain state
 	accounts accounts.Wallet
@@ -107,6 +116,10 @@ type context struct {
 	accountKeeper balance.AccountKeeper
 	stateDB       *vm.CommitStateDB
 	blockStore    *store.BlockStore
+
+	// log index of the web3 filters
+	bloomDB      tmdb.DB
+	bloomIndexer *bloombits.Indexer
 }
 
 func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (context, error) {
@@ -134,7 +147,7 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	ctx.deliver = storage.NewState(ctx.chainstate)
 	ctx.check = storage.NewState(ctx.chainstate)
 
-	ctx.validators = identity.NewValidatorStore("v", "purged", storage.NewState(ctx.chainstate))
+	ctx.validators = identity.NewValidatorStore("v", "purged", "rotation", "profile", storage.NewState(ctx.chainstate))
 	ctx.witnesses = identity.NewWitnessStore("w", storage.NewState(ctx.chainstate))
 	ctx.balances = balance.NewStore("b", storage.NewState(ctx.chainstate))
 	ctx.domains = ons.NewDomainStore("d", storage.NewState(ctx.chainstate))
@@ -152,6 +165,8 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	ctx.transaction = transactions.NewTransactionStore("intx", cs)
 
 	ctx.ethTrackers = ethereum.NewTrackerStore("etht", "ethfailed", "ethsuccess", storage.NewState(ctx.chainstate))
+	ctx.nfts = ethereum.NewNFTStore("nft", storage.NewState(ctx.chainstate))
+	ctx.bridge = bridge.NewStore("bridge", storage.NewState(ctx.chainstate))
 	ctx.accounts = accounts.NewWallet(cfg, ctx.dbDir())
 
 	// TODO check if validator
@@ -175,6 +190,8 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	logger := log.NewLoggerWithPrefix(ctx.logWriter, "stateDB").WithLevel(log.Level(ctx.cfg.Node.LogLevel))
 
 	ctx.stateDB = vm.NewCommitStateDB(ctx.contracts, ctx.accountKeeper, logger)
+	// native contracts build stores of their own on the state of every call
+	native.EnableNative(ctx.currencies)
 
 	err = external_apps.RegisterExtApp(ctx.chainstate, ctx.actionRouter, ctx.extStores, ctx.extServiceMap, ctx.extFunctions)
 	if err != nil {
@@ -205,6 +222,8 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 
 	_ = eth.EnableETH(ctx.actionRouter)
 	_ = eth.EnableInternalETH(ctx.internalRouter)
+	_ = action_bridge.EnableBridge(ctx.actionRouter)
+	_ = action_bridge.EnableInternalBridge(ctx.internalRouter)
 
 	_ = action_rewards.EnableRewards(ctx.actionRouter)
 	_ = action_netwkdeleg.EnableNetworkDelegation(ctx.actionRouter)
@@ -256,6 +275,8 @@ func (ctx *context) Action(header *Header, state *storage.State) *action.Context
 		ctx.evidenceStore.WithState(state),
 		ctx.btcTrackers.WithState(state),
 		ctx.ethTrackers.WithState(state),
+		ctx.nfts.WithState(state),
+		ctx.bridge.WithState(state),
 		ctx.jobStore,
 		ctx.lockScriptStore,
 		log.NewLoggerWithPrefix(ctx.logWriter, "action").WithLevel(log.Level(ctx.cfg.Node.LogLevel)),
@@ -270,6 +291,108 @@ func (ctx *context) Action(header *Header, state *storage.State) *action.Context
 	return actionCtx
 }
 
+// replayAction returns an action context on stores of its own, for the replays of committed blocks
+// the web3 services run next to the deliver of the app
+func (ctx *context) replayAction(header *Header, state *storage.State) *action.Context {
+	logger := log.NewLoggerWithPrefix(ctx.logWriter, "replay").WithLevel(log.Level(ctx.cfg.Node.LogLevel))
+
+	balances := balance.NewStore("b", state)
+	govern := governance.NewStore("g", state)
+
+	feePool := fees.NewStore("f", state)
+	feeOpt, err := govern.GetFeeOption()
+	if err != nil {
+		logger.Error("failed to get feeOption", err)
+		opt := *ctx.feePool.GetOpt()
+		feeOpt = &opt
+	}
+	feePool.SetupOpt(feeOpt)
+	err = feePool.LoadBaseFee()
+	if err != nil {
+		logger.Error("failed to load base fee", err)
+	}
+
+	domains := ons.NewDomainStore("d", state)
+	domains.SetOptions(ctx.domains.GetOptions())
+
+	btcTrackers := bitcoin.NewTrackerStore("btct", state)
+	btcTrackers.SetConfig(ctx.btcTrackers.GetConfig())
+	btcTrackers.SetOption(ctx.btcTrackers.GetOption())
+
+	ethTrackers := ethereum.NewTrackerStore("etht", "ethfailed", "ethsuccess", state)
+	ethTrackers.SetupOption(ctx.ethTrackers.GetOption())
+	ethTrackers.SetupRegistry(ctx.ethTrackers.GetRegistry())
+
+	proposalMaster := NewProposalMasterStore(ctx.chainstate).WithState(state)
+	proposalMaster.Proposal.SetOptions(ctx.proposalMaster.Proposal.GetOptions())
+
+	rewardMaster := NewRewardMasterStore(ctx.chainstate).WithState(state)
+	rewardMaster.SetOptions(ctx.rewardMaster.GetOptions())
+
+	extStores := data.NewStorageRouter()
+	for name, store := range common.LoadExtAppData(ctx.chainstate).ExtStores {
+		_ = extStores.Add(data.Type(name), store)
+	}
+
+	stateDB := vm.NewCommitStateDB(
+		evm.NewContractStore(state),
+		balance.NewNesterAccountKeeper(state, balances, ctx.currencies),
+		logger,
+	)
+	blockStore := ctx.stateDB.GetBlockStore()
+	stateDB.SetBlockStore(blockStore)
+	if blockStore != nil {
+		if meta := blockStore.LoadBlockMeta(header.Height); meta != nil {
+			stateDB.SetBlockHash(ethcmn.BytesToHash(meta.BlockID.Hash))
+		}
+	}
+
+	// the jobs and lock scripts are node stores outside of the chain state, a replay leaves them alone
+	return action.NewContext(
+		ctx.actionRouter,
+		header,
+		state,
+		ctx.accounts,
+		balances,
+		ctx.currencies,
+		feePool,
+		identity.NewValidatorStore("v", "purged", "rotation", "profile", state),
+		identity.NewWitnessStore("w", state),
+		domains,
+		delegation.NewDelegationStore("st", state),
+		netwkDeleg.NewMasterStore("deleg", "delegRwz", state),
+		evidence.NewEvidenceStore("es", state),
+		btcTrackers,
+		ethTrackers,
+		ethereum.NewNFTStore("nft", state),
+		bridge.NewStore("bridge", state),
+		nil,
+		nil,
+		logger,
+		proposalMaster,
+		rewardMaster,
+		govern,
+		extStores.WithState(state),
+		ctx.govupdate,
+		stateDB,
+	)
+}
+
+// replayDeliverer delivers the transactions of committed blocks again for the web3 services
+func (ctx *context) replayDeliverer() web3types.TxDeliverer {
+	logger := log.NewLoggerWithPrefix(ctx.logWriter, "replay").WithLevel(log.Level(ctx.cfg.Node.LogLevel))
+	return func(header *Header, state *storage.State, tx []byte) (result ResponseDeliverTx) {
+		// a transaction panicking in the replay fails, the state is thrown away by the caller anyway
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("replay of tx panicked", r)
+				result = ResponseDeliverTx{Code: CodeNotOK.uint32(), Log: fmt.Sprint(r)}
+			}
+		}()
+		return deliverTx(ctx.replayAction(header, state), tx, logger)
+	}
+}
+
 func (ctx *context) ID() {}
 func (ctx *context) Accounts() accounts.Wallet {
 	return ctx.accounts
@@ -295,7 +418,13 @@ func (ctx *context) Balances() *balance.Context {
 		ctx.currencies)
 }
 
-func (ctx *context) Web3Services(node *consensus.Node) map[string]web3types.Web3Service {
+func (ctx *context) Web3Services(node *consensus.Node) (map[string]web3types.Web3Service, error) {
+	if ctx.cfg.API.Filter().BloomIndex && ctx.bloomIndexer == nil {
+		err := ctx.startBloomIndexer(node)
+		if err != nil {
+			return nil, err
+		}
+	}
 	web3Ctx := web3.NewContext(
 		log.NewLoggerWithPrefix(ctx.logWriter, "web3").WithLevel(log.Level(ctx.cfg.Node.LogLevel)),
 		node,
@@ -304,8 +433,38 @@ func (ctx *context) Web3Services(node *consensus.Node) map[string]web3types.Web3
 		&ctx.cfg,
 		ctx.chainstate,
 		ctx.currencies,
+		ctx.bloomIndexer,
+		ctx.replayDeliverer(),
 	)
-	return web3Ctx.ServiceList()
+	return web3Ctx.ServiceList(), nil
+}
+
+// startBloomIndexer starts indexing the log blooms of the committed blocks for the web3 filters
+func (ctx *context) startBloomIndexer(node *consensus.Node) error {
+	db, err := storage.GetDatabase(bloombits.DBName, ctx.dbDir(), ctx.cfg.Node.DB)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the bloom bits db")
+	}
+	// the results of the latest block are saved after it, so they are only read one block behind
+	head := func() int64 {
+		return node.BlockStore().Height() - 1
+	}
+	bloomAt := func(height int64) (vm.Bloom, error) {
+		results, err := tmrpccore.BlockResults(nil, &height)
+		if err != nil {
+			return vm.Bloom{}, err
+		}
+		return web3types.GetBlockBloom(results.EndBlockEvents), nil
+	}
+	indexer, err := bloombits.NewIndexer(db, head, bloomAt)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	indexer.Start()
+	ctx.bloomDB = db
+	ctx.bloomIndexer = indexer
+	return nil
 }
 
 func (ctx *context) Services() (service.Map, error) {
@@ -322,6 +481,7 @@ func (ctx *context) Services() (service.Map, error) {
 
 	ethTracker := ethereum.NewTrackerStore("etht", "ethfailed", "ethsuccess", storage.NewState(ctx.chainstate))
 	ethTracker.SetupOption(ctx.ethTrackers.GetOption())
+	ethTracker.SetupRegistry(ctx.ethTrackers.GetRegistry())
 
 	onsStore := ons.NewDomainStore("d", storage.NewState(ctx.chainstate))
 
@@ -340,7 +500,7 @@ func (ctx *context) Services() (service.Map, error) {
 		FeePool:         feePool,
 		Cfg:             ctx.cfg,
 		NodeContext:     ctx.node,
-		ValidatorSet:    identity.NewValidatorStore("v", "purged", storage.NewState(ctx.chainstate)),
+		ValidatorSet:    identity.NewValidatorStore("v", "purged", "rotation", "profile", storage.NewState(ctx.chainstate)),
 		WitnessSet:      identity.NewWitnessStore("w", storage.NewState(ctx.chainstate)),
 		Domains:         onsStore,
 		Delegators:      delegation.NewDelegationStore("st", storage.NewState(ctx.chainstate)),
@@ -354,8 +514,11 @@ func (ctx *context) Services() (service.Map, error) {
 		Logger:          log.NewLoggerWithPrefix(ctx.logWriter, "rpc").WithLevel(log.Level(ctx.cfg.Node.LogLevel)),
 		Services:        extSvcs,
 		EthTrackers:     ethTracker,
+		NFTs:            ethereum.NewNFTStore("nft", storage.NewState(ctx.chainstate)),
 		Trackers:        btcTrackers,
 		Govern:          governance.NewStore("g", storage.NewState(ctx.chainstate)),
+		Bridge:          bridge.NewStore("bridge", storage.NewState(ctx.chainstate)),
+		JobStore:        ctx.jobStore,
 		GovUpdate:       ctx.govupdate,
 		Contracts:       ctx.contracts,
 		AccountKeeper:   ctx.accountKeeper,
@@ -403,6 +566,7 @@ type StorageCtx struct {
 	FeePool         *fees.Store
 	Govern          *governance.Store
 	Trackers        *ethereum.TrackerStore //TODO: Create struct to contain all tracker types including Bitcoin.
+	NFTs            *ethereum.NFTStore
 
 	Currencies *balance.CurrencySet
 	FeeOption  *fees.FeeOption
@@ -428,12 +592,17 @@ func (ctx *context) Storage() StorageCtx {
 		Currencies:      ctx.currencies,
 		FeeOption:       ctx.feePool.GetOpt(),
 		Trackers:        ctx.ethTrackers,
+		NFTs:            ctx.nfts,
 	}
 }
 
 // Close all things that need to be closed
 func (ctx *context) Close() {
 	closers := []closer{ctx.db, ctx.accounts, ctx.rpc, ctx.jobBus}
+	if ctx.bloomIndexer != nil {
+		ctx.bloomIndexer.Stop()
+		closers = append(closers, ctx.bloomDB)
+	}
 	for _, closer := range closers {
 		err := closer.Close()
 		if err != nil {
@@ -27,6 +27,7 @@ import (
 	ceth "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/fees"
@@ -181,8 +182,15 @@ func (app *App) blockBeginner() blockBeginner {
 			app.logger.Error("failed to get feeOption", err)
 		}
 		app.Context.feePool.SetupOpt(feeOpt)
+		err = app.Context.feePool.WithState(app.Context.deliver).LoadBaseFee()
+		if err != nil {
+			app.logger.Error("failed to load base fee", err)
+		}
+
+		// votes and proposer of rotated validators are reported with their current consensus key
+		resolvedReq := resolveConsensusAddresses(req, app.Context.validators.WithState(app.Context.deliver))
 
-		err = ManageVotes(&req, &app.Context, app.logger)
+		err = ManageVotes(&resolvedReq, &app.Context, app.logger)
 		if err != nil {
 			app.logger.Error("manage votes error", err)
 		}
@@ -212,7 +220,7 @@ func (app *App) blockBeginner() blockBeginner {
 		}
 
 		// update Block Rewards
-		blockRewardEvent := handleBlockRewards(&app.Context, req, app.logger)
+		blockRewardEvent := handleBlockRewards(&app.Context, resolvedReq, app.logger)
 		result.Events = append(result.Events, blockRewardEvent)
 
 		//update the header to current block
@@ -319,46 +327,52 @@ func (app *App) txDeliverer() txDeliverer {
 			return cachedResponse
 		}
 
-		app.Context.deliver.BeginTxSession()
+		txCtx := app.Context.Action(&app.header, app.Context.deliver)
+		return deliverTx(txCtx, msg.Tx, app.logger)
+	}
+}
 
-		tx := &action.SignedTx{}
+// deliverTx runs a transaction on the state of the action context in a tx session of its own, the
+// session is committed when the transaction and its fee went through
+func deliverTx(txCtx *action.Context, msgTx []byte, logger *log.Logger) ResponseDeliverTx {
+	txCtx.State.BeginTxSession()
 
-		err := serialize.GetSerializer(serialize.NETWORK).Deserialize(msg.Tx, tx)
-		if err != nil {
-			app.logger.Errorf("deliverTx failed to deserialize msg: %v, error: %s ", msg, err)
-		}
-		txCtx := app.Context.Action(&app.header, app.Context.deliver)
+	tx := &action.SignedTx{}
 
-		handler := txCtx.Router.Handler(tx.Type)
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(msgTx, tx)
+	if err != nil {
+		logger.Errorf("deliverTx failed to deserialize msg: %v, error: %s ", msgTx, err)
+	}
 
-		gas := txCtx.State.ConsumedGas()
+	handler := txCtx.Router.Handler(tx.Type)
 
-		ok, response := handler.ProcessDeliver(txCtx, tx.RawTx)
-		feeOk, feeResponse := handler.ProcessFee(txCtx, *tx, gas, storage.Gas(len(msg.Tx)), storage.Gas(response.GasUsed))
+	gas := txCtx.State.ConsumedGas()
 
-		logString := marshalLog(ok, response, feeResponse)
+	ok, response := handler.ProcessDeliver(txCtx, tx.RawTx)
+	feeOk, feeResponse := handler.ProcessFee(txCtx, *tx, gas, storage.Gas(len(msgTx)), storage.Gas(response.GasUsed))
 
-		result := ResponseDeliverTx{
-			Code:      getCode(ok && feeOk).uint32(),
-			Data:      response.Data,
-			Log:       logString,
-			Info:      response.Info,
-			GasWanted: feeResponse.GasWanted,
-			GasUsed:   feeResponse.GasUsed,
-			Events:    response.Events,
-			Codespace: "",
-		}
-		app.logger.Detail("Deliver Tx: ", result)
+	logString := marshalLog(ok, response, feeResponse)
 
-		app.Context.stateDB.Finality(response.Events)
+	result := ResponseDeliverTx{
+		Code:      getCode(ok && feeOk).uint32(),
+		Data:      response.Data,
+		Log:       logString,
+		Info:      response.Info,
+		GasWanted: feeResponse.GasWanted,
+		GasUsed:   feeResponse.GasUsed,
+		Events:    response.Events,
+		Codespace: "",
+	}
+	logger.Detail("Deliver Tx: ", result)
 
-		if !(ok && feeOk) {
-			app.Context.deliver.DiscardTxSession()
-		} else {
-			app.Context.deliver.CommitTxSession()
-		}
-		return result
+	txCtx.StateDB.Finality(response.Events)
+
+	if !(ok && feeOk) {
+		txCtx.State.DiscardTxSession()
+	} else {
+		txCtx.State.CommitTxSession()
 	}
+	return result
 }
 
 func (app *App) blockEnder() blockEnder {
@@ -367,6 +381,12 @@ func (app *App) blockEnder() blockEnder {
 
 		fee, err := app.Context.feePool.WithState(app.Context.deliver).Get([]byte(fees.POOL_KEY))
 		app.logger.Detail("endblock fee", fee, err)
+		blockFee, err := app.Context.feePool.WithState(app.Context.deliver).UpdateBaseFee(int64(app.Context.deliver.ConsumedGas()))
+		if err != nil {
+			app.logger.Error("failed to update base fee", err)
+		} else if blockFee != nil {
+			app.logger.Detail("endblock base fee", blockFee.BaseFee.String(), "gas used", blockFee.GasUsed, "next", blockFee.NextBaseFee.String())
+		}
 		updates := app.Context.validators.WithState(app.Context.deliver).GetEndBlockUpdate(app.Context.ValidatorCtx(), req)
 		app.logger.Detailf("Sending updates with nodes to tendermint: %+v\n", updates)
 
@@ -376,8 +396,9 @@ func (app *App) blockEnder() blockEnder {
 		app.Context.validators.WithState(app.Context.deliver).ClearEvents()
 
 		ethTrackerlog := log.NewLoggerWithPrefix(app.Context.logWriter, "ethtracker").WithLevel(log.Level(app.Context.cfg.Node.LogLevel))
-		doTransitions(app.Context.jobStore, app.Context.btcTrackers.WithState(app.Context.deliver), app.Context.validators)
-		doEthTransitions(app.Context.jobStore, app.Context.ethTrackers, app.Context.node.ValidatorAddress(), ethTrackerlog, app.Context.witnesses, app.Context.deliver)
+		bridges := app.Context.bridge.WithState(app.Context.deliver)
+		doTransitions(app.Context.jobStore, app.Context.btcTrackers.WithState(app.Context.deliver), app.Context.validators, bridges, app.header.Height)
+		doEthTransitions(app.Context.jobStore, app.Context.ethTrackers, app.Context.node.ValidatorAddress(), ethTrackerlog, app.Context.witnesses, app.Context.deliver, bridges, app.header.Height)
 		// Proposals currently in store are cleared if deliver is successful
 		// If Expire or Finalize TX returns false,they will added to the proposals queue in the next block
 		// Errors are logged at the function level
@@ -425,6 +446,11 @@ func (app *App) commitor() commitor {
 		hash, ver := app.Context.deliver.Commit()
 		app.logger.Detailf("Committed New Block height[%d], hash[%s], versions[%d]", app.header.Height, hex.EncodeToString(hash), ver)
 
+		err := app.loadEVMChains()
+		if err != nil {
+			app.logger.Error("failed to load the evm chains", err)
+		}
+
 		// update check state by deliver state
 		gc := app.getGasCalculator()
 		app.Context.check = storage.NewState(app.Context.chainstate).WithGas(gc)
@@ -473,7 +499,7 @@ func (app *App) getGasCalculator() storage.GasCalculator {
 	return storage.NewGasCalculator(gas)
 }
 
-func doTransitions(js *jobs.JobStore, ts *bitcoin.TrackerStore, validators *identity.ValidatorStore) {
+func doTransitions(js *jobs.JobStore, ts *bitcoin.TrackerStore, validators *identity.ValidatorStore, bridges *bridge.Store, height int64) {
 
 	btcTracker := []bitcoin.Tracker{}
 	if js != nil {
@@ -496,19 +522,37 @@ func doTransitions(js *jobs.JobStore, ts *bitcoin.TrackerStore, validators *iden
 
 		ctx := bitcoin.BTCTransitionContext{&t, js.WithChain(chain.BITCOIN), validators}
 
-		stt, err := event.BtcEngine.Process(t.NextStep(), ctx, transition.Status(t.State))
+		step := t.NextStep()
+		stt, err := event.BtcEngine.Process(step, ctx, transition.Status(t.State))
 		if err != nil {
 			continue
 		}
 		if stt != -1 {
 			t.State = bitcoin.TrackerState(stt)
 			err = ts.SetTracker(t.Name, &t)
+			if err == nil {
+				_ = bridges.RecordHistory(chain.BITCOIN, t.Name, bridge.HistoryEntry{Height: height, Event: step, State: t.State.String()})
+			}
 		}
 	}
 }
 
-func doEthTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, myValAddr keys.Address, logger *log.Logger, witnesses *identity.WitnessStore, deliver *storage.State) {
+func doEthTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, myValAddr keys.Address, logger *log.Logger, witnesses *identity.WitnessStore, deliver *storage.State,
+	bridges *bridge.Store, height int64) {
 	ts = ts.WithState(deliver)
+	witnesses = witnesses.WithState(deliver)
+	doEVMChainTransitions(js, ts, chain.ETHEREUM, myValAddr, logger, witnesses, deliver, bridges, height)
+	event.ScheduleWitnessRotation(js.WithChain(chain.ETHEREUM), witnesses, chain.ETHEREUM, 0, myValAddr, height, logger)
+	for _, evmChain := range ts.GetRegistry().Chains {
+		chainType := chain.EVMType(evmChain.ChainID)
+		doEVMChainTransitions(js, ts.WithChain(evmChain.ChainID), chainType, myValAddr, logger, witnesses, deliver, bridges, height)
+		event.ScheduleWitnessRotation(js.WithChain(chainType), witnesses, chainType, evmChain.ChainID, myValAddr, height, logger)
+	}
+}
+
+// doEVMChainTransitions moves the ongoing trackers of one bridged evm chain along
+func doEVMChainTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, chainType chain.Type, myValAddr keys.Address, logger *log.Logger, witnesses *identity.WitnessStore, deliver *storage.State,
+	bridges *bridge.Store, height int64) {
 	tnames := make([]*ceth.TrackerName, 0, 20)
 	ts.WithPrefixType(ethereum.PrefixOngoing).Iterate(func(name *ceth.TrackerName, tracker *ethereum.Tracker) bool {
 		tnames = append(tnames, name)
@@ -519,9 +563,14 @@ func doEthTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, myValAddr ke
 		deliver.BeginTxSession()
 		t, _ := ts.WithPrefixType(ethereum.PrefixOngoing).Get(*name)
 		state := t.State
-		ctx := ethereum.NewTrackerCtx(t, myValAddr, js.WithChain(chain.ETHEREUM), ts, witnesses, logger)
+		step := t.NextStep()
+		ctx := ethereum.NewTrackerCtx(t, myValAddr, js.WithChain(chainType), ts, witnesses, logger)
+		err := event.ResetRetriedJobs(ctx)
+		if err != nil {
+			logger.Error("failed to reset the jobs of retried eth tracker", err)
+		}
 
-		if t.Type == ethereum.ProcessTypeLock || t.Type == ethereum.ProcessTypeLockERC {
+		if t.Type == ethereum.ProcessTypeLock || t.Type == ethereum.ProcessTypeLockERC || t.Type == ethereum.ProcessTypeLockNFT {
 
 			logger.Debug("Processing Tracker : ", t.Type.String(), " | Tracker Name ", t.TrackerName.String(), " | State :", t.State.String(), " | Finality Votes :", t.FinalityVotes)
 			_, err := event.EthLockEngine.Process(t.NextStep(), ctx, transition.Status(t.State))
@@ -530,7 +579,7 @@ func doEthTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, myValAddr ke
 				continue
 			}
 
-		} else if t.Type == ethereum.ProcessTypeRedeem || t.Type == ethereum.ProcessTypeRedeemERC {
+		} else if t.Type == ethereum.ProcessTypeRedeem || t.Type == ethereum.ProcessTypeRedeemERC || t.Type == ethereum.ProcessTypeRedeemNFT {
 			logger.Debug("Processing Tracker : ", t.Type.String(), " | Tracker Name ", t.TrackerName.String(), " | State :", t.State.String(), " | Finality Votes :", t.FinalityVotes)
 			_, err := event.EthRedeemEngine.Process(t.NextStep(), ctx, transition.Status(t.State))
 			if err != nil {
@@ -546,6 +595,13 @@ func doEthTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, myValAddr ke
 				panic(err)
 			}
 		}
+		// keep the steps that moved the tracker, the cleanups leave it in the same state
+		if state != ctx.Tracker.State || step == ethereum.CLEANUP || step == ethereum.CLEANUPFAILED {
+			err := bridges.RecordHistory(chainType, name.Hex(), bridge.HistoryEntry{Height: height, Event: step, State: ctx.Tracker.State.String()})
+			if err != nil {
+				logger.Error("failed to record eth tracker history", err, ctx.Tracker)
+			}
+		}
 		deliver.CommitTxSession()
 	}
 
@@ -830,6 +886,18 @@ func marshalLog(ok bool, response action.Response, feeResponse action.Response)
 
 }
 
+// map the consensus addresses in the votes and the proposer to the validator addresses
+func resolveConsensusAddresses(req RequestBeginBlock, validators *identity.ValidatorStore) RequestBeginBlock {
+	votes := make([]abciTypes.VoteInfo, len(req.LastCommitInfo.Votes))
+	for i, vote := range req.LastCommitInfo.Votes {
+		vote.Validator.Address = validators.ResolveConsensusAddress(vote.Validator.Address)
+		votes[i] = vote
+	}
+	req.LastCommitInfo.Votes = votes
+	req.Header.ProposerAddress = validators.ResolveConsensusAddress(req.Header.ProposerAddress)
+	return req
+}
+
 func ManageVotes(req *RequestBeginBlock, ctx *context, logger *log.Logger) error {
 	eopts, err := ctx.govern.WithState(ctx.deliver).GetEvidenceOptions()
 	if err != nil {
@@ -929,4 +997,60 @@ func matureDelegationRewards(appCtx *context, req *RequestBeginBlock, kvMap map[
 		}
 		return false
 	})
+
+	compoundDelegationRewards(appCtx, c, kvMap, logger)
+}
+
+// reinvest rewards balance of delegators who opted in to auto compounding, once it reaches their threshold
+func compoundDelegationRewards(appCtx *context, c balance.Currency, kvMap map[string]kv.Pair, logger *log.Logger) {
+	networkDelegators := appCtx.netwkDelegators.WithState(appCtx.deliver)
+	rewardsStore := networkDelegators.Rewards
+	activeStore := networkDelegators.Deleg.WithPrefix(network_delegation.ActiveType)
+	balanceStore := appCtx.balances.WithState(appCtx.deliver)
+
+	delegationPool, err := appCtx.govern.WithState(appCtx.deliver).GetPoolByName(governance.POOL_DELEGATION)
+	if err != nil {
+		logger.Error("failed to get delegation pool for auto compounding", err)
+		return
+	}
+
+	// collect the amounts first, stores are not updated while iterating
+	compounds := make([]network_delegation.Reward, 0)
+	rewardsStore.IterateAutoCompound(func(delegator keys.Address, threshold *balance.Amount) bool {
+		amt, err := rewardsStore.GetRewardsBalance(delegator)
+		if err != nil || amt.LessThan(*threshold) {
+			return false
+		}
+		compounds = append(compounds, network_delegation.Reward{Address: delegator, Amount: amt})
+		return false
+	})
+
+	for _, compound := range compounds {
+		delegator, amt := compound.Address, compound.Amount
+		addr := delegator.String()
+		coin := c.NewCoinFromAmount(*amt)
+		err = rewardsStore.MinusRewardsBalance(delegator, amt)
+		if err != nil {
+			logger.Errorf("failed to deduct rewards balance of %s for auto compounding: %s", addr, err)
+			continue
+		}
+		err = balanceStore.AddToAddress(delegationPool, coin)
+		if err != nil {
+			logger.Errorf("failed to add auto compounded rewards of %s to delegation pool", addr)
+			panic(err)
+		}
+		currentDelegation, _ := activeStore.Get(delegator)
+		newCoin := currentDelegation.Plus(coin)
+		err = activeStore.Set(delegator, &newCoin)
+		if err != nil {
+			logger.Errorf("failed to add auto compounded rewards to delegation of %s", addr)
+			panic(err)
+		}
+		//Create Event for compounding rewards
+		rewardsKey := "deleg_rewards_compound_" + addr
+		kvMap[rewardsKey] = kv.Pair{
+			Key:   []byte(rewardsKey),
+			Value: []byte(amt.String()),
+		}
+	}
 }
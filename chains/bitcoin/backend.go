@@ -0,0 +1,101 @@
+/*
+
+ */
+
+package bitcoin
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	BackendBitcoind    = "bitcoind"
+	BackendBlockCypher = "blockcypher"
+	BackendMock        = "mock"
+
+	// blocks a transaction paying the estimated fee rate should confirm within
+	DefaultFeeTarget = 6
+)
+
+var (
+	ErrOutputNotFound  = errors.New("output not found or already spent")
+	ErrUnknownBackend  = errors.New("unknown bitcoin backend")
+	ErrFeeNotAvailable = errors.New("fee estimation not available")
+)
+
+// Output is an unspent transaction output
+type Output struct {
+	Value         int64
+	PkScript      []byte
+	Confirmations int64
+}
+
+// Backend is what the bitcoin bridge needs of the bitcoin network, UTXO lookup, finality,
+// broadcast and fee estimation
+type Backend interface {
+	// GetOutput returns an unspent output, ErrOutputNotFound if it is unknown or spent
+	GetOutput(hash *chainhash.Hash, index uint32) (*Output, error)
+
+	// Confirmations returns the number of blocks confirming a transaction
+	Confirmations(hash *chainhash.Hash) (int64, error)
+
+	SendTx(tx *wire.MsgTx) (*chainhash.Hash, error)
+
+	// EstimateFeeRate returns the fee rate in satoshi per byte for a transaction to confirm
+	// within target blocks
+	EstimateFeeRate(target int64) (int64, error)
+}
+
+// BackendConfig selects and configures the backend, bitcoind is used unless blockcypher or the
+// in-process mock is asked for
+type BackendConfig struct {
+	Backend string
+
+	// bitcoind json-rpc
+	Host     string
+	User     string
+	Password string
+
+	// blockcypher
+	BlockCypherToken string
+	BlockCypherChain string
+}
+
+var (
+	backends     = make(map[BackendConfig]Backend)
+	backendsLock sync.Mutex
+)
+
+// GetBackend returns the backend of a config, backends are kept to reuse their connections
+func GetBackend(cfg BackendConfig) (Backend, error) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	if b, ok := backends[cfg]; ok {
+		return b, nil
+	}
+
+	var (
+		b   Backend
+		err error
+	)
+	switch cfg.Backend {
+	case "", BackendBitcoind:
+		b, err = NewRPCBackend(cfg.Host, cfg.User, cfg.Password)
+	case BackendBlockCypher:
+		b = NewBlockCypherBackend(cfg.BlockCypherToken, cfg.BlockCypherChain)
+	case BackendMock:
+		b = NewMockBackend()
+	default:
+		return nil, ErrUnknownBackend
+	}
+	if err != nil {
+		return nil, err
+	}
+	backends[cfg] = b
+	return b, nil
+}
@@ -0,0 +1,78 @@
+/*
+
+ */
+
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/blockcypher/gobcy"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/pkg/errors"
+)
+
+// BlockCypherBackend uses the BlockCypher web api, optional for nodes without a bitcoind
+type BlockCypherBackend struct {
+	api gobcy.API
+}
+
+var _ Backend = &BlockCypherBackend{}
+
+func NewBlockCypherBackend(token, chain string) *BlockCypherBackend {
+	return &BlockCypherBackend{api: gobcy.API{token, "btc", chain}}
+}
+
+func (b *BlockCypherBackend) GetOutput(hash *chainhash.Hash, index uint32) (*Output, error) {
+	tx, err := b.api.GetTX(hash.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(tx.Outputs) || tx.Outputs[index].SpentBy != "" {
+		return nil, ErrOutputNotFound
+	}
+	script, err := hex.DecodeString(tx.Outputs[index].Script)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid output script")
+	}
+	return &Output{
+		Value:         int64(tx.Outputs[index].Value),
+		PkScript:      script,
+		Confirmations: int64(tx.Confirmations),
+	}, nil
+}
+
+func (b *BlockCypherBackend) Confirmations(hash *chainhash.Hash) (int64, error) {
+	tx, err := b.api.GetTX(hash.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	return int64(tx.Confirmations), nil
+}
+
+func (b *BlockCypherBackend) SendTx(tx *wire.MsgTx) (*chainhash.Hash, error) {
+	buf := bytes.NewBuffer([]byte{})
+	err := tx.Serialize(buf)
+	if err != nil {
+		return nil, err
+	}
+	skel, err := b.api.PushTX(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(skel.Trans.Hash)
+}
+
+// EstimateFeeRate uses the medium fee of the chain, blockcypher has no per target estimate
+func (b *BlockCypherBackend) EstimateFeeRate(target int64) (int64, error) {
+	chain, err := b.api.GetChain()
+	if err != nil {
+		return 0, err
+	}
+	if chain.MediumFee <= 0 {
+		return 0, ErrFeeNotAvailable
+	}
+	return int64(chain.MediumFee) / 1000, nil
+}
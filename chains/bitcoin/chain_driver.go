@@ -9,10 +9,8 @@ import (
 	"errors"
 	"sort"
 
-	"github.com/blockcypher/gobcy"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
-	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
@@ -24,23 +22,24 @@ type TxHash [chainhash.HashSize]byte
 type ChainDriver interface {
 	//	PrepareLock(prevLock, input *bitcoin.UTXO, lockScriptAddress []byte) (txBytes []byte)
 
-	PrepareLockNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64,
+	PrepareLockNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64, prevLockScriptAddress []byte,
 		inputs []InputTransaction, feesRate int64, lockAmount int64, returnAddress []byte,
 		lockScriptAddress []byte) (txBytes []byte, err error)
 
-	AddLockSignature([]byte, []byte, bool) *wire.MsgTx
+	AddLockSignature(txBytes []byte, signatures [][]byte, lockScript []byte, lockScriptAddress []byte,
+		isFirstLock bool) (*wire.MsgTx, error)
 
-	BroadcastTx(*wire.MsgTx, *rpcclient.Client) (*chainhash.Hash, error)
+	BroadcastTx(*wire.MsgTx) (*chainhash.Hash, error)
 
-	CheckFinality(hash *chainhash.Hash, blockConfirmations int, token, chain string) (bool, error)
+	CheckFinality(hash *chainhash.Hash, blockConfirmations int) (bool, error)
 
-	PrepareRedeemNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64,
+	PrepareRedeemNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64, prevLockScriptAddress []byte,
 		userAddress []byte, redeemAmount int64, feesInSatoshi int64,
 		lockScriptAddress []byte) (txBytes []byte)
 }
 
 type chainDriver struct {
-	blockCypherToken string
+	backend Backend
 }
 
 type InputTransaction struct {
@@ -51,12 +50,12 @@ type InputTransaction struct {
 
 var _ ChainDriver = &chainDriver{}
 
-func NewChainDriver(token string) ChainDriver {
+func NewChainDriver(backend Backend) ChainDriver {
 
-	return &chainDriver{token}
+	return &chainDriver{backend}
 }
 
-func (c *chainDriver) PrepareLockNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64,
+func (c *chainDriver) PrepareLockNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64, prevLockScriptAddress []byte,
 	inputs []InputTransaction, feesRate int64, lockAmount int64, returnAddress []byte,
 	lockScriptAddress []byte) (txBytes []byte, err error) {
 
@@ -91,7 +90,7 @@ func (c *chainDriver) PrepareLockNew(prevLockTxID *chainhash.Hash, prevLockIndex
 	out := wire.NewTxOut(balance, lockScriptAddress)
 	tx.AddTxOut(out)
 
-	feesInSatoshi := int64(EstimateTxSizeBeforeUserSign(tx, isFirstLock)) * feesRate
+	feesInSatoshi := int64(EstimateTxSizeBeforeUserSign(tx, isFirstLock, IsWitnessLock(prevLockScriptAddress))) * feesRate
 
 	var returnBalance = prevLockBalance + totalInputBalance - balance - feesInSatoshi
 	if returnBalance < 0 {
@@ -111,27 +110,51 @@ func (c *chainDriver) PrepareLockNew(prevLockTxID *chainhash.Hash, prevLockIndex
 	return
 }
 
-func (c *chainDriver) AddLockSignature(txBytes []byte, sigScript []byte, isFirstLock bool) *wire.MsgTx {
+func (c *chainDriver) AddLockSignature(txBytes []byte, signatures [][]byte, lockScript []byte,
+	lockScriptAddress []byte, isFirstLock bool) (*wire.MsgTx, error) {
+
 	tx := wire.NewMsgTx(wire.TxVersion)
 
 	buf := bytes.NewBuffer(txBytes)
-	tx.Deserialize(buf)
+	err := tx.Deserialize(buf)
+	if err != nil {
+		return nil, err
+	}
 
 	// if first lock & not redeem
 	if isFirstLock {
-		return tx
+		return tx, nil
+	}
+
+	// a P2WSH lock is unlocked by the witness, the sigScript stays empty
+	if IsWitnessLock(lockScriptAddress) {
+		witness := wire.TxWitness{nil}
+		for i := range signatures {
+			witness = append(witness, signatures[i])
+		}
+		tx.TxIn[0].Witness = append(witness, lockScript)
+
+		return tx, nil
 	}
 
+	builder := txscript.NewScriptBuilder().AddOp(txscript.OP_FALSE)
+	for i := range signatures {
+		builder.AddData(signatures[i])
+	}
+	builder.AddData(lockScript)
+
+	sigScript, err := builder.Script()
+	if err != nil {
+		return nil, err
+	}
 	tx.TxIn[0].SignatureScript = sigScript
 
-	return tx
+	return tx, nil
 }
 
-func (c *chainDriver) BroadcastTx(tx *wire.MsgTx, clt *rpcclient.Client) (*chainhash.Hash, error) {
-
-	// temp
+func (c *chainDriver) BroadcastTx(tx *wire.MsgTx) (*chainhash.Hash, error) {
 
-	hash, err := clt.SendRawTransaction(tx, false)
+	hash, err := c.backend.SendTx(tx)
 	if err != nil {
 		return &chainhash.Hash{}, err
 	}
@@ -139,17 +162,14 @@ func (c *chainDriver) BroadcastTx(tx *wire.MsgTx, clt *rpcclient.Client) (*chain
 	return hash, nil
 }
 
-func (c *chainDriver) CheckFinality(hash *chainhash.Hash, blockConfirmations int, token, chain string) (bool, error) {
-
-	btc := gobcy.API{token, "btc", chain}
-
-	tx, err := btc.GetTX(hash.String(), nil)
+func (c *chainDriver) CheckFinality(hash *chainhash.Hash, blockConfirmations int) (bool, error) {
 
+	confirmations, err := c.backend.Confirmations(hash)
 	if err != nil {
 		return false, err
 	}
 
-	if tx.Confirmations >= blockConfirmations {
+	if confirmations >= int64(blockConfirmations) {
 		return true, nil
 	}
 
@@ -157,7 +177,7 @@ func (c *chainDriver) CheckFinality(hash *chainhash.Hash, blockConfirmations int
 }
 
 func (c *chainDriver) PrepareRedeemNew(prevLockTxID *chainhash.Hash, prevLockIndex uint32,
-	prevLockBalance int64, userAddress []byte, redeemAmount int64, feesInSatoshi int64,
+	prevLockBalance int64, prevLockScriptAddress []byte, userAddress []byte, redeemAmount int64, feesInSatoshi int64,
 	lockScriptAddress []byte) (txBytes []byte) {
 
 	tx := wire.NewMsgTx(wire.TxVersion)
@@ -174,10 +194,9 @@ func (c *chainDriver) PrepareRedeemNew(prevLockTxID *chainhash.Hash, prevLockInd
 	userOP := wire.NewTxOut(redeemAmount, userAddress)
 	tx.AddTxOut(userOP)
 
-	tempBuf := bytes.NewBuffer([]byte{})
-	tx.Serialize(tempBuf)
-	size := len(tempBuf.Bytes()) * 2
-	fees := int64(40 * size)
+	// the size validators check the fee rate against, it counts in the multisig unlocking data
+	size := estimateTxSize(tx, false, IsWitnessLock(prevLockScriptAddress))
+	fees := c.feeRate() * int64(size)
 
 	tx.TxOut[1].Value = tx.TxOut[1].Value - fees
 
@@ -188,13 +207,48 @@ func (c *chainDriver) PrepareRedeemNew(prevLockTxID *chainhash.Hash, prevLockInd
 	return
 }
 
+// feeRate is the fee rate of the backend kept within the rates the validators accept
+func (c *chainDriver) feeRate() int64 {
+	rate, err := c.backend.EstimateFeeRate(DefaultFeeTarget)
+	if err != nil {
+		return DefaultFeeRate
+	}
+	if rate < MinFeeRate {
+		return MinFeeRate
+	}
+	if rate > MaxFeeRate {
+		return MaxFeeRate
+	}
+	return rate
+}
+
 func CreateMultiSigAddress(m int, publicKeys []*btcutil.AddressPubKey, randomBytes []byte,
 	params *chaincfg.Params) (
 
 	script, address []byte, btcAddressList []string, err error) {
 
+	script, btcAddressList, err = multiSigScript(m, publicKeys)
+	if err != nil {
+		return
+	}
+
+	addressObj, err := btcutil.NewAddressScriptHash(script, params)
+	if err != nil {
+		return
+	}
+
+	address, err = txscript.PayToAddrScript(addressObj)
+
+	return
+}
+
+// multiSigScript builds the m of n redeem script of the validators, the keys are sorted so every
+// validator ends up with the same script
+func multiSigScript(m int, publicKeys []*btcutil.AddressPubKey) (script []byte, btcAddressList []string, err error) {
+
 	if len(publicKeys) >= 20 {
 		err = errors.New("signers should be less than 20")
+		return
 	}
 	// ideally m should be
 	//	m = len(publicKeys) * 2 /3 ) + 1
@@ -224,16 +278,6 @@ func CreateMultiSigAddress(m int, publicKeys []*btcutil.AddressPubKey, randomByt
 	// builder.AddData(randomBytes)
 
 	script, err = builder.Script()
-	if err != nil {
-		return
-	}
-
-	addressObj, err := btcutil.NewAddressScriptHash(script, params)
-	if err != nil {
-		return
-	}
-
-	address, err = txscript.PayToAddrScript(addressObj)
 
 	return
 }
@@ -9,4 +9,7 @@ type ChainDriverOption struct {
 	TotalSupply       string
 	TotalSupplyAddr   string
 	BlockConfirmation int64
+
+	// SegWit makes new tracker lock addresses P2WSH instead of P2SH
+	SegWit bool
 }
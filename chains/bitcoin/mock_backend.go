@@ -0,0 +1,187 @@
+/*
+
+ */
+
+package bitcoin
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// DefaultMockFeeRate is the fee rate in satoshi per byte a mock backend estimates
+const DefaultMockFeeRate = DefaultFeeRate
+
+var (
+	ErrMockDisconnected = errors.New("mock bitcoin backend is disconnected")
+	ErrMockMissingInput = errors.New("missing inputs")
+	ErrMockTxNotFound   = errors.New("No such mempool or blockchain transaction")
+)
+
+type mockOutput struct {
+	Output
+	block int64
+}
+
+// MockBackend simulates a bitcoin network in process. Outputs are funded with AddOutput, sent
+// transactions spend their inputs and wait in the mempool until Mine confirms them.
+// SetConnected and SetFeeRate inject failures.
+type MockBackend struct {
+	sync.Mutex
+
+	height       int64
+	feeRate      int64
+	disconnected bool
+
+	outputs map[wire.OutPoint]*mockOutput
+	txs     map[chainhash.Hash]int64
+	mempool []chainhash.Hash
+
+	// Sent keeps the transactions accepted, in order
+	Sent []*wire.MsgTx
+}
+
+var _ Backend = &MockBackend{}
+
+func NewMockBackend() *MockBackend {
+	return &MockBackend{
+		feeRate: DefaultMockFeeRate,
+		outputs: make(map[wire.OutPoint]*mockOutput),
+		txs:     make(map[chainhash.Hash]int64),
+	}
+}
+
+// AddOutput adds a confirmed unspent output, as paid by a transaction out of the bridge
+func (b *MockBackend) AddOutput(hash *chainhash.Hash, index uint32, value int64, pkScript []byte) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.height++
+	b.outputs[*wire.NewOutPoint(hash, index)] = &mockOutput{
+		Output: Output{Value: value, PkScript: pkScript},
+		block:  b.height,
+	}
+	b.txs[*hash] = b.height
+}
+
+// Mine mines n blocks, the transactions of the mempool go in the first one
+func (b *MockBackend) Mine(n int64) {
+	b.Lock()
+	defer b.Unlock()
+
+	if n <= 0 {
+		return
+	}
+	b.height++
+	for _, hash := range b.mempool {
+		b.txs[hash] = b.height
+		for op, out := range b.outputs {
+			if op.Hash == hash {
+				out.block = b.height
+			}
+		}
+	}
+	b.mempool = nil
+	b.height += n - 1
+}
+
+func (b *MockBackend) SetConnected(connected bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.disconnected = !connected
+}
+
+// SetFeeRate sets the estimated fee rate, 0 makes the estimation unavailable
+func (b *MockBackend) SetFeeRate(feeRate int64) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.feeRate = feeRate
+}
+
+func (b *MockBackend) confirmations(block int64) int64 {
+	if block == 0 {
+		return 0
+	}
+	return b.height - block + 1
+}
+
+func (b *MockBackend) GetOutput(hash *chainhash.Hash, index uint32) (*Output, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.disconnected {
+		return nil, ErrMockDisconnected
+	}
+	out, ok := b.outputs[*wire.NewOutPoint(hash, index)]
+	if !ok {
+		return nil, ErrOutputNotFound
+	}
+	return &Output{
+		Value:         out.Value,
+		PkScript:      out.PkScript,
+		Confirmations: b.confirmations(out.block),
+	}, nil
+}
+
+func (b *MockBackend) Confirmations(hash *chainhash.Hash) (int64, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.disconnected {
+		return 0, ErrMockDisconnected
+	}
+	block, ok := b.txs[*hash]
+	if !ok {
+		return 0, ErrMockTxNotFound
+	}
+	return b.confirmations(block), nil
+}
+
+func (b *MockBackend) SendTx(tx *wire.MsgTx) (*chainhash.Hash, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.disconnected {
+		return nil, ErrMockDisconnected
+	}
+	hash := tx.TxHash()
+	if _, ok := b.txs[hash]; ok {
+		return &hash, nil
+	}
+	for _, in := range tx.TxIn {
+		if _, ok := b.outputs[in.PreviousOutPoint]; !ok {
+			return nil, ErrMockMissingInput
+		}
+	}
+
+	for _, in := range tx.TxIn {
+		delete(b.outputs, in.PreviousOutPoint)
+	}
+	for i, out := range tx.TxOut {
+		b.outputs[*wire.NewOutPoint(&hash, uint32(i))] = &mockOutput{
+			Output: Output{Value: out.Value, PkScript: out.PkScript},
+		}
+	}
+	b.txs[hash] = 0
+	b.mempool = append(b.mempool, hash)
+	b.Sent = append(b.Sent, tx)
+	return &hash, nil
+}
+
+func (b *MockBackend) EstimateFeeRate(target int64) (int64, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.disconnected {
+		return 0, ErrMockDisconnected
+	}
+	if b.feeRate <= 0 {
+		return 0, ErrFeeNotAvailable
+	}
+	return b.feeRate, nil
+}
@@ -0,0 +1,64 @@
+package bitcoin
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockBackend(t *testing.T) {
+	b, err := GetBackend(BackendConfig{Backend: BackendMock, Host: t.Name()})
+	require.NoError(t, err)
+	mock, ok := b.(*MockBackend)
+	require.True(t, ok)
+
+	funding := chainhash.DoubleHashH([]byte("funding"))
+	lockScript := []byte{0xa9, 0x14}
+	mock.AddOutput(&funding, 0, 100000, []byte{0x76, 0xa9})
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&funding, 0), []byte{0x01}, nil))
+	tx.AddTxOut(wire.NewTxOut(0, lockScript))
+	tx.TxOut[0].Value = 100000 - int64(estimateTxSize(tx, true, false))*DefaultMockFeeRate
+	assert.True(t, ValidateLock(tx, b, lockScript, 0, tx.TxOut[0].Value, true))
+
+	cd := NewChainDriver(b)
+	hash, err := cd.BroadcastTx(tx)
+	require.NoError(t, err)
+
+	// the lock spent the funding and is in the mempool
+	_, err = b.GetOutput(&funding, 0)
+	assert.Equal(t, ErrOutputNotFound, err)
+	_, err = cd.BroadcastTx(tx)
+	assert.NoError(t, err)
+	ok, err = cd.CheckFinality(hash, 1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	mock.Mine(3)
+	ok, err = cd.CheckFinality(hash, 3)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	out, err := b.GetOutput(hash, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, lockScript, out.PkScript)
+	assert.Equal(t, int64(3), out.Confirmations)
+
+	// double spend
+	double := tx.Copy()
+	double.TxOut[0].Value--
+	_, err = cd.BroadcastTx(double)
+	assert.Equal(t, ErrMockMissingInput, err)
+
+	mock.SetConnected(false)
+	_, err = cd.CheckFinality(hash, 3)
+	assert.Equal(t, ErrMockDisconnected, err)
+	mock.SetConnected(true)
+
+	mock.SetFeeRate(0)
+	_, err = b.EstimateFeeRate(DefaultFeeTarget)
+	assert.Equal(t, ErrFeeNotAvailable, err)
+}
@@ -0,0 +1,114 @@
+/*
+
+ */
+
+package bitcoin
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/pkg/errors"
+)
+
+// rpcClient is the part of the bitcoind json-rpc client the backend uses
+type rpcClient interface {
+	GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*btcjson.GetTxOutResult, error)
+	GetRawTransactionVerbose(txHash *chainhash.Hash) (*btcjson.TxRawResult, error)
+	SendRawTransaction(tx *wire.MsgTx, allowHighFees bool) (*chainhash.Hash, error)
+	RawRequest(method string, params []json.RawMessage) (json.RawMessage, error)
+}
+
+// RPCBackend talks to a bitcoind node over json-rpc, the node needs txindex=1 to look up
+// transactions it doesn't hold in its wallet
+type RPCBackend struct {
+	client rpcClient
+}
+
+var _ Backend = &RPCBackend{}
+
+func NewRPCBackend(host, user, password string) (*RPCBackend, error) {
+	connCfg := &rpcclient.ConnConfig{
+		Host:         host,
+		User:         user,
+		Pass:         password,
+		HTTPPostMode: true, // Bitcoin core only supports HTTP POST mode
+		DisableTLS:   true, // Bitcoin core does not provide TLS by default
+	}
+	clt, err := rpcclient.New(connCfg, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect bitcoin node")
+	}
+	return &RPCBackend{client: clt}, nil
+}
+
+func (b *RPCBackend) GetOutput(hash *chainhash.Hash, index uint32) (*Output, error) {
+	res, err := b.client.GetTxOut(hash, index, true)
+	if err != nil {
+		return nil, err
+	}
+	// bitcoind answers null for spent and unknown outputs
+	if res == nil {
+		return nil, ErrOutputNotFound
+	}
+	value, err := btcutil.NewAmount(res.Value)
+	if err != nil {
+		return nil, err
+	}
+	script, err := hex.DecodeString(res.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid output script")
+	}
+	return &Output{
+		Value:         int64(value),
+		PkScript:      script,
+		Confirmations: res.Confirmations,
+	}, nil
+}
+
+func (b *RPCBackend) Confirmations(hash *chainhash.Hash) (int64, error) {
+	res, err := b.client.GetRawTransactionVerbose(hash)
+	if err != nil {
+		return 0, err
+	}
+	return int64(res.Confirmations), nil
+}
+
+func (b *RPCBackend) SendTx(tx *wire.MsgTx) (*chainhash.Hash, error) {
+	return b.client.SendRawTransaction(tx, false)
+}
+
+type estimateSmartFeeResult struct {
+	FeeRate *float64 `json:"feerate"`
+	Errors  []string `json:"errors"`
+}
+
+func (b *RPCBackend) EstimateFeeRate(target int64) (int64, error) {
+	param, err := json.Marshal(target)
+	if err != nil {
+		return 0, err
+	}
+	raw, err := b.client.RawRequest("estimatesmartfee", []json.RawMessage{param})
+	if err != nil {
+		return 0, err
+	}
+	res := estimateSmartFeeResult{}
+	err = json.Unmarshal(raw, &res)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid estimatesmartfee result")
+	}
+	// no estimate until the node has seen enough blocks, regtest never has one
+	if res.FeeRate == nil {
+		return 0, ErrFeeNotAvailable
+	}
+	perKB, err := btcutil.NewAmount(*res.FeeRate)
+	if err != nil {
+		return 0, err
+	}
+	return int64(perKB) / 1000, nil
+}
@@ -0,0 +1,118 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRPC serves the outputs and transactions of a fake bitcoind
+type stubRPC struct {
+	outputs map[wire.OutPoint]*btcjson.GetTxOutResult
+	txs     map[chainhash.Hash]*btcjson.TxRawResult
+	feeRate string
+	sent    []*wire.MsgTx
+}
+
+func (s *stubRPC) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*btcjson.GetTxOutResult, error) {
+	return s.outputs[*wire.NewOutPoint(txHash, index)], nil
+}
+
+func (s *stubRPC) GetRawTransactionVerbose(txHash *chainhash.Hash) (*btcjson.TxRawResult, error) {
+	tx, ok := s.txs[*txHash]
+	if !ok {
+		return nil, errors.New("No such mempool or blockchain transaction")
+	}
+	return tx, nil
+}
+
+func (s *stubRPC) SendRawTransaction(tx *wire.MsgTx, allowHighFees bool) (*chainhash.Hash, error) {
+	s.sent = append(s.sent, tx)
+	hash := tx.TxHash()
+	return &hash, nil
+}
+
+func (s *stubRPC) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method != "estimatesmartfee" {
+		return nil, errors.New("Method not found")
+	}
+	return json.RawMessage(s.feeRate), nil
+}
+
+func TestRPCBackend(t *testing.T) {
+	hash := chainhash.DoubleHashH([]byte("funding"))
+	script := []byte{0x76, 0xa9}
+	stub := &stubRPC{
+		outputs: map[wire.OutPoint]*btcjson.GetTxOutResult{
+			*wire.NewOutPoint(&hash, 1): {
+				Confirmations: 7,
+				Value:         0.015,
+				ScriptPubKey:  btcjson.ScriptPubKeyResult{Hex: hex.EncodeToString(script)},
+			},
+		},
+		txs: map[chainhash.Hash]*btcjson.TxRawResult{
+			hash: {Confirmations: 7},
+		},
+		feeRate: `{"feerate":0.00025,"blocks":6}`,
+	}
+	b := &RPCBackend{client: stub}
+
+	out, err := b.GetOutput(&hash, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500000), out.Value)
+	assert.Equal(t, script, out.PkScript)
+	assert.Equal(t, int64(7), out.Confirmations)
+
+	_, err = b.GetOutput(&hash, 0)
+	assert.Equal(t, ErrOutputNotFound, err)
+
+	n, err := b.Confirmations(&hash)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	rate, err := b.EstimateFeeRate(DefaultFeeTarget)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(25), rate)
+
+	stub.feeRate = `{"errors":["Insufficient data or no feerate found"],"blocks":0}`
+	_, err = b.EstimateFeeRate(DefaultFeeTarget)
+	assert.Equal(t, ErrFeeNotAvailable, err)
+
+	// finality goes through the backend
+	cd := NewChainDriver(b)
+	ok, err := cd.CheckFinality(&hash, 6)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = cd.CheckFinality(&hash, 8)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestValidateLock_Backend(t *testing.T) {
+	funding := chainhash.DoubleHashH([]byte("funding"))
+	lockScript := []byte{0xa9, 0x14}
+	stub := &stubRPC{
+		outputs: map[wire.OutPoint]*btcjson.GetTxOutResult{
+			*wire.NewOutPoint(&funding, 0): {Confirmations: 10, Value: 0.001},
+		},
+	}
+	b := &RPCBackend{client: stub}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&funding, 0), []byte{0x01}, nil))
+	tx.AddTxOut(wire.NewTxOut(0, lockScript))
+	// pay about 40 satoshi per byte
+	tx.TxOut[0].Value = 100000 - int64(estimateTxSize(tx, true, false))*40
+
+	assert.True(t, ValidateLock(tx, b, lockScript, 0, tx.TxOut[0].Value, true))
+
+	// spent input
+	delete(stub.outputs, *wire.NewOutPoint(&funding, 0))
+	assert.False(t, ValidateLock(tx, b, lockScript, 0, tx.TxOut[0].Value, true))
+}
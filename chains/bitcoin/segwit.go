@@ -0,0 +1,103 @@
+/*
+
+ */
+
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// IsWitnessLock tells whether a lock script address is a P2WSH output, those are unlocked
+// by a witness instead of a sigScript
+func IsWitnessLock(lockScriptAddress []byte) bool {
+	return txscript.IsPayToWitnessScriptHash(lockScriptAddress)
+}
+
+// CreateWitnessMultiSigAddress creates the same multisig as CreateMultiSigAddress, paid to as
+// P2WSH (native segwit) instead of P2SH
+func CreateWitnessMultiSigAddress(m int, publicKeys []*btcutil.AddressPubKey, randomBytes []byte,
+	params *chaincfg.Params) (
+
+	script, address []byte, btcAddressList []string, err error) {
+
+	script, btcAddressList, err = multiSigScript(m, publicKeys)
+	if err != nil {
+		return
+	}
+
+	scriptHash := sha256.Sum256(script)
+	addressObj, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], params)
+	if err != nil {
+		return
+	}
+
+	address, err = txscript.PayToAddrScript(addressObj)
+
+	return
+}
+
+// LockSigHash is the hash a validator signs to spend the tracker input (input 0) of tx, balance
+// is the value of that input which segwit commits to
+func LockSigHash(tx *wire.MsgTx, lockScript, lockScriptAddress []byte, balance int64) ([]byte, error) {
+
+	if IsWitnessLock(lockScriptAddress) {
+		return txscript.CalcWitnessSigHash(lockScript, txscript.NewTxSigHashes(tx),
+			txscript.SigHashAll, tx, 0, balance)
+	}
+
+	return txscript.CalcSignatureHash(lockScript, txscript.SigHashAll, tx, 0)
+}
+
+// SignLock signs the tracker input of tx, the signature carries the sighash type as the
+// multisig script expects
+func SignLock(tx *wire.MsgTx, lockScript, lockScriptAddress []byte, balance int64,
+	key *btcec.PrivateKey) ([]byte, error) {
+
+	if IsWitnessLock(lockScriptAddress) {
+		return txscript.RawTxInWitnessSignature(tx, txscript.NewTxSigHashes(tx), 0, balance,
+			lockScript, txscript.SigHashAll, key)
+	}
+
+	return txscript.RawTxInSignature(tx, 0, lockScript, txscript.SigHashAll, key)
+}
+
+// InputSigned tells whether a user input carries its signature, in the sigScript or the witness
+func InputSigned(in *wire.TxIn) bool {
+	return len(in.SignatureScript) > 0 || len(in.Witness) > 0
+}
+
+// PrepareSweep moves the whole tracker balance to a new lock script address, the fee is paid out
+// of the balance at feeRate satoshi per byte
+func PrepareSweep(prevLockTxID *chainhash.Hash, prevLockIndex uint32, prevLockBalance int64,
+	prevLockScriptAddress []byte, lockScriptAddress []byte, feeRate int64) (txBytes []byte, balance int64, err error) {
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(prevLockTxID, prevLockIndex), nil, nil))
+
+	out := wire.NewTxOut(0, lockScriptAddress)
+	tx.AddTxOut(out)
+
+	fees := int64(estimateTxSize(tx, false, IsWitnessLock(prevLockScriptAddress))) * feeRate
+	balance = prevLockBalance - fees
+	if balance <= 0 {
+		err = errors.New("not enough balance to pay the sweep fees")
+		return
+	}
+	out.Value = balance
+
+	buf := bytes.NewBuffer([]byte{})
+	err = tx.Serialize(buf)
+	txBytes = buf.Bytes()
+
+	return
+}
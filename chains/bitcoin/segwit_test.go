@@ -0,0 +1,92 @@
+package bitcoin
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func testValidatorKeys(t *testing.T, n int) ([]*btcec.PrivateKey, []*btcutil.AddressPubKey) {
+	privs := make([]*btcec.PrivateKey, n)
+	pubs := make([]*btcutil.AddressPubKey, n)
+	for i := range privs {
+		pk, err := btcec.NewPrivateKey(btcec.S256())
+		assert.NoError(t, err)
+		privs[i] = pk
+		pubs[i], err = btcutil.NewAddressPubKey(pk.PubKey().SerializeCompressed(), &chaincfg.RegressionNetParams)
+		assert.NoError(t, err)
+	}
+	return privs, pubs
+}
+
+// spendTracker signs the tracker input with m validators in the order of the lock script and
+// runs the script engine over the result
+func spendTracker(t *testing.T, create func(int, []*btcutil.AddressPubKey, []byte, *chaincfg.Params) ([]byte, []byte, []string, error)) *wire.MsgTx {
+	privs, pubs := testValidatorKeys(t, 3)
+	lockScript, lockScriptAddress, addressList, err := create(2, pubs, nil, &chaincfg.RegressionNetParams)
+	assert.NoError(t, err)
+	assert.Len(t, addressList, 3)
+
+	var balance int64 = 100000
+	prev := chainhash.DoubleHashH([]byte("tracker"))
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prev, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(balance-10000, lockScriptAddress))
+	buf := bytes.NewBuffer([]byte{})
+	assert.NoError(t, tx.Serialize(buf))
+
+	// CHECKMULTISIG wants the signatures in the order of the keys in the script
+	pushes, err := txscript.PushedData(lockScript)
+	assert.NoError(t, err)
+	sigs := make([][]byte, 0, 2)
+	for _, key := range pushes {
+		for _, pk := range privs {
+			if len(sigs) < 2 && bytes.Equal(pk.PubKey().SerializeCompressed(), key) {
+				sig, err := SignLock(tx, lockScript, lockScriptAddress, balance, pk)
+				assert.NoError(t, err)
+
+				hash, err := LockSigHash(tx, lockScript, lockScriptAddress, balance)
+				assert.NoError(t, err)
+				parsed, err := btcec.ParseSignature(sig, btcec.S256())
+				assert.NoError(t, err)
+				assert.True(t, parsed.Verify(hash, pk.PubKey()))
+
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+
+	cd := NewChainDriver(nil)
+	signed, err := cd.AddLockSignature(buf.Bytes(), sigs, lockScript, lockScriptAddress, false)
+	assert.NoError(t, err)
+
+	vm, err := txscript.NewEngine(lockScriptAddress, signed, 0, txscript.StandardVerifyFlags, nil, nil, balance)
+	assert.NoError(t, err)
+	assert.NoError(t, vm.Execute())
+
+	return signed
+}
+
+func TestLockSignature_P2SH(t *testing.T) {
+	tx := spendTracker(t, CreateMultiSigAddress)
+	assert.NotEmpty(t, tx.TxIn[0].SignatureScript)
+	assert.Empty(t, tx.TxIn[0].Witness)
+	assert.False(t, IsWitnessLock(tx.TxOut[0].PkScript))
+}
+
+func TestLockSignature_P2WSH(t *testing.T) {
+	tx := spendTracker(t, CreateWitnessMultiSigAddress)
+	assert.Empty(t, tx.TxIn[0].SignatureScript)
+	assert.Len(t, tx.TxIn[0].Witness, 4)
+	assert.True(t, IsWitnessLock(tx.TxOut[0].PkScript))
+
+	// the validators pay for a quarter of the unlocking data in a witness
+	assert.True(t, estimateTxSize(tx, false, true) < estimateTxSize(tx, false, false))
+}
@@ -8,35 +8,44 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/blockcypher/gobcy"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 )
 
-func ValidateLock(tx *wire.MsgTx, token, chainType string, lockScriptAddress []byte, currentBalance, lockAmount int64, isFirstlock bool) bool {
+// fee rates in satoshi per byte the validators accept for lock and redeem transactions
+const (
+	MinFeeRate     = 20
+	MaxFeeRate     = 70
+	DefaultFeeRate = 40
+)
+
+func ValidateLock(tx *wire.MsgTx, backend Backend, lockScriptAddress []byte, currentBalance, lockAmount int64, isFirstlock bool) bool {
 
 	// 2, 3
 	var input int64
+	witness := false
 	for i := range tx.TxIn {
 		h := tx.TxIn[i].PreviousOutPoint.Hash
 		index := tx.TxIn[i].PreviousOutPoint.Index
 
-		btc := gobcy.API{token, "btc", chainType}
+		txIn, err := backend.GetOutput(&h, index)
+		if err == ErrOutputNotFound {
 
-		txIn, err := btc.GetTX(h.String(), nil)
+			fmt.Println("btc lock validate err, not spendable txIn", i)
+			return false
+		}
 		if err != nil {
 
 			fmt.Println("btc lock validate err, error finding txIn", i, err)
 			return false
 		}
 
-		if txIn.Outputs[index].SpentBy != "" {
-
-			fmt.Println("btc lock validate err, not spendable txIn", i)
-			return false
+		// the tracker input is unlocked by the validators
+		if i == 0 && !isFirstlock {
+			witness = IsWitnessLock(txIn.PkScript)
 		}
 
-		input += int64(txIn.Outputs[index].Value)
+		input += txIn.Value
 	}
 
 	if lockAmount > (input - currentBalance) {
@@ -62,10 +71,10 @@ func ValidateLock(tx *wire.MsgTx, token, chainType string, lockScriptAddress []b
 	}
 
 	fees := input - output
-	txSize := estimateTxSize(tx, isFirstlock)
+	txSize := estimateTxSize(tx, isFirstlock, witness)
 	fees_per_byte := fees / int64(txSize)
 
-	if fees_per_byte < 20 || fees_per_byte > 70 {
+	if fees_per_byte < MinFeeRate || fees_per_byte > MaxFeeRate {
 
 		fmt.Println("btc lock validate err, fees should be more than 20 per byte or less than 70 per byte")
 		return false
@@ -81,7 +90,7 @@ func ValidateLock(tx *wire.MsgTx, token, chainType string, lockScriptAddress []b
 	return true
 }
 
-func ValidateRedeem(tx *wire.MsgTx, token, chainType string, trackerPrevTxID *chainhash.Hash,
+func ValidateRedeem(tx *wire.MsgTx, backend Backend, trackerPrevTxID *chainhash.Hash,
 	lockScriptAddress []byte, currentBalance, redeemAmount int64) bool {
 
 	if !(len(tx.TxIn) == 1) {
@@ -104,34 +113,33 @@ func ValidateRedeem(tx *wire.MsgTx, token, chainType string, trackerPrevTxID *ch
 
 	// 2, 3
 	var input int64
+	witness := false
 	for i := range tx.TxIn {
 		h := tx.TxIn[i].PreviousOutPoint.Hash
 		index := tx.TxIn[i].PreviousOutPoint.Index
 
-		btc := gobcy.API{token, "btc", chainType}
-
-		txIn, err := btc.GetTX(h.String(), nil)
-		if err != nil {
-			fmt.Println(i, "redeem validate err, TxIn must exist on chain", err)
+		txIn, err := backend.GetOutput(&h, index)
+		if err == ErrOutputNotFound {
+			fmt.Println(i, "redeem validate err, TxIn must be spendable")
 			return false
 		}
-
-		if txIn.Outputs[index].SpentBy != "" {
-			fmt.Println(i, "redeem validate err, TxIn must be spendable")
+		if err != nil {
+			fmt.Println(i, "redeem validate err, TxIn must exist on chain", err)
 			return false
 		}
 
-		input += int64(txIn.Outputs[index].Value)
+		witness = IsWitnessLock(txIn.PkScript)
+		input += txIn.Value
 	}
 
 	// 4
 	output := tx.TxOut[0].Value + tx.TxOut[1].Value
 	fees := input - output
 
-	txSize := estimateTxSize(tx, false)
+	txSize := estimateTxSize(tx, false, witness)
 	fees_per_byte := fees / int64(txSize)
 
-	if fees_per_byte < 20 || fees_per_byte > 70 {
+	if fees_per_byte < MinFeeRate || fees_per_byte > MaxFeeRate {
 		fmt.Println("redeem validate error, fees per byte should be more than 20 and less than 70")
 		return false
 	}
@@ -155,17 +163,16 @@ func ValidateRedeem(tx *wire.MsgTx, token, chainType string, trackerPrevTxID *ch
 	return true
 }
 
-func estimateTxSize(tx *wire.MsgTx, isFirstLock bool) int {
+func estimateTxSize(tx *wire.MsgTx, isFirstLock bool, witness bool) int {
 
 	if isFirstLock {
 		return tx.SerializeSize()
 	}
 
-	sigScriptSize := 46 + 74*6 + 34*8
-	return tx.SerializeSize() + sigScriptSize
+	return tx.SerializeSize() + multiSigUnlockSize(witness)
 }
 
-func EstimateTxSizeBeforeUserSign(tx *wire.MsgTx, isFirstLock bool) int {
+func EstimateTxSizeBeforeUserSign(tx *wire.MsgTx, isFirstLock bool, witness bool) int {
 
 	p2pkhSigSize := 146
 
@@ -176,6 +183,17 @@ func EstimateTxSizeBeforeUserSign(tx *wire.MsgTx, isFirstLock bool) int {
 		return tx.SerializeSize() + inputSigsSize + 20
 	}
 
+	return tx.SerializeSize() + multiSigUnlockSize(witness) + inputSigsSize + 20
+}
+
+// multiSigUnlockSize is what the validators' signatures and lock script add to the tracker input,
+// in a witness they weigh a quarter of a byte each
+func multiSigUnlockSize(witness bool) int {
+
 	sigScriptSize := 46 + 74*6 + 34*8
-	return tx.SerializeSize() + sigScriptSize + inputSigsSize + 20
+	if witness {
+		return (sigScriptSize + 3) / 4
+	}
+
+	return sigScriptSize
 }
@@ -2,9 +2,13 @@ package ethereum
 
 import (
 	"crypto/ecdsa"
+	"encoding/hex"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/pkg/errors"
 )
 
 // Contract is our main access point to the Ethereum smart contract we use to lock and redeem ethereum tokens
@@ -14,6 +18,27 @@ func newKeyTransactor(key *ecdsa.PrivateKey) *bind.TransactOpts {
 	return bind.NewKeyedTransactor(key)
 }
 
+// CheckContractBin checks the compiled bytecode dispatches every given method of the abi, a bin
+// generated before the methods were added would deploy a contract the witnesses can't call
+func CheckContractBin(bin string, contractAbi string, methods ...string) error {
+	parsed, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		return errors.Wrap(err, "invalid contract abi")
+	}
+	code := strings.ToLower(strings.TrimPrefix(bin, "0x"))
+	for _, name := range methods {
+		method, ok := parsed.Methods[name]
+		if !ok {
+			return errors.Errorf("method %s not in the contract abi", name)
+		}
+		// the dispatcher pushes every selector with PUSH4
+		if !strings.Contains(code, "63"+hex.EncodeToString(method.ID)) {
+			return errors.Wrapf(ErrStaleBin, "method %s", name)
+		}
+	}
+	return nil
+}
+
 // TODO: embed and implement
 // SContract is the stub implementation thereof the
 type LRContract interface {
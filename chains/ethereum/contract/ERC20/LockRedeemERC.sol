@@ -564,6 +564,11 @@ contract LockRedeemERC {
     address[] validatorsToRemove;
 
     mapping (address => int) public validators;
+    address[] validatorList;
+
+    // validatorEpoch goes up by one every time the validators hand over to a new set
+    uint256 public validatorEpoch;
+    mapping (bytes32 => Proposal) validatorUpdateProposals;
 
 
     //string tokenName;
@@ -611,6 +616,8 @@ contract LockRedeemERC {
 
     event NewThreshold(uint _prevThreshold, uint _newThreshold);
 
+    event ValidatorsUpdated(uint256 epoch, address[] validators);
+
     constructor(address[] memory initialValidators) public {
         // Require at least 4 validators
         require(initialValidators.length >= MIN_VALIDATORS, "insufficient validators passed to constructor");
@@ -718,6 +725,31 @@ contract LockRedeemERC {
 
     }
 
+    // updateValidators is voted by the validators, they hand over to the set of the next epoch once
+    // votingThreshold of them voted the same set
+    function updateValidators(uint256 epoch_, address[] memory validators_) public onlyValidator {
+        require(epoch_ == validatorEpoch + 1, "not the next validator epoch");
+        require(validators_.length >= MIN_VALIDATORS, "insufficient validators in the new set");
+        Proposal storage proposal = validatorUpdateProposals[keccak256(abi.encodePacked(epoch_, validators_))];
+        require(!proposal.voters[msg.sender], "sender has already voted for this validator set");
+        proposal.voters[msg.sender] = true;
+        proposal.voteCount += 1;
+        if (proposal.voteCount < votingThreshold) {
+            return;
+        }
+
+        while (validatorList.length > 0) {
+            removeValidator(validatorList[validatorList.length - 1]);
+        }
+        for (uint i = 0; i < validators_.length; i++) {
+            require(validators[validators_[i]] == 0, "found non-unique validator in validators_");
+            addValidator(validators_[i]);
+        }
+        votingThreshold = (validators_.length * 2 / 3) + 1;
+        validatorEpoch = epoch_;
+        emit ValidatorsUpdated(epoch_, validators_);
+    }
+
     function declareNewEpoch(uint nextEpochHeight) internal onlyValidator {
         epochBlockHeight = nextEpochHeight;
         emit NewEpoch(epochBlockHeight);
@@ -748,6 +780,7 @@ contract LockRedeemERC {
     // Adds a validator to our current store
     function addValidator(address v) internal {
         validators[v] = DEFAULT_VALIDATOR_POWER;
+        validatorList.push(v);
         numValidators += 1;
         emit AddValidator(v, validators[v]);
     }
@@ -755,6 +788,13 @@ contract LockRedeemERC {
     // Deletes a validator from our store
     function removeValidator(address v) internal {
         delete validators[v];
+        for (uint i = 0; i < validatorList.length; i++) {
+            if (validatorList[i] == v) {
+                validatorList[i] = validatorList[validatorList.length - 1];
+                validatorList.length--;
+                break;
+            }
+        }
         numValidators -= 1;
         emit DeleteValidator(v);
     }
@@ -0,0 +1,159 @@
+pragma solidity >=0.5.0 <0.6.0;
+
+/**
+ * @dev Part of the ERC721 standard the bridge needs.
+ */
+interface IERC721 {
+    function ownerOf(uint256 tokenId) external view returns (address);
+    function transferFrom(address from, address to, uint256 tokenId) external;
+    function tokenURI(uint256 tokenId) external view returns (string memory);
+}
+
+/**
+ * @dev Bridge contract holding the ERC721 tokens locked for OneLedger.
+ * A token is locked by transferring it to the bridge, and released back once enough validators
+ * signed the redeem request of its OneLedger owner.
+ */
+contract LockRedeemERC721 {
+
+    struct RedeemTx {
+        address recipient;
+        mapping (address => bool) votes;
+        uint signatureCount;
+        uint until;
+    }
+
+    uint constant DEFAULT_VALIDATOR_POWER = 100;
+
+    uint public numValidators;
+    uint public votingThreshold;
+    uint public lockPeriod;
+
+    mapping (address => uint) public validators;
+    address[] validatorList;
+
+    // validatorEpoch goes up by one every time the validators hand over to a new set
+    uint256 public validatorEpoch;
+    mapping (bytes32 => uint) validatorUpdateVotes;
+    mapping (bytes32 => mapping (address => bool)) validatorUpdateSigners;
+    mapping (bytes32 => bool) public locked;
+    mapping (bytes32 => RedeemTx) redeemRequests;
+
+    event Lock(address indexed token, uint256 indexed tokenId, address sender);
+    event RedeemRequest(address indexed token, uint256 indexed tokenId, address recipient, uint until);
+    event ValidatorSignedRedeem(address indexed token, uint256 indexed tokenId, address recipient, address validator);
+    event Redeemed(address indexed token, uint256 indexed tokenId, address recipient);
+    event ValidatorsUpdated(uint256 epoch, address[] validators);
+
+    constructor(address[] memory initialValidators, uint lockPeriod_) public {
+        for (uint i = 0; i < initialValidators.length; i++) {
+            address v = initialValidators[i];
+            require(validators[v] == 0, "found non-unique validator in initialValidators");
+            validators[v] = DEFAULT_VALIDATOR_POWER;
+            validatorList.push(v);
+            numValidators += 1;
+        }
+        votingThreshold = (numValidators * 2 / 3) + 1;
+        lockPeriod = lockPeriod_;
+    }
+
+    modifier onlyValidator() {
+        require(validators[msg.sender] > 0, "sender is not a validator");
+        _;
+    }
+
+    function key(address token_, uint256 tokenId_) internal pure returns (bytes32) {
+        return keccak256(abi.encodePacked(token_, tokenId_));
+    }
+
+    // updateValidators is voted by the validators, they hand over to the set of the next epoch once
+    // votingThreshold of them voted the same set
+    function updateValidators(uint256 epoch_, address[] memory validators_) public onlyValidator {
+        require(epoch_ == validatorEpoch + 1, "not the next validator epoch");
+        require(validators_.length > 0, "empty validator set");
+        bytes32 update = keccak256(abi.encodePacked(epoch_, validators_));
+        require(!validatorUpdateSigners[update][msg.sender], "validator already voted");
+        validatorUpdateSigners[update][msg.sender] = true;
+        validatorUpdateVotes[update] += 1;
+        if (validatorUpdateVotes[update] < votingThreshold) {
+            return;
+        }
+
+        for (uint i = 0; i < validatorList.length; i++) {
+            validators[validatorList[i]] = 0;
+        }
+        delete validatorList;
+        for (uint i = 0; i < validators_.length; i++) {
+            address v = validators_[i];
+            require(validators[v] == 0, "found non-unique validator in validators_");
+            validators[v] = DEFAULT_VALIDATOR_POWER;
+            validatorList.push(v);
+        }
+        numValidators = validators_.length;
+        votingThreshold = (numValidators * 2 / 3) + 1;
+        validatorEpoch = epoch_;
+        emit ValidatorsUpdated(epoch_, validators_);
+    }
+
+    // lock moves the token to the bridge, the sender has to approve the bridge first
+    function lock(address token_, uint256 tokenId_) public {
+        bytes32 k = key(token_, tokenId_);
+        require(!locked[k], "token already locked");
+        IERC721(token_).transferFrom(msg.sender, address(this), tokenId_);
+        locked[k] = true;
+        emit Lock(token_, tokenId_, msg.sender);
+    }
+
+    // redeem opens a redeem request for a locked token, the sender receives the token
+    function redeem(address token_, uint256 tokenId_) public {
+        bytes32 k = key(token_, tokenId_);
+        require(locked[k], "token is not locked");
+        RedeemTx storage request = redeemRequests[k];
+        require(request.until < block.number || request.recipient == address(0), "redeem request is ongoing");
+
+        delete redeemRequests[k];
+        redeemRequests[k].recipient = msg.sender;
+        redeemRequests[k].until = block.number + lockPeriod;
+        emit RedeemRequest(token_, tokenId_, msg.sender, block.number + lockPeriod);
+    }
+
+    function sign(address token_, uint256 tokenId_, address recipient_) public onlyValidator {
+        bytes32 k = key(token_, tokenId_);
+        RedeemTx storage request = redeemRequests[k];
+        require(locked[k], "token is not locked");
+        require(request.recipient == recipient_, "recipient does not match the redeem request");
+        require(request.until >= block.number, "redeem request expired");
+        require(!request.votes[msg.sender], "validator already signed");
+
+        request.votes[msg.sender] = true;
+        request.signatureCount += 1;
+        emit ValidatorSignedRedeem(token_, tokenId_, recipient_, msg.sender);
+
+        if (request.signatureCount >= votingThreshold) {
+            locked[k] = false;
+            request.until = 0;
+            IERC721(token_).transferFrom(address(this), recipient_, tokenId_);
+            emit Redeemed(token_, tokenId_, recipient_);
+        }
+    }
+
+    function hasValidatorSigned(address token_, uint256 tokenId_) public view returns (bool) {
+        return redeemRequests[key(token_, tokenId_)].votes[msg.sender];
+    }
+
+    // verifyRedeem returns -1 before any request, 0 while ongoing, 1 once redeemed and 2 when expired
+    function verifyRedeem(address token_, uint256 tokenId_) public view returns (int8) {
+        bytes32 k = key(token_, tokenId_);
+        RedeemTx storage request = redeemRequests[k];
+        if (request.recipient == address(0)) {
+            return -1;
+        }
+        if (!locked[k]) {
+            return 1;
+        }
+        if (request.until < block.number) {
+            return 2;
+        }
+        return 0;
+    }
+}
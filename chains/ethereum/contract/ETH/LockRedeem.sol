@@ -29,6 +29,11 @@ contract LockRedeem {
     mapping(address => uint) migrationCount;
     address [] migrationAddress;
 
+    // validatorEpoch goes up by one every time the validators hand over to a new set
+    uint256 public validatorEpoch;
+    mapping(bytes32 => uint) validatorUpdateVotes;
+    mapping(bytes32 => mapping(address => bool)) validatorUpdateSigners;
+
     // Default Voting power should be updated at one point
     //int constant DEFAULT_VALIDATOR_POWER = 100;
     uint constant MIN_VALIDATORS = 0;
@@ -77,6 +82,11 @@ contract LockRedeem {
         address indexed _address
     );
 
+    event ValidatorsUpdated(
+        uint256 epoch,
+        address[] validators
+    );
+
     modifier isActive() {
         require(ACTIVE);
         _;
@@ -143,6 +153,34 @@ contract LockRedeem {
             require(success, "Transfer failed");
         }
     }
+    // function called by protocol, the validators vote the set of the next epoch and hand over to it
+    // once votingThreshold of them voted the same set
+    function updateValidators(uint256 epoch_, address[] memory validators_) public isActive onlyValidator {
+        require(epoch_ == validatorEpoch + 1, "not the next validator epoch");
+        require(validators_.length > MIN_VALIDATORS, "empty validator set");
+        bytes32 update = keccak256(abi.encodePacked(epoch_, validators_));
+        require(!validatorUpdateSigners[update][msg.sender], "validator has already voted");
+        validatorUpdateSigners[update][msg.sender] = true;
+        validatorUpdateVotes[update] += 1;
+        if (validatorUpdateVotes[update] < votingThreshold) {
+            return;
+        }
+
+        for (uint i = 0; i < validatorList.length; i++) {
+            validators[validatorList[i]] = 0;
+        }
+        delete validatorList;
+        for (uint i = 0; i < validators_.length; i++) {
+            require(validators[validators_[i]] == 0, "found non-unique validator in validators_");
+            addValidator(validators_[i]);
+        }
+        numValidators = validators_.length;
+        votingThreshold = (validators_.length * 2 / 3) + 1;
+        activeThreshold = (validators_.length * 1 / 3) + 1;
+        validatorEpoch = epoch_;
+        emit ValidatorsUpdated(epoch_, validators_);
+    }
+
     // function called by user
     function lock() payable public isActive {
         require(msg.value >= 0, "Must pay a balance more than 0");
@@ -0,0 +1,51 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+)
+
+func TestCheckContractBin(t *testing.T) {
+	err := CheckContractBin("0x6063a48d0ea014", contract.LockRedeemABI, "validatorEpoch")
+	assert.NoError(t, err)
+
+	err = CheckContractBin("0x6063a48d0ea014", contract.LockRedeemABI, "updateValidators")
+	assert.Equal(t, ErrStaleBin, errors.Cause(err))
+
+	err = CheckContractBin("0x6063a48d0ea014", contract.LockRedeemABI, "notAMethod")
+	assert.Error(t, err)
+}
+
+func TestLockRedeem_ValidatorEpoch(t *testing.T) {
+	err := CheckContractBin(contract.LockRedeemBin, contract.LockRedeemABI, "updateValidators", "validatorEpoch")
+	if errors.Cause(err) == ErrStaleBin {
+		t.Skip("LockRedeemBin is older than ETH/LockRedeem.sol: ", err)
+	}
+	require.NoError(t, err)
+
+	key, _ := crypto.GenerateKey()
+	auth := bind.NewKeyedTransactor(key)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{
+		auth.From: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	}, 10000000)
+	defer sim.Close()
+
+	_, _, lr, err := contract.DeployLockRedeem(auth, sim, []common.Address{auth.From}, big.NewInt(25), common.Address{}, big.NewInt(1))
+	require.NoError(t, err)
+	sim.Commit()
+
+	epoch, err := lr.ValidatorEpoch(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), epoch.Int64())
+}
@@ -0,0 +1,234 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+)
+
+// ERC721Request holds the parameters of a lock or redeem call of the ERC721 bridge contract
+type ERC721Request struct {
+	Token   common.Address
+	TokenID *big.Int
+}
+
+// IsNFTContract checks the token contract is allowed in the ERC721 bridge
+func IsNFTContract(contracts []common.Address, token common.Address) bool {
+	for _, c := range contracts {
+		if c == token {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseERC721Lock parses a lock call of the ERC721 bridge contract
+func ParseERC721Lock(rawTx []byte, bridgeAbi string) (*ERC721Request, error) {
+	return parseERC721Call(rawTx, bridgeAbi, "lock")
+}
+
+// ParseERC721Redeem parses a redeem call of the ERC721 bridge contract
+func ParseERC721Redeem(rawTx []byte, bridgeAbi string) (*ERC721Request, error) {
+	return parseERC721Call(rawTx, bridgeAbi, "redeem")
+}
+
+func parseERC721Call(rawTx []byte, bridgeAbi string, method string) (*ERC721Request, error) {
+	tx, err := DecodeTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	contractAbi, err := StringTOABI(bridgeAbi)
+	if err != nil {
+		return nil, err
+	}
+	data := tx.Data()
+	if len(data) < 4 {
+		return nil, errors.New("transaction does not call the ERC721 bridge")
+	}
+	m, err := contractAbi.MethodById(data[:4])
+	if err != nil || m.Name != method {
+		return nil, errors.Errorf("transaction is not an ERC721 %s", method)
+	}
+	args, err := m.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unpack ERC721 %s", method)
+	}
+	token, ok := args[0].(common.Address)
+	if !ok {
+		return nil, errors.New("invalid token address")
+	}
+	tokenID, ok := args[1].(*big.Int)
+	if !ok {
+		return nil, errors.New("invalid token id")
+	}
+	return &ERC721Request{Token: token, TokenID: tokenID}, nil
+}
+
+// VerifyERC721Lock makes sure the transaction is a lock call sent to the ERC721 bridge contract
+func VerifyERC721Lock(rawTx []byte, bridgeAbi string, bridgeAddr common.Address) (*ERC721Request, error) {
+	tx, err := DecodeTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	if tx.To() == nil || *tx.To() != bridgeAddr {
+		return nil, errors.New("transaction is not sent to the ERC721 bridge contract")
+	}
+	if tx.Value().Sign() != 0 {
+		return nil, errors.New("ERC721 lock should not transfer ether")
+	}
+	return ParseERC721Lock(rawTx, bridgeAbi)
+}
+
+// ERC721RedeemRecipient returns the sender of a redeem call, the bridge contract releases the token to it
+func ERC721RedeemRecipient(rawTx []byte) (common.Address, error) {
+	tx, err := DecodeTransaction(rawTx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
+// SignERC721Redeem creates the transaction used by validators to sign the redeem of a locked ERC721 token
+func (acc *ETHChainDriver) SignERC721Redeem(fromaddr common.Address, token common.Address, tokenID *big.Int, recipient common.Address) (*Transaction, error) {
+	c, cancel := defaultContext()
+	defer cancel()
+	nonce, err := acc.GetClient().PendingNonceAt(c, fromaddr)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := acc.GetClient().SuggestGasPrice(c)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice = big.NewInt(0).Add(gasPrice, big.NewInt(0).Div(gasPrice, big.NewInt(2)))
+	contractAbi, err := abi.JSON(strings.NewReader(acc.ContractABI))
+	if err != nil {
+		return nil, err
+	}
+	bytesData, err := contractAbi.Pack("sign", token, tokenID, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTransaction(nonce, acc.ContractAddress, big.NewInt(0), uint64(gasLimit), gasPrice, bytesData), nil
+}
+
+// VerifyERC721Redeem returns the status of the redeem request of a locked ERC721 token
+func (acc *ETHChainDriver) VerifyERC721Redeem(validatorAddress common.Address, token common.Address, tokenID *big.Int) RedeemStatus {
+	out := make([]interface{}, 0)
+	bound, err := acc.bound(acc.ContractAddress, acc.ContractABI)
+	if err != nil {
+		return ErrorConnecting
+	}
+	err = bound.Call(acc.CallOpts(validatorAddress), &out, "verifyRedeem", token, tokenID)
+	if err != nil || len(out) == 0 {
+		return ErrorConnecting
+	}
+	status, ok := out[0].(int8)
+	if !ok {
+		return ErrorConnecting
+	}
+	return RedeemStatus(status)
+}
+
+// HasValidatorSignedERC721 checks if the validator already signed the redeem request of a locked ERC721 token
+func (acc *ETHChainDriver) HasValidatorSignedERC721(validatorAddress common.Address, token common.Address, tokenID *big.Int) (bool, error) {
+	out := make([]interface{}, 0)
+	bound, err := acc.bound(acc.ContractAddress, acc.ContractABI)
+	if err != nil {
+		return false, err
+	}
+	err = bound.Call(acc.CallOpts(validatorAddress), &out, "hasValidatorSigned", token, tokenID)
+	if err != nil {
+		return false, err
+	}
+	if len(out) == 0 {
+		return false, errors.New("empty hasValidatorSigned result")
+	}
+	signed, ok := out[0].(bool)
+	if !ok {
+		return false, errors.New("invalid hasValidatorSigned result")
+	}
+	return signed, nil
+}
+
+// ErrTokenURIMismatch is returned when the evm nodes disagree on the metadata uri of a token
+var ErrTokenURIMismatch = errors.New("token uri sources disagree")
+
+// TokenURI reads the metadata uri of an ERC721 token at the cross checked head of the header chain,
+// every header connection has to return the same uri as the main connection
+func (acc *ETHChainDriver) TokenURI(token common.Address, tokenID *big.Int) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*DefaultTimeout)
+	defer cancel()
+
+	headers, err := GetHeaderChain(acc.cfg)
+	if err != nil {
+		return "", err
+	}
+	err = headers.Sync(ctx)
+	if err != nil {
+		return "", err
+	}
+	head := headers.Head()
+	if head == nil {
+		return "", ErrHeaderNotTracked
+	}
+
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+	uri, err := tokenURI(acc.GetClient(), opts, token, tokenID)
+	if err != nil {
+		return "", err
+	}
+	for _, conn := range acc.cfg.HeaderConnections {
+		client, err := ethclient.DialContext(ctx, conn)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to connect header source %s", conn)
+		}
+		other, err := tokenURI(client, opts, token, tokenID)
+		client.Close()
+		if err != nil {
+			return "", errors.Wrapf(err, "header source %s", conn)
+		}
+		if other != uri {
+			return "", errors.Wrapf(ErrTokenURIMismatch, "header source %s", conn)
+		}
+	}
+	return uri, nil
+}
+
+func tokenURI(client *Client, opts *bind.CallOpts, token common.Address, tokenID *big.Int) (string, error) {
+	parsed, err := abi.JSON(strings.NewReader(contract.ERC721MetadataABI))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid contract abi")
+	}
+	out := make([]interface{}, 0)
+	err = bind.NewBoundContract(token, parsed, client, client, client).Call(opts, &out, "tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New("empty tokenURI result")
+	}
+	uri, ok := out[0].(string)
+	if !ok {
+		return "", errors.New("invalid tokenURI result")
+	}
+	return uri, nil
+}
+
+func (acc *ETHChainDriver) bound(address common.Address, contractAbi string) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid contract abi")
+	}
+	client := acc.GetClient()
+	return bind.NewBoundContract(address, parsed, client, client, client), nil
+}
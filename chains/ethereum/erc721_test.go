@@ -0,0 +1,69 @@
+package ethereum
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+)
+
+var (
+	nftBridgeAddr = common.HexToAddress("0x5e3A2B7a8C3b8A15E6B9f0d7f5f1cB8d0a1E2f3c")
+	nftTokenAddr  = common.HexToAddress("0x9aB4c1D2e3F405162738495A6b7C8d9E0F1a2B3c")
+)
+
+func erc721Tx(t *testing.T, to common.Address, value *big.Int, method string, args ...interface{}) []byte {
+	bridgeAbi, err := abi.JSON(strings.NewReader(contract.LockRedeemERC721ABI))
+	assert.NoError(t, err)
+	data, err := bridgeAbi.Pack(method, args...)
+	assert.NoError(t, err)
+	tx := types.NewTransaction(0, to, value, 100000, big.NewInt(1), data)
+	raw, err := rlp.EncodeToBytes(tx)
+	assert.NoError(t, err)
+	return raw
+}
+
+func TestVerifyERC721Lock(t *testing.T) {
+	raw := erc721Tx(t, nftBridgeAddr, big.NewInt(0), "lock", nftTokenAddr, big.NewInt(42))
+	req, err := VerifyERC721Lock(raw, contract.LockRedeemERC721ABI, nftBridgeAddr)
+	assert.NoError(t, err)
+	assert.Equal(t, nftTokenAddr, req.Token)
+	assert.Equal(t, int64(42), req.TokenID.Int64())
+
+	// not the bridge
+	_, err = VerifyERC721Lock(raw, contract.LockRedeemERC721ABI, nftTokenAddr)
+	assert.Error(t, err)
+
+	// sending ether along
+	raw = erc721Tx(t, nftBridgeAddr, big.NewInt(1), "lock", nftTokenAddr, big.NewInt(42))
+	_, err = VerifyERC721Lock(raw, contract.LockRedeemERC721ABI, nftBridgeAddr)
+	assert.Error(t, err)
+
+	// a redeem is not a lock
+	raw = erc721Tx(t, nftBridgeAddr, big.NewInt(0), "redeem", nftTokenAddr, big.NewInt(42))
+	_, err = VerifyERC721Lock(raw, contract.LockRedeemERC721ABI, nftBridgeAddr)
+	assert.Error(t, err)
+}
+
+func TestParseERC721Redeem(t *testing.T) {
+	raw := erc721Tx(t, nftBridgeAddr, big.NewInt(0), "redeem", nftTokenAddr, big.NewInt(7))
+	req, err := ParseERC721Redeem(raw, contract.LockRedeemERC721ABI)
+	assert.NoError(t, err)
+	assert.Equal(t, nftTokenAddr, req.Token)
+	assert.Equal(t, int64(7), req.TokenID.Int64())
+
+	_, err = ParseERC721Lock(raw, contract.LockRedeemERC721ABI)
+	assert.Error(t, err)
+}
+
+func TestIsNFTContract(t *testing.T) {
+	assert.True(t, IsNFTContract([]common.Address{nftBridgeAddr, nftTokenAddr}, nftTokenAddr))
+	assert.False(t, IsNFTContract([]common.Address{nftBridgeAddr}, nftTokenAddr))
+}
@@ -7,4 +7,5 @@ import (
 var (
 	ErrTxFailed      = errors.New("ethereum tx status failed")
 	ErrRedeemExpired = errors.New("ethereum redeem request expired")
+	ErrStaleBin      = errors.New("contract bytecode is older than its abi, regenerate it with solc and abigen")
 )
@@ -14,6 +14,10 @@ type ChainDriverOption struct {
 	TotalSupply        string
 	TotalSupplyAddr    string
 	BlockConfirmation  int64
+	// ERC721 bridge contract and the token contracts allowed to be locked in it
+	ERC721ContractABI     string
+	ERC721ContractAddress common.Address
+	NFTContracts          []common.Address
 }
 
 type ERC20Token struct {
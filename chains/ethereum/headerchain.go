@@ -0,0 +1,358 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/config"
+)
+
+const (
+	// number of recent headers a header chain keeps
+	DefaultHeaderWindow = 128
+	// number of headers below the window an older block is linked back through at most
+	MaxHeaderLookback = 100000
+)
+
+var (
+	ErrHeaderGap          = errors.New("header does not follow the tracked chain")
+	ErrHeaderUnlinked     = errors.New("header parent hash does not match the tracked chain")
+	ErrHeaderTooOld       = errors.New("header is older than the tracked window")
+	ErrHeaderNotTracked   = errors.New("header is not tracked yet")
+	ErrHeaderNotCanonical = errors.New("block is not part of the tracked chain")
+	ErrHeaderMismatch     = errors.New("header sources disagree")
+	ErrHeaderUntrusted    = errors.New("header does not match the trusted checkpoint")
+	ErrHeaderNoTrust      = errors.New("header chain needs two header sources at least")
+)
+
+// HeaderSource serves block headers, usually an ethereum node, a nil number is the latest header
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Checkpoint is a block hash trusted out of band, the header chain starts from that block
+type Checkpoint struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// HeaderChain follows the headers of an ethereum chain on its own. Every header has to link to its
+// parent and be served identically by two sources at least, so the receipts proven against it don't
+// depend on what a single rpc provider reports. The chain starts from a trusted checkpoint when it
+// has one, the sources still have to agree on every header after it.
+type HeaderChain struct {
+	mu         sync.Mutex
+	window     uint64
+	checkpoint *Checkpoint
+	sources    []HeaderSource
+	headers    map[uint64]*types.Header
+	head       *types.Header
+	// ancients are the headers below the window already linked back to it
+	ancients map[uint64]*types.Header
+}
+
+func NewHeaderChain(window uint64, checkpoint *Checkpoint, sources ...HeaderSource) *HeaderChain {
+	if window == 0 {
+		window = DefaultHeaderWindow
+	}
+	return &HeaderChain{
+		window:     window,
+		checkpoint: checkpoint,
+		sources:    sources,
+		headers:    make(map[uint64]*types.Header),
+		ancients:   make(map[uint64]*types.Header),
+	}
+}
+
+// Head returns the latest tracked header, nil before the first header
+func (hc *HeaderChain) Head() *types.Header {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	return hc.head
+}
+
+// Header returns the tracked header at the given number
+func (hc *HeaderChain) Header(number uint64) (*types.Header, bool) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	h, ok := hc.headers[number]
+	return h, ok
+}
+
+// Insert adds the next header of the chain, a header at or below the head replaces the
+// tracked branch from its number on as long as it links to the tracked parent. The first
+// header has to be the checkpoint when the chain has one
+func (hc *HeaderChain) Insert(header *types.Header) error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	return hc.insert(header)
+}
+
+func (hc *HeaderChain) insert(header *types.Header) error {
+	number := header.Number.Uint64()
+	if hc.head == nil {
+		if hc.checkpoint != nil && (number != hc.checkpoint.Number || header.Hash() != hc.checkpoint.Hash) {
+			return ErrHeaderUntrusted
+		}
+		hc.headers[number] = header
+		hc.head = header
+		return nil
+	}
+
+	head := hc.head.Number.Uint64()
+	if number > head+1 {
+		return ErrHeaderGap
+	}
+	if number <= hc.tail() {
+		return ErrHeaderTooOld
+	}
+	parent := hc.headers[number-1]
+	if parent.Hash() != header.ParentHash {
+		return ErrHeaderUnlinked
+	}
+
+	for n := number; n <= head; n++ {
+		delete(hc.headers, n)
+	}
+	hc.headers[number] = header
+	hc.head = header
+
+	for n := hc.tail(); number-n >= hc.window; n++ {
+		delete(hc.headers, n)
+	}
+	return nil
+}
+
+// tail is the number of the oldest tracked header, the tracked headers are contiguous
+func (hc *HeaderChain) tail() uint64 {
+	return hc.head.Number.Uint64() + 1 - uint64(len(hc.headers))
+}
+
+// Confirmations returns the number of blocks built on top of the given block, the block has to be
+// part of the tracked chain. Blocks below the window are linked back to it first
+func (hc *HeaderChain) Confirmations(ctx context.Context, number uint64, hash common.Hash) (int64, error) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	h, err := hc.ancestor(ctx, number)
+	if err != nil {
+		return 0, err
+	}
+	if h.Hash() != hash {
+		return 0, ErrHeaderNotCanonical
+	}
+	return int64(hc.head.Number.Uint64() - number), nil
+}
+
+// Ancestor returns the header of the tracked chain at the given number, a header below the window
+// is fetched from all the sources and linked back to the oldest tracked header parent hash by
+// parent hash
+func (hc *HeaderChain) Ancestor(ctx context.Context, number uint64) (*types.Header, error) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	return hc.ancestor(ctx, number)
+}
+
+func (hc *HeaderChain) ancestor(ctx context.Context, number uint64) (*types.Header, error) {
+	if hc.head == nil || number > hc.head.Number.Uint64() {
+		return nil, ErrHeaderNotTracked
+	}
+	if h, ok := hc.headers[number]; ok {
+		return h, nil
+	}
+	if len(hc.sources) < 2 {
+		return nil, ErrHeaderNoTrust
+	}
+	tail := hc.tail()
+	if tail-number > MaxHeaderLookback || (hc.checkpoint != nil && number < hc.checkpoint.Number) {
+		return nil, ErrHeaderTooOld
+	}
+	for n := range hc.ancients {
+		if n >= tail || tail-n > MaxHeaderLookback {
+			delete(hc.ancients, n)
+		}
+	}
+
+	child := hc.headers[tail]
+	for n := tail - 1; ; n-- {
+		h, ok := hc.ancients[n]
+		if !ok || h.Hash() != child.ParentHash {
+			var err error
+			h, err = hc.fetch(ctx, n)
+			if err != nil {
+				return nil, err
+			}
+			if h.Hash() != child.ParentHash {
+				return nil, errors.Wrapf(ErrHeaderUnlinked, "header %d", n)
+			}
+			hc.ancients[n] = h
+		}
+		if n == number {
+			return h, nil
+		}
+		child = h
+	}
+}
+
+// Sync fetches the headers from the tracked head up to the latest header all the sources agree on,
+// following reorgs as deep as the window. It only trusts the agreement of two sources at least, a
+// single source could serve a forged chain of its own otherwise, on top of a checkpoint too
+func (hc *HeaderChain) Sync(ctx context.Context) error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if len(hc.sources) < 2 {
+		return ErrHeaderNoTrust
+	}
+
+	var latest uint64
+	for i, src := range hc.sources {
+		h, err := src.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get latest header")
+		}
+		if i == 0 || h.Number.Uint64() < latest {
+			latest = h.Number.Uint64()
+		}
+	}
+
+	var next uint64
+	if hc.head == nil && hc.checkpoint != nil {
+		// every header from the checkpoint on has to link up to it
+		next = hc.checkpoint.Number
+	} else if hc.head == nil {
+		if latest+1 > hc.window {
+			next = latest + 1 - hc.window
+		}
+	} else {
+		next = hc.head.Number.Uint64() + 1
+	}
+
+	for next <= latest {
+		header, err := hc.fetch(ctx, next)
+		if err != nil {
+			return err
+		}
+		err = hc.insert(header)
+		switch err {
+		case nil:
+			next++
+		case ErrHeaderUnlinked:
+			// reorg, walk back until the new branch links to the tracked chain
+			next--
+		default:
+			return errors.Wrapf(err, "header %d", next)
+		}
+	}
+	return nil
+}
+
+// fetch gets a header from all the sources and makes sure they serve the same one
+func (hc *HeaderChain) fetch(ctx context.Context, number uint64) (*types.Header, error) {
+	var header *types.Header
+	for _, src := range hc.sources {
+		h, err := src.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get header %d", number)
+		}
+		if h.Number.Uint64() != number {
+			return nil, errors.Wrapf(ErrHeaderMismatch, "header %d", number)
+		}
+		if header != nil && header.Hash() != h.Hash() {
+			return nil, errors.Wrapf(ErrHeaderMismatch, "header %d", number)
+		}
+		header = h
+	}
+	return header, nil
+}
+
+var (
+	headerChains     = make(map[string]*HeaderChain)
+	headerChainsLock sync.Mutex
+)
+
+// ValidateHeaderConfig makes sure the headers of the ethereum chain and of every evm chain of a
+// driver config can be followed, a node can't prove any bridge transaction otherwise. Mock chains
+// need nothing
+func ValidateHeaderConfig(cfg *config.EthereumChainDriverConfig) error {
+	err := validateHeaderConnections(cfg)
+	if err != nil {
+		return errors.Wrap(err, "ethereum")
+	}
+	for _, c := range cfg.Chains {
+		chainCfg, err := cfg.ForChain(c.ChainID)
+		if err != nil {
+			return err
+		}
+		err = validateHeaderConnections(chainCfg)
+		if err != nil {
+			return errors.Wrapf(err, "evm chain %d", c.ChainID)
+		}
+	}
+	return nil
+}
+
+func validateHeaderConnections(cfg *config.EthereumChainDriverConfig) error {
+	if IsMockConnection(cfg.Connection) {
+		return nil
+	}
+	if len(cfg.HeaderConnections) == 0 {
+		return errors.Wrap(ErrHeaderNoTrust, "set header_connections")
+	}
+	_, err := headerCheckpoint(cfg)
+	return err
+}
+
+func headerCheckpoint(cfg *config.EthereumChainDriverConfig) (*Checkpoint, error) {
+	if cfg.CheckpointHash == "" {
+		return nil, nil
+	}
+	hash := common.HexToHash(cfg.CheckpointHash)
+	if hash == (common.Hash{}) {
+		return nil, errors.Errorf("invalid checkpoint hash %s", cfg.CheckpointHash)
+	}
+	return &Checkpoint{Number: cfg.CheckpointNumber, Hash: hash}, nil
+}
+
+// GetHeaderChain returns the header chain followed for a driver config, the header connections of
+// the config are used as sources next to the main connection. The config needs one header
+// connection at least
+func GetHeaderChain(cfg *config.EthereumChainDriverConfig) (*HeaderChain, error) {
+	headerChainsLock.Lock()
+	defer headerChainsLock.Unlock()
+
+	if hc, ok := headerChains[cfg.Connection]; ok {
+		return hc, nil
+	}
+
+	err := validateHeaderConnections(cfg)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := headerCheckpoint(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]HeaderSource, 0, len(cfg.HeaderConnections)+1)
+	for _, conn := range append([]string{cfg.Connection}, cfg.HeaderConnections...) {
+		client, err := ethclient.Dial(conn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect header source %s", conn)
+		}
+		sources = append(sources, client)
+	}
+	hc := NewHeaderChain(DefaultHeaderWindow, checkpoint, sources...)
+	headerChains[cfg.Connection] = hc
+	return hc, nil
+}
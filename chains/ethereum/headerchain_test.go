@@ -0,0 +1,197 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testHeaderSource struct {
+	headers []*types.Header
+}
+
+func (src *testHeaderSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return src.headers[len(src.headers)-1], nil
+	}
+	return src.headers[number.Int64()], nil
+}
+
+// testHeaders builds a chain of n headers on top of parent, extra tells branches apart
+func testHeaders(parent *types.Header, n int, extra byte) []*types.Header {
+	headers := make([]*types.Header, 0, n)
+	for i := 0; i < n; i++ {
+		h := &types.Header{
+			Number:     big.NewInt(0),
+			Difficulty: big.NewInt(1),
+			Extra:      []byte{extra},
+		}
+		if parent != nil {
+			h.ParentHash = parent.Hash()
+			h.Number = new(big.Int).Add(parent.Number, big.NewInt(1))
+		}
+		headers = append(headers, h)
+		parent = h
+	}
+	return headers
+}
+
+func TestHeaderChain_Insert(t *testing.T) {
+	headers := testHeaders(nil, 10, 0)
+	hc := NewHeaderChain(5, nil)
+	for _, h := range headers {
+		assert.NoError(t, hc.Insert(h))
+	}
+	assert.Equal(t, headers[9].Hash(), hc.Head().Hash())
+
+	// only the window is kept
+	_, ok := hc.Header(4)
+	assert.False(t, ok)
+	_, ok = hc.Header(5)
+	assert.True(t, ok)
+
+	// headers have to link to the tracked chain
+	assert.Equal(t, ErrHeaderGap, hc.Insert(testHeaders(headers[9], 2, 0)[1]))
+	assert.Equal(t, ErrHeaderUnlinked, hc.Insert(testHeaders(headers[8], 2, 1)[1]))
+	assert.Equal(t, ErrHeaderTooOld, hc.Insert(testHeaders(headers[3], 2, 1)[1]))
+
+	// a fork below the head replaces the tracked branch
+	fork := testHeaders(headers[7], 1, 1)[0]
+	assert.NoError(t, hc.Insert(fork))
+	assert.Equal(t, fork.Hash(), hc.Head().Hash())
+	_, ok = hc.Header(9)
+	assert.False(t, ok)
+}
+
+func TestHeaderChain_Confirmations(t *testing.T) {
+	headers := testHeaders(nil, 10, 0)
+	hc := NewHeaderChain(5, nil)
+	for _, h := range headers {
+		assert.NoError(t, hc.Insert(h))
+	}
+
+	ctx := context.Background()
+	n, err := hc.Confirmations(ctx, 7, headers[7].Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	_, err = hc.Confirmations(ctx, 7, common.HexToHash("0x01"))
+	assert.Equal(t, ErrHeaderNotCanonical, err)
+	_, err = hc.Confirmations(ctx, 10, common.HexToHash("0x01"))
+	assert.Equal(t, ErrHeaderNotTracked, err)
+	// older blocks need the sources to be linked back
+	_, err = hc.Confirmations(ctx, 2, headers[2].Hash())
+	assert.Equal(t, ErrHeaderNoTrust, err)
+}
+
+func TestHeaderChain_Ancestor(t *testing.T) {
+	ctx := context.Background()
+	headers := testHeaders(nil, 20, 0)
+	hc := NewHeaderChain(5, nil, &testHeaderSource{headers: headers}, &testHeaderSource{headers: headers})
+	assert.NoError(t, hc.Sync(ctx))
+	_, ok := hc.Header(12)
+	assert.False(t, ok)
+
+	// a block out of the window is linked back to it
+	h, err := hc.Ancestor(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, headers[3].Hash(), h.Hash())
+	n, err := hc.Confirmations(ctx, 12, headers[12].Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	// sources serving a branch that doesn't link to the window are refused
+	forged := append(append([]*types.Header{}, testHeaders(nil, 15, 1)...), headers[15:]...)
+	hc = NewHeaderChain(5, nil, &testHeaderSource{headers: forged}, &testHeaderSource{headers: forged})
+	assert.NoError(t, hc.Sync(ctx))
+	_, err = hc.Ancestor(ctx, 3)
+	assert.Equal(t, ErrHeaderUnlinked, errors.Cause(err))
+
+	// nothing older than the checkpoint is trusted
+	hc = NewHeaderChain(5, &Checkpoint{Number: 10, Hash: headers[10].Hash()},
+		&testHeaderSource{headers: headers}, &testHeaderSource{headers: headers})
+	assert.NoError(t, hc.Sync(ctx))
+	_, err = hc.Ancestor(ctx, 11)
+	assert.NoError(t, err)
+	_, err = hc.Ancestor(ctx, 9)
+	assert.Equal(t, ErrHeaderTooOld, err)
+}
+
+func TestHeaderChain_Sync(t *testing.T) {
+	headers := testHeaders(nil, 20, 0)
+	src := &testHeaderSource{headers: headers[:15]}
+	hc := NewHeaderChain(8, &Checkpoint{Number: 7, Hash: headers[7].Hash()}, src, src)
+
+	assert.NoError(t, hc.Sync(context.Background()))
+	assert.Equal(t, headers[14].Hash(), hc.Head().Hash())
+	_, ok := hc.Header(7)
+	assert.True(t, ok)
+	_, ok = hc.Header(6)
+	assert.False(t, ok)
+
+	// reorg of the last three blocks
+	src.headers = append(append([]*types.Header{}, headers[:12]...), testHeaders(headers[11], 6, 1)...)
+	assert.NoError(t, hc.Sync(context.Background()))
+	assert.Equal(t, src.headers[17].Hash(), hc.Head().Hash())
+	_, err := hc.Confirmations(context.Background(), 13, headers[13].Hash())
+	assert.Equal(t, ErrHeaderNotCanonical, err)
+	n, err := hc.Confirmations(context.Background(), 11, headers[11].Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+}
+
+func TestHeaderChain_SyncSourcesDisagree(t *testing.T) {
+	headers := testHeaders(nil, 10, 0)
+	honest := &testHeaderSource{headers: headers}
+	lying := &testHeaderSource{headers: append(append([]*types.Header{}, headers[:6]...), testHeaders(headers[5], 4, 1)...)}
+
+	hc := NewHeaderChain(8, nil, honest, lying)
+	err := hc.Sync(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, headers[5].Hash(), hc.Head().Hash())
+}
+
+func TestHeaderChain_SyncForgedHeaders(t *testing.T) {
+	headers := testHeaders(nil, 10, 0)
+	forged := testHeaders(nil, 10, 1)
+	checkpoint := &Checkpoint{Number: 3, Hash: headers[3].Hash()}
+
+	// a single source is not trusted, with a checkpoint neither
+	hc := NewHeaderChain(8, nil, &testHeaderSource{headers: forged})
+	assert.Equal(t, ErrHeaderNoTrust, hc.Sync(context.Background()))
+	assert.Nil(t, hc.Head())
+	hc = NewHeaderChain(8, checkpoint, &testHeaderSource{headers: headers})
+	assert.Equal(t, ErrHeaderNoTrust, hc.Sync(context.Background()))
+	assert.Nil(t, hc.Head())
+
+	// a forged chain doesn't start from the checkpoint
+	hc = NewHeaderChain(8, checkpoint, &testHeaderSource{headers: forged}, &testHeaderSource{headers: forged})
+	err := hc.Sync(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, hc.Head())
+
+	// a forged branch on top of the checkpoint doesn't link to it
+	src := &testHeaderSource{headers: append(append([]*types.Header{}, headers[:4]...), forged[4:]...)}
+	hc = NewHeaderChain(8, checkpoint, src, src)
+	err = hc.Sync(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, headers[3].Hash(), hc.Head().Hash())
+
+	// a lying source forging a linked branch on top of the checkpoint is outvoted by an honest one
+	linked := append(append([]*types.Header{}, headers[:4]...), testHeaders(headers[3], 6, 1)...)
+	hc = NewHeaderChain(8, checkpoint, &testHeaderSource{headers: headers}, &testHeaderSource{headers: linked})
+	err = hc.Sync(context.Background())
+	assert.Equal(t, ErrHeaderMismatch, errors.Cause(err))
+	assert.Equal(t, headers[3].Hash(), hc.Head().Hash())
+
+	// the first header inserted has to be the checkpoint
+	hc = NewHeaderChain(8, checkpoint)
+	assert.Equal(t, ErrHeaderUntrusted, hc.Insert(forged[3]))
+	assert.NoError(t, hc.Insert(headers[3]))
+	assert.NoError(t, hc.Insert(headers[4]))
+}
@@ -0,0 +1,600 @@
+package ethereum
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+)
+
+const (
+	// MockConnection as the connection of a driver config selects an in-process mock chain instead
+	// of an evm node, "mock" and "mock://<name>" are different chains
+	MockConnection = "mock"
+
+	MockChainID = 1337
+
+	// DefaultMockRedeemBlocks is the number of blocks a redeem request stays open on a mock chain
+	DefaultMockRedeemBlocks = 100
+
+	// DefaultMockThreshold is the number of validator signatures a mock chain needs to release a redeem
+	DefaultMockThreshold = 1
+
+	mockGasPrice = 1000000000
+)
+
+var ErrMockDisconnected = errors.New("mock chain is disconnected")
+
+var mockValidatorSetABI, _ = abi.JSON(strings.NewReader(contract.ValidatorSetABI))
+
+var (
+	mockChains     = make(map[string]*MockChain)
+	mockChainsLock sync.Mutex
+)
+
+// IsMockConnection tells whether a driver connection is served by a mock chain
+func IsMockConnection(connection string) bool {
+	return strings.HasPrefix(connection, MockConnection)
+}
+
+// GetMockChain returns the mock chain of a connection, it is created on first use
+func GetMockChain(connection string) *MockChain {
+	mockChainsLock.Lock()
+	defer mockChainsLock.Unlock()
+
+	c, ok := mockChains[connection]
+	if !ok {
+		c = NewMockChain(MockChainID)
+		mockChains[connection] = c
+	}
+	return c
+}
+
+// ResetMockChains drops every mock chain, the next drivers start on empty chains
+func ResetMockChains() {
+	mockChainsLock.Lock()
+	defer mockChainsLock.Unlock()
+
+	mockChains = make(map[string]*MockChain)
+}
+
+type mockTx struct {
+	tx     *types.Transaction
+	from   common.Address
+	block  uint64
+	status uint64
+	revert bool
+	uncled bool
+}
+
+// mockRedeemKey is the recipient of an ether or erc20 redeem, the token of an erc721 redeem
+type mockRedeemKey struct {
+	recipient common.Address
+	token     common.Address
+	tokenID   string
+}
+
+type mockRedeem struct {
+	until   uint64
+	signers map[common.Address]bool
+	done    bool
+}
+
+// mockValidatorSet is the validators of a contract, a contract takes any signer until it is first
+// handed over to a set
+type mockValidatorSet struct {
+	epoch      uint64
+	validators map[common.Address]bool
+	votes      map[common.Hash]map[common.Address]bool
+}
+
+func (s *mockValidatorSet) isValidator(addr common.Address) bool {
+	return len(s.validators) == 0 || s.validators[addr]
+}
+
+// MockChain simulates an evm chain and the bridge contracts deployed on it. Broadcast transactions
+// wait in a pool until blocks are mined, and the lock/redeem contract calls take effect as they are
+// mined: a redeem opens a request for its sender, the validator signs are counted on it until the
+// threshold releases it, and the same threshold of validator votes hands a contract over to a new
+// validator set. Failures are injected with Revert, Reorg and SetConnected.
+type MockChain struct {
+	sync.Mutex
+
+	chainID      *big.Int
+	height       uint64
+	disconnected bool
+	threshold    int
+	redeemBlocks uint64
+
+	contracts map[common.Address]abi.ABI
+	txs       map[common.Hash]*mockTx
+	pending   []common.Hash
+	nonces    map[common.Address]uint64
+	redeems   map[mockRedeemKey]*mockRedeem
+	tokenURIs map[mockRedeemKey]string
+	valsets   map[common.Address]*mockValidatorSet
+}
+
+func NewMockChain(chainID int64) *MockChain {
+	return &MockChain{
+		chainID:      big.NewInt(chainID),
+		threshold:    DefaultMockThreshold,
+		redeemBlocks: DefaultMockRedeemBlocks,
+		contracts:    make(map[common.Address]abi.ABI),
+		txs:          make(map[common.Hash]*mockTx),
+		nonces:       make(map[common.Address]uint64),
+		redeems:      make(map[mockRedeemKey]*mockRedeem),
+		tokenURIs:    make(map[mockRedeemKey]string),
+		valsets:      make(map[common.Address]*mockValidatorSet),
+	}
+}
+
+func (c *MockChain) ChainID() *big.Int {
+	c.Lock()
+	defer c.Unlock()
+
+	return new(big.Int).Set(c.chainID)
+}
+
+func (c *MockChain) SetChainID(chainID int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.chainID = big.NewInt(chainID)
+}
+
+func (c *MockChain) Height() uint64 {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.height
+}
+
+// SetThreshold sets the number of validator signatures releasing a redeem
+func (c *MockChain) SetThreshold(threshold int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.threshold = threshold
+}
+
+// SetRedeemBlocks sets the number of blocks a redeem request stays open
+func (c *MockChain) SetRedeemBlocks(blocks uint64) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.redeemBlocks = blocks
+}
+
+// SetConnected simulates losing and getting back the connection to the node
+func (c *MockChain) SetConnected(connected bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.disconnected = !connected
+}
+
+// SetTokenURI sets the metadata uri of an erc721 token
+func (c *MockChain) SetTokenURI(token common.Address, tokenID *big.Int, uri string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.tokenURIs[mockRedeemKey{token: token, tokenID: tokenID.String()}] = uri
+}
+
+// ValidatorEpoch returns the epoch of the validator set of a contract
+func (c *MockChain) ValidatorEpoch(contractAddress common.Address) uint64 {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.validatorSet(contractAddress).epoch
+}
+
+// IsValidator tells whether an address can sign for a contract
+func (c *MockChain) IsValidator(contractAddress common.Address, addr common.Address) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.validatorSet(contractAddress).isValidator(addr)
+}
+
+func (c *MockChain) validatorSet(contractAddress common.Address) *mockValidatorSet {
+	set, ok := c.valsets[contractAddress]
+	if !ok {
+		set = &mockValidatorSet{
+			validators: make(map[common.Address]bool),
+			votes:      make(map[common.Hash]map[common.Address]bool),
+		}
+		c.valsets[contractAddress] = set
+	}
+	return set
+}
+
+// Submit adds a signed transaction to the pool, it is how users reach the mock chain
+func (c *MockChain) Submit(tx *types.Transaction) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.submit(tx)
+}
+
+func (c *MockChain) submit(tx *types.Transaction) error {
+	if c.disconnected {
+		return ErrMockDisconnected
+	}
+	if _, ok := c.txs[tx.Hash()]; ok {
+		return nil
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return errors.Wrap(err, "invalid transaction signature")
+	}
+	c.txs[tx.Hash()] = &mockTx{tx: tx, from: from}
+	c.pending = append(c.pending, tx.Hash())
+	c.nonces[from]++
+	return nil
+}
+
+// Revert makes a transaction fail, as it is mined if it is still pending
+func (c *MockChain) Revert(hash common.Hash) {
+	c.Lock()
+	defer c.Unlock()
+
+	if t, ok := c.txs[hash]; ok {
+		t.revert = true
+		if t.block > 0 {
+			t.status = types.ReceiptStatusFailed
+		}
+	}
+}
+
+// Reorg moves the block of a mined transaction out of the canonical chain
+func (c *MockChain) Reorg(hash common.Hash) {
+	c.Lock()
+	defer c.Unlock()
+
+	if t, ok := c.txs[hash]; ok {
+		t.uncled = true
+	}
+}
+
+// Mine mines n blocks, the pending transactions go in the first one
+func (c *MockChain) Mine(n int) {
+	c.Lock()
+	defer c.Unlock()
+
+	for i := 0; i < n; i++ {
+		c.height++
+		if i > 0 {
+			continue
+		}
+		for _, hash := range c.pending {
+			t := c.txs[hash]
+			t.block = c.height
+			t.status = types.ReceiptStatusSuccessful
+			if t.revert || !c.apply(t) {
+				t.status = types.ReceiptStatusFailed
+			}
+		}
+		c.pending = nil
+	}
+}
+
+// apply runs the contract call of a transaction, false reverts it
+func (c *MockChain) apply(t *mockTx) bool {
+	if t.tx.To() == nil || len(t.tx.Data()) < 4 {
+		return true
+	}
+	contractAbi, ok := c.contracts[*t.tx.To()]
+	if !ok {
+		return true
+	}
+	method, err := contractAbi.MethodById(t.tx.Data()[:4])
+	if err != nil {
+		method, err = mockValidatorSetABI.MethodById(t.tx.Data()[:4])
+		if err != nil {
+			return false
+		}
+	}
+	args, err := method.Inputs.Unpack(t.tx.Data()[4:])
+	if err != nil {
+		return false
+	}
+
+	switch method.Name {
+	case "redeem":
+		key := mockRedeemKey{recipient: t.from}
+		if len(args) == 2 {
+			if token, ok := args[0].(common.Address); ok {
+				key = mockRedeemKey{token: token, tokenID: args[1].(*big.Int).String()}
+			}
+		}
+		if c.redeemStatus(key) == Ongoing {
+			return false
+		}
+		c.redeems[key] = &mockRedeem{
+			until:   c.height + c.redeemBlocks,
+			signers: make(map[common.Address]bool),
+		}
+	case "sign":
+		if !c.validatorSet(*t.tx.To()).isValidator(t.from) {
+			return false
+		}
+		var key mockRedeemKey
+		if len(args) == 3 {
+			key = mockRedeemKey{token: args[0].(common.Address), tokenID: args[1].(*big.Int).String()}
+		} else {
+			key = mockRedeemKey{recipient: args[1].(common.Address)}
+		}
+		r, ok := c.redeems[key]
+		if !ok || c.redeemStatus(key) != Ongoing || r.signers[t.from] {
+			return false
+		}
+		r.signers[t.from] = true
+		r.done = len(r.signers) >= c.threshold
+	case "updateValidators":
+		set := c.validatorSet(*t.tx.To())
+		epoch := args[0].(*big.Int)
+		validators := args[1].([]common.Address)
+		if !set.isValidator(t.from) || epoch.Uint64() != set.epoch+1 || len(validators) == 0 {
+			return false
+		}
+		update := crypto.Keccak256Hash(t.tx.Data()[4:])
+		votes, ok := set.votes[update]
+		if !ok {
+			votes = make(map[common.Address]bool)
+			set.votes[update] = votes
+		}
+		if votes[t.from] {
+			return false
+		}
+		votes[t.from] = true
+		if len(votes) < c.threshold {
+			return true
+		}
+		set.epoch = epoch.Uint64()
+		set.validators = make(map[common.Address]bool)
+		for _, v := range validators {
+			set.validators[v] = true
+		}
+		set.votes = make(map[common.Hash]map[common.Address]bool)
+	}
+	return true
+}
+
+func (c *MockChain) redeemStatus(key mockRedeemKey) RedeemStatus {
+	r, ok := c.redeems[key]
+	switch {
+	case !ok:
+		return NewRedeem
+	case r.done:
+		return Success
+	case c.height > r.until:
+		return Expired
+	}
+	return Ongoing
+}
+
+func (c *MockChain) hasSigned(key mockRedeemKey, validator common.Address) bool {
+	r, ok := c.redeems[key]
+	return ok && r.signers[validator]
+}
+
+// MockChainDriver serves the OnlineChainDriver of a contract from a mock chain
+type MockChainDriver struct {
+	chain           *MockChain
+	ContractAddress common.Address
+	ContractABI     string
+	ContractType    ContractType
+}
+
+var _ OnlineChainDriver = &MockChainDriver{}
+
+// NewMockChainDriver deploys the contract on the mock chain, if it is not yet, and returns its driver
+func NewMockChainDriver(chain *MockChain, contractAddress common.Address, contractAbi string, contractType ContractType) (*MockChainDriver, error) {
+	parsed, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid contract abi")
+	}
+
+	chain.Lock()
+	chain.contracts[contractAddress] = parsed
+	chain.Unlock()
+
+	return &MockChainDriver{
+		chain:           chain,
+		ContractAddress: contractAddress,
+		ContractABI:     contractAbi,
+		ContractType:    contractType,
+	}, nil
+}
+
+func (acc *MockChainDriver) ChainId() (*big.Int, error) {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return nil, ErrMockDisconnected
+	}
+	return new(big.Int).Set(acc.chain.chainID), nil
+}
+
+func (acc *MockChainDriver) newContractTx(from common.Address, value *big.Int, method string, args ...interface{}) (*Transaction, error) {
+	return acc.newABITx(from, value, acc.ContractABI, method, args...)
+}
+
+func (acc *MockChainDriver) newABITx(from common.Address, value *big.Int, abiStr string, method string, args ...interface{}) (*Transaction, error) {
+	contractAbi, err := abi.JSON(strings.NewReader(abiStr))
+	if err != nil {
+		return nil, err
+	}
+	bytesData, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return nil, ErrMockDisconnected
+	}
+	nonce := acc.chain.nonces[from]
+	return types.NewTransaction(nonce, acc.ContractAddress, value, gasLimit, big.NewInt(mockGasPrice), bytesData), nil
+}
+
+func (acc *MockChainDriver) PrepareUnsignedETHLock(addr common.Address, lockAmount *big.Int) ([]byte, error) {
+	tx, err := acc.newContractTx(addr, lockAmount, "lock")
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(tx)
+}
+
+func (acc *MockChainDriver) DecodeTransaction(rawBytes []byte) (*types.Transaction, error) {
+	return DecodeTransaction(rawBytes)
+}
+
+func (acc *MockChainDriver) GetTransactionMessage(tx *types.Transaction) (*types.Message, error) {
+	msg, err := tx.AsMessage(types.NewEIP155Signer(tx.ChainId()), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to convert Tx to message")
+	}
+	return &msg, nil
+}
+
+func (acc *MockChainDriver) CheckFinality(txHash TransactionHash, blockConfirmation int64) CheckFinalityStatus {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return UnabletoGetHeader
+	}
+	t, ok := acc.chain.txs[txHash]
+	if !ok || t.block == 0 {
+		return TransactionNotMined
+	}
+	if t.uncled {
+		return BlockHashFailed
+	}
+	if int64(acc.chain.height-t.block+1) < blockConfirmation {
+		return NotEnoughConfirmations
+	}
+	if t.status != types.ReceiptStatusSuccessful {
+		return ReciptNotFound
+	}
+	return TXSuccess
+}
+
+func (acc *MockChainDriver) VerifyReceipt(txHash TransactionHash) (VerifyReceiptStatus, error) {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return Other, ErrMockDisconnected
+	}
+	t, ok := acc.chain.txs[txHash]
+	if !ok || t.block == 0 {
+		return NotFound, nil
+	}
+	if t.uncled || t.status == types.ReceiptStatusFailed {
+		return Failed, nil
+	}
+	return Found, nil
+}
+
+func (acc *MockChainDriver) BroadcastTx(tx *types.Transaction) (TransactionHash, error) {
+	return tx.Hash(), acc.chain.Submit(tx)
+}
+
+func (acc *MockChainDriver) ParseRedeem(data []byte, abi string) (*RedeemRequest, error) {
+	return ParseRedeem(data, abi)
+}
+
+func (acc *MockChainDriver) ParseERC20Redeem(rawTx []byte, lockredeemERCAbi string) (*RedeemErcRequest, error) {
+	return ParseERC20RedeemParams(rawTx, lockredeemERCAbi)
+}
+
+func (acc *MockChainDriver) SignRedeem(fromaddr common.Address, redeemAmount *big.Int, recipient common.Address) (*Transaction, error) {
+	return acc.newContractTx(fromaddr, big.NewInt(0), "sign", redeemAmount, recipient)
+}
+
+func (acc *MockChainDriver) VerifyRedeem(validatorAddress common.Address, recipient common.Address) RedeemStatus {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return ErrorConnecting
+	}
+	return acc.chain.redeemStatus(mockRedeemKey{recipient: recipient})
+}
+
+func (acc *MockChainDriver) HasValidatorSigned(validatorAddress common.Address, recipient common.Address) (bool, error) {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return false, ErrMockDisconnected
+	}
+	return acc.chain.hasSigned(mockRedeemKey{recipient: recipient}, validatorAddress), nil
+}
+
+func (acc *MockChainDriver) SignERC721Redeem(fromaddr common.Address, token common.Address, tokenID *big.Int, recipient common.Address) (*Transaction, error) {
+	return acc.newContractTx(fromaddr, big.NewInt(0), "sign", token, tokenID, recipient)
+}
+
+func (acc *MockChainDriver) VerifyERC721Redeem(validatorAddress common.Address, token common.Address, tokenID *big.Int) RedeemStatus {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return ErrorConnecting
+	}
+	return acc.chain.redeemStatus(mockRedeemKey{token: token, tokenID: tokenID.String()})
+}
+
+func (acc *MockChainDriver) HasValidatorSignedERC721(validatorAddress common.Address, token common.Address, tokenID *big.Int) (bool, error) {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return false, ErrMockDisconnected
+	}
+	return acc.chain.hasSigned(mockRedeemKey{token: token, tokenID: tokenID.String()}, validatorAddress), nil
+}
+
+func (acc *MockChainDriver) TokenURI(token common.Address, tokenID *big.Int) (string, error) {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return "", ErrMockDisconnected
+	}
+	uri, ok := acc.chain.tokenURIs[mockRedeemKey{token: token, tokenID: tokenID.String()}]
+	if !ok {
+		return "", errors.New("nonexistent token")
+	}
+	return uri, nil
+}
+
+func (acc *MockChainDriver) UpdateValidators(fromaddr common.Address, epoch int64, validators []common.Address) (*Transaction, error) {
+	return acc.newABITx(fromaddr, big.NewInt(0), contract.ValidatorSetABI, "updateValidators", big.NewInt(epoch), validators)
+}
+
+func (acc *MockChainDriver) ValidatorEpoch() (int64, error) {
+	acc.chain.Lock()
+	defer acc.chain.Unlock()
+
+	if acc.chain.disconnected {
+		return 0, ErrMockDisconnected
+	}
+	return int64(acc.chain.validatorSet(acc.ContractAddress).epoch), nil
+}
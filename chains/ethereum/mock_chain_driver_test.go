@@ -0,0 +1,201 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+	"github.com/Oneledger/protocol/config"
+)
+
+var mockContract = common.HexToAddress("0x8ce1D5ecbD1A6a7E9E8B1bA7c9A8B6F9CF4A1C55")
+
+func mockDriver(t *testing.T) (*MockChain, OnlineChainDriver) {
+	ResetMockChains()
+	cfg := &config.EthereumChainDriverConfig{Connection: MockConnection}
+	cd, err := GetChainDriver(cfg, nil, mockContract, contract.LockRedeemABI, ETH)
+	require.NoError(t, err)
+	return GetMockChain(MockConnection), cd
+}
+
+func signMockTx(t *testing.T, c *MockChain, tx *types.Transaction) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(c.ChainID()), key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestMockChainDriver_CheckFinality(t *testing.T) {
+	c, cd := mockDriver(t)
+
+	raw, err := cd.PrepareUnsignedETHLock(common.Address{}, big.NewInt(100))
+	require.NoError(t, err)
+	unsigned, err := cd.DecodeTransaction(raw)
+	require.NoError(t, err)
+	lock := signMockTx(t, c, unsigned)
+
+	assert.Equal(t, TransactionNotMined, cd.CheckFinality(lock.Hash(), 3))
+	_, err = cd.BroadcastTx(lock)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionNotMined, cd.CheckFinality(lock.Hash(), 3))
+
+	c.Mine(1)
+	assert.Equal(t, NotEnoughConfirmations, cd.CheckFinality(lock.Hash(), 3))
+	c.Mine(2)
+	assert.Equal(t, TXSuccess, cd.CheckFinality(lock.Hash(), 3))
+
+	c.SetConnected(false)
+	assert.Equal(t, UnabletoGetHeader, cd.CheckFinality(lock.Hash(), 3))
+	_, err = cd.VerifyReceipt(lock.Hash())
+	assert.Equal(t, ErrMockDisconnected, err)
+	c.SetConnected(true)
+
+	c.Reorg(lock.Hash())
+	assert.Equal(t, BlockHashFailed, cd.CheckFinality(lock.Hash(), 3))
+	status, err := cd.VerifyReceipt(lock.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, Failed, status)
+}
+
+func TestMockChainDriver_Redeem(t *testing.T) {
+	c, cd := mockDriver(t)
+	c.SetThreshold(2)
+
+	contractAbi, err := abi.JSON(strings.NewReader(contract.LockRedeemABI))
+	require.NoError(t, err)
+	data, err := contractAbi.Pack("redeem", big.NewInt(50))
+	require.NoError(t, err)
+	redeem := signMockTx(t, c, types.NewTransaction(0, mockContract, big.NewInt(0), gasLimit, big.NewInt(mockGasPrice), data))
+	msg, err := cd.GetTransactionMessage(redeem)
+	require.NoError(t, err)
+	user := msg.From()
+
+	require.NoError(t, c.Submit(redeem))
+	assert.Equal(t, NewRedeem, cd.VerifyRedeem(common.Address{}, user))
+	c.Mine(1)
+	status, err := cd.VerifyReceipt(redeem.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, Found, status)
+	assert.Equal(t, Ongoing, cd.VerifyRedeem(common.Address{}, user))
+
+	signers := make([]common.Address, 3)
+	signs := make([]*types.Transaction, 3)
+	for i := range signs {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signers[i] = crypto.PubkeyToAddress(key.PublicKey)
+		tx, err := cd.SignRedeem(signers[i], big.NewInt(50), user)
+		require.NoError(t, err)
+		signs[i], err = types.SignTx(tx, types.NewEIP155Signer(c.ChainID()), key)
+		require.NoError(t, err)
+	}
+
+	_, err = cd.BroadcastTx(signs[0])
+	require.NoError(t, err)
+	c.Mine(1)
+	signed, err := cd.HasValidatorSigned(signers[0], user)
+	assert.NoError(t, err)
+	assert.True(t, signed)
+	assert.Equal(t, Ongoing, cd.VerifyRedeem(signers[0], user))
+
+	// the sign past the threshold is reverted by the contract
+	_, err = cd.BroadcastTx(signs[1])
+	require.NoError(t, err)
+	_, err = cd.BroadcastTx(signs[2])
+	require.NoError(t, err)
+	c.Mine(1)
+	assert.Equal(t, Success, cd.VerifyRedeem(signers[0], user))
+	status, _ = cd.VerifyReceipt(signs[2].Hash())
+	assert.Equal(t, Failed, status)
+	signed, _ = cd.HasValidatorSigned(signers[2], user)
+	assert.False(t, signed)
+}
+
+func TestMockChainDriver_RedeemExpired(t *testing.T) {
+	c, cd := mockDriver(t)
+	c.SetRedeemBlocks(5)
+
+	contractAbi, err := abi.JSON(strings.NewReader(contract.LockRedeemABI))
+	require.NoError(t, err)
+	data, err := contractAbi.Pack("redeem", big.NewInt(50))
+	require.NoError(t, err)
+	redeem := signMockTx(t, c, types.NewTransaction(0, mockContract, big.NewInt(0), gasLimit, big.NewInt(mockGasPrice), data))
+	msg, err := cd.GetTransactionMessage(redeem)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Submit(redeem))
+	c.Mine(6)
+	assert.Equal(t, Ongoing, cd.VerifyRedeem(common.Address{}, msg.From()))
+	c.Mine(1)
+	assert.Equal(t, Expired, cd.VerifyRedeem(common.Address{}, msg.From()))
+}
+
+func TestMockChainDriver_UpdateValidators(t *testing.T) {
+	c, cd := mockDriver(t)
+	c.SetThreshold(2)
+
+	validators := make([]common.Address, 3)
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		validators[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	vote := func(i int, epoch int64, set []common.Address) *types.Transaction {
+		tx, err := cd.UpdateValidators(validators[i], epoch, set)
+		require.NoError(t, err)
+		signed, err := types.SignTx(tx, types.NewEIP155Signer(c.ChainID()), keys[i])
+		require.NoError(t, err)
+		_, err = cd.BroadcastTx(signed)
+		require.NoError(t, err)
+		return signed
+	}
+
+	// the contract takes any signer before it is first handed over
+	assert.True(t, c.IsValidator(mockContract, validators[2]))
+
+	vote(0, 1, validators[:2])
+	c.Mine(1)
+	epoch, err := cd.ValidatorEpoch()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), epoch)
+
+	// a vote for another set does not count for the first one
+	vote(1, 1, validators[1:])
+	c.Mine(1)
+	epoch, _ = cd.ValidatorEpoch()
+	assert.Equal(t, int64(0), epoch)
+
+	vote(1, 1, validators[:2])
+	c.Mine(1)
+	epoch, _ = cd.ValidatorEpoch()
+	assert.Equal(t, int64(1), epoch)
+	assert.True(t, c.IsValidator(mockContract, validators[0]))
+	assert.False(t, c.IsValidator(mockContract, validators[2]))
+
+	// only the validators of the current epoch vote the next one
+	out := vote(2, 2, validators[1:])
+	c.Mine(1)
+	status, err := cd.VerifyReceipt(out.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, Failed, status)
+	stale := vote(0, 1, validators[1:])
+	c.Mine(1)
+	status, _ = cd.VerifyReceipt(stale.Hash())
+	assert.Equal(t, Failed, status)
+
+	c.SetConnected(false)
+	_, err = cd.ValidatorEpoch()
+	assert.Equal(t, ErrMockDisconnected, err)
+}
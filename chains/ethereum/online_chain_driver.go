@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/chains/ethereum/contract"
@@ -25,6 +26,7 @@ const gasLimit = 700000
 type ETHChainDriver struct {
 	cfg             *config.EthereumChainDriverConfig
 	client          *Client
+	rpcClient       *rpc.Client
 	contract        Contract
 	logger          *log.Logger
 	ContractAddress Address
@@ -34,6 +36,45 @@ type ETHChainDriver struct {
 
 // Implements ChainDriver interface
 var _ ChainDriver = &ETHChainDriver{}
+var _ OnlineChainDriver = &ETHChainDriver{}
+
+// OnlineChainDriver is what the bridge jobs and services need of a contract on an evm node
+type OnlineChainDriver interface {
+	ChainId() (*big.Int, error)
+	PrepareUnsignedETHLock(addr common.Address, lockAmount *big.Int) ([]byte, error)
+	DecodeTransaction(rawBytes []byte) (*types.Transaction, error)
+	GetTransactionMessage(tx *types.Transaction) (*types.Message, error)
+	CheckFinality(txHash TransactionHash, blockConfirmation int64) CheckFinalityStatus
+	VerifyReceipt(txHash TransactionHash) (VerifyReceiptStatus, error)
+	BroadcastTx(tx *types.Transaction) (TransactionHash, error)
+
+	ParseRedeem(data []byte, abi string) (*RedeemRequest, error)
+	ParseERC20Redeem(rawTx []byte, lockredeemERCAbi string) (*RedeemErcRequest, error)
+	SignRedeem(fromaddr common.Address, redeemAmount *big.Int, recipient common.Address) (*Transaction, error)
+	VerifyRedeem(validatorAddress common.Address, recipient common.Address) RedeemStatus
+	HasValidatorSigned(validatorAddress common.Address, recipient common.Address) (bool, error)
+
+	SignERC721Redeem(fromaddr common.Address, token common.Address, tokenID *big.Int, recipient common.Address) (*Transaction, error)
+	VerifyERC721Redeem(validatorAddress common.Address, token common.Address, tokenID *big.Int) RedeemStatus
+	HasValidatorSignedERC721(validatorAddress common.Address, token common.Address, tokenID *big.Int) (bool, error)
+	TokenURI(token common.Address, tokenID *big.Int) (string, error)
+
+	UpdateValidators(fromaddr common.Address, epoch int64, validators []common.Address) (*Transaction, error)
+	ValidatorEpoch() (int64, error)
+}
+
+// GetChainDriver returns the driver of a contract on the evm node of the config, a mock connection
+// gets the in-process mock chain of that name instead of a node
+func GetChainDriver(cfg *config.EthereumChainDriverConfig, logger *log.Logger, contractAddress common.Address, contractAbi string, contractType ContractType) (OnlineChainDriver, error) {
+	if IsMockConnection(cfg.Connection) {
+		return NewMockChainDriver(GetMockChain(cfg.Connection), contractAddress, contractAbi, contractType)
+	}
+	cd, err := NewChainDriver(cfg, logger, contractAddress, contractAbi, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
 
 func NewChainDriver(cfg *config.EthereumChainDriverConfig, logger *log.Logger, contractAddress common.Address, contractAbi string, contractType ContractType) (*ETHChainDriver, error) {
 
@@ -68,11 +109,12 @@ func defaultContext() (context.Context, context.CancelFunc) {
 
 func (acc *ETHChainDriver) GetClient() *Client {
 	if acc.client == nil {
-		client, err := ethclient.Dial(acc.cfg.Connection)
+		rpcClient, err := rpc.Dial(acc.cfg.Connection)
 		if err != nil {
 			panic(err)
 		}
-		acc.client = client
+		acc.rpcClient = rpcClient
+		acc.client = ethclient.NewClient(rpcClient)
 	}
 	return acc.client
 }
@@ -193,33 +235,47 @@ func (acc *ETHChainDriver) GetTransactionMessage(tx *types.Transaction) (*types.
 	return &msg, nil
 }
 
-// CheckFinalityStatus verifies the finality of a transaction on the ethereum blockchain , waits for 12 block confirmations
+// CheckFinalityStatus verifies the finality of a transaction on the ethereum blockchain , waits for 12 block confirmations.
+// The rpc receipt only locates the transaction, the confirmations come from the headers tracked by the node
+// and the transaction and receipt are proven against the tracked block header.
 func (acc *ETHChainDriver) CheckFinality(txHash TransactionHash, blockConfirmation int64) CheckFinalityStatus {
 	result, err := acc.GetClient().TransactionReceipt(context.Background(), txHash)
 	if err != nil {
 		acc.logger.Debug("Transaction not added to Block yet :", err)
 		return TransactionNotMined
 	}
-	if result.Status == types.ReceiptStatusSuccessful {
-		latestHeader, err := acc.client.HeaderByNumber(context.Background(), nil)
-		if err != nil {
-			acc.logger.Debug("Original Receipt Successful , But unable to get Latest Header (Connection Problem)", err)
-			return UnabletoGetHeader
-		}
-		diff := big.NewInt(0).Sub(latestHeader.Number, result.BlockNumber)
-		if big.NewInt(blockConfirmation).Cmp(diff) > 0 {
-			acc.logger.Debug("Waiting for confirmation . Current Block Confirmations : " + diff.String())
-			return NotEnoughConfirmations
-		}
-		txHeaderCalculated, err := acc.client.HeaderByNumber(context.Background(), result.BlockNumber)
-		if err != nil {
-			acc.logger.Debug("Block Confirmed ,Error in getting Header of Original block at T - "+diff.String(), "  ", err)
-			return TxBlockNotFound
-		}
-		if txHeaderCalculated.Hash() != result.BlockHash {
-			acc.logger.Debug("BlockHash Check Failed ,Uncle block Detected")
-			return BlockHashFailed
-		}
+
+	headers, err := acc.syncHeaders()
+	if err != nil {
+		acc.logger.Debug("Unable to sync block headers (Connection Problem)", err)
+		return UnabletoGetHeader
+	}
+	c, cancel := context.WithTimeout(context.Background(), 10*DefaultTimeout)
+	defer cancel()
+	confirmations, err := headers.Confirmations(c, result.BlockNumber.Uint64(), result.BlockHash)
+	switch errors.Cause(err) {
+	case nil:
+	case ErrHeaderNotTracked:
+		acc.logger.Debug("Waiting for the block header of the transaction")
+		return NotEnoughConfirmations
+	case ErrHeaderNotCanonical:
+		acc.logger.Debug("BlockHash Check Failed ,Uncle block Detected")
+		return BlockHashFailed
+	default:
+		acc.logger.Debug("Block header of the transaction is not tracked", err)
+		return TxBlockNotFound
+	}
+	if confirmations < blockConfirmation {
+		acc.logger.Debugf("Waiting for confirmation . Current Block Confirmations : %d", confirmations)
+		return NotEnoughConfirmations
+	}
+
+	receipt, err := acc.provenReceipt(c, headers, txHash, result)
+	if err != nil {
+		acc.logger.Error("Unable to prove transaction receipt", txHash.Hex(), err)
+		return ReceiptProofFailed
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
 		return TXSuccess
 	}
 
@@ -232,6 +288,7 @@ func (acc *ETHChainDriver) CheckFinality(txHash TransactionHash, blockConfirmati
 
 // Bool value is for recipt status
 // Err is to handle error , we need to ignore NotFound and wait for the tx
+// The receipt status is only reported once it is proven against a tracked block header
 func (acc *ETHChainDriver) VerifyReceipt(txHash TransactionHash) (VerifyReceiptStatus, error) {
 
 	result, err := acc.GetClient().TransactionReceipt(context.Background(), txHash)
@@ -241,16 +298,108 @@ func (acc *ETHChainDriver) VerifyReceipt(txHash TransactionHash) (VerifyReceiptS
 	if err != nil {
 		return Other, err
 	}
-	if result.Status == types.ReceiptStatusFailed {
+
+	headers, err := acc.syncHeaders()
+	if err != nil {
+		acc.logger.Debug("Unable to sync block headers (Connection Problem)", err)
+		return NotFound, nil
+	}
+	c, cancel := context.WithTimeout(context.Background(), 10*DefaultTimeout)
+	defer cancel()
+	_, err = headers.Confirmations(c, result.BlockNumber.Uint64(), result.BlockHash)
+	if errors.Cause(err) == ErrHeaderNotCanonical {
 		return Failed, nil
 	}
-	if result.Status == types.ReceiptStatusSuccessful {
+	if err != nil {
+		acc.logger.Debug("Waiting for the block header of the transaction", err)
+		return NotFound, nil
+	}
+	receipt, err := acc.provenReceipt(c, headers, txHash, result)
+	if err != nil {
+		acc.logger.Error("Unable to prove transaction receipt", txHash.Hex(), err)
+		return NotFound, nil
+	}
+
+	if receipt.Status == types.ReceiptStatusFailed {
+		return Failed, nil
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
 		return Found, nil
 	}
 	// Returning generic result ( no err )
 	return Other, nil
 }
 
+// syncHeaders brings the header chain followed for the driver connection up to date
+func (acc *ETHChainDriver) syncHeaders() (*HeaderChain, error) {
+	headers, err := GetHeaderChain(acc.cfg)
+	if err != nil {
+		return nil, err
+	}
+	c, cancel := context.WithTimeout(context.Background(), 10*DefaultTimeout)
+	defer cancel()
+	err = headers.Sync(c)
+	if err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// ProveReceipt builds the receipt proof of the transaction at index of a block from the rpc node,
+// nothing it returns is trusted before the proof is verified against a tracked header
+func (acc *ETHChainDriver) ProveReceipt(blockHash common.Hash, index uint) (*ReceiptProof, error) {
+	block, err := acc.GetClient().BlockByHash(context.Background(), blockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block")
+	}
+	// all the receipts of the block go in a single batch request
+	txs := block.Transactions()
+	receipts := make(types.Receipts, len(txs))
+	batch := make([]rpc.BatchElem, len(txs))
+	for i, tx := range txs {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{tx.Hash()},
+			Result: &receipts[i],
+		}
+	}
+	c, cancel := context.WithTimeout(context.Background(), 10*DefaultTimeout)
+	defer cancel()
+	err = acc.rpcClient.BatchCallContext(c, batch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the block receipts")
+	}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, errors.Wrapf(elem.Error, "failed to get receipt of %s", txs[i].Hash().Hex())
+		}
+		if receipts[i] == nil {
+			return nil, errors.Wrapf(ethereum2.NotFound, "receipt of %s", txs[i].Hash().Hex())
+		}
+	}
+	return NewReceiptProof(block.Header(), txs, receipts, index)
+}
+
+// provenReceipt verifies the receipt of a transaction against the tracked header of its block
+func (acc *ETHChainDriver) provenReceipt(ctx context.Context, headers *HeaderChain, txHash TransactionHash, result *types.Receipt) (*types.Receipt, error) {
+	header, err := headers.Ancestor(ctx, result.BlockNumber.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	proof, err := acc.ProveReceipt(header.Hash(), result.TransactionIndex)
+	if err != nil {
+		return nil, err
+	}
+	tx, receipt, err := proof.Verify(header)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Hash() != txHash {
+		return nil, errors.Wrap(ErrInvalidReceiptProof, "proven transaction is not the expected one")
+	}
+	return receipt, nil
+}
+
 // BroadcastTx takes a signed transaction as input and broadcasts it to the network
 func (acc *ETHChainDriver) BroadcastTx(tx *types.Transaction) (TransactionHash, error) {
 
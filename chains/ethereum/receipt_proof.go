@@ -0,0 +1,159 @@
+package ethereum
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidReceiptProof = errors.New("invalid receipt proof")
+
+// ReceiptProof proves a transaction and its receipt are part of a block, the transaction against
+// the transactions root and the receipt against the receipts root of the block header
+type ReceiptProof struct {
+	BlockHash    common.Hash `json:"blockHash"`
+	BlockNumber  uint64      `json:"blockNumber"`
+	TxIndex      uint        `json:"txIndex"`
+	Transaction  []byte      `json:"transaction"`
+	TxProof      [][]byte    `json:"txProof"`
+	Receipt      []byte      `json:"receipt"`
+	ReceiptProof [][]byte    `json:"receiptProof"`
+}
+
+// NewReceiptProof builds the proof of the transaction at index from all the transactions and
+// receipts of the block
+func NewReceiptProof(header *types.Header, txs types.Transactions, receipts types.Receipts, index uint) (*ReceiptProof, error) {
+	if int(index) >= len(txs) || len(txs) != len(receipts) {
+		return nil, errors.Errorf("no transaction and receipt at index %d", index)
+	}
+
+	tx, txProof, err := proveIndex(txs, index)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prove transaction")
+	}
+	receipt, receiptProof, err := proveIndex(receipts, index)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prove receipt")
+	}
+
+	return &ReceiptProof{
+		BlockHash:    header.Hash(),
+		BlockNumber:  header.Number.Uint64(),
+		TxIndex:      index,
+		Transaction:  tx,
+		TxProof:      txProof,
+		Receipt:      receipt,
+		ReceiptProof: receiptProof,
+	}, nil
+}
+
+// Verify checks the proof against a header and returns the proven transaction and receipt
+func (p *ReceiptProof) Verify(header *types.Header) (*types.Transaction, *types.Receipt, error) {
+	if header.Hash() != p.BlockHash || header.Number.Uint64() != p.BlockNumber {
+		return nil, nil, errors.Wrap(ErrInvalidReceiptProof, "header does not match the proof block")
+	}
+
+	txData, err := verifyIndex(header.TxHash, p.TxIndex, p.TxProof)
+	if err != nil {
+		return nil, nil, errors.Wrap(ErrInvalidReceiptProof, err.Error())
+	}
+	if !bytes.Equal(txData, p.Transaction) {
+		return nil, nil, errors.Wrap(ErrInvalidReceiptProof, "transaction does not match the proof")
+	}
+	receiptData, err := verifyIndex(header.ReceiptHash, p.TxIndex, p.ReceiptProof)
+	if err != nil {
+		return nil, nil, errors.Wrap(ErrInvalidReceiptProof, err.Error())
+	}
+	if !bytes.Equal(receiptData, p.Receipt) {
+		return nil, nil, errors.Wrap(ErrInvalidReceiptProof, "receipt does not match the proof")
+	}
+
+	tx := &types.Transaction{}
+	err = tx.UnmarshalBinary(txData)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode transaction")
+	}
+	receipt, err := decodeReceipt(receiptData)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode receipt")
+	}
+	receipt.TxHash = tx.Hash()
+	receipt.BlockHash = p.BlockHash
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = p.TxIndex
+	return tx, receipt, nil
+}
+
+// proveIndex builds the trie of a block list the way the block header roots are derived and
+// returns the consensus encoding of the item at index along with its proof
+func proveIndex(list types.DerivableList, index uint) ([]byte, [][]byte, error) {
+	t, err := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	if err != nil {
+		return nil, nil, err
+	}
+	var value []byte
+	buf := new(bytes.Buffer)
+	for i := 0; i < list.Len(); i++ {
+		buf.Reset()
+		list.EncodeIndex(i, buf)
+		if uint(i) == index {
+			value = common.CopyBytes(buf.Bytes())
+		}
+		t.Update(indexKey(uint(i)), common.CopyBytes(buf.Bytes()))
+	}
+
+	proofDB := memorydb.New()
+	err = t.Prove(indexKey(index), 0, proofDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := make([][]byte, 0)
+	it := proofDB.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	return value, proof, nil
+}
+
+func verifyIndex(root common.Hash, index uint, proof [][]byte) ([]byte, error) {
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		err := proofDB.Put(crypto.Keccak256(node), node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(root, indexKey(index), proofDB)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.Errorf("nothing at index %d", index)
+	}
+	return value, nil
+}
+
+func indexKey(index uint) []byte {
+	key, _ := rlp.EncodeToBytes(index)
+	return key
+}
+
+// decodeReceipt decodes the consensus encoding of a receipt, typed receipts aren't rlp lists
+func decodeReceipt(data []byte) (*types.Receipt, error) {
+	receipt := &types.Receipt{}
+	if len(data) > 0 && data[0] >= 0xc0 {
+		return receipt, rlp.DecodeBytes(data, receipt)
+	}
+	enc, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, rlp.DecodeBytes(enc, receipt)
+}
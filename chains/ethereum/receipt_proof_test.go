@@ -0,0 +1,125 @@
+package ethereum
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBlock builds a block of n signed transactions with their receipts, the odd ones typed and
+// the last one failed
+func testBlock(t *testing.T, n int) (*types.Header, types.Transactions, types.Receipts) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer := types.NewEIP2930Signer(big.NewInt(1))
+
+	txs := make(types.Transactions, 0, n)
+	receipts := make(types.Receipts, 0, n)
+	for i := 0; i < n; i++ {
+		var data types.TxData = &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &toAddress,
+			Value:    big.NewInt(int64(i)),
+			Gas:      21000,
+			GasPrice: big.NewInt(1),
+		}
+		if i%2 == 1 {
+			data = &types.AccessListTx{
+				ChainID:  big.NewInt(1),
+				Nonce:    uint64(i),
+				To:       &toAddress,
+				Value:    big.NewInt(int64(i)),
+				Gas:      21000,
+				GasPrice: big.NewInt(1),
+			}
+		}
+		tx, err := types.SignNewTx(key, signer, data)
+		assert.NoError(t, err)
+		txs = append(txs, tx)
+
+		receipt := &types.Receipt{
+			Type:              tx.Type(),
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Logs:              []*types.Log{{Address: toAddress, Topics: []common.Hash{tx.Hash()}, Data: []byte{byte(i)}}},
+		}
+		if i == n-1 {
+			receipt.Status = types.ReceiptStatusFailed
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receipts = append(receipts, receipt)
+	}
+
+	header := &types.Header{
+		Number:      big.NewInt(100),
+		Difficulty:  big.NewInt(1),
+		TxHash:      types.DeriveSha(txs, trie.NewStackTrie(nil)),
+		ReceiptHash: types.DeriveSha(receipts, trie.NewStackTrie(nil)),
+	}
+	return header, txs, receipts
+}
+
+func TestReceiptProof_Verify(t *testing.T) {
+	header, txs, receipts := testBlock(t, 140)
+
+	for _, i := range []uint{0, 1, 64, 127, 128, 139} {
+		proof, err := NewReceiptProof(header, txs, receipts, i)
+		assert.NoError(t, err)
+
+		tx, receipt, err := proof.Verify(header)
+		assert.NoError(t, err)
+		assert.Equal(t, txs[i].Hash(), tx.Hash())
+		assert.Equal(t, txs[i].Hash(), receipt.TxHash)
+		assert.Equal(t, receipts[i].Status, receipt.Status)
+		assert.Equal(t, receipts[i].CumulativeGasUsed, receipt.CumulativeGasUsed)
+		assert.Equal(t, receipts[i].Logs[0].Data, receipt.Logs[0].Data)
+	}
+
+	_, err := NewReceiptProof(header, txs, receipts, 140)
+	assert.Error(t, err)
+}
+
+func TestReceiptProof_Tampered(t *testing.T) {
+	header, txs, receipts := testBlock(t, 10)
+
+	// a provider reporting the failed transaction as successful
+	forged := *receipts[9]
+	forged.Status = types.ReceiptStatusSuccessful
+	forgedReceipts := append(append(types.Receipts{}, receipts[:9]...), &forged)
+	proof, err := NewReceiptProof(header, txs, forgedReceipts, 9)
+	assert.NoError(t, err)
+	_, _, err = proof.Verify(header)
+	assert.Error(t, err)
+
+	// proof of another block
+	proof, err = NewReceiptProof(header, txs, receipts, 3)
+	assert.NoError(t, err)
+	other := types.CopyHeader(header)
+	other.Number = big.NewInt(101)
+	_, _, err = proof.Verify(other)
+	assert.Error(t, err)
+
+	// receipt swapped with the one of another transaction
+	proof.Receipt = mustEncodeIndex(receipts, 4)
+	_, _, err = proof.Verify(header)
+	assert.Error(t, err)
+
+	// proof nodes dropped
+	proof, err = NewReceiptProof(header, txs, receipts, 3)
+	assert.NoError(t, err)
+	proof.ReceiptProof = proof.ReceiptProof[:1]
+	_, _, err = proof.Verify(header)
+	assert.Error(t, err)
+}
+
+func mustEncodeIndex(list types.DerivableList, i int) []byte {
+	buf := new(bytes.Buffer)
+	list.EncodeIndex(i, buf)
+	return buf.Bytes()
+}
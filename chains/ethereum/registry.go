@@ -0,0 +1,90 @@
+package ethereum
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// EVMChain is an evm compatible network bridged next to the default ethereum chain,
+// each one has its own lock/redeem contracts, tokens, trackers, witnesses and currency
+type EVMChain struct {
+	Name     string            `json:"name"`
+	ChainID  int64             `json:"chainId"`
+	Currency string            `json:"currency"`
+	Option   ChainDriverOption `json:"option"`
+}
+
+// EVMChainRegistry lists the evm chains bridged besides the default ethereum chain
+type EVMChainRegistry struct {
+	Chains []EVMChain `json:"chains"`
+}
+
+func (r *EVMChainRegistry) Get(chainID int64) (*EVMChain, bool) {
+	for i := range r.Chains {
+		if r.Chains[i].ChainID == chainID {
+			return &r.Chains[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r *EVMChainRegistry) GetByCurrency(currency string) (*EVMChain, bool) {
+	for i := range r.Chains {
+		if r.Chains[i].Currency == currency {
+			return &r.Chains[i], true
+		}
+	}
+	return nil, false
+}
+
+// Add appends a new chain to the registry after validating it
+func (r *EVMChainRegistry) Add(c EVMChain) error {
+	registry := EVMChainRegistry{Chains: append(append([]EVMChain{}, r.Chains...), c)}
+	err := registry.Validate()
+	if err != nil {
+		return err
+	}
+	r.Chains = registry.Chains
+	return nil
+}
+
+func (r *EVMChainRegistry) Validate() error {
+	ids := make(map[int64]bool)
+	names := make(map[string]bool)
+	currencies := make(map[string]bool)
+	for _, c := range r.Chains {
+		if c.ChainID <= 0 {
+			return errors.Errorf("invalid chain id %d of evm chain %s", c.ChainID, c.Name)
+		}
+		if c.Name == "" || c.Currency == "" {
+			return errors.Errorf("evm chain %d needs a name and a currency", c.ChainID)
+		}
+		if ids[c.ChainID] || names[c.Name] || currencies[c.Currency] {
+			return errors.Errorf("duplicate evm chain %s (%d)", c.Name, c.ChainID)
+		}
+		if c.Option.ContractAddress == (common.Address{}) {
+			return errors.Errorf("missing lock redeem contract of evm chain %s", c.Name)
+		}
+		if c.Option.BlockConfirmation <= 0 {
+			return errors.Errorf("block confirmation of evm chain %s should be positive", c.Name)
+		}
+		ids[c.ChainID] = true
+		names[c.Name] = true
+		currencies[c.Currency] = true
+	}
+	// tokens come after the chain currencies so a token can't take the currency of any chain
+	for _, c := range r.Chains {
+		tokens := make(map[common.Address]bool)
+		for _, tok := range c.Option.TokenList {
+			if tok.TokName == "" || tok.TokAddr == (common.Address{}) {
+				return errors.Errorf("token of evm chain %s needs a name and an address", c.Name)
+			}
+			if tokens[tok.TokAddr] || currencies[tok.TokName] {
+				return errors.Errorf("duplicate token %s on evm chain %s", tok.TokName, c.Name)
+			}
+			tokens[tok.TokAddr] = true
+			currencies[tok.TokName] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEVMChain(name string, chainID int64, currency string) EVMChain {
+	return EVMChain{
+		Name:     name,
+		ChainID:  chainID,
+		Currency: currency,
+		Option: ChainDriverOption{
+			ContractAddress:   common.HexToAddress("0x8ce1D5ecbD1A6a7E9E8B1bA7c9A8B6F9CF4A1C55"),
+			BlockConfirmation: 12,
+		},
+	}
+}
+
+func TestEVMChainRegistry_Add(t *testing.T) {
+	registry := EVMChainRegistry{}
+	assert.NoError(t, registry.Add(testEVMChain("Polygon", 137, "MATIC")))
+	assert.NoError(t, registry.Add(testEVMChain("BSC", 56, "BNB")))
+
+	c, ok := registry.Get(56)
+	assert.True(t, ok)
+	assert.Equal(t, "BSC", c.Name)
+	c, ok = registry.GetByCurrency("MATIC")
+	assert.True(t, ok)
+	assert.Equal(t, int64(137), c.ChainID)
+	_, ok = registry.Get(1)
+	assert.False(t, ok)
+
+	// rejected chains leave the registry untouched
+	assert.Error(t, registry.Add(testEVMChain("Polygon2", 137, "MATIC2")))
+	assert.Error(t, registry.Add(testEVMChain("BSC", 97, "TBNB")))
+	assert.Error(t, registry.Add(testEVMChain("Avalanche", 43114, "BNB")))
+	assert.Len(t, registry.Chains, 2)
+}
+
+func TestEVMChainRegistry_Validate(t *testing.T) {
+	noContract := testEVMChain("Polygon", 137, "MATIC")
+	noContract.Option.ContractAddress = common.Address{}
+	noConfirmation := testEVMChain("Polygon", 137, "MATIC")
+	noConfirmation.Option.BlockConfirmation = 0
+	token := ERC20Token{TokName: "USDT", TokAddr: common.HexToAddress("0xc2132D05D31c914a87C6611C10748AEb04B58e8F")}
+	noTokenAddress := testEVMChain("Polygon", 137, "MATIC")
+	noTokenAddress.Option.TokenList = []ERC20Token{{TokName: "USDT"}}
+	duplicateToken := testEVMChain("Polygon", 137, "MATIC")
+	duplicateToken.Option.TokenList = []ERC20Token{token, token}
+	tokenCurrency := testEVMChain("Polygon", 137, "MATIC")
+	tokenCurrency.Option.TokenList = []ERC20Token{{TokName: "MATIC", TokAddr: token.TokAddr}}
+
+	for _, c := range []EVMChain{
+		testEVMChain("Polygon", 0, "MATIC"),
+		testEVMChain("", 137, "MATIC"),
+		testEVMChain("Polygon", 137, ""),
+		noContract,
+		noConfirmation,
+		noTokenAddress,
+		duplicateToken,
+		tokenCurrency,
+	} {
+		registry := EVMChainRegistry{Chains: []EVMChain{c}}
+		assert.Error(t, registry.Validate())
+	}
+
+	withToken := testEVMChain("Polygon", 137, "MATIC")
+	withToken.Option.TokenList = []ERC20Token{token}
+	registry := EVMChainRegistry{Chains: []EVMChain{withToken}}
+	assert.NoError(t, registry.Validate())
+}
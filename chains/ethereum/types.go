@@ -46,6 +46,7 @@ const (
 	ReciptNotFound         CheckFinalityStatus = 0x05
 	TransactionNotMined    CheckFinalityStatus = 0x06
 	TXSuccess              CheckFinalityStatus = 0x07
+	ReceiptProofFailed     CheckFinalityStatus = 0x08
 )
 
 type VerifyReceiptStatus int8
@@ -81,6 +82,7 @@ type ContractType int8
 const (
 	ETH ContractType = 0x00
 	ERC ContractType = 0x01
+	NFT ContractType = 0x02
 )
 
 type Contract interface {
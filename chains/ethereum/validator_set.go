@@ -0,0 +1,83 @@
+package ethereum
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+)
+
+// UpdateValidators creates the transaction of a validator voting the validator set of the next
+// epoch of the contract, the contract hands over to it once enough validators voted the same set
+func (acc *ETHChainDriver) UpdateValidators(fromaddr common.Address, epoch int64, validators []common.Address) (*Transaction, error) {
+	c, cancel := defaultContext()
+	defer cancel()
+	nonce, err := acc.GetClient().PendingNonceAt(c, fromaddr)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := acc.GetClient().SuggestGasPrice(c)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice = big.NewInt(0).Add(gasPrice, big.NewInt(0).Div(gasPrice, big.NewInt(2)))
+	validatorSetAbi, err := abi.JSON(strings.NewReader(contract.ValidatorSetABI))
+	if err != nil {
+		return nil, err
+	}
+	bytesData, err := validatorSetAbi.Pack("updateValidators", big.NewInt(epoch), validators)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTransaction(nonce, acc.ContractAddress, big.NewInt(0), uint64(gasLimit), gasPrice, bytesData), nil
+}
+
+// ValidatorEpoch returns the epoch of the validator set of the contract
+func (acc *ETHChainDriver) ValidatorEpoch() (int64, error) {
+	out := make([]interface{}, 0)
+	bound, err := acc.bound(acc.ContractAddress, contract.ValidatorSetABI)
+	if err != nil {
+		return 0, err
+	}
+	err = bound.Call(&bind.CallOpts{}, &out, "validatorEpoch")
+	if err != nil {
+		return 0, err
+	}
+	if len(out) == 0 {
+		return 0, errors.New("empty validatorEpoch result")
+	}
+	epoch, ok := out[0].(*big.Int)
+	if !ok {
+		return 0, errors.New("invalid validatorEpoch result")
+	}
+	return epoch.Int64(), nil
+}
+
+// BridgeContract is one of the bridge contracts deployed on an evm chain
+type BridgeContract struct {
+	Type    ContractType
+	Address common.Address
+	ABI     string
+}
+
+// BridgeContracts lists the bridge contracts set in the options, each of them keeps its own
+// validator set
+func (opt *ChainDriverOption) BridgeContracts() []BridgeContract {
+	contracts := make([]BridgeContract, 0, 3)
+	if opt.ContractAddress != (common.Address{}) {
+		contracts = append(contracts, BridgeContract{Type: ETH, Address: opt.ContractAddress, ABI: opt.ContractABI})
+	}
+	if opt.ERCContractAddress != (common.Address{}) {
+		contracts = append(contracts, BridgeContract{Type: ERC, Address: opt.ERCContractAddress, ABI: opt.ERCContractABI})
+	}
+	if opt.ERC721ContractAddress != (common.Address{}) {
+		contracts = append(contracts, BridgeContract{Type: NFT, Address: opt.ERC721ContractAddress, ABI: opt.ERC721ContractABI})
+	}
+	return contracts
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+type BridgeStatusRequest struct {
+	// Chain is the name of the bridged chain, as Bitcoin, Ethereum or EVM-<chainId>
+	Chain string `json:"chain"`
+}
+
+// BridgeLimitUsage is what moved through the bridge for an asset over the window of its limit
+type BridgeLimitUsage struct {
+	Limit   bridge.Limit   `json:"limit"`
+	Inflow  balance.Amount `json:"inflow"`
+	Outflow balance.Amount `json:"outflow"`
+}
+
+type BridgeStatusReply struct {
+	Pause  bridge.Pause       `json:"pause"`
+	Limits []BridgeLimitUsage `json:"limits"`
+	Height int64              `json:"height"`
+}
+
+type TrackerHistoryRequest struct {
+	// Chain is the name of the bridged chain, as Bitcoin, Ethereum or EVM-<chainId>
+	Chain string `json:"chain"`
+	// Name is the hex name of an evm tracker or the name of a bitcoin tracker
+	Name string `json:"name"`
+}
+
+type TrackerHistoryReply struct {
+	History bridge.TrackerHistory `json:"history"`
+	Height  int64                 `json:"height"`
+}
+
+type ListTrackersRequest struct {
+	// Chain is the name of the bridged chain, all of them when empty
+	Chain string       `json:"chain"`
+	Owner keys.Address `json:"owner"`
+	State string       `json:"state"`
+	// FromHeight and ToHeight bound the heights the trackers were active between, 0 leaves them open
+	FromHeight int64 `json:"fromHeight"`
+	ToHeight   int64 `json:"toHeight"`
+}
+
+type ListTrackersReply struct {
+	Trackers []bridge.TrackerHistory `json:"trackers"`
+	Height   int64                   `json:"height"`
+}
+
+type RetryTrackerRequest struct {
+	// TrackerName is the hex name of the stuck evm tracker
+	TrackerName string `json:"trackerName"`
+	// ChainID is the evm chain of the registry the tracker is for, 0 for the default ethereum chain
+	ChainID  int64         `json:"chainId"`
+	Reason   string        `json:"reason"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type RetryTrackerReply struct {
+	RawTx     []byte           `json:"rawTx"`
+	Signature action.Signature `json:"signature"`
+}
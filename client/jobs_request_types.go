@@ -0,0 +1,39 @@
+package client
+
+import (
+	"time"
+
+	"github.com/Oneledger/protocol/data/jobs"
+)
+
+type ListJobsRequest struct {
+	// Chain is the name of the chain the jobs are for, as Bitcoin, Ethereum, EVM-<chainId> or OneLedger
+	Chain string `json:"chain"`
+	// Dead lists the dead-lettered jobs only
+	Dead bool `json:"dead"`
+}
+
+// JobInfo is a validator job of this node with its failed attempts
+type JobInfo struct {
+	JobID string `json:"jobId"`
+	Type  string `json:"type"`
+	// State is one of pending, retrying, done, failed or dead
+	State    string         `json:"state"`
+	Attempts *jobs.Attempts `json:"attempts,omitempty"`
+	// Dead is when the job was dead-lettered
+	Dead *time.Time  `json:"dead,omitempty"`
+	Job  interface{} `json:"job,omitempty"`
+}
+
+type ListJobsReply struct {
+	Jobs []JobInfo `json:"jobs"`
+}
+
+type JobRequest struct {
+	Chain string `json:"chain"`
+	JobID string `json:"jobId"`
+}
+
+type JobReply struct {
+	Job JobInfo `json:"job"`
+}
@@ -50,7 +50,8 @@ type GetDelegRewardsReply struct {
 	Balance balance.Amount                       `json:"balance"`
 	Pending []*network_delegation.PendingRewards `json:"pending"`
 	//Matured balance.Amount                       `json:"matured"`
-	Height int64 `json:"height"`
+	AutoCompound *balance.Amount `json:"autoCompound"`
+	Height       int64           `json:"height"`
 }
 
 type ListDelegationRequest struct {
@@ -109,3 +110,9 @@ type ReinvestDelegRewardsRequest struct {
 	Delegator keys.Address  `json:"delegator"`
 	Amount    action.Amount `json:"amount"`
 }
+
+type AutoCompoundDelegRewardsRequest struct {
+	Delegator keys.Address  `json:"delegator"`
+	Enable    bool          `json:"enable"`
+	MinAmount action.Amount `json:"minAmount"`
+}
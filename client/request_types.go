@@ -175,6 +175,32 @@ type WithdrawReply struct {
 	RawTx []byte `json:"rawTx"`
 }
 
+type RotateKeyRequest struct {
+	StakeAddress     keys.Address   `json:"stakeAddress"`
+	ValidatorAddress keys.Address   `json:"validatorAddress"`
+	NewPubKey        keys.PublicKey `json:"newPubKey"`
+	NewECDSAPubKey   keys.PublicKey `json:"newEcdsaPubKey"`
+}
+
+type RotateKeyReply struct {
+	RawTx []byte `json:"rawTx"`
+}
+
+// EditValidatorRequest leaves the fields that are nil unchanged
+type EditValidatorRequest struct {
+	StakeAddress     keys.Address `json:"stakeAddress"`
+	ValidatorAddress keys.Address `json:"validatorAddress"`
+	Name             *string      `json:"name,omitempty"`
+	Website          *string      `json:"website,omitempty"`
+	Contact          *string      `json:"contact,omitempty"`
+	Details          *string      `json:"details,omitempty"`
+	Logo             *string      `json:"logo,omitempty"`
+}
+
+type EditValidatorReply struct {
+	RawTx []byte `json:"rawTx"`
+}
+
 type NodeNameRequest struct{}
 type NodeNameReply struct {
 	Name string `json:"name"`
@@ -229,12 +255,25 @@ type ListValidatorsReply struct {
 	FMap   map[string]bool `json:"fmap"`
 }
 
+type ValidatorProfileHistoryRequest struct {
+	Address keys.Address `json:"address"`
+}
+type ValidatorProfileHistoryReply struct {
+	// Profile changes of the validator, oldest first
+	History []identity.ProfileRecord `json:"history"`
+	Height  int64                    `json:"height"`
+}
+
 type ListWitnessesRequest struct {
 	ChainType chain.Type `json:"chainType"`
 }
 type ListWitnessesReply struct {
 	// The list of active witnesses
 	Witnesses []keys.Address `json:"witnesses"`
+	// Epoch of the witness set, it goes up on every confirmed rotation
+	Epoch int64 `json:"epoch"`
+	// Rotation waiting for the bridge contracts to take the next witness set, if any
+	Rotation *identity.WitnessRotation `json:"rotation,omitempty"`
 	// Height at which this witness set was active
 	Height int64 `json:"height"`
 }
@@ -328,7 +367,8 @@ type BTCGetTrackerRequest struct {
 	Name string `json:"name"`
 }
 type BTCGetTrackerReply struct {
-	TrackerData string `json:"tracker"`
+	TrackerData  string `json:"tracker"`
+	BridgePaused bool   `json:"bridgePaused"`
 }
 
 type BTCRedeemRequest struct {
@@ -371,6 +411,9 @@ type MaxTrackerBalanceReply struct {
 
 type FeeOptionsReply struct {
 	FeeOption fees.FeeOption `json:"feeOption"`
+	// base fee of the next block, and the base fee market of the last one when it is on
+	BaseFee   balance.Coin   `json:"baseFee"`
+	LastBlock *fees.BlockFee `json:"lastBlock,omitempty"`
 }
 
 type ListTxTypesRequest struct{}
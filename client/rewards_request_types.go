@@ -1,8 +1,11 @@
 package client
 
 import (
+	"time"
+
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/rewards"
 )
 
 type ListRewardsRequest struct{}
@@ -47,3 +50,54 @@ type WithdrawRewardsReply struct {
 	RawTx []byte `json:"rawTx"`
 	//Signature action.Signature `json:"signature"`
 }
+
+type RewardScheduleReply struct {
+	Schedule                 rewards.Schedule `json:"schedule"`
+	BurnoutRate              balance.Amount   `json:"burnoutRate"`
+	BlockSpeedCalculateCycle int64            `json:"blockSpeedCalculateCycle"`
+	Height                   int64            `json:"height"`
+}
+
+type ProjectedAPRReply struct {
+	// yearly return in percent
+	ValidatorAPR    string         `json:"validatorAPR"`
+	DelegatorAPR    string         `json:"delegatorAPR"`
+	AnnualIssuance  balance.Amount `json:"annualIssuance"`
+	ValidatorStake  balance.Amount `json:"validatorStake"`
+	DelegationStake balance.Amount `json:"delegationStake"`
+	Height          int64          `json:"height"`
+}
+
+// reward statement entry types
+const (
+	StatementBlockReward       = "block_reward"
+	StatementDelegationReward  = "delegation_reward"
+	StatementRewardsWithdrawal = "rewards_withdrawal"
+	StatementRewardsReinvest   = "rewards_reinvest"
+	StatementRewardsCompound   = "rewards_compound"
+	StatementFee               = "fee"
+)
+
+type RewardStatementRequest struct {
+	Address keys.Address `json:"address"`
+	// inclusive height range
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+type StatementEntry struct {
+	Height int64          `json:"height"`
+	Time   time.Time      `json:"time"`
+	Type   string         `json:"type"`
+	Amount balance.Amount `json:"amount"`
+	TxHash string         `json:"txHash,omitempty"`
+}
+
+type RewardStatementReply struct {
+	Address keys.Address              `json:"address"`
+	From    int64                     `json:"from"`
+	To      int64                     `json:"to"`
+	Entries []StatementEntry          `json:"entries"`
+	Totals  map[string]balance.Amount `json:"totals"`
+	Height  int64                     `json:"height"`
+}
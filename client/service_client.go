@@ -128,6 +128,16 @@ func (c *ServiceClient) Withdraw(req WithdrawRequest) (out WithdrawReply, err er
 	return
 }
 
+func (c *ServiceClient) RotateKey(req RotateKeyRequest) (out RotateKeyReply, err error) {
+	err = c.Call("tx.RotateKey", req, &out)
+	return
+}
+
+func (c *ServiceClient) EditValidator(req EditValidatorRequest) (out EditValidatorReply, err error) {
+	err = c.Call("tx.EditValidator", req, &out)
+	return
+}
+
 /* ONS */
 func (c *ServiceClient) ONS_CreateRawCreate(req ONSCreateRequest) (out CreateTxReply, err error) {
 	err = c.Call("tx.ONS_CreateRawCreate", req, &out)
@@ -177,11 +187,56 @@ func (c *ServiceClient) ListValidators() (out ListValidatorsReply, err error) {
 	return
 }
 
+func (c *ServiceClient) ValidatorProfileHistory(req ValidatorProfileHistoryRequest) (out ValidatorProfileHistoryReply, err error) {
+	err = c.Call("query.ValidatorProfileHistory", req, &out)
+	return
+}
+
 func (c *ServiceClient) ListWitnesses(req ListWitnessesRequest) (out ListWitnessesReply, err error) {
 	err = c.Call("query.ListWitnesses", req, &out)
 	return
 }
 
+func (c *ServiceClient) GetBridgeStatus(req BridgeStatusRequest) (out BridgeStatusReply, err error) {
+	err = c.Call("query.GetBridgeStatus", req, &out)
+	return
+}
+
+func (c *ServiceClient) GetTrackerHistory(req TrackerHistoryRequest) (out TrackerHistoryReply, err error) {
+	err = c.Call("query.GetTrackerHistory", req, &out)
+	return
+}
+
+func (c *ServiceClient) ListTrackers(req ListTrackersRequest) (out ListTrackersReply, err error) {
+	err = c.Call("query.ListTrackers", req, &out)
+	return
+}
+
+func (c *ServiceClient) ListJobs(req ListJobsRequest) (out ListJobsReply, err error) {
+	err = c.Call("jobs.ListJobs", req, &out)
+	return
+}
+
+func (c *ServiceClient) GetJob(req JobRequest) (out JobReply, err error) {
+	err = c.Call("jobs.GetJob", req, &out)
+	return
+}
+
+func (c *ServiceClient) RequeueJob(req JobRequest) (out JobReply, err error) {
+	err = c.Call("jobs.RequeueJob", req, &out)
+	return
+}
+
+func (c *ServiceClient) CancelJob(req JobRequest) (out JobReply, err error) {
+	err = c.Call("jobs.CancelJob", req, &out)
+	return
+}
+
+func (c *ServiceClient) RetryTracker(req RetryTrackerRequest) (out RetryTrackerReply, err error) {
+	err = c.Call("tx.RetryTracker", req, &out)
+	return
+}
+
 func (c *ServiceClient) ListCurrencies() (out *ListCurrenciesReply, err error) {
 	err = c.Call("query.ListCurrencies", struct{}{}, &out)
 	return
@@ -202,6 +257,21 @@ func (c *ServiceClient) ListRewards(req RewardsRequest) (out *ListRewardsReply,
 	return
 }
 
+func (c *ServiceClient) GetRewardSchedule() (out RewardScheduleReply, err error) {
+	err = c.Call("query.GetRewardSchedule", struct{}{}, &out)
+	return
+}
+
+func (c *ServiceClient) GetProjectedAPR() (out ProjectedAPRReply, err error) {
+	err = c.Call("query.GetProjectedAPR", struct{}{}, &out)
+	return
+}
+
+func (c *ServiceClient) GetRewardStatement(req RewardStatementRequest) (out RewardStatementReply, err error) {
+	err = c.Call("query.GetRewardStatement", req, &out)
+	return
+}
+
 func (c *ServiceClient) WithdrawRewards(req WithdrawRewardsRequest) (out WithdrawRewardsReply, err error) {
 	err = c.Call("tx.WithdrawRewards", req, &out)
 	return
@@ -129,6 +129,19 @@ func (ctx ExtServiceContext) Block(height int64) (res *ctypes.ResultBlock) {
 	return result
 }
 
+func (ctx ExtServiceContext) BlockResults(height int64) (res *ctypes.ResultBlockResults, err error) {
+
+	h := blockHeightConvert(height)
+
+	result, err := ctx.rpcClient.BlockResults(h)
+	if err != nil {
+		logger.Error("error in getting block results at height", "height", height, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (ctx ExtServiceContext) Search(query string, prove bool, page, perPage int, orderBy string) (res *ctypes.ResultTxSearch) {
 
 	result, err := ctx.rpcClient.TxSearch(query, prove, page, perPage, orderBy)
@@ -0,0 +1,151 @@
+/*
+	Copyright 2017-2018 OneLedger
+
+	Cli to interact with a with the chain.
+*/
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+type EditValidatorArguments struct {
+	Address          []byte `json:"address"`
+	ValidatorAddress []byte `json:"validatorAddress"`
+	Name             string `json:"name"`
+	Website          string `json:"website"`
+	Contact          string `json:"contact"`
+	Details          string `json:"details"`
+	Logo             string `json:"logo"`
+	Password         string `json:"password"`
+}
+
+// ClientRequest only carries the profile fields set on the command line, the rest are kept as is
+func (args *EditValidatorArguments) ClientRequest(cmd *cobra.Command) client.EditValidatorRequest {
+	req := client.EditValidatorRequest{
+		StakeAddress:     args.Address,
+		ValidatorAddress: args.ValidatorAddress,
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		req.Name = &args.Name
+	}
+	if flags.Changed("website") {
+		req.Website = &args.Website
+	}
+	if flags.Changed("contact") {
+		req.Contact = &args.Contact
+	}
+	if flags.Changed("details") {
+		req.Details = &args.Details
+	}
+	if flags.Changed("logo") {
+		req.Logo = &args.Logo
+	}
+	return req
+}
+
+var editValidatorCmd = &cobra.Command{
+	Use:   "editvalidator",
+	Short: "Edit the name and profile of a validator",
+	RunE:  editValidator,
+}
+
+var editValidatorArgs = &EditValidatorArguments{}
+
+func setEditValidatorArgs() {
+	// Transaction Parameters
+	editValidatorCmd.Flags().BytesHexVar(&editValidatorArgs.Address, "address", []byte{}, "stake address of the validator, pays the fee")
+	editValidatorCmd.Flags().BytesHexVar(&editValidatorArgs.ValidatorAddress, "validator", []byte{}, "validator address, default to the validator of the node")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Name, "name", "", "validator name")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Website, "website", "", "validator website")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Contact, "contact", "", "validator contact")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Details, "details", "", "validator description")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Logo, "logo", "", "url of the validator logo")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Password, "password", "", "password to access secure wallet")
+}
+
+func init() {
+	DelegationCmd.AddCommand(editValidatorCmd)
+	setEditValidatorArgs()
+}
+
+func editValidator(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	ctx.logger.Debug("Have EditValidator Request", "editValidatorArgs", editValidatorArgs)
+
+	req := editValidatorArgs.ClientRequest(cmd)
+
+	//Prompt for password
+	if len(editValidatorArgs.Password) == 0 {
+		editValidatorArgs.Password = PromptForPassword()
+	}
+
+	//Create new Wallet and User Address
+	wallet, err := accounts.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+
+	//Verify User Password
+	usrAddress := keys.Address(editValidatorArgs.Address)
+	authenticated, err := wallet.VerifyPassphrase(usrAddress, editValidatorArgs.Password)
+	if !authenticated {
+		ctx.logger.Error("authentication error", err)
+		return err
+	}
+
+	// Create message
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	out, err := fullnode.EditValidator(req)
+	if err != nil {
+		ctx.logger.Error("Error in editing validator ", err.Error())
+		return err
+	}
+
+	//Sign Transaction with secure wallet
+	signedTx := &action.SignedTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(out.RawTx, signedTx)
+	if err != nil {
+		return errors.New("error de-serializing signedTx")
+	}
+
+	if !wallet.Open(usrAddress, editValidatorArgs.Password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	pub, signature, err := wallet.SignWithAddress(signedTx.RawTx.RawBytes(), usrAddress)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+
+	signedTx.Signatures = []action.Signature{{Signer: pub, Signed: signature}}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
+	if packet == nil || err != nil {
+		return errors.New("error serializing packet: " + err.Error())
+	}
+
+	result, err := ctx.clCtx.BroadcastTxSync(packet)
+	if err != nil {
+		ctx.logger.Error("error in BroadcastTxSync", err)
+	}
+
+	if BroadcastStatusSync(ctx, result) {
+		PollTxResult(ctx, result.Hash.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/client"
+)
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Manage the validator jobs of the node, needs the jobs service enabled",
+}
+
+var (
+	jobsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the jobs of a chain",
+		RunE:  listJobs,
+	}
+
+	jobsShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Print out a job with its failed attempts",
+		RunE:  showJob,
+	}
+
+	jobsRequeueCmd = &cobra.Command{
+		Use:   "requeue",
+		Short: "Take a job out of the dead-letter queue and attempt it again",
+		RunE:  requeueJob,
+	}
+
+	jobsCancelCmd = &cobra.Command{
+		Use:   "cancel",
+		Short: "Drop a job",
+		RunE:  cancelJob,
+	}
+)
+
+// Arguments to look up and manage the jobs
+type JobsArguments struct {
+	Chain string `json:"chain"`
+	JobID string `json:"jobId"`
+	Dead  bool   `json:"dead"`
+}
+
+var jobsArgs = &JobsArguments{}
+
+func init() {
+	RootCmd.AddCommand(jobsCmd)
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsShowCmd)
+	jobsCmd.AddCommand(jobsRequeueCmd)
+	jobsCmd.AddCommand(jobsCancelCmd)
+
+	jobsCmd.PersistentFlags().StringVar(&jobsArgs.Chain, "chain", "Ethereum", "chain of the jobs, Bitcoin / Ethereum / EVM-<chainId> / OneLedger")
+	jobsListCmd.Flags().BoolVar(&jobsArgs.Dead, "dead", false, "list the dead-lettered jobs only")
+	for _, cmd := range []*cobra.Command{jobsShowCmd, jobsRequeueCmd, jobsCancelCmd} {
+		cmd.Flags().StringVar(&jobsArgs.JobID, "job_id", "", "id of the job")
+	}
+}
+
+func listJobs(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	reply, err := fullnode.ListJobs(client.ListJobsRequest{
+		Chain: jobsArgs.Chain,
+		Dead:  jobsArgs.Dead,
+	})
+	if err != nil {
+		return errors.New("error in listing jobs: " + err.Error())
+	}
+
+	for _, job := range reply.Jobs {
+		printJob(job, false)
+	}
+	return nil
+}
+
+func showJob(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	reply, err := fullnode.GetJob(client.JobRequest{Chain: jobsArgs.Chain, JobID: jobsArgs.JobID})
+	if err != nil {
+		return errors.New("error in getting job: " + err.Error())
+	}
+
+	printJob(reply.Job, true)
+	return nil
+}
+
+func requeueJob(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	reply, err := fullnode.RequeueJob(client.JobRequest{Chain: jobsArgs.Chain, JobID: jobsArgs.JobID})
+	if err != nil {
+		return errors.New("error in requeuing job: " + err.Error())
+	}
+
+	printJob(reply.Job, false)
+	return nil
+}
+
+func cancelJob(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	reply, err := fullnode.CancelJob(client.JobRequest{Chain: jobsArgs.Chain, JobID: jobsArgs.JobID})
+	if err != nil {
+		return errors.New("error in cancelling job: " + err.Error())
+	}
+
+	printJob(reply.Job, false)
+	return nil
+}
+
+func printJob(job client.JobInfo, details bool) {
+	fmt.Println("Job   : ", job.JobID)
+	fmt.Println("Type  : ", job.Type)
+	fmt.Println("State : ", job.State)
+	if job.Attempts != nil {
+		fmt.Println("Failed attempts : ", job.Attempts.Failed, "since", job.Attempts.FirstFailure)
+		fmt.Println("Next attempt    : ", job.Attempts.NextAttempt)
+		fmt.Println("Last error      : ", job.Attempts.LastError)
+	}
+	if job.Dead != nil {
+		fmt.Println("Dead since      : ", *job.Dead)
+	}
+	if details && job.Job != nil {
+		data, err := json.MarshalIndent(job.Job, "", "  ")
+		if err == nil {
+			fmt.Println(string(data))
+		}
+	}
+	fmt.Println()
+}
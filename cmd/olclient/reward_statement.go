@@ -0,0 +1,111 @@
+/*
+	Copyright 2017-2018 OneLedger
+
+	Cli to interact with a with the chain.
+*/
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/balance"
+)
+
+type RewardStatementArguments struct {
+	Address []byte `json:"address"`
+	From    int64  `json:"from"`
+	To      int64  `json:"to"`
+	Format  string `json:"format"`
+}
+
+func (args *RewardStatementArguments) ClientRequest() client.RewardStatementRequest {
+	return client.RewardStatementRequest{
+		Address: args.Address,
+		From:    args.From,
+		To:      args.To,
+	}
+}
+
+var rewardStatementCmd = &cobra.Command{
+	Use:   "statement",
+	Short: "Print itemized rewards, withdrawals and fees of an address over a height range",
+	RunE:  rewardStatement,
+}
+
+var rewardStatementArgs = &RewardStatementArguments{}
+
+func setRewardStatementArgs() {
+	rewardStatementCmd.Flags().BytesHexVar(&rewardStatementArgs.Address, "address", []byte{}, "validator or delegator address")
+	rewardStatementCmd.Flags().Int64Var(&rewardStatementArgs.From, "from", 1, "first height of the statement")
+	rewardStatementCmd.Flags().Int64Var(&rewardStatementArgs.To, "to", 0, "last height of the statement, latest height if not set")
+	rewardStatementCmd.Flags().StringVar(&rewardStatementArgs.Format, "format", "csv", "output format, csv or json")
+}
+
+func init() {
+	RewardsCmd.AddCommand(rewardStatementCmd)
+	setRewardStatementArgs()
+}
+
+func rewardStatement(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+
+	if len(rewardStatementArgs.Address) == 0 {
+		return errors.New("missing address")
+	}
+	if rewardStatementArgs.Format != "csv" && rewardStatementArgs.Format != "json" {
+		return errors.Errorf("unknown format %s", rewardStatementArgs.Format)
+	}
+
+	fullnode := ctx.clCtx.FullNodeClient()
+	out, err := fullnode.GetRewardStatement(rewardStatementArgs.ClientRequest())
+	if err != nil {
+		ctx.logger.Error("error in getting reward statement", err)
+		return err
+	}
+
+	if rewardStatementArgs.Format == "json" {
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	return printStatementCSV(out)
+}
+
+func printStatementCSV(out client.RewardStatementReply) error {
+	w := csv.NewWriter(os.Stdout)
+	err := w.Write([]string{"height", "time", "type", "amount", "amount_olt", "tx_hash"})
+	if err != nil {
+		return err
+	}
+	for _, entry := range out.Entries {
+		err = w.Write([]string{
+			strconv.FormatInt(entry.Height, 10),
+			entry.Time.UTC().Format(time.RFC3339),
+			entry.Type,
+			entry.Amount.String(),
+			humanizeOLT(entry.Amount),
+			entry.TxHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func humanizeOLT(amount balance.Amount) string {
+	return balance.PrintDecimal(amount.BigInt(), 18)
+}
@@ -0,0 +1,155 @@
+/*
+	Copyright 2017-2018 OneLedger
+
+	Cli to interact with a with the chain.
+*/
+package main
+
+import (
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+type RotateKeyArguments struct {
+	Address          []byte `json:"address"`
+	ValidatorAddress []byte `json:"validatorAddress"`
+	PubKey           string `json:"pubKey"`
+	ECDSAPubKey      string `json:"ecdsaPubKey"`
+	Password         string `json:"password"`
+}
+
+func (args *RotateKeyArguments) ClientRequest() (client.RotateKeyRequest, error) {
+	req := client.RotateKeyRequest{
+		StakeAddress:     args.Address,
+		ValidatorAddress: args.ValidatorAddress,
+	}
+
+	if len(args.PubKey) > 0 {
+		data, err := base64.StdEncoding.DecodeString(args.PubKey)
+		if err != nil {
+			return req, errors.Wrap(err, "invalid consensus pubkey")
+		}
+		req.NewPubKey, err = keys.GetPublicKeyFromBytes(data, keys.ED25519)
+		if err != nil {
+			return req, errors.Wrap(err, "invalid consensus pubkey")
+		}
+	}
+
+	if len(args.ECDSAPubKey) > 0 {
+		data, err := base64.StdEncoding.DecodeString(args.ECDSAPubKey)
+		if err != nil {
+			return req, errors.Wrap(err, "invalid ecdsa pubkey")
+		}
+		req.NewECDSAPubKey, err = keys.GetPublicKeyFromBytes(data, keys.SECP256K1)
+		if err != nil {
+			return req, errors.Wrap(err, "invalid ecdsa pubkey")
+		}
+	}
+
+	return req, nil
+}
+
+var rotateKeyCmd = &cobra.Command{
+	Use:   "rotatekey",
+	Short: "Rotate the consensus and/or ECDSA key of a validator",
+	RunE:  rotateKey,
+}
+
+var rotateKeyArgs = &RotateKeyArguments{}
+
+func setRotateKeyArgs() {
+	// Transaction Parameters
+	rotateKeyCmd.Flags().BytesHexVar(&rotateKeyArgs.Address, "address", []byte{}, "stake address of the validator, pays the fee")
+	rotateKeyCmd.Flags().BytesHexVar(&rotateKeyArgs.ValidatorAddress, "validator", []byte{}, "validator address, default to the validator of the node")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyArgs.PubKey, "pubkey", "", "new base64 encoded ed25519 consensus pubkey")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyArgs.ECDSAPubKey, "ecdsa-pubkey", "", "new base64 encoded secp256k1 pubkey used for witness signing")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyArgs.Password, "password", "", "password to access secure wallet")
+}
+
+func init() {
+	DelegationCmd.AddCommand(rotateKeyCmd)
+	setRotateKeyArgs()
+}
+
+func rotateKey(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	ctx.logger.Debug("Have RotateKey Request", "rotateKeyArgs", rotateKeyArgs)
+
+	req, err := rotateKeyArgs.ClientRequest()
+	if err != nil {
+		return err
+	}
+
+	//Prompt for password
+	if len(rotateKeyArgs.Password) == 0 {
+		rotateKeyArgs.Password = PromptForPassword()
+	}
+
+	//Create new Wallet and User Address
+	wallet, err := accounts.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+
+	//Verify User Password
+	usrAddress := keys.Address(rotateKeyArgs.Address)
+	authenticated, err := wallet.VerifyPassphrase(usrAddress, rotateKeyArgs.Password)
+	if !authenticated {
+		ctx.logger.Error("authentication error", err)
+		return err
+	}
+
+	// Create message
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	out, err := fullnode.RotateKey(req)
+	if err != nil {
+		ctx.logger.Error("Error in rotating key ", err.Error())
+		return err
+	}
+
+	//Sign Transaction with secure wallet
+	signedTx := &action.SignedTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(out.RawTx, signedTx)
+	if err != nil {
+		return errors.New("error de-serializing signedTx")
+	}
+
+	if !wallet.Open(usrAddress, rotateKeyArgs.Password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	pub, signature, err := wallet.SignWithAddress(signedTx.RawTx.RawBytes(), usrAddress)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+
+	signedTx.Signatures = []action.Signature{{Signer: pub, Signed: signature}}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
+	if packet == nil || err != nil {
+		return errors.New("error serializing packet: " + err.Error())
+	}
+
+	result, err := ctx.clCtx.BroadcastTxSync(packet)
+	if err != nil {
+		ctx.logger.Error("error in BroadcastTxSync", err)
+	}
+
+	if BroadcastStatusSync(ctx, result) {
+		PollTxResult(ctx, result.Hash.String())
+	}
+
+	return nil
+}
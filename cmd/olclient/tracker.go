@@ -5,7 +5,17 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+
 	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
 )
 
 var trackerCmd = &cobra.Command{
@@ -14,17 +24,76 @@ var trackerCmd = &cobra.Command{
 	Run:   TrackerNode,
 }
 
+var (
+	trackerHistoryCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Print out the history of a bridge tracker",
+		RunE:  trackerHistory,
+	}
+
+	trackerListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List bridge trackers",
+		RunE:  listTrackers,
+	}
+
+	trackerRetryCmd = &cobra.Command{
+		Use:   "retry",
+		Short: "Retry a stuck ethereum tracker, signed by the witness of this node",
+		RunE:  retryTracker,
+	}
+)
+
 type TrackerReq struct {
 	name string
 }
 
+// Arguments to look up the trackers of the bridges
+type TrackerHistoryArguments struct {
+	Chain      string `json:"chain"`
+	Name       string `json:"name"`
+	Owner      []byte `json:"owner"`
+	State      string `json:"state"`
+	FromHeight int64  `json:"fromHeight"`
+	ToHeight   int64  `json:"toHeight"`
+}
+
+// Arguments to retry a stuck tracker
+type TrackerRetryArguments struct {
+	Name     string `json:"name"`
+	ChainID  int64  `json:"chainId"`
+	Reason   string `json:"reason"`
+	GasPrice string `json:"gasPrice"`
+	Gas      int64  `json:"gas"`
+}
+
 var trackerArgs *TrackerReq = &TrackerReq{}
+var trackerHistoryArgs = &TrackerHistoryArguments{}
+var trackerRetryArgs = &TrackerRetryArguments{}
 
 func init() {
 	RootCmd.AddCommand(trackerCmd)
+	trackerCmd.AddCommand(trackerHistoryCmd)
+	trackerCmd.AddCommand(trackerListCmd)
+	trackerCmd.AddCommand(trackerRetryCmd)
 
 	// Transaction Parameters
 	trackerCmd.Flags().StringVar(&trackerArgs.name, "tracker_name", "tracker_0", "tracker name")
+
+	trackerHistoryCmd.Flags().StringVar(&trackerHistoryArgs.Chain, "chain", "Ethereum", "bridged chain, Bitcoin / Ethereum / EVM-<chainId>")
+	trackerHistoryCmd.Flags().StringVar(&trackerHistoryArgs.Name, "tracker_name", "", "tracker name, hex for evm trackers")
+
+	trackerListCmd.Flags().StringVar(&trackerHistoryArgs.Chain, "chain", "", "bridged chain, all of them when empty")
+	trackerListCmd.Flags().BytesHexVar(&trackerHistoryArgs.Owner, "owner", []byte{}, "owner address")
+	trackerListCmd.Flags().StringVar(&trackerHistoryArgs.State, "state", "", "current tracker state")
+	trackerListCmd.Flags().Int64Var(&trackerHistoryArgs.FromHeight, "from", 0, "active from height")
+	trackerListCmd.Flags().Int64Var(&trackerHistoryArgs.ToHeight, "to", 0, "active up to height")
+
+	trackerRetryCmd.Flags().StringVar(&trackerRetryArgs.Name, "tracker_name", "", "hex name of the stuck tracker")
+	trackerRetryCmd.Flags().Int64Var(&trackerRetryArgs.ChainID, "chain_id", 0, "evm chain id of the tracker, 0 for ethereum")
+	trackerRetryCmd.Flags().StringVar(&trackerRetryArgs.Reason, "reason", "", "why the tracker is retried")
+	trackerRetryCmd.Flags().StringVar(&trackerRetryArgs.GasPrice, "gasprice", "0", "include a gas price in OLT")
+	trackerRetryCmd.Flags().Int64Var(&trackerRetryArgs.Gas, "gas", 40000, "gas limit")
 }
 
 // IssueRequest sends out a sendTx to all of the nodes in the chain
@@ -47,3 +116,124 @@ func TrackerNode(cmd *cobra.Command, args []string) {
 func printTracker(tracker string, nodeName string) {
 	logger.Infof("\n %s \n Node Name: %s \n", tracker, nodeName)
 }
+
+func trackerHistory(cmd *cobra.Command, args []string) error {
+	Ctx := NewContext()
+	fullnode := Ctx.clCtx.FullNodeClient()
+
+	reply, err := fullnode.GetTrackerHistory(client.TrackerHistoryRequest{
+		Chain: trackerHistoryArgs.Chain,
+		Name:  trackerHistoryArgs.Name,
+	})
+	if err != nil {
+		return errors.New("error in getting tracker history: " + err.Error())
+	}
+
+	printTrackerHistory(reply.History, true)
+	fmt.Println("Height: ", reply.Height)
+	return nil
+}
+
+func listTrackers(cmd *cobra.Command, args []string) error {
+	Ctx := NewContext()
+	fullnode := Ctx.clCtx.FullNodeClient()
+
+	owner := keys.Address(trackerHistoryArgs.Owner)
+	if len(trackerHistoryArgs.Owner) != 0 {
+		if err := owner.Err(); err != nil {
+			return errors.New("invalid owner address")
+		}
+	}
+	reply, err := fullnode.ListTrackers(client.ListTrackersRequest{
+		Chain:      trackerHistoryArgs.Chain,
+		Owner:      owner,
+		State:      trackerHistoryArgs.State,
+		FromHeight: trackerHistoryArgs.FromHeight,
+		ToHeight:   trackerHistoryArgs.ToHeight,
+	})
+	if err != nil {
+		return errors.New("error in listing trackers: " + err.Error())
+	}
+
+	for _, history := range reply.Trackers {
+		printTrackerHistory(history, false)
+	}
+	fmt.Println("Height: ", reply.Height)
+	return nil
+}
+
+func retryTracker(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	currencies, err := fullnode.ListCurrencies()
+	if err != nil {
+		ctx.logger.Error("failed to get currencies", err)
+		return err
+	}
+	olt, _ := currencies.Currencies.GetCurrencySet().GetCurrencyByName("OLT")
+	_, err = strconv.ParseFloat(trackerRetryArgs.GasPrice, 64)
+	if err != nil {
+		return err
+	}
+	amt := olt.NewCoinFromString(padZero(trackerRetryArgs.GasPrice)).Amount
+
+	// Witness sign Tx
+	reply, err := fullnode.RetryTracker(client.RetryTrackerRequest{
+		TrackerName: trackerRetryArgs.Name,
+		ChainID:     trackerRetryArgs.ChainID,
+		Reason:      trackerRetryArgs.Reason,
+		GasPrice:    action.Amount{Currency: "OLT", Value: *amt},
+		Gas:         trackerRetryArgs.Gas,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to create retry request", err)
+		return err
+	}
+
+	signedTx := &action.SignedTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(reply.RawTx, &signedTx.RawTx)
+	if err != nil {
+		return errors.New("error de-serializing rawTx")
+	}
+	signedTx.Signatures = []action.Signature{reply.Signature}
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
+	if packet == nil || err != nil {
+		return errors.New("error serializing packet")
+	}
+
+	// Broadcast Tx
+	result, err := ctx.clCtx.BroadcastTxSync(packet)
+	if err != nil {
+		ctx.logger.Error("error in BroadcastTxSync", err)
+	}
+
+	if BroadcastStatusSync(ctx, result) {
+		PollTxResult(ctx, result.Hash.String())
+	}
+
+	return nil
+}
+
+func printTrackerHistory(h bridge.TrackerHistory, entries bool) {
+	fmt.Println("Tracker : ", h.Name)
+	fmt.Println("Chain   : ", h.Chain.String())
+	fmt.Println("Type    : ", h.Type)
+	fmt.Println("Owner   : ", h.Owner.Humanize())
+	fmt.Println("State   : ", h.State)
+	fmt.Println("Created : ", h.Created)
+	fmt.Println("Updated : ", h.Updated)
+	if entries {
+		for _, e := range h.Entries {
+			line := fmt.Sprintf("  %d %s -> %s", e.Height, e.Event, e.State)
+			if len(e.Witness) != 0 {
+				line += " by " + e.Witness.Humanize()
+			}
+			if e.Reason != "" {
+				line += ": " + e.Reason
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/identity"
 
 	"github.com/spf13/cobra"
@@ -29,15 +30,23 @@ var validatorsetCmd = &cobra.Command{
 	Run:   ListValidator,
 }
 
+var profileHistoryAddress []byte
+
 func init() {
 	RootCmd.AddCommand(validatorsetCmd)
-
+	validatorsetCmd.Flags().BytesHexVar(&profileHistoryAddress, "profile-history", []byte{}, "print the profile changes of the validator with this address")
 }
 
 // IssueRequest sends out a sendTx to all of the nodes in the chain
 func ListValidator(cmd *cobra.Command, args []string) {
 	Ctx := NewContext()
 	fullnode := Ctx.clCtx.FullNodeClient()
+
+	if len(profileHistoryAddress) > 0 {
+		listProfileHistory(fullnode, profileHistoryAddress)
+		return
+	}
+
 	out, err := fullnode.ListValidators()
 	if err != nil {
 		logger.Error("error in getting all validators", err)
@@ -85,7 +94,32 @@ func printValidator(v identity.Validator, isActive bool, isFrozen bool) {
 	fmt.Println("Power", v.Power)
 	fmt.Println("Name", v.Name)
 	fmt.Println("Staking", v.Staking)
+	printProfile(v.Profile)
 
 	fmt.Println()
 
 }
+
+func printProfile(p identity.ValidatorProfile) {
+	fmt.Println("Website", p.Website)
+	fmt.Println("Contact", p.Contact)
+	fmt.Println("Details", p.Details)
+	fmt.Println("Logo", p.Logo)
+}
+
+func listProfileHistory(fullnode *client.ServiceClient, address []byte) {
+	out, err := fullnode.ValidatorProfileHistory(client.ValidatorProfileHistoryRequest{Address: address})
+	if err != nil {
+		logger.Error("error in getting validator profile history", err)
+		return
+	}
+
+	for _, record := range out.History {
+		fmt.Println("Changed at", record.Height)
+		fmt.Println("Name", record.Name)
+		printProfile(record.Profile)
+		fmt.Println()
+	}
+
+	fmt.Println("Height", out.Height)
+}
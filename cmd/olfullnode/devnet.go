@@ -130,6 +130,7 @@ type testnetConfig struct {
 	initialTokenHolders []string
 
 	ethUrl                   string
+	ethHeaderUrls            []string
 	deploySmartcontracts     bool
 	ethpk                    string
 	cloud                    bool
@@ -150,6 +151,7 @@ type testnetConfig struct {
 	useAsync                 bool
 	cacheSize                uint64
 	frankensteinBlock        int64
+	nativeBlock              int64
 }
 
 func init() {
@@ -165,6 +167,7 @@ func init() {
 	testnetCmd.Flags().Int64Var(&testnetArgs.totalFunds, "total_funds", 1000000000, "The total amount of tokens in circulation")
 	testnetCmd.Flags().StringSliceVar(&testnetArgs.initialTokenHolders, "initial_token_holders", []string{}, "Initial list of addresses that hold an equal share of Total funds")
 	testnetCmd.Flags().StringVar(&testnetArgs.ethUrl, "eth_rpc", "", "URL for ethereum network")
+	testnetCmd.Flags().StringSliceVar(&testnetArgs.ethHeaderUrls, "eth_header_rpc", []string{}, "URLs of independent ethereum nodes the block headers are cross checked against")
 	testnetCmd.Flags().BoolVar(&testnetArgs.deploySmartcontracts, "deploy_smart_contracts", false, "deploy eth contracts")
 	testnetCmd.Flags().StringVar(&testnetArgs.ethpk, "eth_pk", "", "ethereum test private key")
 	testnetCmd.Flags().BoolVar(&testnetArgs.cloud, "cloud_deploy", false, "set true for deploying on cloud")
@@ -185,6 +188,7 @@ func init() {
 	testnetCmd.Flags().BoolVar(&testnetArgs.useAsync, "use_async", false, "async mode for olvm send transaction")
 	testnetCmd.Flags().Uint64Var(&testnetArgs.cacheSize, "cache_size", 10000, "cache size for mempool")
 	testnetCmd.Flags().Int64Var(&testnetArgs.frankensteinBlock, "frankenstein_block", 1, "Fork block for frankenstein update")
+	testnetCmd.Flags().Int64Var(&testnetArgs.nativeBlock, "native_block", 1, "Fork block for the native contracts, 0 disables them")
 }
 
 func randStr(size int) string {
@@ -377,7 +381,7 @@ func runDevnet(_ *cobra.Command, _ []string) error {
 		// Generate new configuration file
 		cfg := config.DefaultServerConfig()
 
-		ethConnection := config.EthereumChainDriverConfig{Connection: url}
+		ethConnection := config.EthereumChainDriverConfig{Connection: url, HeaderConnections: args.ethHeaderUrls}
 		cfg.EthChainDriver = &ethConnection
 		cfg.Node.NodeName = nodeName
 		cfg.Node.LogLevel = args.loglevel
@@ -407,9 +411,7 @@ func runDevnet(_ *cobra.Command, _ []string) error {
 		cfg.Node.UseAsync = args.useAsync
 
 		if args.archiveNode {
-			cfg.Node.ChainStateRotation.Recent = 0
-			cfg.Node.ChainStateRotation.Every = 1
-			cfg.Node.ChainStateRotation.Cycles = 0
+			cfg.Node.ChainStateRotation.Archive = true
 		}
 
 		dirs := []string{configDir, dataDir, nodeDataDir}
@@ -513,6 +515,7 @@ func runDevnet(_ *cobra.Command, _ []string) error {
 		totalBTCSupply,
 		lockBalanceAddress,
 		btcBlockConfirmation,
+		false,
 	}
 	proposalFundingDeadline = args.fundingDeadline
 	proposalVotingDeadline = args.votingDeadline
@@ -570,6 +573,7 @@ func runDevnet(_ *cobra.Command, _ []string) error {
 
 	genesisDoc.ForkParams = &config.ForkParams{
 		FrankensteinBlock: args.frankensteinBlock,
+		NativeBlock:       args.nativeBlock,
 	}
 
 	for i := 0; i < totalNodes; i++ {
@@ -880,6 +884,10 @@ func deployethcdcontract(conn string, nodeList []node, ethprivKey string) (*ethc
 	auth.Nonce = big.NewInt(int64(nonce))
 
 	num_of_validators := big.NewInt(1)
+	err = ethchain.CheckContractBin(contract.LockRedeemBin, contract.LockRedeemABI, "updateValidators", "validatorEpoch")
+	if err != nil {
+		return nil, errors.Wrap(err, "Eth LockRedeem")
+	}
 	address, _, _, err := contract.DeployLockRedeem(auth, cli, initialValidatorList, lock_period, fromAddress, num_of_validators)
 	if err != nil {
 		return nil, errors.Wrap(err, "Deployement Eth LockRedeem")
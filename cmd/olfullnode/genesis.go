@@ -51,8 +51,10 @@ type genesisArgument struct {
 
 	// fork
 	frankensteinBlock int64
+	nativeBlock       int64
 
 	ethUrl               string
+	ethHeaderUrls        []string
 	deploySmartcontracts bool
 	cloud                bool
 	loglevel             int
@@ -88,12 +90,14 @@ func init() {
 	//genesisCmd.Flags().StringVar(&genesisCmdArgs.namesPath, "names", "", "Specify a path to a file containing a list of names separated by newlines if you want the nodes to be generated with human-readable names")
 	// 1 billion by default
 	genesisCmd.Flags().StringVar(&genesisCmdArgs.ethUrl, "eth_rpc", "HTTP://127.0.0.1:7545", "Specify a path to a file containing a list of names separated by newlines if you want the nodes to be generated with human-readable names")
+	genesisCmd.Flags().StringSliceVar(&genesisCmdArgs.ethHeaderUrls, "eth_header_rpc", []string{}, "URLs of independent ethereum nodes the block headers are cross checked against")
 	genesisCmd.Flags().IntVar(&genesisCmdArgs.loglevel, "loglevel", 3, "Specify the log level for olfullnode. 0: Fatal, 1: Error, 2: Warning, 3: Info, 4: Debug, 5: Detail")
 	genesisCmd.Flags().Int64Var(&genesisCmdArgs.totalFunds, "total_funds", 400000000, "The total amount of tokens in circulation")
 	genesisCmd.Flags().StringSliceVar(&genesisCmdArgs.initialTokenHolders, "initial_token_holders", []string{}, "Initial list of addresses that hold an equal share of Total funds")
 	genesisCmd.Flags().BoolVar(&genesisCmdArgs.deploySmartcontracts, "deploy_smart_contracts", false, "deploy eth contracts")
 	// fork
 	genesisCmd.Flags().Int64Var(&genesisCmdArgs.frankensteinBlock, "frankenstein_block", 1, "Fork block for frankenstein update")
+	genesisCmd.Flags().Int64Var(&genesisCmdArgs.nativeBlock, "native_block", 1, "Fork block for the native contracts, 0 disables them")
 }
 
 func newMainetContext(args *genesisArgument) (*mainetContext, error) {
@@ -191,7 +195,7 @@ func runGenesis(_ *cobra.Command, _ []string) error {
 		// Generate new configuration file
 		cfg := config.DefaultServerConfig()
 
-		ethConnection := config.EthereumChainDriverConfig{Connection: url}
+		ethConnection := config.EthereumChainDriverConfig{Connection: url, HeaderConnections: args.ethHeaderUrls}
 		cfg.EthChainDriver = &ethConnection
 		cfg.Node.NodeName = nodeName
 		cfg.Node.LogLevel = args.loglevel
@@ -262,6 +266,7 @@ func runGenesis(_ *cobra.Command, _ []string) error {
 	genesisDoc.Validators = validatorList
 	genesisDoc.ForkParams = &config.ForkParams{
 		FrankensteinBlock: genesisCmdArgs.frankensteinBlock,
+		NativeBlock:       genesisCmdArgs.nativeBlock,
 	}
 
 	for _, nodeName := range ctx.names {
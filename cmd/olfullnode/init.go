@@ -337,6 +337,10 @@ func getEthOpt(conn string, nodeList []node) (*ethchain.ChainDriverOption, error
 	auth.Nonce = big.NewInt(int64(nonce))
 	oldaddress := common.Address{}
 	num_of_validators := big.NewInt(8)
+	err = ethchain.CheckContractBin(contract.LockRedeemBin, contract.LockRedeemABI, "updateValidators", "validatorEpoch")
+	if err != nil {
+		return nil, errors.Wrap(err, "Eth LockRedeem")
+	}
 	address, _, _, err := contract.DeployLockRedeem(auth, cli, initialValidatorList, lock_period, oldaddress, num_of_validators)
 	if err != nil {
 		return nil, errors.Wrap(err, "Deployement Eth LockRedeem")
@@ -378,5 +382,6 @@ func getBtcOpt() bitcoin.ChainDriverOption {
 		totalBTCSupply,
 		lockBalanceAddress,
 		btcBlockConfirmation,
+		false,
 	}
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+	"github.com/Oneledger/protocol/web3/bloombits"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var rebuildBloomBitsCmd = &cobra.Command{
+	Use:   "rebuild-bloombits",
+	Short: "Rebuild the bloom bits index of the logs, the node has to be stopped",
+	RunE:  rebuildBloomBits,
+}
+
+func init() {
+	nodeCmd.AddCommand(rebuildBloomBitsCmd)
+}
+
+// rebuildBloomBits drops the indexed sections and indexes all the committed blocks again
+func rebuildBloomBits(cmd *cobra.Command, args []string) error {
+	ctx := nodeCtx
+	err := ctx.init(rootArgs.rootDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize config")
+	}
+
+	tmcfg := ctx.cfg.TMConfig()
+	backend := dbm.BackendType(tmcfg.DBBackend)
+	blockStoreDB := dbm.NewDB("blockstore", backend, tmcfg.DBDir())
+	defer blockStoreDB.Close()
+	stateDB := dbm.NewDB("state", backend, tmcfg.DBDir())
+	defer stateDB.Close()
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	db, err := storage.GetDatabase(bloombits.DBName, filepath.Join(ctx.cfg.RootDir(), ctx.cfg.Node.DBDir), ctx.cfg.Node.DB)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the bloom bits db")
+	}
+	defer db.Close()
+
+	head := func() int64 {
+		return blockStore.Height()
+	}
+	bloomAt := func(height int64) (vm.Bloom, error) {
+		responses, err := sm.LoadABCIResponses(stateDB, height)
+		if err != nil {
+			return vm.Bloom{}, err
+		}
+		if responses.EndBlock == nil {
+			return vm.Bloom{}, nil
+		}
+		return rpctypes.GetBlockBloom(responses.EndBlock.Events), nil
+	}
+	indexer, err := bloombits.NewIndexer(db, head, bloomAt)
+	if err != nil {
+		return err
+	}
+	err = indexer.Reset()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop the indexed sections")
+	}
+
+	ctx.logger.Infof("indexing the bloom bits of %d blocks", blockStore.Height())
+	err = indexer.Index()
+	if err != nil {
+		return err
+	}
+	ctx.logger.Infof("indexed %d sections of %d blocks", indexer.Sections(), bloombits.SectionSize)
+	return nil
+}
@@ -70,6 +70,7 @@ type publicKey struct {
 
 type ForkParams struct {
 	FrankensteinBlock string `json:"frankensteinBlock"`
+	NativeBlock       string `json:"nativeBlock"`
 }
 
 type GenesisValidator struct {
@@ -254,6 +255,8 @@ func writeListWithTag(ctx app.StorageCtx, writer io.Writer, tag string) bool {
 		DumpDomainToFile(ctx.Domains, ctx.Version, writer, writeStruct)
 	case "trackers":
 		DumpTrackerToFile(ctx.Trackers, writer, writeStruct)
+	case "nfts":
+		DumpNFTsToFile(ctx.NFTs, writer, writeStruct)
 	case "proposals":
 		DumpGovProposalsToFile(ctx.ProposalMaster, writer, writeStruct)
 	case "fees":
@@ -340,6 +343,7 @@ func SaveChainState(application *app.App, filename string, directory string) err
 
 	writeStructWithTag(writer, ForkParams{
 		FrankensteinBlock: strconv.Itoa(int(genesisDoc.ForkParams.FrankensteinBlock)),
+		NativeBlock:       strconv.Itoa(int(genesisDoc.ForkParams.NativeBlock)),
 	}, "fork")
 
 	for jsonDecoder.More() {
@@ -369,6 +373,7 @@ func SaveChainState(application *app.App, filename string, directory string) err
 	writeStoreWithTag(ctx, writer, "rewards")
 	writeListWithTag(ctx, writer, "domains")
 	writeListWithTag(ctx, writer, "trackers")
+	writeListWithTag(ctx, writer, "nfts")
 	writeListWithTag(ctx, writer, "proposals")
 	writeCustomStructWithTag(ctx, writer, "net_delegators")
 	writeStoreWithTag(ctx, writer, "delegator_rewards")
@@ -527,6 +532,7 @@ func DumpTrackerToFile(ts *ethereum.TrackerStore, writer io.Writer, fn func(writ
 			trackerState.TrackerName = tracker.TrackerName
 			trackerState.Witnesses = tracker.Witnesses
 			trackerState.To = tracker.To
+			trackerState.TokenURI = tracker.TokenURI
 
 			fn(writer, trackerState)
 			iterator++
@@ -536,6 +542,26 @@ func DumpTrackerToFile(ts *ethereum.TrackerStore, writer io.Writer, fn func(writ
 	}
 }
 
+func DumpNFTsToFile(ns *ethereum.NFTStore, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+
+	ns.Iterate(func(nft *ethereum.NFT) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		if !fn(writer, *nft) {
+			return true
+		}
+		iterator++
+		return false
+	})
+}
+
 func GetGovernance(gs *governance.Store) *governance.GovernanceState {
 	btcOption, err := gs.GetBTCChainDriverOption()
 	if err != nil {
@@ -548,6 +574,16 @@ func GetGovernance(gs *governance.Store) *governance.GovernanceState {
 		fmt.Print("Error Reading ETH chain driver options: ", err)
 		return nil
 	}
+	evmChains, err := gs.GetEVMChainRegistry()
+	if err != nil {
+		fmt.Print("Error Reading EVM chain registry: ", err)
+		return nil
+	}
+	bridgeOption, err := gs.GetBridgeOptions()
+	if err != nil {
+		fmt.Print("Error Reading bridge options: ", err)
+		return nil
+	}
 	onsOption, err := gs.GetONSOptions()
 	if err != nil {
 		fmt.Print("Error Reading ONS Domain options: ", err)
@@ -584,7 +620,9 @@ func GetGovernance(gs *governance.Store) *governance.GovernanceState {
 	return &governance.GovernanceState{
 		FeeOption:       *feeOption,
 		ETHCDOption:     *ethOption,
+		EVMChains:       *evmChains,
 		BTCCDOption:     *btcOption,
+		BridgeOptions:   *bridgeOption,
 		ONSOptions:      *onsOption,
 		PropOptions:     *proposalOptions,
 		StakingOptions:  *stakingOptions,
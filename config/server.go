@@ -187,7 +187,7 @@ type NodeConfig struct {
 	IndexAllTags bool `toml:"index_all_tags" desc:"Tells the indexer to index all available tags, IndexTags has precedence over IndexAllTags"`
 
 	//rpc package
-	Services []string `toml:"services" desc:"List of services used by the current Node. Possible valued [broadcast, node, owner, query, tx]"`
+	Services []string `toml:"services" desc:"List of services used by the current Node. Possible valued [broadcast, node, owner, query, tx, eth, jobs]"`
 
 	Auth Authorisation `toml:"Auth" desc:"the OwnerCredentials and RPCPrivateKey should be configured together"`
 
@@ -200,6 +200,9 @@ type Authorisation struct {
 
 	//Private key for RPC Authentication
 	RPCPrivateKey string `toml:"rpc_private_key" desc:"(ED25519 key) This private key will be used to generate a token for authentication through RPC Port; if not configured, anyone can access the SDK rpc port without authentication"`
+
+	//Node accounts over web3
+	Web3Accounts bool `toml:"web3_accounts" desc:"Allow the web3 personal namespace, eth_sendTransaction and eth_sign to use the accounts of the node keystore. Calls must pass the token generated at /token in the Authorization header of the HTTP request, the node does not start without the RPCPrivateKey (default: false)"`
 }
 
 type ChainStateRotationCfg struct {
@@ -220,6 +223,9 @@ type ChainStateRotationCfg struct {
 	// cycles = 1 : only keep one of latest every
 	// cycles = 0 : keep every "every"
 	Cycles int64
+
+	// "archive" : keep every version for the historical queries, the other settings are ignored
+	Archive bool
 }
 
 func DefaultNodeConfig() *NodeConfig {
@@ -234,6 +240,7 @@ func DefaultNodeConfig() *NodeConfig {
 		Auth: Authorisation{
 			OwnerCredentials: []string{},
 			RPCPrivateKey:    "",
+			Web3Accounts:     false,
 		},
 
 		ChainStateRotation: ChainStateRotationCfg{
@@ -289,9 +296,30 @@ type WSConfig struct {
 	Origins []string `toml:"origins" desc:"Origins from which to accept websockets requests"`
 }
 
+type FilterConfig struct {
+	BloomIndex    bool  `toml:"bloom_index" desc:"Index the log blooms of the blocks in sections, so the log filters over large ranges only read the matching blocks (default: false)"`
+	MaxBlockRange int64 `toml:"max_block_range" desc:"Maximum number of blocks a log filter can query at once, 0 for no limit (default: 0)"`
+}
+
 type APIConfig struct {
-	HTTPConfig *HTTPConfig `toml:"http"`
-	WSConfig   *WSConfig   `toml:"ws"`
+	HTTPConfig   *HTTPConfig   `toml:"http"`
+	WSConfig     *WSConfig     `toml:"ws"`
+	FilterConfig *FilterConfig `toml:"filter"`
+}
+
+// Filter returns the log filter config, the default one for the configs written before it
+func (cfg *APIConfig) Filter() *FilterConfig {
+	if cfg.FilterConfig == nil {
+		return DefaultFilterConfig()
+	}
+	return cfg.FilterConfig
+}
+
+func DefaultFilterConfig() *FilterConfig {
+	return &FilterConfig{
+		BloomIndex:    false,
+		MaxBlockRange: 0,
+	}
 }
 
 func DefaultAPIConfig() *APIConfig {
@@ -310,6 +338,7 @@ func DefaultAPIConfig() *APIConfig {
 			API:     []string{"eth", "web3", "net"},
 			Origins: make([]string, 0),
 		},
+		FilterConfig: DefaultFilterConfig(),
 	}
 }
 
@@ -490,17 +519,49 @@ type ChainDriverConfig struct {
 	BitcoinRPCUsername string `toml:"bitcoin_rpc_username" desc:"rpc username of bitcoin node"`
 	BitcoinRPCPassword string `toml:"bitcoin_rpc_password" desc:"rpc password of bitcoin node"`
 
-	BlockCypherToken string `toml:"blockcypher_token" desc:"token to use blockcypher APIs"`
+	BitcoinBackend   string `toml:"bitcoin_backend" desc:"backend of the bitcoin bridge, bitcoind (default), blockcypher or mock (in-process, for testing)"`
+	BlockCypherToken string `toml:"blockcypher_token" desc:"token to use blockcypher APIs, only needed with the blockcypher backend"`
 }
 
 type EthereumChainDriverConfig struct {
-	Connection string `toml:"connection" desc:"ethereum node connection url default: http://localhost:7545"`
+	Connection        string               `toml:"connection" desc:"ethereum node connection url default: http://localhost:7545, mock for an in-process chain (testing)"`
+	Chains            []EVMChainConnection `toml:"chains" desc:"node connections of the evm chains in the bridge registry"`
+	HeaderConnections []string             `toml:"header_connections" desc:"independent ethereum node urls every block header is cross checked against, a witness needs at least one"`
+	CheckpointNumber  uint64               `toml:"checkpoint_number" desc:"number of a recent ethereum block trusted to start following the headers from"`
+	CheckpointHash    string               `toml:"checkpoint_hash" desc:"hash of the checkpoint block, no header below it is trusted"`
+}
+
+type EVMChainConnection struct {
+	ChainID           int64    `toml:"chain_id" desc:"chain id of the evm chain"`
+	Connection        string   `toml:"connection" desc:"evm node connection url"`
+	HeaderConnections []string `toml:"header_connections" desc:"independent evm node urls every block header is cross checked against, a witness needs at least one"`
+	CheckpointNumber  uint64   `toml:"checkpoint_number" desc:"number of a recent block trusted to start following the headers from"`
+	CheckpointHash    string   `toml:"checkpoint_hash" desc:"hash of the checkpoint block, no header below it is trusted"`
+}
+
+// ForChain returns the driver config to reach the given evm chain, chain id 0 is the default ethereum chain
+func (cfg *EthereumChainDriverConfig) ForChain(chainID int64) (*EthereumChainDriverConfig, error) {
+	if chainID == 0 {
+		return cfg, nil
+	}
+	for _, c := range cfg.Chains {
+		if c.ChainID == chainID {
+			return &EthereumChainDriverConfig{
+				Connection:        c.Connection,
+				HeaderConnections: c.HeaderConnections,
+				CheckpointNumber:  c.CheckpointNumber,
+				CheckpointHash:    c.CheckpointHash,
+			}, nil
+		}
+	}
+	return nil, errors.Errorf("no connection configured for evm chain %d", chainID)
 }
 
 func DefaultChainDriverConfig() *ChainDriverConfig {
 
 	var cfg ChainDriverConfig
 	cfg.BitcoinChainType = ""
+	cfg.BitcoinBackend = "bitcoind"
 	cfg.BlockCypherToken = ""
 	cfg.BitcoinNodeAddress = ""
 	cfg.BitcoinRPCPort = "18332"
@@ -118,12 +118,14 @@ func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
 // ForkParams determine the fork blocks number where to apply the global update for network
 type ForkParams struct {
 	FrankensteinBlock int64 `json:"frankensteinBlock"`
+	NativeBlock       int64 `json:"nativeBlock"`
 }
 
 // DefaultForkParams initial config
 func DefaultForkParams() *ForkParams {
 	return &ForkParams{
 		FrankensteinBlock: 1, // 0 means disabled as tendermint blocks started from 1
+		NativeBlock:       0, // chains started before the native contracts keep them disabled
 	}
 }
 
@@ -148,6 +150,11 @@ func (f *ForkParams) IsFrankensteinUpdate(height int64) bool {
 	return f.FrankensteinBlock != 0 && f.FrankensteinBlock <= height
 }
 
+// IsNativeUpdate check if fork update arrived to run the native contracts after specific block
+func (f *ForkParams) IsNativeUpdate(height int64) bool {
+	return f.NativeBlock != 0 && f.NativeBlock <= height
+}
+
 // Validate validates the ForkParams to ensure all values are within their
 // allowed limits, and returns an error if they are not.
 func (f *ForkParams) Validate() error {
@@ -69,6 +69,7 @@ type Tracker struct {
 	ProcessOwner  keys.Address         `json:"processOwner"`
 	FinalityVotes []ethData.Vote       `json:"finalityVotes"`
 	To            []byte               `json:"to"`
+	TokenURI      string               `json:"tokenUri"`
 }
 
 type ChainState struct {
@@ -89,6 +90,7 @@ type AppState struct {
 	Rewards       rewards.RewardMasterState      `json:"rewards"`
 	Domains       []DomainState                  `json:"domains"`
 	Trackers      []Tracker                      `json:"trackers"`
+	NFTs          []ethData.NFT                  `json:"nfts"`
 	Fees          []BalanceState                 `json:"fees"`
 	Proposals     []governance.GovProposal       `json:"proposals"`
 	NetDelegators network_delegation.State       `json:"net_delegators"`
@@ -81,6 +81,14 @@ type AccountKeeper interface {
 
 var _ AccountKeeper = (*NesterAccountKeeper)(nil)
 
+const accountKeeperPrefix = "keeper"
+
+// AccountStoreKey returns the key the account of the address is kept under, its balance is kept in the
+// balance store
+func AccountStoreKey(addr keys.Address) storage.StoreKey {
+	return storage.StoreKey(append(storage.Prefix(accountKeeperPrefix), addr.Bytes()...))
+}
+
 // NesterAccountKeeper is used to combine two stores - balance and nonces
 type NesterAccountKeeper struct {
 	balances   *Store
@@ -101,7 +109,7 @@ func NewNesterAccountKeeper(state *storage.State, balances *Store, currencies *C
 		balances:   balances,
 		currencies: currencies,
 		state:      state,
-		prefix:     storage.Prefix("keeper"),
+		prefix:     storage.Prefix(accountKeeperPrefix),
 		logger:     log.NewLoggerWithPrefix(os.Stdout, "account_keeper"),
 	}
 }
@@ -21,3 +21,25 @@ const (
 	REPORT_BROADCAST     = "reportBroadcastSuccess"
 	CLEANUP              = "cleanup"
 )
+
+func (s TrackerState) String() string {
+	switch s {
+	case Available:
+		return "Available"
+	case Requested:
+		return "Requested"
+	case BusySigning:
+		return "BusySigning"
+	case BusyScheduleBroadcasting:
+		return "BusyScheduleBroadcasting"
+	case BusyBroadcasting:
+		return "BusyBroadcasting"
+	case BusyScheduleFinalizing:
+		return "BusyScheduleFinalizing"
+	case BusyFinalizing:
+		return "BusyFinalizing"
+	case Finalized:
+		return "Finalized"
+	}
+	return "Unknown"
+}
@@ -220,6 +220,7 @@ type BTCConfig struct {
 
 	BTCParams *chaincfg.Params
 
+	BTCBackend           string
 	BlockCypherToken     string
 	BlockCypherChainType string
 }
@@ -233,7 +234,20 @@ func NewBTCConfig(cfg *config.ChainDriverConfig, chainType string) BTCConfig {
 		cfg.BitcoinRPCPassword,
 		chainType,
 		bitcoin.GetChainParams(chainType),
+		cfg.BitcoinBackend,
 		cfg.BlockCypherToken,
 		bitcoin.GetBlockCypherChainType(chainType),
 	}
 }
+
+// Backend returns the bitcoin backend the node is configured with
+func (cfg BTCConfig) Backend() (bitcoin.Backend, error) {
+	return bitcoin.GetBackend(bitcoin.BackendConfig{
+		Backend:          cfg.BTCBackend,
+		Host:             cfg.BTCAddress + ":" + cfg.BTCRPCPort,
+		User:             cfg.BTCRPCUsername,
+		Password:         cfg.BTCRPCPassword,
+		BlockCypherToken: cfg.BlockCypherToken,
+		BlockCypherChain: cfg.BlockCypherChainType,
+	})
+}
@@ -24,6 +24,9 @@ const (
 	ProcessTypeNone   = 0x00
 	ProcessTypeLock   = 0x01
 	ProcessTypeRedeem = 0x02
+
+	// ProcessTypeMigrate sweeps the tracker balance to a new lock address of the validators
+	ProcessTypeMigrate = 0x03
 )
 
 var NilTxHash *chainhash.Hash
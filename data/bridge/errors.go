@@ -0,0 +1,16 @@
+package bridge
+
+import (
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+var (
+	ErrPaused           = codes.ProtocolError{Code: codes.BridgeErrPaused, Msg: "bridge is paused"}
+	ErrLimitExceeded    = codes.ProtocolError{Code: codes.BridgeErrLimitExceeded, Msg: "bridge flow limit exceeded"}
+	ErrTxLimitExceeded  = codes.ProtocolError{Code: codes.BridgeErrTxLimitExceeded, Msg: "bridge transaction limit exceeded"}
+	ErrInvalidOptions   = codes.ProtocolError{Code: codes.BridgeErrInvalidOptions, Msg: "invalid bridge options"}
+	ErrNotPauseVoter    = codes.ProtocolError{Code: codes.BridgeErrNotPauseVoter, Msg: "signer can't vote to pause the bridge"}
+	ErrUnableToSetPause = codes.ProtocolError{Code: codes.BridgeErrUnableToSetPause, Msg: "unable to set bridge pause"}
+	ErrNotRotationVoter = codes.ProtocolError{Code: codes.BridgeErrNotRotationVoter, Msg: "signer can't vote on the witness rotation"}
+	ErrUnableToRotate   = codes.ProtocolError{Code: codes.BridgeErrUnableToRotate, Msg: "unable to rotate the bridge witnesses"}
+)
@@ -0,0 +1,181 @@
+package bridge
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	// EventOpened starts the history of a tracker, with a new lock or redeem
+	EventOpened = "opened"
+	// EventVoteYes and EventVoteNo are the finality votes of the witnesses, the reason tells
+	// why a witness voted no
+	EventVoteYes = "vote_yes"
+	EventVoteNo  = "vote_no"
+	// EventRetry is the vote of a witness to step a stuck tracker back
+	EventRetry = "retry"
+	// EventReset is a failed broadcast of a bitcoin tracker being reset
+	EventReset = "reset"
+)
+
+// HistoryEntry is a step in the life of a tracker
+type HistoryEntry struct {
+	Height int64  `json:"height"`
+	Event  string `json:"event"`
+	State  string `json:"state"`
+	// Witness is the witness behind the step, empty for the steps taken by the block ender
+	Witness keys.Address `json:"witness,omitempty"`
+	Reason  string       `json:"reason,omitempty"`
+}
+
+// TrackerHistory keeps what happened to a tracker of a bridge, it outlives the tracker once
+// it is cleaned up after finalization or failure
+type TrackerHistory struct {
+	Chain   chain.Type     `json:"chain"`
+	Name    string         `json:"name"`
+	Type    string         `json:"type"`
+	Owner   keys.Address   `json:"owner"`
+	State   string         `json:"state"`
+	Created int64          `json:"created"`
+	Updated int64          `json:"updated"`
+	Entries []HistoryEntry `json:"entries"`
+}
+
+func (s *Store) historyKey(c chain.Type, name string) storage.StoreKey {
+	return storage.StoreKey(string(s.historyPrefix()) + strconv.Itoa(int(c)) + storage.DB_PREFIX + name)
+}
+
+func (s *Store) historyPrefix() []byte {
+	return []byte(string(s.prefix) + "history" + storage.DB_PREFIX)
+}
+
+func (s *Store) GetHistory(c chain.Type, name string) (*TrackerHistory, error) {
+	history, err := s.getHistory(c, name)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		return nil, errors.Errorf("no history for tracker %s of %s", name, c)
+	}
+	return history, nil
+}
+
+// getHistory returns the history of a tracker, nil when it has none
+func (s *Store) getHistory(c chain.Type, name string) (*TrackerHistory, error) {
+	data, err := s.State.Get(s.historyKey(c, name))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	history := &TrackerHistory{}
+	err = s.szlr.Deserialize(data, history)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize tracker history")
+	}
+	return history, nil
+}
+
+func (s *Store) setHistory(history *TrackerHistory) error {
+	data, err := s.szlr.Serialize(history)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize tracker history")
+	}
+	return s.State.Set(s.historyKey(history.Chain, history.Name), data)
+}
+
+// OpenHistory starts the history of a tracker taking a new lock or redeem. Bitcoin trackers are
+// reused, their history goes on with the new owner
+func (s *Store) OpenHistory(c chain.Type, name, typ string, owner keys.Address, state string, height int64) error {
+	history, err := s.getHistory(c, name)
+	if err != nil {
+		return err
+	}
+	if history == nil {
+		history = &TrackerHistory{
+			Chain:   c,
+			Name:    name,
+			Created: height,
+			Entries: []HistoryEntry{},
+		}
+	}
+	history.Type = typ
+	history.Owner = owner
+	return s.record(history, HistoryEntry{Height: height, Event: EventOpened, State: state})
+}
+
+// RecordHistory adds a step to the history of a tracker, trackers opened before the history was
+// kept get one on their first step
+func (s *Store) RecordHistory(c chain.Type, name string, entry HistoryEntry) error {
+	history, err := s.getHistory(c, name)
+	if err != nil {
+		return err
+	}
+	if history == nil {
+		history = &TrackerHistory{
+			Chain:   c,
+			Name:    name,
+			Created: entry.Height,
+			Entries: []HistoryEntry{},
+		}
+	}
+	return s.record(history, entry)
+}
+
+func (s *Store) record(history *TrackerHistory, entry HistoryEntry) error {
+	history.State = entry.State
+	history.Updated = entry.Height
+	history.Entries = append(history.Entries, entry)
+	return s.setHistory(history)
+}
+
+// IterateHistory goes through the tracker histories of all the bridges
+func (s *Store) IterateHistory(fn func(history *TrackerHistory) bool) (stopped bool) {
+	prefix := s.historyPrefix()
+	return s.State.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			history := &TrackerHistory{}
+			err := s.szlr.Deserialize(value, history)
+			if err != nil {
+				return false
+			}
+			return fn(history)
+		},
+	)
+}
+
+// FilterHistory lists the tracker histories of a chain, or of all of them for a negative chain
+// type, by owner, current state and the heights they were updated between. Empty filters and a
+// zero height match all
+func (s *Store) FilterHistory(c chain.Type, owner keys.Address, state string, from, to int64) []TrackerHistory {
+	histories := make([]TrackerHistory, 0)
+	s.IterateHistory(func(history *TrackerHistory) bool {
+		if c >= 0 && history.Chain != c {
+			return false
+		}
+		if len(owner) != 0 && !history.Owner.Equal(owner) {
+			return false
+		}
+		if state != "" && history.State != state {
+			return false
+		}
+		if from > 0 && history.Updated < from {
+			return false
+		}
+		if to > 0 && history.Created > to {
+			return false
+		}
+		histories = append(histories, *history)
+		return false
+	})
+	return histories
+}
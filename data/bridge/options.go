@@ -0,0 +1,109 @@
+package bridge
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+)
+
+// Direction tells which way an asset moves through the bridge
+type Direction int8
+
+const (
+	// Inflow is locked on the external chain and minted on OneLedger
+	Inflow Direction = 0x01
+	// Outflow is burnt on OneLedger and released on the external chain
+	Outflow Direction = 0x02
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inflow:
+		return "inflow"
+	case Outflow:
+		return "outflow"
+	}
+	return "UNKNOWN"
+}
+
+// Limit caps what can move through the bridge for one asset. Amounts are in the smallest unit
+// of the currency of the asset, a zero amount leaves the cap out
+type Limit struct {
+	Asset   string         `json:"asset"`
+	MaxTx   balance.Amount `json:"maxTx"`
+	Inflow  balance.Amount `json:"inflow"`
+	Outflow balance.Amount `json:"outflow"`
+	// Window is the number of blocks the inflow and outflow caps roll over
+	Window int64 `json:"window"`
+}
+
+// Cap returns the cap of a direction over the window, nil when there is none
+func (l Limit) Cap(dir Direction) *big.Int {
+	c := l.Inflow.BigInt()
+	if dir == Outflow {
+		c = l.Outflow.BigInt()
+	}
+	if c.Sign() == 0 {
+		return nil
+	}
+	return c
+}
+
+func (l Limit) Validate() error {
+	if l.Asset == "" {
+		return errors.New("limit without an asset")
+	}
+	if l.MaxTx.BigInt().Sign() < 0 || l.Inflow.BigInt().Sign() < 0 || l.Outflow.BigInt().Sign() < 0 {
+		return errors.Errorf("negative limit for %s", l.Asset)
+	}
+	if l.Window < 0 {
+		return errors.Errorf("negative window for %s", l.Asset)
+	}
+	if l.Window == 0 && (l.Cap(Inflow) != nil || l.Cap(Outflow) != nil) {
+		return errors.Errorf("inflow and outflow limits of %s need a window", l.Asset)
+	}
+	return nil
+}
+
+// Options are the governance options of the bridge
+type Options struct {
+	Limits []Limit `json:"limits"`
+}
+
+// GetLimit returns the limit of an asset, assets without one move freely
+func (o *Options) GetLimit(asset string) (Limit, bool) {
+	for _, l := range o.Limits {
+		if l.Asset == asset {
+			return l, true
+		}
+	}
+	return Limit{}, false
+}
+
+// SetLimit adds the limit of an asset or replaces the one it had
+func (o *Options) SetLimit(limit Limit) {
+	for i, l := range o.Limits {
+		if l.Asset == limit.Asset {
+			o.Limits[i] = limit
+			return
+		}
+	}
+	o.Limits = append(o.Limits, limit)
+}
+
+func (o *Options) Validate() error {
+	assets := make(map[string]bool)
+	for _, l := range o.Limits {
+		err := l.Validate()
+		if err != nil {
+			return err
+		}
+		if assets[l.Asset] {
+			return errors.Errorf("more than one limit for %s", l.Asset)
+		}
+		assets[l.Asset] = true
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package bridge
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// PauseVoteWindow is the number of blocks a vote to pause a bridge counts for
+const PauseVoteWindow int64 = 1200
+
+// PauseVote is a witness asking for a pause at a height
+type PauseVote struct {
+	Witness keys.Address `json:"witness"`
+	Height  int64        `json:"height"`
+}
+
+// Pause is the circuit breaker of the bridge of one chain. New locks and redeems are refused
+// while it is tripped, the trackers already in flight carry on
+type Pause struct {
+	Chain  chain.Type `json:"chain"`
+	Paused bool       `json:"paused"`
+	Height int64      `json:"height"`
+	Reason string     `json:"reason"`
+	// Votes are the witnesses asking for a pause, the breaker trips once they reach a quorum
+	Votes []PauseVote `json:"votes"`
+}
+
+// keepVotes drops the votes out of the window ending at height and the ones of addresses
+// that are not voters anymore
+func (p *Pause) keepVotes(voters []keys.Address, height int64) {
+	votes := make([]PauseVote, 0, len(p.Votes))
+	for _, v := range p.Votes {
+		if v.Height > height-PauseVoteWindow && isVoter(voters, v.Witness) {
+			votes = append(votes, v)
+		}
+	}
+	p.Votes = votes
+}
+
+func isVoter(voters []keys.Address, addr keys.Address) bool {
+	for _, v := range voters {
+		if v.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// FlowEntry is the amount of an asset that moved through the bridge at a height
+type FlowEntry struct {
+	Height int64          `json:"height"`
+	Amount balance.Amount `json:"amount"`
+}
+
+// Flow keeps what moved through the bridge for one asset and direction, over the last window
+type Flow struct {
+	Entries []FlowEntry `json:"entries"`
+}
+
+// Total sums what moved over the window of blocks ending at height
+func (f *Flow) Total(height, window int64) *big.Int {
+	total := big.NewInt(0)
+	for _, e := range f.Entries {
+		if e.Height > height-window {
+			total.Add(total, e.Amount.BigInt())
+		}
+	}
+	return total
+}
+
+func (f *Flow) add(height, window int64, amount *big.Int) {
+	entries := make([]FlowEntry, 0, len(f.Entries)+1)
+	for _, e := range f.Entries {
+		if e.Height > height-window {
+			entries = append(entries, e)
+		}
+	}
+	last := len(entries) - 1
+	if last >= 0 && entries[last].Height == height {
+		entries[last].Amount = *entries[last].Amount.Plus(*balance.NewAmountFromBigInt(amount))
+	} else {
+		entries = append(entries, FlowEntry{Height: height, Amount: *balance.NewAmountFromBigInt(new(big.Int).Set(amount))})
+	}
+	f.Entries = entries
+}
+
+// remove takes an amount back from the entry at a height, entries out of the window are gone
+// and there is nothing to take back from them
+func (f *Flow) remove(height int64, amount *big.Int) {
+	for i, e := range f.Entries {
+		if e.Height != height {
+			continue
+		}
+		left := new(big.Int).Sub(e.Amount.BigInt(), amount)
+		if left.Sign() > 0 {
+			f.Entries[i].Amount = *balance.NewAmountFromBigInt(left)
+		} else {
+			f.Entries = append(f.Entries[:i], f.Entries[i+1:]...)
+		}
+		return
+	}
+}
+
+// Admission is what a tracker got through the flow limits of an asset, it counts against them
+// until the tracker fails and it is released
+type Admission struct {
+	Asset     string         `json:"asset"`
+	Direction Direction      `json:"direction"`
+	Height    int64          `json:"height"`
+	Amount    balance.Amount `json:"amount"`
+}
+
+// Store keeps the circuit breakers of the bridged chains and the flows of the limited assets
+type Store struct {
+	State  *storage.State
+	szlr   serialize.Serializer
+	prefix []byte
+}
+
+func NewStore(prefix string, state *storage.State) *Store {
+	return &Store{
+		State:  state,
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+		prefix: storage.Prefix(prefix),
+	}
+}
+
+func (s *Store) WithState(state *storage.State) *Store {
+	s.State = state
+	return s
+}
+
+func (s *Store) pauseKey(c chain.Type) storage.StoreKey {
+	return storage.StoreKey(string(s.prefix) + "pause" + storage.DB_PREFIX + strconv.Itoa(int(c)))
+}
+
+func (s *Store) admissionKey(c chain.Type, name string) storage.StoreKey {
+	return storage.StoreKey(string(s.prefix) + "admission" + storage.DB_PREFIX + strconv.Itoa(int(c)) + storage.DB_PREFIX + name)
+}
+
+func (s *Store) flowKey(asset string, dir Direction) storage.StoreKey {
+	return storage.StoreKey(string(s.prefix) + "flow" + storage.DB_PREFIX + asset + storage.DB_PREFIX + dir.String())
+}
+
+// GetPause returns the circuit breaker of a chain, chains never paused get a closed one
+func (s *Store) GetPause(c chain.Type) (*Pause, error) {
+	pause := &Pause{Chain: c, Votes: []PauseVote{}}
+	data, err := s.State.Get(s.pauseKey(c))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return pause, nil
+	}
+	err = s.szlr.Deserialize(data, pause)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize bridge pause")
+	}
+	return pause, nil
+}
+
+func (s *Store) SetPause(pause *Pause) error {
+	data, err := s.szlr.Serialize(pause)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize bridge pause")
+	}
+	return s.State.Set(s.pauseKey(pause.Chain), data)
+}
+
+// IsPaused tells whether the circuit breaker of a chain is tripped
+func (s *Store) IsPaused(c chain.Type) bool {
+	pause, err := s.GetPause(c)
+	return err == nil && pause.Paused
+}
+
+// CheckPaused fails when the bridge of a chain is paused
+func (s *Store) CheckPaused(c chain.Type) error {
+	pause, err := s.GetPause(c)
+	if err != nil {
+		return err
+	}
+	if pause.Paused {
+		return ErrPaused.Wrap(errors.Errorf("%s bridge paused at height %d: %s", c, pause.Height, pause.Reason))
+	}
+	return nil
+}
+
+// VotePause adds the vote of a witness to pause the bridge of a chain, a vote again moves it up
+// to the new height. Only the votes of the current voters over the last PauseVoteWindow blocks
+// count, the bridge is paused as soon as they reach two thirds of the voters
+func (s *Store) VotePause(c chain.Type, witness keys.Address, voters []keys.Address, height int64, reason string) (*Pause, error) {
+	pause, err := s.GetPause(c)
+	if err != nil {
+		return nil, err
+	}
+	if pause.Paused {
+		return pause, nil
+	}
+	if !isVoter(voters, witness) {
+		return nil, errors.Errorf("%s can't vote to pause the %s bridge", witness.String(), c)
+	}
+	pause.keepVotes(voters, height)
+	for i, v := range pause.Votes {
+		if v.Witness.Equal(witness) {
+			pause.Votes = append(pause.Votes[:i], pause.Votes[i+1:]...)
+			break
+		}
+	}
+	pause.Votes = append(pause.Votes, PauseVote{Witness: witness, Height: height})
+	if len(pause.Votes) >= len(voters)*2/3+1 {
+		pause.Paused = true
+		pause.Height = height
+		pause.Reason = reason
+	}
+	return pause, s.SetPause(pause)
+}
+
+// Resume closes the circuit breaker of a chain and drops the votes to pause it
+func (s *Store) Resume(c chain.Type) error {
+	return s.SetPause(&Pause{Chain: c, Votes: []PauseVote{}})
+}
+
+func (s *Store) GetFlow(asset string, dir Direction) (*Flow, error) {
+	flow := &Flow{Entries: []FlowEntry{}}
+	data, err := s.State.Get(s.flowKey(asset, dir))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return flow, nil
+	}
+	err = s.szlr.Deserialize(data, flow)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize bridge flow")
+	}
+	return flow, nil
+}
+
+// Usage returns what moved through the bridge over the window of the limit of an asset
+func (s *Store) Usage(limit Limit, dir Direction, height int64) (*big.Int, error) {
+	flow, err := s.GetFlow(limit.Asset, dir)
+	if err != nil {
+		return nil, err
+	}
+	return flow.Total(height, limit.Window), nil
+}
+
+func (s *Store) setFlow(asset string, dir Direction, flow *Flow) error {
+	data, err := s.szlr.Serialize(flow)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize bridge flow")
+	}
+	return s.State.Set(s.flowKey(asset, dir), data)
+}
+
+// Admit lets the amount of an asset of a new lock or redeem through the bridge of a chain. It
+// fails when the bridge is paused or the amount goes over the limits of the asset, otherwise
+// the amount counts against them until the tracker named name is released or settled
+func (s *Store) Admit(opt *Options, c chain.Type, name string, asset string, dir Direction, height int64, amount *big.Int) error {
+	err := s.CheckPaused(c)
+	if err != nil {
+		return err
+	}
+	limit, ok := opt.GetLimit(asset)
+	if !ok {
+		return nil
+	}
+	if limit.MaxTx.BigInt().Sign() > 0 && amount.Cmp(limit.MaxTx.BigInt()) > 0 {
+		return ErrTxLimitExceeded.Wrap(errors.Errorf("%s %s of %s, max %s", asset, dir, amount, limit.MaxTx.String()))
+	}
+	max := limit.Cap(dir)
+	if max == nil {
+		return nil
+	}
+	flow, err := s.GetFlow(asset, dir)
+	if err != nil {
+		return err
+	}
+	total := new(big.Int).Add(flow.Total(height, limit.Window), amount)
+	if total.Cmp(max) > 0 {
+		return ErrLimitExceeded.Wrap(errors.Errorf("%s %s of %s over %d blocks, max %s", asset, dir, total, limit.Window, max))
+	}
+	flow.add(height, limit.Window, amount)
+	err = s.setFlow(asset, dir, flow)
+	if err != nil {
+		return err
+	}
+	data, err := s.szlr.Serialize(&Admission{
+		Asset:     asset,
+		Direction: dir,
+		Height:    height,
+		Amount:    *balance.NewAmountFromBigInt(amount),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize bridge admission")
+	}
+	return s.State.Set(s.admissionKey(c, name), data)
+}
+
+// GetAdmission returns what a tracker got through the flow limits, nil when it is not counted
+func (s *Store) GetAdmission(c chain.Type, name string) (*Admission, error) {
+	data, err := s.State.Get(s.admissionKey(c, name))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	admission := &Admission{}
+	err = s.szlr.Deserialize(data, admission)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize bridge admission")
+	}
+	return admission, nil
+}
+
+// Release gives the amount admitted for a tracker back to the flow limits, once its lock failed
+// or its redeem was refunded
+func (s *Store) Release(c chain.Type, name string) error {
+	admission, err := s.GetAdmission(c, name)
+	if err != nil || admission == nil {
+		return err
+	}
+	flow, err := s.GetFlow(admission.Asset, admission.Direction)
+	if err != nil {
+		return err
+	}
+	flow.remove(admission.Height, admission.Amount.BigInt())
+	err = s.setFlow(admission.Asset, admission.Direction, flow)
+	if err != nil {
+		return err
+	}
+	_, err = s.State.Delete(s.admissionKey(c, name))
+	return err
+}
+
+// Settle forgets the admission of a tracker that went through, its amount keeps counting until
+// it rolls out of the window
+func (s *Store) Settle(c chain.Type, name string) error {
+	admission, err := s.GetAdmission(c, name)
+	if err != nil || admission == nil {
+		return err
+	}
+	_, err = s.State.Delete(s.admissionKey(c, name))
+	return err
+}
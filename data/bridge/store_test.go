@@ -0,0 +1,204 @@
+package bridge
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func newTestStore() *Store {
+	memDB := db.NewDB("test", db.MemDBBackend, "")
+	return NewStore("bridge", storage.NewState(storage.NewChainState("chainstate", memDB)))
+}
+
+func testOptions() *Options {
+	return &Options{Limits: []Limit{{
+		Asset:   "ETH",
+		MaxTx:   *balance.NewAmount(50),
+		Inflow:  *balance.NewAmount(100),
+		Outflow: *balance.NewAmount(60),
+		Window:  10,
+	}}}
+}
+
+func TestStore_AdmitRollingWindow(t *testing.T) {
+	s := newTestStore()
+	opt := testOptions()
+
+	assert.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x01", "ETH", Inflow, 1, big.NewInt(50)))
+	assert.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x02", "ETH", Inflow, 5, big.NewInt(40)))
+	// over the inflow of the window
+	assert.Error(t, s.Admit(opt, chain.ETHEREUM, "0x03", "ETH", Inflow, 8, big.NewInt(20)))
+	// the first entry rolled out of the window
+	assert.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x04", "ETH", Inflow, 11, big.NewInt(20)))
+
+	usage, err := s.Usage(opt.Limits[0], Inflow, 11)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), usage.Int64())
+
+	// outflow is capped on its own
+	assert.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x05", "ETH", Outflow, 11, big.NewInt(50)))
+	assert.Error(t, s.Admit(opt, chain.ETHEREUM, "0x06", "ETH", Outflow, 11, big.NewInt(20)))
+}
+
+func TestStore_AdmitRelease(t *testing.T) {
+	s := newTestStore()
+	opt := testOptions()
+
+	require.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x01", "ETH", Inflow, 1, big.NewInt(50)))
+	require.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x02", "ETH", Inflow, 1, big.NewInt(50)))
+	assert.Error(t, s.Admit(opt, chain.ETHEREUM, "0x03", "ETH", Inflow, 2, big.NewInt(10)))
+
+	// a failed lock gives its flow back, a second release finds nothing to give
+	require.NoError(t, s.Release(chain.ETHEREUM, "0x01"))
+	require.NoError(t, s.Release(chain.ETHEREUM, "0x01"))
+	usage, err := s.Usage(opt.Limits[0], Inflow, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), usage.Int64())
+	assert.NoError(t, s.Admit(opt, chain.ETHEREUM, "0x03", "ETH", Inflow, 2, big.NewInt(10)))
+
+	// a settled lock keeps counting until it rolls out of the window
+	require.NoError(t, s.Settle(chain.ETHEREUM, "0x02"))
+	admission, err := s.GetAdmission(chain.ETHEREUM, "0x02")
+	require.NoError(t, err)
+	assert.Nil(t, admission)
+	require.NoError(t, s.Release(chain.ETHEREUM, "0x02"))
+	usage, err = s.Usage(opt.Limits[0], Inflow, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), usage.Int64())
+}
+
+func TestStore_AdmitMaxTx(t *testing.T) {
+	s := newTestStore()
+	opt := testOptions()
+
+	assert.Error(t, s.Admit(opt, chain.ETHEREUM, "0x07", "ETH", Inflow, 1, big.NewInt(51)))
+	// assets without a limit move freely
+	assert.NoError(t, s.Admit(opt, chain.BITCOIN, "0x08", "BTC", Inflow, 1, big.NewInt(1000000)))
+}
+
+func TestStore_VotePause(t *testing.T) {
+	s := newTestStore()
+	opt := testOptions()
+	witnesses := []keys.Address{
+		keys.Address("witness1witness1witn"),
+		keys.Address("witness2witness2witn"),
+		keys.Address("witness3witness3witn"),
+	}
+
+	pause, err := s.VotePause(chain.ETHEREUM, witnesses[0], witnesses, 3, "drain")
+	require.NoError(t, err)
+	assert.False(t, pause.Paused)
+
+	// voting twice does not count
+	pause, err = s.VotePause(chain.ETHEREUM, witnesses[0], witnesses, 4, "drain")
+	require.NoError(t, err)
+	assert.False(t, pause.Paused)
+	assert.Len(t, pause.Votes, 1)
+
+	pause, err = s.VotePause(chain.ETHEREUM, witnesses[1], witnesses, 5, "drain")
+	require.NoError(t, err)
+	assert.False(t, pause.Paused)
+
+	pause, err = s.VotePause(chain.ETHEREUM, witnesses[2], witnesses, 6, "drain")
+	require.NoError(t, err)
+	assert.True(t, pause.Paused)
+	assert.Equal(t, int64(6), pause.Height)
+	assert.True(t, s.IsPaused(chain.ETHEREUM))
+	assert.False(t, s.IsPaused(chain.BITCOIN))
+
+	assert.Error(t, s.Admit(opt, chain.ETHEREUM, "0x01", "ETH", Inflow, 7, big.NewInt(1)))
+	assert.NoError(t, s.Admit(opt, chain.BITCOIN, "tracker_0", "BTC", Inflow, 7, big.NewInt(1)))
+
+	require.NoError(t, s.Resume(chain.ETHEREUM))
+	assert.False(t, s.IsPaused(chain.ETHEREUM))
+	pause, err = s.GetPause(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Empty(t, pause.Votes)
+}
+
+func TestStore_VotePauseStale(t *testing.T) {
+	s := newTestStore()
+	witnesses := []keys.Address{
+		keys.Address("witness1witness1witn"),
+		keys.Address("witness2witness2witn"),
+		keys.Address("witness3witness3witn"),
+	}
+
+	_, err := s.VotePause(chain.ETHEREUM, witnesses[0], witnesses, 1, "drain")
+	require.NoError(t, err)
+	_, err = s.VotePause(chain.ETHEREUM, witnesses[1], witnesses, 2, "drain")
+	require.NoError(t, err)
+
+	// the first vote is out of the window
+	pause, err := s.VotePause(chain.ETHEREUM, witnesses[2], witnesses, 1+PauseVoteWindow, "drain")
+	require.NoError(t, err)
+	assert.False(t, pause.Paused)
+	assert.Len(t, pause.Votes, 2)
+
+	// the second witness left, its vote does not count anymore
+	rotated := []keys.Address{witnesses[0], witnesses[2], keys.Address("witness4witness4witn")}
+	pause, err = s.VotePause(chain.ETHEREUM, witnesses[0], rotated, 2+PauseVoteWindow, "drain")
+	require.NoError(t, err)
+	assert.False(t, pause.Paused)
+	assert.Len(t, pause.Votes, 2)
+
+	// only the voters vote
+	_, err = s.VotePause(chain.ETHEREUM, witnesses[1], rotated, 3+PauseVoteWindow, "drain")
+	assert.Error(t, err)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	opt := testOptions()
+	assert.NoError(t, opt.Validate())
+
+	opt.SetLimit(Limit{Asset: "BTC", Inflow: *balance.NewAmount(10)})
+	assert.Error(t, opt.Validate())
+
+	opt.SetLimit(Limit{Asset: "BTC", Inflow: *balance.NewAmount(10), Window: 5})
+	assert.NoError(t, opt.Validate())
+	assert.Len(t, opt.Limits, 2)
+
+	opt.Limits = append(opt.Limits, Limit{Asset: "BTC"})
+	assert.Error(t, opt.Validate())
+}
+
+func TestStore_History(t *testing.T) {
+	s := newTestStore()
+	owner := keys.Address("owner1owner1owner1ow")
+	witness := keys.Address("witness1witness1witn")
+
+	require.NoError(t, s.OpenHistory(chain.ETHEREUM, "0x01", "ETH LOCK", owner, "NEWTRACKER", 2))
+	require.NoError(t, s.RecordHistory(chain.ETHEREUM, "0x01", HistoryEntry{
+		Height: 4, Event: EventVoteNo, State: "BusyBroadcasting", Witness: witness, Reason: "token uri does not match the lock",
+	}))
+	require.NoError(t, s.OpenHistory(chain.BITCOIN, "tracker_0", "lock", keys.Address("owner2owner2owner2ow"), "Requested", 3))
+	s.State.Commit()
+
+	history, err := s.GetHistory(chain.ETHEREUM, "0x01")
+	require.NoError(t, err)
+	assert.Equal(t, owner, history.Owner)
+	assert.Equal(t, "BusyBroadcasting", history.State)
+	assert.Equal(t, int64(2), history.Created)
+	assert.Equal(t, int64(4), history.Updated)
+	assert.Len(t, history.Entries, 2)
+	assert.Equal(t, witness, history.Entries[1].Witness)
+
+	_, err = s.GetHistory(chain.ETHEREUM, "0x02")
+	assert.Error(t, err)
+
+	assert.Len(t, s.FilterHistory(chain.Type(-1), nil, "", 0, 0), 2)
+	assert.Len(t, s.FilterHistory(chain.ETHEREUM, nil, "", 0, 0), 1)
+	assert.Len(t, s.FilterHistory(chain.Type(-1), owner, "", 0, 0), 1)
+	assert.Len(t, s.FilterHistory(chain.Type(-1), nil, "Requested", 0, 0), 1)
+	assert.Len(t, s.FilterHistory(chain.Type(-1), nil, "", 4, 0), 1)
+	assert.Len(t, s.FilterHistory(chain.Type(-1), nil, "", 0, 2), 1)
+}
@@ -15,6 +15,10 @@
 package chain
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/pkg/errors"
 )
 
@@ -31,6 +35,9 @@ const (
 	BITCOIN   Type = 1
 	ETHEREUM  Type = 2
 	TESTTOKEN Type = 3
+
+	// chains of the evm registry are typed by their chain id on top of this base
+	EVM_CHAIN_BASE Type = 0x1000
 )
 
 var chainTypes = map[string]Type{}
@@ -53,15 +60,47 @@ func (ctype Type) String() string {
 
 	name, ok := chainTypeNames[ctype]
 	if !ok {
+		if ctype.IsEVM() {
+			return fmt.Sprintf("EVM-%d", ctype.EVMChainID())
+		}
 		return "INVALID"
 	}
 
 	return name
 }
 
+// EVMType returns the chain type of an evm chain in the bridge registry,
+// chain id 0 stands for the default ethereum chain
+func EVMType(chainID int64) Type {
+	if chainID == 0 {
+		return ETHEREUM
+	}
+	return EVM_CHAIN_BASE + Type(chainID)
+}
+
+// IsEVM tells whether the chain type belongs to a chain of the evm registry
+func (ctype Type) IsEVM() bool {
+	return ctype > EVM_CHAIN_BASE
+}
+
+// EVMChainID returns the chain id of a registry evm chain, 0 for any other chain type
+func (ctype Type) EVMChainID() int64 {
+	if !ctype.IsEVM() {
+		return 0
+	}
+	return int64(ctype - EVM_CHAIN_BASE)
+}
+
 func TypeFromName(chainName string) (Type, error) {
 	typ, ok := chainTypes[chainName]
 	if !ok {
+		// chains of the evm registry are named after their chain id
+		if strings.HasPrefix(chainName, "EVM-") {
+			chainID, err := strconv.ParseInt(strings.TrimPrefix(chainName, "EVM-"), 10, 64)
+			if err == nil && chainID > 0 {
+				return EVMType(chainID), nil
+			}
+		}
 		return Type(-1), errors.New("wrong chain name")
 	}
 
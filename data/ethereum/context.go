@@ -1,6 +1,7 @@
 package ethereum
 
 import (
+	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/jobs"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/identity"
@@ -26,3 +27,8 @@ func NewTrackerCtx(t *Tracker, addr keys.Address, js *jobs.JobStore, ts *Tracker
 		Logger:       log,
 	}
 }
+
+// IsWitness tells whether this node witnesses the evm chain of the tracker
+func (ctx *TrackerCtx) IsWitness() bool {
+	return ctx.Witnesses.IsChainWitness(chain.EVMType(ctx.Tracker.ChainID))
+}
@@ -8,4 +8,10 @@ var (
 	// Options Objects from store
 	ErrETHTrackerExists      = codes.ProtocolError{codes.ETHTrackerExists, "Tracker Already exists"}
 	ErrETHTrackerUnableToSet = codes.ProtocolError{codes.ETHTrackerUnabletoSet, "Unable to set ETH tracker"}
+	ErrNFTNotFound           = codes.ProtocolError{codes.ETHNFTNotFound, "NFT not found"}
+	ErrNFTExists             = codes.ProtocolError{codes.ETHNFTExists, "NFT already bridged"}
+	ErrNFTNotOwner           = codes.ProtocolError{codes.ETHNFTNotOwner, "NFT not owned by the signer"}
+	ErrNFTRedeeming          = codes.ProtocolError{codes.ETHNFTRedeeming, "NFT is being redeemed"}
+	ErrNotTrackerWitness     = codes.ProtocolError{codes.ETHTrackerNotWitness, "signer is not a witness of the tracker chain"}
+	ErrTrackerNotRetriable   = codes.ProtocolError{codes.ETHTrackerNotRetriable, "tracker can't be retried"}
 )
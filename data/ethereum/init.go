@@ -29,6 +29,8 @@ const (
 	ProcessTypeRedeem    ProcessType = 0x02
 	ProcessTypeLockERC   ProcessType = 0x03
 	ProcessTypeRedeemERC ProcessType = 0x04
+	ProcessTypeLockNFT   ProcessType = 0x05
+	ProcessTypeRedeemNFT ProcessType = 0x06
 )
 
 var (
@@ -50,6 +52,10 @@ func (t ProcessType) String() string {
 		return "ERC LOCK"
 	case 0x04:
 		return "ERC REDEEM"
+	case 0x05:
+		return "NFT LOCK"
+	case 0x06:
+		return "NFT REDEEM"
 	}
 	return "UNKNOWN TYPE"
 
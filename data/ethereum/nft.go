@@ -0,0 +1,113 @@
+package ethereum
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// NFT is the OneLedger record of an ERC721 token locked in the bridge contract of an evm chain
+type NFT struct {
+	ChainID  int64          `json:"chainId"`
+	Contract common.Address `json:"contract"`
+	TokenID  string         `json:"tokenId"`
+	TokenURI string         `json:"tokenUri"`
+	Owner    keys.Address   `json:"owner"`
+	// Redeeming is set while a redeem of the token is in progress, it can't be transferred meanwhile
+	Redeeming bool `json:"redeeming"`
+}
+
+func nftKey(chainID int64, contract common.Address, tokenID string) string {
+	return strconv.FormatInt(chainID, 10) + storage.DB_PREFIX + strings.ToLower(contract.Hex()) + storage.DB_PREFIX + tokenID
+}
+
+// NFTStore keeps the records of the ERC721 tokens bridged to OneLedger
+type NFTStore struct {
+	State  *storage.State
+	szlr   serialize.Serializer
+	prefix []byte
+}
+
+func NewNFTStore(prefix string, state *storage.State) *NFTStore {
+	return &NFTStore{
+		State:  state,
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+		prefix: storage.Prefix(prefix),
+	}
+}
+
+func (ns *NFTStore) WithState(state *storage.State) *NFTStore {
+	ns.State = state
+	return ns
+}
+
+func (ns *NFTStore) Get(chainID int64, contract common.Address, tokenID string) (*NFT, error) {
+	key := append(ns.prefix, nftKey(chainID, contract, tokenID)...)
+	data, err := ns.State.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNFTNotFound
+	}
+
+	nft := &NFT{}
+	err = ns.szlr.Deserialize(data, nft)
+	if err != nil {
+		return nil, errors.Wrap(err, "error de-serializing nft")
+	}
+	return nft, nil
+}
+
+func (ns *NFTStore) Set(nft *NFT) error {
+	data, err := ns.szlr.Serialize(nft)
+	if err != nil {
+		return err
+	}
+	key := append(ns.prefix, nftKey(nft.ChainID, nft.Contract, nft.TokenID)...)
+	return ns.State.Set(key, data)
+}
+
+func (ns *NFTStore) Exists(chainID int64, contract common.Address, tokenID string) bool {
+	key := append(ns.prefix, nftKey(chainID, contract, tokenID)...)
+	return ns.State.Exists(key)
+}
+
+func (ns *NFTStore) Delete(chainID int64, contract common.Address, tokenID string) (bool, error) {
+	key := append(ns.prefix, nftKey(chainID, contract, tokenID)...)
+	return ns.State.Delete(key)
+}
+
+func (ns *NFTStore) Iterate(fn func(nft *NFT) bool) (stopped bool) {
+	return ns.State.IterateRange(
+		ns.prefix,
+		storage.Rangefix(string(ns.prefix)),
+		true,
+		func(key, value []byte) bool {
+			nft := &NFT{}
+			err := ns.szlr.Deserialize(value, nft)
+			if err != nil {
+				return false
+			}
+			return fn(nft)
+		},
+	)
+}
+
+// GetOwned lists the NFTs held by an address
+func (ns *NFTStore) GetOwned(owner keys.Address) []NFT {
+	nfts := make([]NFT, 0)
+	ns.Iterate(func(nft *NFT) bool {
+		if nft.Owner.Equal(owner) {
+			nfts = append(nfts, *nft)
+		}
+		return false
+	})
+	return nfts
+}
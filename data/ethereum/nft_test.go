@@ -0,0 +1,47 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestNFTStore(t *testing.T) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	state := storage.NewState(storage.NewChainState("nft", db))
+	ns := NewNFTStore("nft", state)
+
+	contract := common.HexToAddress("0x9aB4c1D2e3F405162738495A6b7C8d9E0F1a2B3c")
+	owner := keys.Address([]byte("owner1"))
+	nfts := []*NFT{
+		{ChainID: 0, Contract: contract, TokenID: "1", TokenURI: "ipfs://1", Owner: owner},
+		{ChainID: 0, Contract: contract, TokenID: "2", TokenURI: "ipfs://2", Owner: keys.Address([]byte("owner2"))},
+		{ChainID: 137, Contract: contract, TokenID: "1", TokenURI: "ipfs://p1", Owner: owner},
+	}
+	for _, nft := range nfts {
+		assert.NoError(t, ns.Set(nft))
+	}
+	state.Commit()
+
+	nft, err := ns.Get(137, contract, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ipfs://p1", nft.TokenURI)
+
+	_, err = ns.Get(1, contract, "1")
+	assert.Equal(t, ErrNFTNotFound, err)
+
+	owned := ns.GetOwned(owner)
+	assert.Len(t, owned, 2)
+
+	ok, err := ns.Delete(0, contract, "1")
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	state.Commit()
+	assert.False(t, ns.Exists(0, contract, "1"))
+	assert.Len(t, ns.GetOwned(owner), 1)
+}
@@ -1,6 +1,9 @@
 package ethereum
 
 import (
+	"bytes"
+	"strconv"
+
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
@@ -14,6 +17,9 @@ type TrackerStore struct {
 	prefixsuccess []byte
 	prefixongoing []byte
 	cdOpt         *ethereum.ChainDriverOption
+	registry      *ethereum.EVMChainRegistry
+	chainID       int64
+	base          *TrackerStore
 }
 
 func (ts *TrackerStore) Get(key ethereum.TrackerName) (*Tracker, error) {
@@ -83,6 +89,7 @@ func NewTrackerStore(prefixon string, prefixfail string, prefixsuccess string, s
 		prefixsuccess: storage.Prefix(prefixsuccess),
 		prefixongoing: storage.Prefix(prefixon),
 		cdOpt:         &ethereum.ChainDriverOption{},
+		registry:      &ethereum.EVMChainRegistry{},
 	}
 }
 
@@ -133,3 +140,51 @@ func (ts *TrackerStore) SetupOption(opt *ethereum.ChainDriverOption) {
 func (ts *TrackerStore) GetOption() *ethereum.ChainDriverOption {
 	return ts.cdOpt
 }
+
+func (ts *TrackerStore) SetupRegistry(registry *ethereum.EVMChainRegistry) {
+	ts.registry = registry
+}
+
+func (ts *TrackerStore) GetRegistry() *ethereum.EVMChainRegistry {
+	return ts.registry
+}
+
+// ChainID returns the evm chain the trackers of the store belong to, 0 for the default ethereum chain
+func (ts *TrackerStore) ChainID() int64 {
+	return ts.chainID
+}
+
+// WithChain returns a copy of the store keeping the trackers of an evm chain from the registry,
+// chain id 0 gives the trackers of the default ethereum chain back
+func (ts *TrackerStore) WithChain(chainID int64) *TrackerStore {
+	if chainID == ts.chainID {
+		return ts
+	}
+	base := ts
+	if ts.chainID != 0 {
+		base = ts.base
+	}
+	if chainID == 0 {
+		return base
+	}
+
+	chainStore := *base
+	chainStore.base = base
+	chainStore.chainID = chainID
+	chainStore.prefixongoing = chainPrefix(base.prefixongoing, chainID)
+	chainStore.prefixfailed = chainPrefix(base.prefixfailed, chainID)
+	chainStore.prefixsuccess = chainPrefix(base.prefixsuccess, chainID)
+	chainStore.prefix = chainStore.prefixongoing
+	chainStore.cdOpt = &ethereum.ChainDriverOption{}
+	if c, ok := base.registry.Get(chainID); ok {
+		chainStore.cdOpt = &c.Option
+	}
+	return &chainStore
+}
+
+// chain prefixes have to sort out of the range of the default ones, or iterating
+// the default trackers would hit them, '-' comes before the range of the db prefix
+func chainPrefix(prefix []byte, chainID int64) []byte {
+	name := bytes.TrimSuffix(prefix, []byte(storage.DB_PREFIX))
+	return storage.Prefix(string(name) + "-evm" + strconv.FormatInt(chainID, 10))
+}
@@ -76,3 +76,41 @@ func TestTrackerStore_Iterate(t *testing.T) {
 		return false
 	})
 }
+
+func TestTrackerStore_WithChain(t *testing.T) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	state := storage.NewState(storage.NewChainState("chains", db))
+	ts := NewTrackerStore("etht", "ethfailed", "ethsuccess", state)
+	polygonOpt := ethereum.ChainDriverOption{BlockConfirmation: 64}
+	ts.SetupRegistry(&ethereum.EVMChainRegistry{Chains: []ethereum.EVMChain{{Name: "Polygon", ChainID: 137, Currency: "MATIC", Option: polygonOpt}}})
+
+	h := &common.Hash{}
+	h.SetBytes([]byte("ethtracker"))
+	ethTracker := NewTracker(ProcessTypeLock, []byte("locker"), []byte("signedeth"), ethereum.TrackerName(*h), addresses)
+	assert.NoError(t, ts.WithPrefixType(PrefixOngoing).Set(ethTracker))
+
+	h = &common.Hash{}
+	h.SetBytes([]byte("polygontracker"))
+	polygonTracker := NewTracker(ProcessTypeLock, []byte("locker"), []byte("signedpolygon"), ethereum.TrackerName(*h), addresses)
+	polygonTracker.ChainID = 137
+	polygon := ts.WithChain(137)
+	assert.NoError(t, polygon.WithPrefixType(PrefixOngoing).Set(polygonTracker))
+	state.Commit()
+
+	assert.Equal(t, int64(137), polygon.ChainID())
+	assert.Equal(t, int64(64), polygon.GetOption().BlockConfirmation)
+	assert.Equal(t, ts, polygon.WithChain(0))
+	assert.False(t, ts.WithPrefixType(PrefixOngoing).Exists(polygonTracker.TrackerName))
+	assert.False(t, polygon.WithPrefixType(PrefixOngoing).Exists(ethTracker.TrackerName))
+
+	count := 0
+	ts.WithPrefixType(PrefixOngoing).Iterate(func(name *ethereum.TrackerName, tracker *Tracker) bool {
+		assert.Equal(t, ethTracker.TrackerName, *name)
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+	tracker, err := polygon.QueryAllStores(polygonTracker.TrackerName)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(137), tracker.ChainID)
+}
@@ -5,6 +5,8 @@ import (
 
 	"strconv"
 
+	"github.com/pkg/errors"
+
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/storage"
@@ -24,9 +26,19 @@ type Tracker struct {
 	ProcessOwner  keys.Address
 	FinalityVotes []Vote
 	To            []byte
+	// ChainID is the evm chain of the registry the tracker is for, 0 for the default ethereum chain
+	ChainID int64
+	// TokenURI is the metadata uri the locker claims for an ERC721 token, checked by the witnesses
+	TokenURI string
+	// RetryVotes are the witnesses asking to step the tracker back from RetryState
+	RetryVotes []keys.Address
+	RetryState TrackerState
+	// Retries counts the times the tracker was stepped back, the witnesses drop the jobs of the
+	// steps stepped back over once they see it go up
+	Retries int64
 }
 
-//number of validator should be smaller than 64
+// number of validator should be smaller than 64
 func NewTracker(typ ProcessType, owner keys.Address, signedEthTx []byte, name ethereum.TrackerName, witnesses []keys.Address) *Tracker {
 
 	return &Tracker{
@@ -168,7 +180,7 @@ func (t Tracker) NextStep() string {
 		}
 		return transition.NOOP
 	}
-	if t.Type == ProcessTypeLockERC {
+	if t.Type == ProcessTypeLockERC || t.Type == ProcessTypeLockNFT {
 		switch t.State {
 		case New:
 			return BROADCASTING
@@ -201,7 +213,7 @@ func (t Tracker) NextStep() string {
 			return CLEANUPFAILED
 		}
 	}
-	if t.Type == ProcessTypeRedeemERC {
+	if t.Type == ProcessTypeRedeemERC || t.Type == ProcessTypeRedeemNFT {
 		switch t.State {
 		case New:
 			return SIGNING
@@ -219,3 +231,46 @@ func (t Tracker) NextStep() string {
 	}
 	return transition.NOOP
 }
+
+// VoteRetry adds the vote of a witness to step a stuck tracker back to the state its last step
+// started from. Only the votes of the witnesses given for the current state count, once two
+// thirds of them voted the tracker is stepped back, the votes of the step are dropped and the
+// witnesses are the ones given. It tells whether the tracker was stepped back
+func (t *Tracker) VoteRetry(witness keys.Address, witnesses []keys.Address) (bool, error) {
+	if t.State != BusyBroadcasting && t.State != BusyFinalizing {
+		return false, errors.Errorf("tracker in state %s can't be retried", t.State)
+	}
+	votes := make([]keys.Address, 0, len(t.RetryVotes)+1)
+	if t.RetryState == t.State {
+		for _, v := range t.RetryVotes {
+			if isWitness(witnesses, v) && !v.Equal(witness) {
+				votes = append(votes, v)
+			}
+		}
+	}
+	t.RetryVotes = append(votes, witness)
+	t.RetryState = t.State
+	if len(t.RetryVotes) < len(witnesses)*2/3+1 {
+		return false, nil
+	}
+
+	if t.State == BusyFinalizing {
+		t.State = BusyBroadcasting
+	} else {
+		t.State = New
+	}
+	t.Witnesses = witnesses
+	t.FinalityVotes = make([]Vote, len(witnesses))
+	t.RetryVotes = nil
+	t.Retries++
+	return true, nil
+}
+
+func isWitness(witnesses []keys.Address, addr keys.Address) bool {
+	for _, w := range witnesses {
+		if w.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
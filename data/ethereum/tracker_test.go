@@ -103,3 +103,51 @@ func TestTracker_NextStep(t *testing.T) {
 	}
 
 }
+
+func TestTracker_VoteRetry(t *testing.T) {
+	h := &common.Hash{}
+	h.SetBytes([]byte("retry"))
+	tracker := NewTracker(ProcessTypeLock, addresses[0], []byte("retry"), *h, addresses)
+	witnesses := addresses[:4]
+
+	_, err := tracker.VoteRetry(witnesses[0], witnesses)
+	assert.Error(t, err)
+
+	tracker.State = BusyFinalizing
+	assert.NoError(t, tracker.AddVote(addresses[1], 1, true))
+	// one witness alone can't step the tracker back, voting again does not count twice
+	for i := 0; i < 2; i++ {
+		retried, err := tracker.VoteRetry(witnesses[0], witnesses)
+		assert.NoError(t, err)
+		assert.False(t, retried)
+	}
+	retried, err := tracker.VoteRetry(witnesses[1], witnesses)
+	assert.NoError(t, err)
+	assert.False(t, retried)
+	assert.Equal(t, BusyFinalizing, tracker.State)
+
+	retried, err = tracker.VoteRetry(witnesses[2], witnesses)
+	assert.NoError(t, err)
+	assert.True(t, retried)
+	assert.Equal(t, BusyBroadcasting, tracker.State)
+	assert.Equal(t, int64(1), tracker.Retries)
+	assert.Empty(t, tracker.RetryVotes)
+	assert.Len(t, tracker.Witnesses, 4)
+	yes, no := tracker.GetVotes()
+	assert.Equal(t, 0, yes+no)
+
+	// the votes of a witness that left don't count
+	_, err = tracker.VoteRetry(witnesses[3], witnesses)
+	assert.NoError(t, err)
+	rotated := append([]keys.Address{addresses[4]}, witnesses[:3]...)
+	for _, w := range rotated[:2] {
+		retried, err = tracker.VoteRetry(w, rotated)
+		assert.NoError(t, err)
+		assert.False(t, retried)
+	}
+	retried, err = tracker.VoteRetry(rotated[2], rotated)
+	assert.NoError(t, err)
+	assert.True(t, retried)
+	assert.Equal(t, New, tracker.State)
+	assert.Equal(t, int64(2), tracker.Retries)
+}
@@ -15,8 +15,10 @@ const (
 type FeeOption struct {
 	FeeCurrency   balance.Currency `json:"feeCurrency"`
 	MinFeeDecimal int64            `json:"minFeeDecimal"`
+	BaseFeeMarket BaseFeeOption    `json:"baseFeeMarket"`
 
 	minimalFee *balance.Coin
+	baseFee    *balance.Coin
 	rmu        sync.RWMutex
 }
 
@@ -34,3 +36,25 @@ func (fo *FeeOption) MinFee() balance.Coin {
 	}
 	return *fo.minimalFee
 }
+
+// BaseFee returns the price per gas every transaction of the block pays at least, the minimal fee
+// when the base fee market is off
+func (fo *FeeOption) BaseFee() balance.Coin {
+	fo.rmu.RLock()
+	baseFee := fo.baseFee
+	fo.rmu.RUnlock()
+
+	if baseFee == nil || !fo.BaseFeeMarket.Enabled() {
+		return fo.MinFee()
+	}
+	return *baseFee
+}
+
+// SetBaseFee sets the base fee of the block
+func (fo *FeeOption) SetBaseFee(amount balance.Amount) {
+	coin := fo.FeeCurrency.NewCoinFromAmount(amount)
+
+	fo.rmu.Lock()
+	defer fo.rmu.Unlock()
+	fo.baseFee = &coin
+}
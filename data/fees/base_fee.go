@@ -0,0 +1,136 @@
+package fees
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const BLOCK_FEE_KEY = "blockfee"
+
+// BaseFeeOption sets the base fee market, the base fee of a block follows how full the previous
+// block was
+type BaseFeeOption struct {
+	// gas used by a block that leaves the base fee as it is, the market is off when zero
+	TargetGas int64 `json:"targetGas"`
+	// the base fee moves by 1/ChangeDenominator at most from a block to the next
+	ChangeDenominator int64 `json:"changeDenominator"`
+	// burn the base fee of the transactions instead of adding it to the fee pool
+	Burn bool `json:"burn"`
+}
+
+// Enabled tells whether the base fee follows the block gas usage, or stays at the minimal fee
+func (o BaseFeeOption) Enabled() bool {
+	return o.TargetGas > 0 && o.ChangeDenominator > 0
+}
+
+// NextBaseFee returns the base fee of the block after one that used the given gas at the given base
+// fee, it never goes under the minimal fee
+func (o BaseFeeOption) NextBaseFee(baseFee *big.Int, gasUsed int64, minFee *big.Int) *big.Int {
+	next := new(big.Int).Set(baseFee)
+	if !o.Enabled() || gasUsed == o.TargetGas {
+		return maxBig(next, minFee)
+	}
+
+	target := big.NewInt(o.TargetGas)
+	denominator := big.NewInt(o.ChangeDenominator)
+	if gasUsed > o.TargetGas {
+		delta := new(big.Int).Mul(baseFee, big.NewInt(gasUsed-o.TargetGas))
+		delta.Div(delta, target).Div(delta, denominator)
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return maxBig(next.Add(next, delta), minFee)
+	}
+	delta := new(big.Int).Mul(baseFee, big.NewInt(o.TargetGas-gasUsed))
+	delta.Div(delta, target).Div(delta, denominator)
+	return maxBig(next.Sub(next, delta), minFee)
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return new(big.Int).Set(b)
+	}
+	return a
+}
+
+// BlockFee keeps the base fee market of a block
+type BlockFee struct {
+	BaseFee     balance.Amount `json:"baseFee"`
+	GasUsed     int64          `json:"gasUsed"`
+	NextBaseFee balance.Amount `json:"nextBaseFee"`
+}
+
+func (st *Store) blockFeeKey() storage.StoreKey {
+	return append(st.prefix, storage.StoreKey(BLOCK_FEE_KEY)...)
+}
+
+func (st *Store) unmarshalBlockFee(dat []byte) (*BlockFee, error) {
+	if len(dat) == 0 {
+		return nil, nil
+	}
+	blockFee := &BlockFee{}
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, blockFee)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize block fee")
+	}
+	return blockFee, nil
+}
+
+// GetBlockFee returns the base fee market of the last block, nil before the first one
+func (st *Store) GetBlockFee() (*BlockFee, error) {
+	dat, _ := st.state.Get(st.blockFeeKey())
+	return st.unmarshalBlockFee(dat)
+}
+
+// GetBlockFeeAt returns the base fee market of the block at the given height, nil when the block
+// ran without it or its state is pruned
+func (st *Store) GetBlockFeeAt(height int64) (*BlockFee, error) {
+	return st.unmarshalBlockFee(st.state.GetVersioned(height, st.blockFeeKey()))
+}
+
+// LoadBaseFee sets the base fee of the block about to run on the fee option, from the gas used by
+// the last one
+func (st *Store) LoadBaseFee() error {
+	if st.feeOpt == nil || !st.feeOpt.BaseFeeMarket.Enabled() {
+		return nil
+	}
+	blockFee, err := st.GetBlockFee()
+	if err != nil {
+		return err
+	}
+	if blockFee == nil {
+		return nil
+	}
+	minFee := st.feeOpt.MinFee().Amount.BigInt()
+	st.feeOpt.SetBaseFee(*balance.NewAmountFromBigInt(maxBig(new(big.Int).Set(blockFee.NextBaseFee.BigInt()), minFee)))
+	return nil
+}
+
+// UpdateBaseFee records the gas used by the block with its base fee, and works out the base fee of
+// the next one
+func (st *Store) UpdateBaseFee(gasUsed int64) (*BlockFee, error) {
+	if st.feeOpt == nil || !st.feeOpt.BaseFeeMarket.Enabled() {
+		return nil, nil
+	}
+	baseFee := st.feeOpt.BaseFee().Amount.BigInt()
+	next := st.feeOpt.BaseFeeMarket.NextBaseFee(baseFee, gasUsed, st.feeOpt.MinFee().Amount.BigInt())
+	blockFee := &BlockFee{
+		BaseFee:     *balance.NewAmountFromBigInt(baseFee),
+		GasUsed:     gasUsed,
+		NextBaseFee: *balance.NewAmountFromBigInt(next),
+	}
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(blockFee)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize block fee")
+	}
+	err = st.state.Set(st.blockFeeKey(), dat)
+	if err != nil {
+		return nil, err
+	}
+	return blockFee, nil
+}
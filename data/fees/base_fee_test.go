@@ -0,0 +1,87 @@
+package fees
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func newFeeOption(market BaseFeeOption) *FeeOption {
+	return &FeeOption{
+		FeeCurrency: balance.Currency{
+			Id:      0,
+			Name:    "OLT",
+			Chain:   0,
+			Decimal: 18,
+			Unit:    "nue",
+		},
+		MinFeeDecimal: 9,
+		BaseFeeMarket: market,
+	}
+}
+
+func TestBaseFeeOption_NextBaseFee(t *testing.T) {
+	market := BaseFeeOption{TargetGas: 1_000_000, ChangeDenominator: 8}
+	minFee := big.NewInt(1_000_000_000)
+	baseFee := big.NewInt(8_000_000_000)
+
+	// on target the base fee stays
+	assert.Equal(t, baseFee, market.NextBaseFee(baseFee, 1_000_000, minFee))
+	// a full block raises it by an eighth
+	assert.Equal(t, big.NewInt(9_000_000_000), market.NextBaseFee(baseFee, 2_000_000, minFee))
+	// an empty block lowers it by an eighth
+	assert.Equal(t, big.NewInt(7_000_000_000), market.NextBaseFee(baseFee, 0, minFee))
+	// it never goes under the minimal fee
+	assert.Equal(t, minFee, market.NextBaseFee(minFee, 0, minFee))
+	// it goes up by one at least over the target
+	assert.Equal(t, big.NewInt(2), market.NextBaseFee(big.NewInt(1), 1_000_001, big.NewInt(1)))
+
+	off := BaseFeeOption{}
+	assert.False(t, off.Enabled())
+	assert.Equal(t, baseFee, off.NextBaseFee(baseFee, 2_000_000, minFee))
+}
+
+func TestStore_UpdateBaseFee(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("fees", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("f", cs)
+
+	t.Run("market off keeps the minimal fee", func(t *testing.T) {
+		opt := newFeeOption(BaseFeeOption{})
+		store.SetupOpt(opt)
+
+		blockFee, err := store.UpdateBaseFee(10_000_000)
+		assert.NoError(t, err)
+		assert.Nil(t, blockFee)
+		assert.Equal(t, opt.MinFee(), opt.BaseFee())
+	})
+
+	t.Run("base fee follows the gas used", func(t *testing.T) {
+		opt := newFeeOption(BaseFeeOption{TargetGas: 1_000_000, ChangeDenominator: 8})
+		store.SetupOpt(opt)
+		assert.NoError(t, store.LoadBaseFee())
+		minFee := opt.MinFee().Amount.BigInt()
+		assert.Equal(t, minFee, opt.BaseFee().Amount.BigInt())
+
+		blockFee, err := store.UpdateBaseFee(2_000_000)
+		assert.NoError(t, err)
+		assert.Equal(t, minFee, blockFee.BaseFee.BigInt())
+		next := new(big.Int).Add(minFee, new(big.Int).Div(minFee, big.NewInt(8)))
+		assert.Equal(t, next, blockFee.NextBaseFee.BigInt())
+		cs.Commit()
+
+		// the option of the next block is read from governance again
+		opt = newFeeOption(opt.BaseFeeMarket)
+		store.SetupOpt(opt)
+		assert.NoError(t, store.LoadBaseFee())
+		assert.Equal(t, next, opt.BaseFee().Amount.BigInt())
+
+		stored, err := store.GetBlockFee()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2_000_000), stored.GasUsed)
+	})
+}
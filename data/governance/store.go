@@ -19,6 +19,7 @@ import (
 	"github.com/Oneledger/protocol/chains/bitcoin"
 	ethchain "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/serialize"
@@ -33,6 +34,7 @@ const (
 	ADMIN_EPOCH_BLOCK_INTERVAL string = "epoch"
 
 	ADMIN_ETH_CHAINDRIVER_OPTION string = "ethcdopt"
+	ADMIN_EVM_CHAINS_OPTION      string = "evmchains"
 
 	ADMIN_BTC_CHAINDRIVER_OPTION string = "btccdopt"
 	ADMIN_ONS_OPTION             string = "onsopt"
@@ -47,6 +49,8 @@ const (
 
 	ADMIN_NETWK_DELEG_OPTION string = "networkdelegopt"
 
+	ADMIN_BRIDGE_OPTION string = "bridgeopt"
+
 	TOTAL_FUNDS_PREFIX string = "t"
 
 	INDIVIDUAL_FUNDS_PREFIX string = "i"
@@ -55,6 +59,7 @@ const (
 	LAST_UPDATE_HEIGHT_CURRENCY    string = "currencyOptions"
 	LAST_UPDATE_HEIGHT_FEE         string = "feeOptions"
 	LAST_UPDATE_HEIGHT_ETH         string = "ethOptions"
+	LAST_UPDATE_HEIGHT_EVM_CHAINS  string = "evmChainsOptions"
 	LAST_UPDATE_HEIGHT_BTC         string = "btcOptions"
 	LAST_UPDATE_HEIGHT_REWARDS     string = "rewardsOptions"
 	LAST_UPDATE_HEIGHT_STAKING     string = "stakingOptions"
@@ -62,6 +67,7 @@ const (
 	LAST_UPDATE_HEIGHT_ONS         string = "onsOptions"
 	LAST_UPDATE_HEIGHT_PROPOSAL    string = "proposalOptions"
 	LAST_UPDATE_HEIGHT_EVIDENCE    string = "evidenceOptions"
+	LAST_UPDATE_HEIGHT_BRIDGE      string = "bridgeOptions"
 	HEIGHT_INDEPENDENT_VALUE       string = "heightindependent"
 
 	// Pool names
@@ -169,10 +175,18 @@ func (st *Store) SetAllLUH() error {
 	if err != nil {
 		return err
 	}
+	err = st.SetLUH(LAST_UPDATE_HEIGHT_EVM_CHAINS)
+	if err != nil {
+		return err
+	}
 	err = st.SetLUH(LAST_UPDATE_HEIGHT_BTC)
 	if err != nil {
 		return err
 	}
+	err = st.SetLUH(LAST_UPDATE_HEIGHT_BRIDGE)
+	if err != nil {
+		return err
+	}
 	err = st.SetLUH(LAST_UPDATE_HEIGHT_REWARDS)
 	if err != nil {
 		return err
@@ -253,7 +267,7 @@ func (st *Store) GetFeeOption() (*fees.FeeOption, error) {
 	return feeOpt, nil
 }
 
-func (st *Store) SetFeeOption(feeOpt fees.FeeOption) error {
+func (st *Store) SetFeeOption(feeOpt *fees.FeeOption) error {
 
 	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(feeOpt)
 	if err != nil {
@@ -398,6 +412,84 @@ func (st *Store) SetETHChainDriverOption(opt ethchain.ChainDriverOption) error {
 	return nil
 }
 
+// GetEVMChainRegistry returns the evm chains bridged besides ethereum, chains started before
+// the registry existed get an empty one
+func (st *Store) GetEVMChainRegistry() (*ethchain.EVMChainRegistry, error) {
+	r := &ethchain.EVMChainRegistry{Chains: []ethchain.EVMChain{}}
+	luh, err := st.GetUnversioned(LAST_UPDATE_HEIGHT, LAST_UPDATE_HEIGHT_EVM_CHAINS)
+	if err != nil || len(luh) == 0 {
+		return r, nil
+	}
+
+	bytes, err := st.Get(ADMIN_EVM_CHAINS_OPTION, LAST_UPDATE_HEIGHT_EVM_CHAINS)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) == 0 {
+		return r, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize evm chain registry stored")
+	}
+
+	return r, nil
+}
+
+func (st *Store) SetEVMChainRegistry(registry ethchain.EVMChainRegistry) error {
+
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(registry)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize evm chain registry")
+	}
+
+	err = st.Set(ADMIN_EVM_CHAINS_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the evm chain registry")
+	}
+
+	return nil
+}
+
+// GetBridgeOptions returns the limits of the bridged assets, chains started before the bridge
+// had options get empty ones
+func (st *Store) GetBridgeOptions() (*bridge.Options, error) {
+	opt := &bridge.Options{Limits: []bridge.Limit{}}
+	luh, err := st.GetUnversioned(LAST_UPDATE_HEIGHT, LAST_UPDATE_HEIGHT_BRIDGE)
+	if err != nil || len(luh) == 0 {
+		return opt, nil
+	}
+
+	bytes, err := st.Get(ADMIN_BRIDGE_OPTION, LAST_UPDATE_HEIGHT_BRIDGE)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) == 0 {
+		return opt, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize bridge options stored")
+	}
+
+	return opt, nil
+}
+
+func (st *Store) SetBridgeOptions(opt bridge.Options) error {
+
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(opt)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize bridge options")
+	}
+
+	err = st.Set(ADMIN_BRIDGE_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the bridge options")
+	}
+
+	return nil
+}
+
 func (st *Store) GetBTCChainDriverOption() (*bitcoin.ChainDriverOption, error) {
 
 	bytes, err := st.Get(ADMIN_BTC_CHAINDRIVER_OPTION, LAST_UPDATE_HEIGHT_BTC)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Oneledger/protocol/chains/bitcoin"
 	ethchain "github.com/Oneledger/protocol/chains/ethereum"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/evidence"
 	"github.com/Oneledger/protocol/data/fees"
@@ -18,7 +19,9 @@ import (
 type GovernanceState struct {
 	FeeOption       fees.FeeOption             `json:"feeOption"`
 	ETHCDOption     ethchain.ChainDriverOption `json:"ethchaindriverOption"`
+	EVMChains       ethchain.EVMChainRegistry  `json:"evmChains"`
 	BTCCDOption     bitcoin.ChainDriverOption  `json:"bitcoinChainDriverOption"`
+	BridgeOptions   bridge.Options             `json:"bridgeOptions"`
 	ONSOptions      ons.Options                `json:"onsOptions"`
 	PropOptions     ProposalOptionSet          `json:"propOptions"`
 	StakingOptions  delegation.Options         `json:"stakingOptions"`
@@ -10,6 +10,7 @@ import (
 	"github.com/Oneledger/protocol/chains/bitcoin"
 	ethchain "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/evidence"
 	"github.com/Oneledger/protocol/data/fees"
@@ -56,8 +57,12 @@ var (
 	minBaseDomainPrice = balance.NewAmountFromInt(0)
 	maxBaseDomainPrice = infiniteMaxBalance
 	//FEE
-	minFeeDecimal = int64(0)
-	maxFeeDecimal = int64(18)
+	minFeeDecimal         = int64(0)
+	maxFeeDecimal         = int64(18)
+	minBaseFeeTargetGas   = int64(0)
+	maxBaseFeeTargetGas   = int64(math.MaxInt64)
+	minBaseFeeDenominator = int64(0)
+	maxBaseFeeDenominator = int64(1000)
 	//ETH
 	minBlockConfirmation = int64(0)
 	maxBlockConfirmation = int64(50)
@@ -76,6 +81,9 @@ var (
 	maxPenaltyBasePercentage   = int64(40)
 	minValidatorVotePercentage = int64(50)
 	maxValidatorVotePercentage = int64(100)
+	//Rewards
+	minBlockSpeedCalculateCycle = int64(10)
+	maxBlockSpeedCalculateCycle = int64(10000)
 	// can be between 0 -100, PenaltyBurnPercentage + PenaltyBountyPercentage is always 100
 )
 
@@ -96,6 +104,14 @@ func (st *Store) ValidateGov(govstate GovernanceState) (bool, error) {
 	if err != nil || !ok {
 		return false, err
 	}
+	ok, err = st.ValidateEVMChains(&govstate.EVMChains)
+	if err != nil || !ok {
+		return false, err
+	}
+	ok, err = st.ValidateBridge(&govstate.BridgeOptions)
+	if err != nil || !ok {
+		return false, err
+	}
 	ok, err = st.ValidateStaking(&govstate.StakingOptions)
 	if err != nil || !ok {
 		return false, err
@@ -106,7 +122,7 @@ func (st *Store) ValidateGov(govstate GovernanceState) (bool, error) {
 	}
 	ok, err = st.ValidateRewards(&govstate.RewardOptions)
 	if err != nil || !ok {
-		return false, errors.New("reward options cannot be changed ")
+		return false, err
 	}
 	ok, err = st.ValidateEvidence(&govstate.EvidenceOptions)
 	if err != nil || !ok {
@@ -149,6 +165,12 @@ func (st *Store) ValidateFee(opt *fees.FeeOption) (bool, error) {
 	if !verifyRangeInt64(opt.MinFeeDecimal, minFeeDecimal, maxFeeDecimal) {
 		return false, errors.New("fee Decimal should be between 0 and 18")
 	}
+	if !verifyRangeInt64(opt.BaseFeeMarket.TargetGas, minBaseFeeTargetGas, maxBaseFeeTargetGas) {
+		return false, errors.New("base fee target gas cannot be negative")
+	}
+	if !verifyRangeInt64(opt.BaseFeeMarket.ChangeDenominator, minBaseFeeDenominator, maxBaseFeeDenominator) {
+		return false, errors.New("base fee change denominator should be between 0 and 1000")
+	}
 	return true, nil
 }
 
@@ -183,6 +205,28 @@ func (st *Store) ValidateETH(opt *ethchain.ChainDriverOption) (bool, error) {
 	return reflect.DeepEqual(oldOptions, opt), nil
 }
 
+func (st *Store) ValidateEVMChains(registry *ethchain.EVMChainRegistry) (bool, error) {
+	err := registry.Validate()
+	if err != nil {
+		return false, err
+	}
+	for _, c := range registry.Chains {
+		if c.Option.BlockConfirmation < minBlockConfirmation || c.Option.BlockConfirmation > maxBlockConfirmation {
+			return false, errors.Errorf("block confirmation of evm chain %s should be between %d and %d",
+				c.Name, minBlockConfirmation, maxBlockConfirmation)
+		}
+	}
+	return true, nil
+}
+
+func (st *Store) ValidateBridge(opt *bridge.Options) (bool, error) {
+	err := opt.Validate()
+	if err != nil {
+		return false, bridge.ErrInvalidOptions.Wrap(err)
+	}
+	return true, nil
+}
+
 func (st *Store) ValidateBTC(opt *bitcoin.ChainDriverOption) (bool, error) {
 	oldOptions, err := st.GetBTCChainDriverOption()
 	if err != nil {
@@ -296,7 +340,36 @@ func (st *Store) ValidateRewards(opt *rewards.Options) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return reflect.DeepEqual(oldOptions, opt), nil
+	if opt.RewardInterval != oldOptions.RewardInterval {
+		return false, errors.New("reward interval cannot be changed")
+	}
+	if opt.RewardPoolAddress != oldOptions.RewardPoolAddress {
+		return false, errors.New("reward pool address cannot be changed")
+	}
+	if opt.RewardCurrency != oldOptions.RewardCurrency {
+		return false, errors.New("reward currency cannot be changed")
+	}
+	if opt.EstimatedSecondsPerCycle != oldOptions.EstimatedSecondsPerCycle {
+		return false, errors.New("estimated seconds per cycle cannot be changed")
+	}
+	if opt.YearCloseWindow != oldOptions.YearCloseWindow {
+		return false, errors.New("year close window cannot be changed")
+	}
+	if !verifyRangeInt64(opt.BlockSpeedCalculateCycle, minBlockSpeedCalculateCycle, maxBlockSpeedCalculateCycle) {
+		return false, errors.New("block speed calculate cycle not within range")
+	}
+	if len(opt.YearBlockRewardShares) < len(oldOptions.YearBlockRewardShares) {
+		return false, errors.New("reward years cannot be removed")
+	}
+	for _, share := range opt.YearBlockRewardShares {
+		if share.BigInt().Sign() < 0 {
+			return false, errors.New("year block reward share cannot be negative")
+		}
+	}
+	if opt.BurnoutRate.BigInt().Sign() < 0 {
+		return false, errors.New("burnout rate cannot be negative")
+	}
+	return true, nil
 }
 
 func (st *Store) ValidateEvidence(opt *evidence.Options) (bool, error) {
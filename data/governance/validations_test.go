@@ -321,6 +321,20 @@ func TestStore_ValidateRewards(t *testing.T) {
 	ok, err = vStore.ValidateRewards(&updates.RewardOptions)
 	assert.NoError(t, err, "Should Pass")
 	assert.True(t, ok)
+	updates = generateGov()
+	updates.RewardOptions.BurnoutRate = *balance.NewAmountFromInt(10)
+	updates.RewardOptions.BlockSpeedCalculateCycle = 200
+	ok, err = vStore.ValidateRewards(&updates.RewardOptions)
+	assert.NoError(t, err, "Should Pass")
+	assert.True(t, ok)
+	updates = generateGov()
+	updates.RewardOptions.YearBlockRewardShares = updates.RewardOptions.YearBlockRewardShares[1:]
+	ok, err = vStore.ValidateRewards(&updates.RewardOptions)
+	assert.False(t, ok, "Cannot remove reward years")
+	updates = generateGov()
+	updates.RewardOptions.RewardCurrency = "VT"
+	ok, err = vStore.ValidateRewards(&updates.RewardOptions)
+	assert.False(t, ok, "Cannot Be changed ")
 }
 
 func TestStore_ValidateEvidence(t *testing.T) {
@@ -379,6 +393,7 @@ func generateGov() *GovernanceState {
 		"1000000000",
 		"oneledgerSupplyAddress",
 		int64(6),
+		false,
 	}
 
 	propOpt := ProposalOptionSet{
@@ -472,7 +487,7 @@ func generateGov() *GovernanceState {
 		AllegationPercentage: 66,
 		AllegationDecimals:   100,
 	}
-	err := vStore.WithHeight(0).SetFeeOption(feeOpt)
+	err := vStore.WithHeight(0).SetFeeOption(&feeOpt)
 	if err != nil {
 		fmt.Println(err)
 	}
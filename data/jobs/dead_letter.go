@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func jobKey(c chain.Type, jobID string) storage.StoreKey {
+	return storage.StoreKey("job:" + c.String() + ":" + jobID)
+}
+
+func attemptsKey(c chain.Type, jobID string) storage.StoreKey {
+	return storage.StoreKey("attempts:" + c.String() + ":" + jobID)
+}
+
+func deadLetterKey(c chain.Type, jobID string) storage.StoreKey {
+	return storage.StoreKey("dead:" + c.String() + ":" + jobID)
+}
+
+// GetAttempts returns the failed attempts in a row of a job, nil when its last attempt went through
+func (js *JobStore) GetAttempts(jobID string) (*Attempts, error) {
+	return js.GetChainAttempts(js.chain, jobID)
+}
+
+// GetChainAttempts returns the failed attempts in a row of a job of the given chain
+func (js *JobStore) GetChainAttempts(c chain.Type, jobID string) (*Attempts, error) {
+	dat, err := js.Get(attemptsKey(c, jobID))
+	if err == storage.ErrNotFound || len(dat) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	attempts := &Attempts{}
+	err = js.ser.Deserialize(dat, attempts)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// FailAttempt records a failed attempt of a job and schedules the next one with the retry policy of
+// its type. The job is dead-lettered once it is out of retries, the returned flag tells so
+func (js *JobStore) FailAttempt(job Job, cause error, now time.Time) (*Attempts, bool, error) {
+	attempts, err := js.GetAttempts(job.GetJobID())
+	if err != nil {
+		return nil, false, err
+	}
+	if attempts == nil {
+		attempts = &Attempts{JobID: job.GetJobID(), Type: job.GetType(), FirstFailure: now}
+	}
+	policy := GetRetryPolicy(job.GetType())
+	attempts.Failed += 1
+	attempts.LastError = cause.Error()
+	attempts.NextAttempt = now.Add(policy.Delay(attempts.Failed))
+
+	if policy.Exhausted(attempts, now) {
+		letter := &DeadLetter{Job: job, Attempts: *attempts, Dead: now}
+		return attempts, true, js.save(deadLetterKey(js.chain, job.GetJobID()), letter)
+	}
+	return attempts, false, js.save(attemptsKey(js.chain, job.GetJobID()), attempts)
+}
+
+// ClearAttempts forgets the failed attempts of a job once one went through
+func (js *JobStore) ClearAttempts(jobID string) error {
+	return js.delete(attemptsKey(js.chain, jobID))
+}
+
+// IsDead tells whether a job is in the dead-letter queue, the job bus leaves those alone
+func (js *JobStore) IsDead(jobID string) bool {
+	ok, _ := js.Exists(deadLetterKey(js.chain, jobID))
+	return ok
+}
+
+// GetDeadLetter returns a dead-lettered job of a chain
+func (js *JobStore) GetDeadLetter(c chain.Type, jobID string) (*DeadLetter, error) {
+	dat, err := js.Get(deadLetterKey(c, jobID))
+	if err != nil && err != storage.ErrNotFound {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, errors.Errorf("job %s of %s is not dead-lettered", jobID, c)
+	}
+	letter := &DeadLetter{}
+	err = js.ser.Deserialize(dat, letter)
+	if err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
+
+// IterateDeadLetters goes through the dead-lettered jobs of a chain
+func (js *JobStore) IterateDeadLetters(c chain.Type, fn func(letter *DeadLetter)) {
+	start := []byte("dead:" + c.String() + ":")
+	end := []byte("dead:" + c.String() + storage.DB_RANGEFIX)
+
+	session := js.BeginSession()
+	session.GetIterable().IterateRange(start, end, true, func(key, val []byte) bool {
+		letter := &DeadLetter{}
+		err := js.ser.Deserialize(val, letter)
+		if err != nil {
+			return false
+		}
+		fn(letter)
+		return false
+	})
+}
+
+// Requeue takes a job of a chain out of the dead-letter queue, the job bus attempts it right away
+func (js *JobStore) Requeue(c chain.Type, jobID string) error {
+	_, err := js.GetDeadLetter(c, jobID)
+	if err != nil {
+		return err
+	}
+	return js.delete(deadLetterKey(c, jobID), attemptsKey(c, jobID))
+}
+
+// Cancel drops a job of a chain, with its failed attempts and its dead letter if any
+func (js *JobStore) Cancel(c chain.Type, jobID string) error {
+	ok, err := js.Exists(jobKey(c, jobID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("no job %s of %s", jobID, c)
+	}
+	return js.delete(jobKey(c, jobID), attemptsKey(c, jobID), deadLetterKey(c, jobID))
+}
+
+func (js *JobStore) save(key storage.StoreKey, obj interface{}) error {
+	dat, err := js.ser.Serialize(obj)
+	if err != nil {
+		return err
+	}
+
+	js.lock.Lock()
+	defer js.lock.Unlock()
+	session := js.BeginSession()
+	err = session.Set(key, dat)
+	if err != nil {
+		return err
+	}
+	if !session.Commit() {
+		return errors.New("err commiting to job store")
+	}
+	return nil
+}
+
+func (js *JobStore) delete(keys ...storage.StoreKey) error {
+	js.lock.Lock()
+	defer js.lock.Unlock()
+	session := js.BeginSession()
+	for _, key := range keys {
+		_, err := session.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	if !session.Commit() {
+		return errors.New("error committing to job store")
+	}
+	return nil
+}
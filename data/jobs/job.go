@@ -12,6 +12,15 @@ type Job interface {
 	GetJobID() string
 }
 
+// Retryable is a job that tells the job bus why its last attempt failed, the bus retries it with
+// the retry policy of its type
+type Retryable interface {
+	Job
+	// AttemptError is the error of the running attempt, the bus retries the job while it is set
+	// and moves it to the dead letters once its policy gives up
+	AttemptError() error
+}
+
 type Status int
 
 const (
@@ -69,7 +69,12 @@ func (js *JobStore) JobExists(jobID string) (bool, error) {
 }
 
 func (js *JobStore) GetJob(jobID string) (Job, error) {
-	key := storage.StoreKey("job:" + js.chain.String() + ":" + jobID)
+	return js.GetChainJob(js.chain, jobID)
+}
+
+// GetChainJob returns a job of the given chain
+func (js *JobStore) GetChainJob(c chain.Type, jobID string) (Job, error) {
+	key := jobKey(c, jobID)
 	dat, err := js.Get(key)
 	if err != nil {
 		return nil, errors.Wrap(err, key.String())
@@ -82,29 +87,20 @@ func (js *JobStore) GetJob(jobID string) (Job, error) {
 	return job, err
 }
 
+// DeleteJob drops a job with its failed attempts and its dead letter, a job saved again with the
+// same id starts over
 func (js *JobStore) DeleteJob(job Job) error {
-
-	key := storage.StoreKey("job:" + js.chain.String() + ":" + job.GetJobID())
-
-	js.lock.Lock()
-	session := js.BeginSession()
-
-	_, err := session.Delete(key)
-	if err != nil {
-		return err
-	}
-
-	ok := session.Commit()
-	js.lock.Unlock()
-	if !ok {
-		return errors.New("error committing to job store")
-	}
-	return nil
+	return js.delete(jobKey(js.chain, job.GetJobID()), attemptsKey(js.chain, job.GetJobID()), deadLetterKey(js.chain, job.GetJobID()))
 }
 
 func (js *JobStore) Iterate(fn func(job Job)) {
-	start := []byte("job:" + js.chain.String() + ":")
-	end := []byte("job:" + js.chain.String() + storage.DB_RANGEFIX)
+	js.IterateChain(js.chain, fn)
+}
+
+// IterateChain goes through the jobs of the given chain
+func (js *JobStore) IterateChain(c chain.Type, fn func(job Job)) {
+	start := []byte("job:" + c.String() + ":")
+	end := []byte("job:" + c.String() + storage.DB_RANGEFIX)
 	isAsc := true
 
 	session := js.BeginSession()
@@ -121,3 +117,33 @@ func (js *JobStore) Iterate(fn func(job Job)) {
 	})
 
 }
+
+func resetsKey(c chain.Type, name string) storage.StoreKey {
+	return storage.StoreKey("resets:" + c.String() + ":" + name)
+}
+
+// GetResets returns how many times this node dropped the jobs of a process so that they run again
+func (js *JobStore) GetResets(name string) (int64, error) {
+	dat, err := js.Get(resetsKey(js.chain, name))
+	if err == storage.ErrNotFound || len(dat) == 0 {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var resets int64
+	err = js.ser.Deserialize(dat, &resets)
+	if err != nil {
+		return 0, err
+	}
+	return resets, nil
+}
+
+func (js *JobStore) SetResets(name string, resets int64) error {
+	return js.save(resetsKey(js.chain, name), resets)
+}
+
+// DeleteResets forgets the resets of a process once it is over
+func (js *JobStore) DeleteResets(name string) error {
+	return js.delete(resetsKey(js.chain, name))
+}
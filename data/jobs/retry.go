@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryPolicy tells the job bus how to retry a job whose attempts fail
+type RetryPolicy struct {
+	// failed attempts in a row before the job is dead-lettered
+	MaxAttempts int
+	// delay after the first failed attempt, it doubles with every other one up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// share of the delay added or taken at random, so the nodes do not all retry at once
+	Jitter float64
+	// time from the first failed attempt of a row after which the job is dead-lettered, none when zero
+	Deadline time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 10,
+	Backoff:     5 * time.Second,
+	MaxBackoff:  10 * time.Minute,
+	Jitter:      0.2,
+	Deadline:    24 * time.Hour,
+}
+
+var (
+	policies    = make(map[string]RetryPolicy)
+	policiesMtx sync.RWMutex
+)
+
+// SetRetryPolicy sets the retry policy of a job type
+func SetRetryPolicy(jobType string, policy RetryPolicy) {
+	policiesMtx.Lock()
+	defer policiesMtx.Unlock()
+
+	policies[jobType] = policy
+}
+
+// GetRetryPolicy returns the retry policy of a job type, the default one when it has none
+func GetRetryPolicy(jobType string) RetryPolicy {
+	policiesMtx.RLock()
+	defer policiesMtx.RUnlock()
+
+	policy, ok := policies[jobType]
+	if !ok {
+		return DefaultRetryPolicy
+	}
+	return policy
+}
+
+// Delay returns the time to wait before the next attempt after the given number of failed ones
+func (p RetryPolicy) Delay(failed int) time.Duration {
+	if failed < 1 {
+		return 0
+	}
+	delay := p.Backoff
+	for i := 1; i < failed && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// Exhausted tells whether a job with these failed attempts is out of retries at the given time
+func (p RetryPolicy) Exhausted(a *Attempts, now time.Time) bool {
+	if p.MaxAttempts > 0 && a.Failed >= p.MaxAttempts {
+		return true
+	}
+	return p.Deadline > 0 && now.Sub(a.FirstFailure) >= p.Deadline
+}
+
+// Attempts keeps the failed attempts in a row of a job, a successful attempt clears them
+type Attempts struct {
+	JobID        string    `json:"jobId"`
+	Type         string    `json:"type"`
+	Failed       int       `json:"failed"`
+	FirstFailure time.Time `json:"firstFailure"`
+	NextAttempt  time.Time `json:"nextAttempt"`
+	LastError    string    `json:"lastError"`
+}
+
+// Due tells whether the job may be attempted again at the given time
+func (a *Attempts) Due(now time.Time) bool {
+	return a == nil || !now.Before(a.NextAttempt)
+}
+
+// DeadLetter is a job the job bus gave up on, it stays there until it is requeued or cancelled
+type DeadLetter struct {
+	Job      Job       `json:"job"`
+	Attempts Attempts  `json:"attempts"`
+	Dead     time.Time `json:"dead"`
+}
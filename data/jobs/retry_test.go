@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, MaxBackoff: 10 * time.Second}
+	assert.Equal(t, time.Duration(0), p.Delay(0))
+	assert.Equal(t, time.Second, p.Delay(1))
+	assert.Equal(t, 4*time.Second, p.Delay(3))
+	assert.Equal(t, 10*time.Second, p.Delay(5))
+	assert.Equal(t, 10*time.Second, p.Delay(100))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.Delay(2)
+		assert.True(t, d >= time.Second && d <= 3*time.Second, d)
+	}
+}
+
+func TestRetryPolicy_Exhausted(t *testing.T) {
+	now := time.Now()
+	p := RetryPolicy{MaxAttempts: 3, Deadline: time.Hour}
+	assert.False(t, p.Exhausted(&Attempts{Failed: 2, FirstFailure: now}, now))
+	assert.True(t, p.Exhausted(&Attempts{Failed: 3, FirstFailure: now}, now))
+	assert.True(t, p.Exhausted(&Attempts{Failed: 1, FirstFailure: now.Add(-time.Hour)}, now))
+
+	unlimited := RetryPolicy{}
+	assert.False(t, unlimited.Exhausted(&Attempts{Failed: 1000, FirstFailure: now.Add(-1000 * time.Hour)}, now))
+}
+
+func TestRetryPolicy_Registry(t *testing.T) {
+	assert.Equal(t, DefaultRetryPolicy, GetRetryPolicy("unknown"))
+	SetRetryPolicy("test", RetryPolicy{MaxAttempts: 1})
+	assert.Equal(t, 1, GetRetryPolicy("test").MaxAttempts)
+}
@@ -13,4 +13,5 @@ var (
 	ErrGettingPendingDelgAmount      = codes.ProtocolError{codes.NetDelgErrGettingPendingDelgAmount, "failed to get pending network delegation amount"}
 	ErrInitiateWithdrawal            = codes.ProtocolError{codes.NetDelgErrWithdraw, "failed to initiate rewards withdrawal"}
 	ErrReinvestRewards               = codes.ProtocolError{codes.NetDelgErrReinvest, "failed to reinvest rewards"}
+	ErrAutoCompound                  = codes.ProtocolError{codes.NetDelgErrAutoCompound, "failed to update rewards auto compound"}
 )
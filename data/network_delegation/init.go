@@ -19,6 +19,9 @@ const (
 	COMMISSION_PERCENTAGE     = 25
 	BLOCK_PROPOSER_COMMISSION = 20
 
+	// lowest rewards balance a delegator can set to trigger auto compounding, 1 OLT
+	MIN_AUTO_COMPOUND_AMOUNT = "1000000000000000000"
+
 	//TODO this is hardcoded for now, will be changed in the future
 	RewardsMaturityTime = 4
 )
@@ -61,6 +61,17 @@ func (drs *DelegRewardStore) GetRewardsBalance(delegator keys.Address) (amt *bal
 	return
 }
 
+// Get rewards balance as of the state version
+func (drs *DelegRewardStore) GetVersionedRewardsBalance(version int64, delegator keys.Address) (amt *balance.Amount, err error) {
+	dat := drs.state.GetVersioned(version, drs.getRewardsBalanceKey(delegator))
+	amt = balance.NewAmount(0)
+	if len(dat) == 0 {
+		return
+	}
+	err = drs.szlr.Deserialize(dat, amt)
+	return
+}
+
 // Get total rewards
 func (drs *DelegRewardStore) GetTotalRewards() (amt *balance.Amount, err error) {
 	key := drs.getTotalRewardsKey()
@@ -134,6 +145,61 @@ func (drs *DelegRewardStore) IterateActiveRewards(fn func(addr *keys.Address, am
 	})
 }
 
+// Enable auto compounding of rewards for a delegator, rewards balance is reinvested once it reaches the threshold
+func (drs *DelegRewardStore) SetAutoCompound(delegator keys.Address, threshold *balance.Amount) error {
+	key := drs.getAutoCompoundKey(delegator)
+	err := drs.set(key, threshold)
+	return err
+}
+
+// Disable auto compounding of rewards for a delegator
+func (drs *DelegRewardStore) DisableAutoCompound(delegator keys.Address) error {
+	key := drs.getAutoCompoundKey(delegator)
+	_, err := drs.state.Delete(key)
+	return err
+}
+
+// Get auto compounding threshold of a delegator, nil if not enabled
+func (drs *DelegRewardStore) GetAutoCompound(delegator keys.Address) (threshold *balance.Amount, err error) {
+	key := drs.getAutoCompoundKey(delegator)
+	dat, err := drs.state.Get(key)
+	if err != nil || len(dat) == 0 {
+		return
+	}
+	threshold = balance.NewAmount(0)
+	err = drs.szlr.Deserialize(dat, threshold)
+	return
+}
+
+// iterate delegators who enabled auto compounding
+func (drs *DelegRewardStore) IterateAutoCompound(fn func(delegator keys.Address, threshold *balance.Amount) bool) bool {
+	prefix := append(drs.prefix, storage.Prefix("autocompound")...)
+	return drs.state.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			// disabled in current block
+			if len(value) == 0 {
+				return false
+			}
+			threshold := balance.NewAmount(0)
+			err := drs.szlr.Deserialize(value, threshold)
+			if err != nil {
+				logger.Error("failed to deserialize delegator auto compound threshold")
+				return false
+			}
+			addr := keys.Address{}
+			err = addr.UnmarshalText(key[len(prefix):])
+			if err != nil {
+				logger.Error("failed to deserialize delegator address")
+				return false
+			}
+			return fn(addr, threshold)
+		},
+	)
+}
+
 // below is removed since finalize withdraw rewards logic is moved to block beginner, OLP-1266
 //// Mature, if any, all delegators' pending withdrawal at a specific height
 //func (drs *DelegRewardStore) MaturePendingRewards(height int64) (event abciTypes.Event, any bool) {
@@ -358,6 +424,12 @@ func (drs *DelegRewardStore) getRewardsBalanceKey(delegator keys.Address) []byte
 	return storage.StoreKey(key)
 }
 
+// Key for delegator auto compound threshold
+func (drs *DelegRewardStore) getAutoCompoundKey(delegator keys.Address) []byte {
+	key := fmt.Sprintf("%sautocompound_%s", string(drs.prefix), delegator)
+	return storage.StoreKey(key)
+}
+
 // Key for total rewards
 func (drs *DelegRewardStore) getTotalRewardsKey() []byte {
 	key := fmt.Sprintf("%stotal_rewards", string(drs.prefix))
@@ -439,10 +511,21 @@ func (drs *DelegRewardStore) SaveState() (*RewardState, bool) {
 		return false
 	})
 
+	var autoCompoundList []Reward
+	//Populate Auto Compound Thresholds
+	drs.IterateAutoCompound(func(delegator keys.Address, threshold *balance.Amount) bool {
+		autoCompoundList = append(autoCompoundList, Reward{
+			Amount:  threshold,
+			Address: delegator,
+		})
+		return false
+	})
+
 	return &RewardState{
 		BalanceList: balanceList,
 		//MatureList:  matureList,
-		PendingList: pendingList,
+		PendingList:      pendingList,
+		AutoCompoundList: autoCompoundList,
 	}, true
 }
 
@@ -470,5 +553,11 @@ func (drs *DelegRewardStore) LoadState(state *RewardState) error {
 			return err
 		}
 	}
+	for _, v := range state.AutoCompoundList {
+		err := drs.SetAutoCompound(v.Address, v.Amount)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	db "github.com/tendermint/tm-db"
 
+	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/storage"
@@ -329,3 +330,68 @@ func TestStore_IterateAllPendingAmounts(t *testing.T) {
 	})
 	assert.Equal(t, 4, count)
 }
+
+func TestDelegRewardStore_AutoCompound(t *testing.T) {
+	setup()
+	threshold, err := storeRwz.GetAutoCompound(delegators[0])
+	assert.Nil(t, err)
+	assert.Nil(t, threshold)
+
+	err = storeRwz.SetAutoCompound(delegators[0], amt1)
+	assert.Nil(t, err)
+	err = storeRwz.SetAutoCompound(delegators[1], amt2)
+	assert.Nil(t, err)
+	storeRwz.state.Commit()
+
+	threshold, err = storeRwz.GetAutoCompound(delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, amt1, threshold)
+
+	// opt out
+	err = storeRwz.DisableAutoCompound(delegators[0])
+	assert.Nil(t, err)
+	storeRwz.state.Commit()
+
+	threshold, err = storeRwz.GetAutoCompound(delegators[0])
+	assert.Nil(t, err)
+	assert.Nil(t, threshold)
+
+	count := 0
+	storeRwz.IterateAutoCompound(func(delegator keys.Address, threshold *balance.Amount) bool {
+		assert.Equal(t, delegators[1], delegator)
+		assert.Equal(t, amt2, threshold)
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestDelegRewardStore_GetVersionedRewardsBalance(t *testing.T) {
+	setup()
+	// keep older versions
+	chainstate := storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, ""))
+	err := chainstate.SetupRotation(config.ChainStateRotationCfg{Recent: 10})
+	assert.Nil(t, err)
+	storeRwz = NewDelegRewardStore("delegRwz", storage.NewState(chainstate))
+
+	err = storeRwz.AddRewardsBalance(delegators[0], amt1)
+	assert.Nil(t, err)
+	_, v1 := storeRwz.state.Commit()
+
+	err = storeRwz.AddRewardsBalance(delegators[0], amt2)
+	assert.Nil(t, err)
+	_, v2 := storeRwz.state.Commit()
+
+	amt, err := storeRwz.GetVersionedRewardsBalance(v1, delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, amt1, amt)
+
+	amt, err = storeRwz.GetVersionedRewardsBalance(v2, delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, amt1.Plus(*amt2), amt)
+
+	// nothing before the first version
+	amt, err = storeRwz.GetVersionedRewardsBalance(v1-1, delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, zero, amt)
+}
@@ -37,9 +37,10 @@ type State struct {
 }
 
 type RewardState struct {
-	BalanceList []Reward        `json:"balance_list"`
-	MatureList  []Reward        `json:"mature_list"`
-	PendingList []PendingReward `json:"pending_list"`
+	BalanceList      []Reward        `json:"balance_list"`
+	MatureList       []Reward        `json:"mature_list"`
+	PendingList      []PendingReward `json:"pending_list"`
+	AutoCompoundList []Reward        `json:"auto_compound_list"`
 }
 
 //------------------------ State Types End ------------------------------
@@ -164,6 +164,8 @@ func (calc *RewardCalculator) cacheResult(year int, cycleNo int64, amt *balance.
 
 func (rws *RewardCalculator) SetOptions(options *Options) {
 	rws.options = options
+	// recalculate the block reward with the new options at next block
+	rws.cached.cycleNo = 0
 }
 
 func (calc *RewardCalculator) Init(blockStore *tmstore.BlockStore) {
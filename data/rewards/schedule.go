@@ -0,0 +1,181 @@
+package rewards
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+)
+
+const secondsPerYear = int64(365 * 24 * 3600)
+
+// ScheduleYear is one year of the block reward issuance schedule
+type ScheduleYear struct {
+	Year        int            `json:"year"`
+	StartTime   time.Time      `json:"startTime"`
+	CloseTime   time.Time      `json:"closeTime"`
+	Share       balance.Amount `json:"share"`
+	Distributed balance.Amount `json:"distributed"`
+	Remaining   balance.Amount `json:"remaining"`
+	Closed      bool           `json:"closed"`
+}
+
+// Schedule is the issuance schedule as seen at a point of time
+type Schedule struct {
+	Years []ScheduleYear `json:"years"`
+	// index of the year in progress, -1 if all reward years are closed
+	CurrentYear int `json:"currentYear"`
+	// total amount still to be issued by the years not yet closed
+	Remaining balance.Amount `json:"remaining"`
+	// amount issued in the year in progress, estimated from the burnout rate once all years are closed
+	AnnualIssuance balance.Amount `json:"annualIssuance"`
+}
+
+// extend appends reward years to the end of the schedule when the options have more year shares than recorded
+func (rewards *RewardYears) extend(numofYears int) {
+	if len(rewards.Years) == 0 {
+		return
+	}
+	for len(rewards.Years) < numofYears {
+		tStart := rewards.Years[len(rewards.Years)-1].CloseTime
+		tClose := tStart.AddDate(1, 0, 0).UTC()
+		reward := RewardYear{
+			StartTime:     tStart,
+			CloseTime:     tClose,
+			Distributed:   balance.NewAmount(0),
+			TillLastCycle: balance.NewAmount(0),
+		}
+		logger.Infof("Extended year-%v [start: %s], [close: %s]", len(rewards.Years)+1, tStart, tClose)
+		rewards.Years = append(rewards.Years, reward)
+	}
+}
+
+// GetSchedule returns the issuance schedule for the options at the given time,
+// reward years are all open with nothing distributed if block rewards have not started yet
+func (rws *RewardCumulativeStore) GetSchedule(options *Options, now time.Time) (*Schedule, error) {
+	rewardYears, err := rws.getRewardYears(rws.getYearDistributedKey())
+	if err != nil && err != YearRewardsMissing {
+		return nil, err
+	}
+	rewardYears.extend(len(options.YearBlockRewardShares))
+
+	schedule := &Schedule{
+		Years:          make([]ScheduleYear, 0, len(options.YearBlockRewardShares)),
+		CurrentYear:    -1,
+		Remaining:      *balance.NewAmount(0),
+		AnnualIssuance: *balance.NewAmount(0),
+	}
+	for i, share := range options.YearBlockRewardShares {
+		year := ScheduleYear{
+			Year:        i + 1,
+			Share:       share,
+			Distributed: *balance.NewAmount(0),
+			Remaining:   share,
+		}
+		if i < len(rewardYears.Years) {
+			rewardYear := rewardYears.Years[i]
+			year.StartTime = rewardYear.StartTime
+			year.CloseTime = rewardYear.CloseTime
+			year.Distributed = *rewardYear.Distributed
+			year.Closed = !rewardYear.CloseTime.After(now)
+			if year.Closed {
+				year.Remaining = *balance.NewAmount(0)
+			} else if left, err := share.Minus(*rewardYear.Distributed); err == nil {
+				year.Remaining = *left
+			} else {
+				year.Remaining = *balance.NewAmount(0)
+			}
+			if !year.Closed && !rewardYear.StartTime.After(now) {
+				schedule.CurrentYear = i
+			}
+		} else if i == 0 {
+			schedule.CurrentYear = 0
+		}
+		schedule.Remaining = *schedule.Remaining.Plus(year.Remaining)
+		schedule.Years = append(schedule.Years, year)
+	}
+
+	if schedule.CurrentYear >= 0 {
+		schedule.AnnualIssuance = schedule.Years[schedule.CurrentYear].Share
+	} else if options.EstimatedSecondsPerCycle > 0 {
+		// burnout rate is paid per block until the reward pool is empty
+		blocks := secondsPerYear * options.BlockSpeedCalculateCycle / options.EstimatedSecondsPerCycle
+		issuance := big.NewInt(0).Mul(options.BurnoutRate.BigInt(), big.NewInt(blocks))
+		schedule.AnnualIssuance = *balance.NewAmountFromBigInt(issuance)
+	}
+	return schedule, nil
+}
+
+// ValidateScheduleUpdate checks the new year shares against what has been issued,
+// the years already closed cannot change, no year can go below its distributed amount
+// and the remaining schedule must be covered by the reward pool
+func (rws *RewardCumulativeStore) ValidateScheduleUpdate(oldOptions, newOptions *Options, poolBalance balance.Amount, now time.Time) error {
+	if len(newOptions.YearBlockRewardShares) < len(oldOptions.YearBlockRewardShares) {
+		return errors.New("reward years cannot be removed from the schedule")
+	}
+
+	schedule, err := rws.GetSchedule(oldOptions, now)
+	if err != nil {
+		return errors.Wrap(err, "failed to get reward schedule")
+	}
+
+	remaining := balance.NewAmount(0)
+	for i, share := range newOptions.YearBlockRewardShares {
+		if share.BigInt().Sign() < 0 {
+			return errors.Errorf("year-%v share cannot be negative", i+1)
+		}
+		if i >= len(schedule.Years) {
+			remaining = remaining.Plus(share)
+			continue
+		}
+		year := schedule.Years[i]
+		if year.Closed {
+			if !share.Equals(year.Share) {
+				return errors.Errorf("year-%v is closed and cannot be changed", i+1)
+			}
+			continue
+		}
+		left, err := share.Minus(year.Distributed)
+		if err != nil {
+			return errors.Errorf("year-%v share is less than the distributed amount %s", i+1, year.Distributed)
+		}
+		remaining = remaining.Plus(*left)
+	}
+
+	if poolBalance.LessThan(*remaining) {
+		return errors.Errorf("remaining schedule %s exceeds the reward pool balance %s", remaining, poolBalance.String())
+	}
+	return nil
+}
+
+// ProjectAPR estimates the yearly return, in percent, of validator stakes and network delegations.
+// Block rewards are shared by power between validators and the delegation pool, the validators
+// also collect the commission cut from the delegation rewards.
+func ProjectAPR(issuance *balance.Amount, validatorStake *balance.Amount, delegationStake *balance.Amount, commissionPercentage int64) (validatorAPR *big.Float, delegatorAPR *big.Float) {
+	validatorAPR = big.NewFloat(0)
+	delegatorAPR = big.NewFloat(0)
+
+	totalStake := big.NewInt(0).Add(validatorStake.BigInt(), delegationStake.BigInt())
+	if totalStake.Sign() <= 0 {
+		return
+	}
+
+	// base rate paid to each unit of power
+	rate := new(big.Float).Quo(issuance.BigFloat(), new(big.Float).SetInt(totalStake))
+	rate.Mul(rate, big.NewFloat(100))
+
+	commission := new(big.Float).Quo(big.NewFloat(float64(commissionPercentage)), big.NewFloat(100))
+	delegatorAPR.Mul(rate, new(big.Float).Sub(big.NewFloat(1), commission))
+
+	validatorAPR.Set(rate)
+	if validatorStake.BigInt().Sign() > 0 {
+		// commission on the delegation rewards, spread over validator stakes
+		extra := new(big.Float).Mul(rate, commission)
+		extra.Mul(extra, delegationStake.BigFloat())
+		extra.Quo(extra, validatorStake.BigFloat())
+		validatorAPR.Add(validatorAPR, extra)
+	}
+	return
+}
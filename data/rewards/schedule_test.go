@@ -0,0 +1,107 @@
+package rewards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func setupSchedule(years []RewardYear) (*RewardCumulativeStore, *Options) {
+	memDb := db.NewDB("test", db.MemDBBackend, "")
+	rws := NewRewardCumulativeStore("rwcum", storage.NewState(storage.NewChainState("chainstate", memDb)))
+	options := &Options{
+		EstimatedSecondsPerCycle: 3600,
+		BlockSpeedCalculateCycle: 100,
+		YearBlockRewardShares: []balance.Amount{
+			*balance.NewAmount(1000),
+			*balance.NewAmount(800),
+		},
+		BurnoutRate: *balance.NewAmount(1),
+	}
+	rws.SetOptions(options)
+	if len(years) > 0 {
+		_ = rws.set(rws.getYearDistributedKey(), RewardYears{Years: years})
+		rws.state.Commit()
+	}
+	return rws, options
+}
+
+func TestRewardCumulativeStore_GetSchedule(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	rws, options := setupSchedule([]RewardYear{
+		{StartTime: start, CloseTime: start.AddDate(1, 0, 0), Distributed: balance.NewAmount(1000), TillLastCycle: balance.NewAmount(1000)},
+		{StartTime: start.AddDate(1, 0, 0), CloseTime: start.AddDate(2, 0, 0), Distributed: balance.NewAmount(300), TillLastCycle: balance.NewAmount(300)},
+	})
+
+	t.Run("in the second year, should return the second year share", func(t *testing.T) {
+		schedule, err := rws.GetSchedule(options, start.AddDate(1, 6, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, schedule.CurrentYear)
+		assert.True(t, schedule.Years[0].Closed)
+		assert.Equal(t, "800", schedule.AnnualIssuance.String())
+		assert.Equal(t, "500", schedule.Remaining.String())
+	})
+	t.Run("with an appended year, should extend the schedule", func(t *testing.T) {
+		extended := *options
+		extended.YearBlockRewardShares = append(extended.YearBlockRewardShares, *balance.NewAmount(600))
+		schedule, err := rws.GetSchedule(&extended, start.AddDate(1, 6, 0))
+		assert.NoError(t, err)
+		if assert.Len(t, schedule.Years, 3) {
+			assert.Equal(t, start.AddDate(3, 0, 0), schedule.Years[2].CloseTime)
+		}
+		assert.Equal(t, "1100", schedule.Remaining.String())
+	})
+	t.Run("after all years closed, should estimate issuance from burnout rate", func(t *testing.T) {
+		schedule, err := rws.GetSchedule(options, start.AddDate(3, 0, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, -1, schedule.CurrentYear)
+		// 1 per block, 100 blocks per hour
+		assert.Equal(t, "876000", schedule.AnnualIssuance.String())
+	})
+}
+
+func TestRewardCumulativeStore_ValidateScheduleUpdate(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.AddDate(1, 6, 0)
+	rws, options := setupSchedule([]RewardYear{
+		{StartTime: start, CloseTime: start.AddDate(1, 0, 0), Distributed: balance.NewAmount(1000), TillLastCycle: balance.NewAmount(1000)},
+		{StartTime: start.AddDate(1, 0, 0), CloseTime: start.AddDate(2, 0, 0), Distributed: balance.NewAmount(300), TillLastCycle: balance.NewAmount(300)},
+	})
+	update := func(shares ...int64) *Options {
+		opt := *options
+		opt.YearBlockRewardShares = make([]balance.Amount, 0, len(shares))
+		for _, s := range shares {
+			opt.YearBlockRewardShares = append(opt.YearBlockRewardShares, *balance.NewAmount(s))
+		}
+		return &opt
+	}
+
+	assert.NoError(t, rws.ValidateScheduleUpdate(options, update(1000, 600, 400), *balance.NewAmount(700), now))
+	// remaining 300 + 500 exceeds the pool
+	assert.Error(t, rws.ValidateScheduleUpdate(options, update(1000, 600, 500), *balance.NewAmount(700), now))
+	// closed year changed
+	assert.Error(t, rws.ValidateScheduleUpdate(options, update(900, 800), *balance.NewAmount(10000), now))
+	// below distributed amount
+	assert.Error(t, rws.ValidateScheduleUpdate(options, update(1000, 200), *balance.NewAmount(10000), now))
+	// year removed
+	assert.Error(t, rws.ValidateScheduleUpdate(options, update(1000), *balance.NewAmount(10000), now))
+}
+
+func TestProjectAPR(t *testing.T) {
+	validatorAPR, delegatorAPR := ProjectAPR(balance.NewAmount(100), balance.NewAmount(600), balance.NewAmount(400), 25)
+
+	// base rate 10%, delegators keep 75%, validators get 25% of 40 on 600 stake
+	v, _ := validatorAPR.Float64()
+	d, _ := delegatorAPR.Float64()
+	assert.InDelta(t, 11.6667, v, 0.001)
+	assert.InDelta(t, 7.5, d, 0.001)
+
+	validatorAPR, delegatorAPR = ProjectAPR(balance.NewAmount(100), balance.NewAmount(0), balance.NewAmount(0), 25)
+	assert.Equal(t, "0", validatorAPR.Text('f', 0))
+	assert.Equal(t, "0", delegatorAPR.Text('f', 0))
+}
@@ -86,6 +86,18 @@ func (rs *RewardStore) GetWithHeight(address keys.Address, height int64) (amount
 	return
 }
 
+// GetVersionedWithHeight returns the amount of the interval holding the height, as it was at the state version
+func (rs *RewardStore) GetVersionedWithHeight(version int64, address keys.Address, height int64) (amount *balance.Amount, err error) {
+	key := append(rs.prefix, rs.generateKey(address, height, rs.rewardOptions.RewardInterval)...)
+	data := rs.State.GetVersioned(version, key)
+	amount = balance.NewAmount(0)
+	if len(data) == 0 {
+		return
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(data, amount)
+	return
+}
+
 func (rs *RewardStore) SetWithHeight(address keys.Address, height int64, amount *balance.Amount) error {
 	data, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(amount)
 	if err != nil {
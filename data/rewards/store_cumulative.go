@@ -103,6 +103,11 @@ func (rws *RewardCumulativeStore) GetYearDistributedRewards() (rewardYears Rewar
 		return
 	}
 	rewardYears, err = rws.getRewardYears(key)
+	if err != nil {
+		return
+	}
+	// years appended to the schedule by governance
+	rewardYears.extend(len(rws.rewardOptions.YearBlockRewardShares))
 	return
 }
 
@@ -150,6 +155,17 @@ func (rws *RewardCumulativeStore) GetWithdrawnRewards(validator keys.Address) (a
 	return
 }
 
+// Get total rewards withdrawn as of the state version
+func (rws *RewardCumulativeStore) GetVersionedWithdrawnRewards(version int64, validator keys.Address) (amt *balance.Amount, err error) {
+	dat := rws.state.GetVersioned(version, rws.getWithdrawnKey(validator))
+	amt = balance.NewAmount(0)
+	if len(dat) == 0 {
+		return
+	}
+	err = rws.szlr.Deserialize(dat, amt)
+	return
+}
+
 // Withdraw an 'amount' of rewards from rewards balance
 func (rws *RewardCumulativeStore) WithdrawRewards(validator keys.Address, amount *balance.Amount) error {
 
@@ -0,0 +1,739 @@
+package event
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	action_bridge "github.com/Oneledger/protocol/action/bridge"
+	"github.com/Oneledger/protocol/action/btc"
+	"github.com/Oneledger/protocol/action/eth"
+	"github.com/Oneledger/protocol/app/node"
+	chainbtc "github.com/Oneledger/protocol/chains/bitcoin"
+	ethchain "github.com/Oneledger/protocol/chains/ethereum"
+	"github.com/Oneledger/protocol/chains/ethereum/contract"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/ethereum"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/utils/transition"
+)
+
+const (
+	bridgeBlocks      = 20
+	btcTrackerName    = "tracker_0"
+	ethSupplyAddress  = "ethSupply"
+	btcSupplyAddress  = "btcSupply"
+	bridgeTotalSupply = "1000000000000000000000000"
+)
+
+var bridgeContract = ethcommon.HexToAddress("0x6A1bB6B2a1F5A56E7c5d4aA5E4d0eE6d9d25cE21")
+
+// bridgeNode is a single validator, ethereum witness node. Its chain state is in memory, the
+// ethereum and bitcoin networks are mocked, and the internal txs of its jobs are delivered
+// as soon as they are broadcast.
+type bridgeNode struct {
+	dir    string
+	state  *storage.State
+	ctx    *action.Context
+	jobs   *jobs.JobStore
+	jobCtx *JobsContext
+	logger *log.Logger
+
+	ethChain   *ethchain.MockChain
+	ethDriver  ethchain.OnlineChainDriver
+	btcBackend *chainbtc.MockBackend
+	height     int64
+}
+
+// deliverBroadcaster stands in for the consensus client, an internal tx goes straight to deliver
+type deliverBroadcaster struct {
+	n *bridgeNode
+}
+
+func (b deliverBroadcaster) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	signed := &action.SignedTx{}
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(tx, signed)
+	if err != nil {
+		return nil, err
+	}
+	handler := b.n.ctx.Router.Handler(signed.Type)
+	ok, err := handler.Validate(b.n.ctx, *signed)
+	if !ok {
+		return &ctypes.ResultBroadcastTx{Code: 1, Log: err.Error(), Hash: tx.Hash()}, nil
+	}
+	ok, resp := b.n.deliver(signed.RawTx)
+	code := uint32(0)
+	if !ok {
+		code = 1
+	}
+	return &ctypes.ResultBroadcastTx{Code: code, Log: resp.Log, Hash: tx.Hash()}, nil
+}
+
+// writeNodeKeys writes the key files of a validator node as devnet does, and returns its
+// ecdsa public key
+func writeNodeKeys(t *testing.T, cfg *config.Server) keys.PublicKey {
+	tmcfg := cfg.TMConfig()
+	for _, f := range []string{tmcfg.NodeKeyFile(), tmcfg.PrivValidatorKeyFile(), tmcfg.PrivValidatorStateFile()} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(f), config.DirPerms))
+	}
+
+	_, err := p2p.LoadOrGenNodeKey(tmcfg.NodeKeyFile())
+	require.NoError(t, err)
+	privval.LoadOrGenFilePV(tmcfg.PrivValidatorKeyFile(), tmcfg.PrivValidatorStateFile()).Save()
+
+	ecdsaPrivKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+	ecdsaFile := strings.Replace(tmcfg.PrivValidatorKeyFile(), ".json", "_ecdsa.json", 1)
+	err = ioutil.WriteFile(ecdsaFile, []byte(base64.StdEncoding.EncodeToString(ecdsaPrivKey.Serialize())), config.FilePerms)
+	require.NoError(t, err)
+
+	ecdsaPk, err := keys.GetPrivateKeyFromBytes(ecdsaPrivKey.Serialize(), keys.BTCECSECP)
+	require.NoError(t, err)
+	h, err := ecdsaPk.GetHandler()
+	require.NoError(t, err)
+	return h.PubKey()
+}
+
+func newBridgeNode(t *testing.T) *bridgeNode {
+	dir, err := ioutil.TempDir("", "bridge_e2e")
+	require.NoError(t, err)
+
+	cfg := config.DefaultServerConfig()
+	cfg.EthChainDriver.Connection = ethchain.MockConnection
+	cfg.ChainDriver.BitcoinBackend = chainbtc.BackendMock
+	cfg.ChainDriver.BitcoinChainType = "testnet3"
+	cfg.ChainDriver.BitcoinNodeAddress = dir
+	require.NoError(t, cfg.SaveFile(filepath.Join(dir, config.FileName)))
+	require.NoError(t, cfg.ReadFile(filepath.Join(dir, config.FileName)))
+	ecdsaPubKey := writeNodeKeys(t, cfg)
+
+	nodeCtx, err := node.NewNodeContext(cfg)
+	require.NoError(t, err)
+
+	n := &bridgeNode{
+		dir:    dir,
+		logger: log.NewDefaultLogger(os.Stdout).WithPrefix("bridge_e2e"),
+	}
+	n.state = storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+
+	currencies := balance.NewCurrencySet()
+	require.NoError(t, currencies.Register(balance.Currency{Id: 2, Name: "BTC", Chain: chain.BITCOIN, Decimal: 8, Unit: "satoshi"}))
+	require.NoError(t, currencies.Register(balance.Currency{Id: 3, Name: "ETH", Chain: chain.ETHEREUM, Decimal: 18, Unit: "wei"}))
+
+	ethOption := ethchain.ChainDriverOption{
+		ContractABI:       contract.LockRedeemABI,
+		ContractAddress:   bridgeContract,
+		TotalSupply:       bridgeTotalSupply,
+		TotalSupplyAddr:   ethSupplyAddress,
+		BlockConfirmation: 3,
+	}
+	govern := governance.NewStore("tg", n.state)
+	require.NoError(t, govern.SetETHChainDriverOption(ethOption))
+	require.NoError(t, govern.WithHeight(0).SetAllLUH())
+
+	ethTrackers := ethereum.NewTrackerStore("etht", "ethfailed", "ethsuccess", n.state)
+	ethTrackers.SetupOption(&ethOption)
+	ethTrackers.SetupRegistry(&ethchain.EVMChainRegistry{})
+
+	btcTrackers := bitcoin.NewTrackerStore("btct", n.state)
+	btcTrackers.SetConfig(bitcoin.NewBTCConfig(cfg.ChainDriver, cfg.ChainDriver.BitcoinChainType))
+	btcTrackers.SetOption(chainbtc.ChainDriverOption{
+		ChainType:         cfg.ChainDriver.BitcoinChainType,
+		TotalSupply:       bridgeTotalSupply,
+		TotalSupplyAddr:   btcSupplyAddress,
+		BlockConfirmation: 3,
+	})
+
+	router := action.NewRouter("bridge_e2e")
+	require.NoError(t, eth.EnableETH(router))
+	require.NoError(t, btc.EnableBTC(router))
+	require.NoError(t, action_bridge.EnableBridge(router))
+
+	n.jobs = jobs.NewJobStore(*cfg, dir)
+	n.ctx = &action.Context{
+		Router:          router,
+		State:           n.state,
+		Header:          &abci.Header{},
+		Balances:        balance.NewStore("b", n.state),
+		Currencies:      currencies,
+		Validators:      identity.NewValidatorStore("v", "purged", "rotation", "profile", n.state),
+		Witnesses:       identity.NewWitnessStore("w", n.state),
+		BTCTrackers:     btcTrackers,
+		ETHTrackers:     ethTrackers,
+		NFTs:            ethereum.NewNFTStore("nft", n.state),
+		Bridge:          bridge.NewStore("bridge", n.state),
+		Logger:          n.logger,
+		JobStore:        n.jobs,
+		LockScriptStore: bitcoin.NewLockScriptStore(*cfg, dir),
+		GovernanceStore: govern,
+	}
+
+	// the node is the only validator and the only ethereum witness
+	stake := identity.Stake{
+		ValidatorAddress: nodeCtx.ValidatorAddress(),
+		StakeAddress:     nodeCtx.Address(),
+		Pubkey:           nodeCtx.ValidatorPubKey(),
+		ECDSAPubKey:      ecdsaPubKey,
+		Name:             "bridge_e2e",
+		Amount:           *balance.NewAmount(100),
+	}
+	require.NoError(t, n.ctx.Validators.HandleStake(stake, false, 0))
+	require.NoError(t, n.ctx.Witnesses.AddWitness(chain.ETHEREUM, stake))
+	n.state.Commit()
+	n.createBTCTracker(t)
+	_, version := n.state.Commit()
+	n.height = version + 1
+	n.ctx.Header.Height = n.height
+	require.NoError(t, n.ctx.Validators.Setup(abci.RequestBeginBlock{Header: abci.Header{Height: n.height}}, nodeCtx.ValidatorAddress()))
+	n.ctx.Witnesses.Init(chain.ETHEREUM, nodeCtx.ValidatorAddress())
+
+	svc := &Service{
+		nodeCtx: *nodeCtx,
+		logger:  n.logger,
+		router:  router,
+		tmrpc:   deliverBroadcaster{n},
+	}
+	n.jobCtx = NewJobsContext(*cfg, svc, btcTrackers, n.ctx.Validators, nodeCtx.ValidatorECDSAPrivateKey(),
+		nodeCtx.ValidatorECDSAPrivateKey(), nodeCtx.ValidatorAddress(), n.ctx.LockScriptStore, ethTrackers,
+		nil, n.logger)
+
+	ethchain.ResetMockChains()
+	n.ethChain = ethchain.GetMockChain(cfg.EthChainDriver.Connection)
+	n.ethDriver, err = ethchain.GetChainDriver(cfg.EthChainDriver, n.logger, bridgeContract, contract.LockRedeemABI, ethchain.ETH)
+	require.NoError(t, err)
+
+	backend, err := btcTrackers.GetConfig().Backend()
+	require.NoError(t, err)
+	n.btcBackend = backend.(*chainbtc.MockBackend)
+
+	return n
+}
+
+// createBTCTracker adds a tracker locked to the multisig of the validators, as genesis does
+func (n *bridgeNode) createBTCTracker(t *testing.T) {
+	params := n.ctx.BTCTrackers.GetConfig().BTCParams
+	validatorPubKeys, err := n.ctx.Validators.GetBitcoinKeys(params)
+	require.NoError(t, err)
+	m := (len(validatorPubKeys) * 2 / 3) + 1
+
+	lockScript, lockScriptAddress, addressList, err := chainbtc.CreateMultiSigAddress(m, validatorPubKeys, []byte("e2e"), params)
+	require.NoError(t, err)
+	signers := make([]keys.Address, len(addressList))
+	for i := range addressList {
+		signers[i] = base58.Decode(addressList[i])
+	}
+
+	tracker, err := bitcoin.NewTracker(lockScriptAddress, m, signers)
+	require.NoError(t, err)
+	tracker.Name = btcTrackerName
+	require.NoError(t, n.ctx.BTCTrackers.SetTracker(btcTrackerName, tracker))
+	require.NoError(t, n.ctx.LockScriptStore.SaveLockScript(lockScriptAddress, lockScript))
+}
+
+func (n *bridgeNode) close() {
+	n.ctx.Witnesses.Init(chain.ETHEREUM, nil)
+	ethchain.ResetMockChains()
+	n.jobs.Close()
+	n.ctx.LockScriptStore.Close()
+	os.RemoveAll(n.dir)
+}
+
+func (n *bridgeNode) deliver(tx action.RawTx) (bool, action.Response) {
+	n.state.BeginTxSession()
+	ok, resp := n.ctx.Router.Handler(tx.Type).ProcessDeliver(n.ctx, tx)
+	if ok {
+		n.state.CommitTxSession()
+	} else {
+		n.state.DiscardTxSession()
+	}
+	return ok, resp
+}
+
+// deliverMsg delivers a user transaction
+func (n *bridgeNode) deliverMsg(t *testing.T, msg action.Msg) {
+	data, err := msg.Marshal()
+	require.NoError(t, err)
+	ok, resp := n.deliver(action.RawTx{Type: msg.Type(), Data: data})
+	require.True(t, ok, resp.Log)
+}
+
+// endBlock moves the trackers along as the block ender does, commits, and lets the jobs run
+func (n *bridgeNode) endBlock() {
+	ts := n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing)
+	names := make([]*ethchain.TrackerName, 0)
+	ts.Iterate(func(name *ethchain.TrackerName, tracker *ethereum.Tracker) bool {
+		names = append(names, name)
+		return false
+	})
+	for _, name := range names {
+		t, _ := n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Get(*name)
+		state := t.State
+		step := t.NextStep()
+		ctx := ethereum.NewTrackerCtx(t, n.jobCtx.ValidatorAddress, n.jobs.WithChain(chain.ETHEREUM),
+			n.ctx.ETHTrackers, n.ctx.Witnesses, n.logger)
+		engine := EthLockEngine
+		if t.Type == ethereum.ProcessTypeRedeem {
+			engine = EthRedeemEngine
+		}
+		_, err := engine.Process(step, ctx, transition.Status(t.State))
+		if err != nil {
+			continue
+		}
+		if ctx.Tracker.State < 5 && state != ctx.Tracker.State {
+			_ = n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Set(ctx.Tracker)
+		}
+		if state != ctx.Tracker.State || step == ethereum.CLEANUP || step == ethereum.CLEANUPFAILED {
+			_ = n.ctx.Bridge.RecordHistory(chain.ETHEREUM, name.Hex(), bridge.HistoryEntry{Height: n.height, Event: step, State: ctx.Tracker.State.String()})
+		}
+	}
+
+	btcTrackers := make([]bitcoin.Tracker, 0)
+	n.ctx.BTCTrackers.Iterate(func(k, v []byte) bool {
+		d := bitcoin.Tracker{}
+		if err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(v, &d); err == nil {
+			btcTrackers = append(btcTrackers, d)
+		}
+		return false
+	})
+	for i := range btcTrackers {
+		t := &btcTrackers[i]
+		ctx := bitcoin.BTCTransitionContext{Tracker: t, JobStore: n.jobs.WithChain(chain.BITCOIN), Validators: n.ctx.Validators}
+		step := t.NextStep()
+		stt, err := BtcEngine.Process(step, ctx, transition.Status(t.State))
+		if err == nil && stt != -1 {
+			t.State = bitcoin.TrackerState(stt)
+			_ = n.ctx.BTCTrackers.SetTracker(t.Name, t)
+			_ = n.ctx.Bridge.RecordHistory(chain.BITCOIN, t.Name, bridge.HistoryEntry{Height: n.height, Event: step, State: t.State.String()})
+		}
+	}
+
+	ScheduleWitnessRotation(n.jobs.WithChain(chain.ETHEREUM), n.ctx.Witnesses, chain.ETHEREUM, 0, n.jobCtx.ValidatorAddress, n.height, n.logger)
+
+	n.state.Commit()
+	n.height++
+	n.ctx.Header.Height = n.height
+
+	ProcessAllJobs(n.jobCtx, n.jobs.WithChain(chain.ETHEREUM))
+	ProcessAllJobs(n.jobCtx, n.jobs.WithChain(chain.BITCOIN))
+}
+
+// runUntil ends blocks, mining one block on each mocked network in between, until done holds
+func (n *bridgeNode) runUntil(t *testing.T, done func() bool) {
+	for i := 0; i < bridgeBlocks; i++ {
+		n.endBlock()
+		if done() {
+			return
+		}
+		n.ethChain.Mine(1)
+		n.btcBackend.Mine(1)
+	}
+	t.Fatalf("not done after %d blocks", bridgeBlocks)
+}
+
+func (n *bridgeNode) ethTrackerIn(prefix ethereum.PrefixType, name ethchain.TrackerName) func() bool {
+	return func() bool {
+		return n.ctx.ETHTrackers.WithPrefixType(prefix).Exists(name)
+	}
+}
+
+func (n *bridgeNode) balance(t *testing.T, addr keys.Address, currency string) *big.Int {
+	curr, ok := n.ctx.Currencies.GetCurrencyByName(currency)
+	require.True(t, ok)
+	coin, err := n.ctx.Balances.GetBalanceForCurr(addr, &curr)
+	require.NoError(t, err)
+	return coin.Amount.BigInt()
+}
+
+func newUser(t *testing.T) (keys.Address, *ecdsa.PrivateKey) {
+	pub, _, err := keys.NewKeyPairFromTendermint()
+	require.NoError(t, err)
+	h, err := pub.GetHandler()
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return h.Address(), key
+}
+
+func (n *bridgeNode) signETHTx(t *testing.T, tx *types.Transaction, key *ecdsa.PrivateKey) []byte {
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(n.ethChain.ChainID()), key)
+	require.NoError(t, err)
+	raw, err := rlp.EncodeToBytes(signed)
+	require.NoError(t, err)
+	return raw
+}
+
+func (n *bridgeNode) ethLock(t *testing.T, locker keys.Address, key *ecdsa.PrivateKey, amount *big.Int) ethchain.TrackerName {
+	raw, err := n.ethDriver.PrepareUnsignedETHLock(crypto.PubkeyToAddress(key.PublicKey), amount)
+	require.NoError(t, err)
+	unsigned, err := n.ethDriver.DecodeTransaction(raw)
+	require.NoError(t, err)
+	lockTx := n.signETHTx(t, unsigned, key)
+
+	n.deliverMsg(t, &eth.Lock{Locker: locker, ETHTxn: lockTx})
+	return ethcommon.BytesToHash(lockTx)
+}
+
+func TestBridge_ETHLockRedeem(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	user, key := newUser(t)
+	amount := big.NewInt(1000000000)
+
+	// lock -> broadcast -> check finality -> mint
+	name := n.ethLock(t, user, key, amount)
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixPassed, name))
+	assert.Equal(t, amount, n.balance(t, user, "ETH"))
+	assert.Equal(t, amount, n.balance(t, keys.Address(ethSupplyAddress), "ETH"))
+	assert.False(t, n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Exists(name))
+
+	// the user redeems on ethereum, then on the bridge
+	contractAbi, err := abi.JSON(strings.NewReader(contract.LockRedeemABI))
+	require.NoError(t, err)
+	data, err := contractAbi.Pack("redeem", amount)
+	require.NoError(t, err)
+	redeemTx := n.signETHTx(t, types.NewTransaction(1, bridgeContract, big.NewInt(0), 400000, big.NewInt(18000000000), data), key)
+	signed, err := n.ethDriver.DecodeTransaction(redeemTx)
+	require.NoError(t, err)
+	require.NoError(t, n.ethChain.Submit(signed))
+	n.ethChain.Mine(1)
+
+	n.deliverMsg(t, &eth.Redeem{Owner: user, To: crypto.PubkeyToAddress(key.PublicKey), ETHTxn: redeemTx})
+	assert.Equal(t, int64(0), n.balance(t, user, "ETH").Int64())
+
+	// sign redeem -> verify redeem -> burn
+	redeemName := ethcommon.BytesToHash(redeemTx)
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixPassed, redeemName))
+	status := n.ethDriver.VerifyRedeem(n.jobCtx.GetValidatorETHAddress(), crypto.PubkeyToAddress(key.PublicKey))
+	assert.Equal(t, ethchain.Success, status)
+	assert.Equal(t, int64(0), n.balance(t, keys.Address(ethSupplyAddress), "ETH").Int64())
+}
+
+func TestBridge_ETHLockReorged(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	user, key := newUser(t)
+	name := n.ethLock(t, user, key, big.NewInt(1000000000))
+
+	tracker, err := n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Get(name)
+	require.NoError(t, err)
+	lockTx, err := n.ethDriver.DecodeTransaction(tracker.SignedETHTx)
+	require.NoError(t, err)
+
+	// the lock gets mined, its block is reorged out before it is final
+	n.runUntil(t, func() bool {
+		return n.ethDriver.CheckFinality(lockTx.Hash(), 1) != ethchain.TransactionNotMined
+	})
+	n.ethChain.Reorg(lockTx.Hash())
+
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixFailed, name))
+	assert.Equal(t, int64(0), n.balance(t, user, "ETH").Int64())
+	assert.False(t, n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixPassed).Exists(name))
+
+	// the witness tells why it voted the lock down
+	history, err := n.ctx.Bridge.GetHistory(chain.ETHEREUM, name.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, ethereum.CLEANUPFAILED, history.Entries[len(history.Entries)-1].Event)
+	vote := history.Entries[len(history.Entries)-2]
+	assert.Equal(t, bridge.EventVoteNo, vote.Event)
+	assert.NotEmpty(t, vote.Reason)
+}
+
+func TestBridge_ETHPaused(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	// the node is the only witness, its vote trips the breaker
+	n.deliverMsg(t, &action_bridge.Pause{
+		Witness: keys.Address(n.jobCtx.ValidatorAddress),
+		Chain:   chain.ETHEREUM,
+		Reason:  "drain",
+	})
+	assert.True(t, n.ctx.Bridge.IsPaused(chain.ETHEREUM))
+	assert.False(t, n.ctx.Bridge.IsPaused(chain.BITCOIN))
+
+	user, key := newUser(t)
+	amount := big.NewInt(1000000000)
+	raw, err := n.ethDriver.PrepareUnsignedETHLock(crypto.PubkeyToAddress(key.PublicKey), amount)
+	require.NoError(t, err)
+	unsigned, err := n.ethDriver.DecodeTransaction(raw)
+	require.NoError(t, err)
+	lockTx := n.signETHTx(t, unsigned, key)
+	data, err := (&eth.Lock{Locker: user, ETHTxn: lockTx}).Marshal()
+	require.NoError(t, err)
+	ok, resp := n.deliver(action.RawTx{Type: action.ETH_LOCK, Data: data})
+	assert.False(t, ok)
+	assert.Contains(t, resp.Log, "bridge is paused")
+
+	// governance resumes the bridge
+	require.NoError(t, n.ctx.Bridge.Resume(chain.ETHEREUM))
+	name := ethcommon.BytesToHash(lockTx)
+	n.deliverMsg(t, &eth.Lock{Locker: user, ETHTxn: lockTx})
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixPassed, name))
+	assert.Equal(t, amount, n.balance(t, user, "ETH"))
+}
+
+// events lists the events of the history of a tracker
+func (n *bridgeNode) events(t *testing.T, c chain.Type, name string) []string {
+	history, err := n.ctx.Bridge.GetHistory(c, name)
+	require.NoError(t, err)
+	events := make([]string, 0, len(history.Entries))
+	for _, e := range history.Entries {
+		events = append(events, e.Event)
+	}
+	return events
+}
+
+func TestBridge_ETHTrackerHistory(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	user, key := newUser(t)
+	name := n.ethLock(t, user, key, big.NewInt(1000000000))
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixPassed, name))
+
+	history, err := n.ctx.Bridge.GetHistory(chain.ETHEREUM, name.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, user, history.Owner)
+	assert.Equal(t, ethereum.Released.String(), history.State)
+	assert.Equal(t, []string{bridge.EventOpened, ethereum.BROADCASTING, bridge.EventVoteYes, ethereum.CLEANUP},
+		n.events(t, chain.ETHEREUM, name.Hex()))
+	assert.Equal(t, keys.Address(n.jobCtx.ValidatorAddress), history.Entries[2].Witness)
+
+	// the history outlives the tracker and lists by owner
+	n.state.Commit()
+	assert.Len(t, n.ctx.Bridge.FilterHistory(chain.ETHEREUM, user, "", 0, 0), 1)
+	other, _ := newUser(t)
+	assert.Empty(t, n.ctx.Bridge.FilterHistory(chain.ETHEREUM, other, "", 0, 0))
+}
+
+func TestBridge_ETHRetry(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	user, key := newUser(t)
+	amount := big.NewInt(1000000000)
+	name := n.ethLock(t, user, key, amount)
+
+	// the broadcast can't reach ethereum, the tracker is stuck broadcasting
+	n.ethChain.SetConnected(false)
+	n.endBlock()
+	n.endBlock()
+	tracker, err := n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Get(name)
+	require.NoError(t, err)
+	require.Equal(t, ethereum.BusyBroadcasting, tracker.State)
+	n.ethChain.SetConnected(true)
+
+	// only witnesses retry
+	other, _ := newUser(t)
+	data, err := (&eth.Retry{Witness: other, TrackerName: name, Reason: "stuck"}).Marshal()
+	require.NoError(t, err)
+	ok, _ := n.deliver(action.RawTx{Type: action.ETH_RETRY, Data: data})
+	assert.False(t, ok)
+
+	witness := keys.Address(n.jobCtx.ValidatorAddress)
+	n.deliverMsg(t, &eth.Retry{Witness: witness, TrackerName: name, Reason: "stuck"})
+	tracker, err = n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Get(name)
+	require.NoError(t, err)
+	assert.Equal(t, ethereum.New, tracker.State)
+	_, err = n.jobs.WithChain(chain.ETHEREUM).GetJob(tracker.GetJobID(ethereum.BusyBroadcasting))
+	assert.Error(t, err)
+
+	// the tracker runs again from the broadcast
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixPassed, name))
+	assert.Equal(t, amount, n.balance(t, user, "ETH"))
+
+	history, err := n.ctx.Bridge.GetHistory(chain.ETHEREUM, name.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, []string{bridge.EventOpened, ethereum.BROADCASTING, bridge.EventRetry, ethereum.BROADCASTING,
+		bridge.EventVoteYes, ethereum.CLEANUP}, n.events(t, chain.ETHEREUM, name.Hex()))
+	assert.Equal(t, witness, history.Entries[2].Witness)
+	assert.Equal(t, "stuck", history.Entries[2].Reason)
+
+	// a tracker done with can't be retried
+	data, err = (&eth.Retry{Witness: witness, TrackerName: name}).Marshal()
+	require.NoError(t, err)
+	ok, _ = n.deliver(action.RawTx{Type: action.ETH_RETRY, Data: data})
+	assert.False(t, ok)
+}
+
+func TestBridge_ETHJobDeadLetter(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	policy := jobs.GetRetryPolicy(JobTypeETHBroadcast)
+	defer jobs.SetRetryPolicy(JobTypeETHBroadcast, policy)
+	jobs.SetRetryPolicy(JobTypeETHBroadcast, jobs.RetryPolicy{MaxAttempts: 3})
+
+	user, key := newUser(t)
+	amount := big.NewInt(1000000000)
+	name := n.ethLock(t, user, key, amount)
+
+	// the broadcast fails while ethereum is out of reach, until it is out of retries
+	n.ethChain.SetConnected(false)
+	for i := 0; i < 3; i++ {
+		n.endBlock()
+	}
+	tracker, err := n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Get(name)
+	require.NoError(t, err)
+	jobID := tracker.GetJobID(ethereum.BusyBroadcasting)
+	attempts, err := n.jobs.GetChainAttempts(chain.ETHEREUM, jobID)
+	require.NoError(t, err)
+	require.NotNil(t, attempts)
+	assert.Equal(t, 2, attempts.Failed)
+	assert.NotEmpty(t, attempts.LastError)
+	_, err = n.jobs.GetDeadLetter(chain.ETHEREUM, jobID)
+	assert.Error(t, err)
+
+	n.endBlock()
+	letter, err := n.jobs.GetDeadLetter(chain.ETHEREUM, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, letter.Attempts.Failed)
+	assert.Equal(t, JobTypeETHBroadcast, letter.Job.GetType())
+	tracker, err = n.ctx.ETHTrackers.WithPrefixType(ethereum.PrefixOngoing).Get(name)
+	require.NoError(t, err)
+	assert.Equal(t, ethereum.BusyBroadcasting, tracker.State)
+
+	// requeued once ethereum is back, the tracker goes through
+	n.ethChain.SetConnected(true)
+	require.NoError(t, n.jobs.Requeue(chain.ETHEREUM, jobID))
+	n.runUntil(t, n.ethTrackerIn(ethereum.PrefixPassed, name))
+	assert.Equal(t, amount, n.balance(t, user, "ETH"))
+}
+
+func TestBridge_ETHWitnessRotation(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	// the node hands the contract over to itself and a new witness
+	current, err := n.ctx.Witnesses.Get(chain.ETHEREUM, n.jobCtx.ValidatorAddress)
+	require.NoError(t, err)
+	pub, _, err := keys.NewKeyPairFromTendermint()
+	require.NoError(t, err)
+	h, err := pub.GetHandler()
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	joining := identity.Witness{
+		Address:     h.Address(),
+		PubKey:      pub,
+		ECDSAPubKey: keys.PublicKey{KeyType: keys.ETHSECP, Data: crypto.CompressPubkey(&key.PublicKey)},
+		Name:        "joining",
+	}
+	_, err = n.ctx.Witnesses.StartRotation(chain.ETHEREUM, []identity.Witness{*current, joining}, n.height, "e2e")
+	require.NoError(t, err)
+
+	// the chain keeps the current set until the contract took the new one
+	n.endBlock()
+	assert.Equal(t, uint64(0), n.ethChain.ValidatorEpoch(bridgeContract))
+	witnesses, err := n.ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Len(t, witnesses, 1)
+
+	n.runUntil(t, func() bool {
+		rotation, err := n.ctx.Witnesses.GetPendingRotation(chain.ETHEREUM)
+		return err == nil && rotation == nil
+	})
+	assert.Equal(t, uint64(1), n.ethChain.ValidatorEpoch(bridgeContract))
+	assert.True(t, n.ethChain.IsValidator(bridgeContract, n.jobCtx.GetValidatorETHAddress()))
+	assert.True(t, n.ethChain.IsValidator(bridgeContract, crypto.PubkeyToAddress(key.PublicKey)))
+
+	n.state.Commit()
+	witnesses, err = n.ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Len(t, witnesses, 2)
+	epoch, err := n.ctx.Witnesses.GetEpoch(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), epoch)
+}
+
+func TestBridge_BTCLock(t *testing.T) {
+	n := newBridgeNode(t)
+	defer n.close()
+
+	user, _ := newUser(t)
+	tracker, err := n.ctx.BTCTrackers.Get(btcTrackerName)
+	require.NoError(t, err)
+	lockScriptAddress := tracker.ProcessLockScriptAddress
+
+	// the user pays an output of theirs to the tracker
+	funding := chainhash.DoubleHashH([]byte(t.Name()))
+	n.btcBackend.AddOutput(&funding, 0, 100000, []byte{0x76, 0xa9})
+	lockTx := wire.NewMsgTx(wire.TxVersion)
+	lockTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&funding, 0), []byte{0x01}, nil))
+	lockTx.AddTxOut(wire.NewTxOut(90000, lockScriptAddress))
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, lockTx.Serialize(buf))
+
+	n.deliverMsg(t, &btc.Lock{Locker: user, TrackerName: btcTrackerName, BTCTxn: buf.Bytes(), LockAmount: 90000})
+
+	// add signature -> broadcast
+	n.runUntil(t, func() bool {
+		tracker, err = n.ctx.BTCTrackers.Get(btcTrackerName)
+		return err == nil && tracker.State == bitcoin.BusyFinalizing
+	})
+	require.Len(t, n.btcBackend.Sent, 1)
+	assert.Equal(t, lockTx.TxHash(), *tracker.ProcessTxId)
+
+	// the finality check is scheduled an hour later, bring it forward once the lock is deep enough
+	n.btcBackend.Mine(3)
+	js := n.jobs.WithChain(chain.BITCOIN)
+	job, err := js.GetJob(tracker.GetJobID(bitcoin.BusyFinalizing))
+	require.NoError(t, err)
+	job.(*JobBTCCheckFinality).CheckAfter = 0
+	require.NoError(t, js.SaveJob(job))
+
+	// check finality -> mint
+	n.runUntil(t, func() bool {
+		tracker, err = n.ctx.BTCTrackers.Get(btcTrackerName)
+		return err == nil && tracker.IsAvailable()
+	})
+	assert.Equal(t, int64(90000), n.balance(t, user, "BTC").Int64())
+	assert.Equal(t, int64(90000), tracker.CurrentBalance)
+	assert.Equal(t, lockScriptAddress, tracker.CurrentLockScriptAddress)
+	_, err = n.ctx.LockScriptStore.GetLockScript(tracker.ProcessLockScriptAddress)
+	assert.NoError(t, err)
+
+	events := n.events(t, chain.BITCOIN, btcTrackerName)
+	assert.Equal(t, bridge.EventOpened, events[0])
+	assert.Equal(t, bridge.EventVoteYes, events[len(events)-1])
+	history, err := n.ctx.Bridge.GetHistory(chain.BITCOIN, btcTrackerName)
+	require.NoError(t, err)
+	assert.Equal(t, bitcoin.Available.String(), history.State)
+}
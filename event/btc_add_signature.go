@@ -9,12 +9,12 @@ import (
 	"encoding/hex"
 
 	"github.com/btcsuite/btcd/btcec"
-	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/action/btc"
+	"github.com/Oneledger/protocol/chains/bitcoin"
 	"github.com/Oneledger/protocol/data/jobs"
 )
 
@@ -86,9 +86,9 @@ func (j *JobAddSignature) DoMyJob(ctxI interface{}) {
 		return
 	}
 
-	sig, err := txscript.RawTxInSignature(lockTx, 0, lockScript, txscript.SigHashAll, pk)
+	sig, err := bitcoin.SignLock(lockTx, lockScript, tracker.CurrentLockScriptAddress, tracker.CurrentBalance, pk)
 	if err != nil {
-		ctx.Logger.Error(err, "RawTxInSignature")
+		ctx.Logger.Error(err, "SignLock")
 		ctx.Logger.Error(hex.EncodeToString(lockScript), hex.EncodeToString(tracker.CurrentLockScriptAddress))
 		return
 	}
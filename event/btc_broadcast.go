@@ -9,7 +9,6 @@ import (
 	"encoding/hex"
 	"fmt"
 
-	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 
@@ -85,33 +84,28 @@ func (j *JobBTCBroadcast) DoMyJob(ctxI interface{}) {
 
 	signatures := tracker.Multisig.GetSignaturesInOrder()
 
-	builder := txscript.NewScriptBuilder().AddOp(txscript.OP_FALSE)
-	for i := range signatures {
-		if i == tracker.Multisig.M {
-			// break
-		}
-
-		builder.AddData(signatures[i])
-	}
-
 	lockScript, err := ctx.LockScripts.GetLockScript(tracker.CurrentLockScriptAddress)
 	if err != nil {
 		ctx.Logger.Error("err trying to get lockscript ", err, j.TrackerName)
 		return
 	}
 
-	builder.AddData(lockScript)
-	sigScript, err := builder.Script()
+	opt := ctx.Trackers.GetConfig()
+
+	backend, err := opt.Backend()
 	if err != nil {
-		ctx.Logger.Error("error in building sig script", err)
+		ctx.Logger.Error("err trying to get bitcoin backend", err, j.TrackerName)
 		return
 	}
 
-	opt := ctx.Trackers.GetConfig()
-
 	isFirstLock := tracker.CurrentTxId == nil
-	cd := bitcoin.NewChainDriver(opt.BlockCypherToken)
-	lockTx = cd.AddLockSignature(tracker.ProcessUnsignedTx, sigScript, isFirstLock)
+	cd := bitcoin.NewChainDriver(backend)
+	lockTx, err = cd.AddLockSignature(tracker.ProcessUnsignedTx, signatures, lockScript,
+		tracker.CurrentLockScriptAddress, isFirstLock)
+	if err != nil {
+		ctx.Logger.Error("error in adding lock signatures", err)
+		return
+	}
 
 	buf := bytes.NewBuffer([]byte{})
 	err = lockTx.Serialize(buf)
@@ -143,23 +137,7 @@ func (j *JobBTCBroadcast) DoMyJob(ctxI interface{}) {
 		}
 	}
 
-	connCfg := &rpcclient.ConnConfig{
-		Host:         opt.BTCAddress + ":" + opt.BTCRPCPort,
-		User:         opt.BTCRPCUsername,
-		Pass:         opt.BTCRPCPassword,
-		HTTPPostMode: true, // Bitcoin core only supports HTTP POST mode
-		DisableTLS:   true, // Bitcoin core does not provide TLS by default
-	}
-	clt, err := rpcclient.New(connCfg, nil)
-	if err != nil {
-		ctx.Logger.Error("err trying to connect to bitcoin node", j.TrackerName)
-		return
-	}
-
-	hash, err := cd.BroadcastTx(lockTx, clt)
-	// use dummy hash for testing without broadcasting
-	// fmt.Println(clt)
-	// hash, err := chainhash.NewHashFromStr("cb0eee8e68b474cd1e845702052847dcbf248eb5a08ec498e887108842019d06")
+	hash, err := cd.BroadcastTx(lockTx)
 	if err == nil {
 
 		ctx.Logger.Info("bitcoin tx successful", hash)
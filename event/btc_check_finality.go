@@ -67,12 +67,15 @@ func (cf *JobBTCCheckFinality) DoMyJob(ctxI interface{}) {
 
 	opt := ctx.Trackers.GetConfig()
 	cdOption := ctx.Trackers.GetOption()
-	cd := bitcoin.NewChainDriver(opt.BlockCypherToken)
-
-	chain := bitcoin.GetBlockCypherChainType(opt.BTCChainnet)
+	backend, err := opt.Backend()
+	if err != nil {
+		ctx.Logger.Error("err trying to get bitcoin backend", err, cf.TrackerName)
+		return
+	}
+	cd := bitcoin.NewChainDriver(backend)
 
 	ctx.Logger.Info("checking btc finality for ", tracker.ProcessTxId)
-	ok, err := cd.CheckFinality(tracker.ProcessTxId, int(cdOption.BlockConfirmation), opt.BlockCypherToken, chain)
+	ok, err := cd.CheckFinality(tracker.ProcessTxId, int(cdOption.BlockConfirmation))
 	if err != nil {
 		ctx.Logger.Error("error while checking finality", err, cf.TrackerName)
 		return
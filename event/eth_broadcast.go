@@ -9,64 +9,84 @@ import (
 	"github.com/Oneledger/protocol/storage"
 )
 
-var _ jobs.Job = &JobETHBroadcast{}
+var _ jobs.Retryable = &JobETHBroadcast{}
 
 type JobETHBroadcast struct {
 	TrackerName ethereum.TrackerName
 	JobID       string
 	RetryCount  int
 	Status      jobs.Status
+	ChainID     int64
+	err         error
 }
 
-func NewETHBroadcast(name ethereum.TrackerName, state trackerlib.TrackerState) *JobETHBroadcast {
+func NewETHBroadcast(name ethereum.TrackerName, state trackerlib.TrackerState, chainID int64) *JobETHBroadcast {
 
 	return &JobETHBroadcast{
 		TrackerName: name,
 		JobID:       name.String() + storage.DB_PREFIX + strconv.Itoa(int(state)),
 		RetryCount:  0,
 		Status:      0,
+		ChainID:     chainID,
 	}
 }
 
 func (job *JobETHBroadcast) DoMyJob(ctx interface{}) {
 
 	job.RetryCount += 1
-	if job.RetryCount > jobs.Max_Retry_Count {
-		job.Status = jobs.Failed
-		BroadcastReportFinalityETHTx(ctx.(*JobsContext), job.TrackerName, job.JobID, false)
-	}
+	job.err = nil
 	if job.Status == jobs.New {
 		job.Status = jobs.InProgress
 	}
 
 	ethCtx, _ := ctx.(*JobsContext)
-	trackerStore := ethCtx.EthereumTrackers
+	trackerStore := ethCtx.EthereumTrackers.WithChain(job.ChainID)
 
 	tracker, err := trackerStore.WithPrefixType(trackerlib.PrefixOngoing).Get(job.TrackerName)
 	if err != nil {
 		ethCtx.Logger.Error("err trying to deserialize tracker: ", job.TrackerName, err)
 		return
 	}
-	ethconfig := ethCtx.cfg.EthChainDriver
+	ethconfig, err := ethCtx.cfg.EthChainDriver.ForChain(job.ChainID)
+	if err != nil {
+		ethCtx.Logger.Error("err trying to get chain driver config : ", job.GetJobID(), err)
+		job.err = err
+		return
+	}
 
 	//logger := log.NewLoggerWithPrefix(os.Stdout, "JOB_ETHBROADCAST")
 	ethoptions := trackerStore.GetOption()
-	cd := new(ethereum.ETHChainDriver)
+	var cd ethereum.OnlineChainDriver
 	if tracker.Type == trackerlib.ProcessTypeLock {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
 			return
 		}
 	}
 	if tracker.Type == trackerlib.ProcessTypeLockERC {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
+		if err != nil {
+			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
+			return
+		}
+	}
+	if tracker.Type == trackerlib.ProcessTypeLockNFT {
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERC721ContractAddress, ethoptions.ERC721ContractABI, ethereum.NFT)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
 			return
 		}
 	}
 
+	if cd == nil {
+		ethCtx.Logger.Error("no chain driver for tracker type : ", job.GetJobID(), tracker.Type)
+		return
+	}
+
 	rawTx := tracker.SignedETHTx
 	tx, err := cd.DecodeTransaction(rawTx)
 	if err != nil {
@@ -77,6 +97,7 @@ func (job *JobETHBroadcast) DoMyJob(ctx interface{}) {
 	_, err = cd.BroadcastTx(tx)
 	if err != nil {
 		ethCtx.Logger.Error("Error in transaction broadcast : ", job.GetJobID(), err)
+		job.err = err
 		return
 	}
 
@@ -98,3 +119,7 @@ func (job *JobETHBroadcast) IsDone() bool {
 func (job *JobETHBroadcast) IsFailed() bool {
 	return job.Status == jobs.Failed
 }
+
+func (job *JobETHBroadcast) AttemptError() error {
+	return job.err
+}
@@ -3,67 +3,86 @@ package event
 import (
 	"strconv"
 
+	"github.com/pkg/errors"
+
 	"github.com/Oneledger/protocol/chains/ethereum"
 	trackerlib "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/jobs"
 	"github.com/Oneledger/protocol/storage"
 )
 
-var _ jobs.Job = &JobETHCheckFinality{}
+var _ jobs.Retryable = &JobETHCheckFinality{}
 
 type JobETHCheckFinality struct {
 	TrackerName ethereum.TrackerName
 	JobID       string
 	RetryCount  int
 	Status      jobs.Status
+	ChainID     int64
+	err         error
 }
 
-func NewETHCheckFinality(name ethereum.TrackerName, state trackerlib.TrackerState) *JobETHCheckFinality {
+func NewETHCheckFinality(name ethereum.TrackerName, state trackerlib.TrackerState, chainID int64) *JobETHCheckFinality {
 	return &JobETHCheckFinality{
 		TrackerName: name,
 		JobID:       name.String() + storage.DB_PREFIX + strconv.Itoa(int(state)),
 		RetryCount:  0,
 		Status:      0,
+		ChainID:     chainID,
 	}
 }
 
 func (job *JobETHCheckFinality) DoMyJob(ctx interface{}) {
 
-	// get tracker
-	//job.RetryCount += 1
-	if job.RetryCount > jobs.Max_Retry_Count {
-		job.Status = jobs.Failed
-	}
+	job.err = nil
 	if job.Status == jobs.New {
 		job.Status = jobs.InProgress
 	}
 	ethCtx, _ := ctx.(*JobsContext)
 
-	trackerStore := ethCtx.EthereumTrackers
+	trackerStore := ethCtx.EthereumTrackers.WithChain(job.ChainID)
 	tracker, err := trackerStore.WithPrefixType(trackerlib.PrefixOngoing).Get(job.TrackerName)
 	if err != nil {
 		ethCtx.Logger.Error("err trying to deserialize tracker: ", job.TrackerName, err)
-		job.RetryCount += 1
 		return
 	}
 
-	ethconfig := ethCtx.cfg.EthChainDriver
+	ethconfig, err := ethCtx.cfg.EthChainDriver.ForChain(job.ChainID)
+	if err != nil {
+		ethCtx.Logger.Error("err trying to get chain driver config : ", job.GetJobID(), err)
+		job.err = err
+		return
+	}
 	ethoptions := trackerStore.GetOption()
-	cd := new(ethereum.ETHChainDriver)
+	var cd ethereum.OnlineChainDriver
 	if tracker.Type == trackerlib.ProcessTypeLock {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
 			return
 		}
 	} else if tracker.Type == trackerlib.ProcessTypeLockERC {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
+			return
+		}
+	} else if tracker.Type == trackerlib.ProcessTypeLockNFT {
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERC721ContractAddress, ethoptions.ERC721ContractABI, ethereum.NFT)
+		if err != nil {
+			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
 			return
 		}
 	}
 
+	if cd == nil {
+		ethCtx.Logger.Error("no chain driver for tracker type : ", job.GetJobID(), tracker.Type)
+		return
+	}
+
 	rawTx := tracker.SignedETHTx
 	tx, err := cd.DecodeTransaction(rawTx)
 	if err != nil {
@@ -71,9 +90,20 @@ func (job *JobETHCheckFinality) DoMyJob(ctx interface{}) {
 		return
 	}
 	finalityStatus := cd.CheckFinality(tx.Hash(), ethoptions.BlockConfirmation)
+	switch finalityStatus {
+	case ethereum.TxBlockNotFound:
+		job.err = errors.New("block of the transaction can't be linked to the tracked headers")
+		return
+	case ethereum.UnabletoGetHeader:
+		job.err = errors.New("unable to sync the block headers")
+		return
+	case ethereum.ReceiptProofFailed:
+		job.err = errors.New("unable to prove the transaction receipt")
+		return
+	}
 	if finalityStatus == ethereum.BlockHashFailed {
 		job.Status = jobs.Failed
-		BroadcastReportFinalityETHTx(ctx.(*JobsContext), job.TrackerName, job.JobID, false)
+		BroadcastReportFinalityETHTx(ctx.(*JobsContext), job.TrackerName, job.ChainID, job.JobID, false, "transaction failed on chain")
 		return
 	}
 	if finalityStatus == ethereum.TXSuccess {
@@ -81,10 +111,33 @@ func (job *JobETHCheckFinality) DoMyJob(ctx interface{}) {
 		if index < 0 {
 			return
 		}
-		BroadcastReportFinalityETHTx(ethCtx, job.TrackerName, job.JobID, true)
+		// the locker claims the token uri, it has to match the one of the token contract
+		if tracker.Type == trackerlib.ProcessTypeLockNFT {
+			req, err := ethereum.ParseERC721Lock(rawTx, ethoptions.ERC721ContractABI)
+			if err != nil {
+				ethCtx.Logger.Error("Error in Parsing token from rawTx (ERC721 Lock)", job.GetJobID(), err)
+				return
+			}
+			uri, err := cd.TokenURI(req.Token, req.TokenID)
+			if err != nil {
+				ethCtx.Logger.Error("Unable to get token uri :", job.GetJobID(), err)
+				job.err = err
+				return
+			}
+			if uri != tracker.TokenURI {
+				ethCtx.Logger.Info("Token uri does not match the lock | Failing Tracker :", job.GetJobID())
+				job.Status = jobs.Failed
+				BroadcastReportFinalityETHTx(ethCtx, job.TrackerName, job.ChainID, job.JobID, false, "token uri does not match the lock")
+				return
+			}
+		}
+		err = BroadcastReportFinalityETHTx(ethCtx, job.TrackerName, job.ChainID, job.JobID, true, "")
+		if err != nil {
+			job.err = err
+			return
+		}
 		job.Status = jobs.Completed
 	}
-	return
 }
 
 func (job *JobETHCheckFinality) GetType() string {
@@ -102,3 +155,7 @@ func (job *JobETHCheckFinality) IsDone() bool {
 func (job *JobETHCheckFinality) IsFailed() bool {
 	return job.Status == jobs.Failed
 }
+
+func (job *JobETHCheckFinality) AttemptError() error {
+	return job.err
+}
@@ -89,9 +89,9 @@ func Broadcasting(ctx interface{}) error {
 	context.Tracker = tracker
 
 	//create broadcasting
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 
-		job := NewETHBroadcast((*tracker).TrackerName, ethereum.BusyBroadcasting)
+		job := NewETHBroadcast((*tracker).TrackerName, ethereum.BusyBroadcasting, tracker.ChainID)
 		err := context.JobStore.SaveJob(job)
 		if err != nil {
 			return errors.Wrap(errors.New("job serialization failed err: "), err.Error())
@@ -118,7 +118,7 @@ func Finalizing(ctx interface{}) error {
 	}
 
 	context.Tracker = tracker
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		_, voted := tracker.CheckIfVoted(context.CurrNodeAddr)
 		if voted {
 			return nil
@@ -137,7 +137,7 @@ func Finalizing(ctx interface{}) error {
 		if fjob != nil {
 			return nil
 		}
-		job := NewETHCheckFinality(tracker.TrackerName, ethereum.BusyFinalizing)
+		job := NewETHCheckFinality(tracker.TrackerName, ethereum.BusyFinalizing, tracker.ChainID)
 		err = context.JobStore.SaveJob(job)
 		if err != nil {
 			return errors.Wrap(errors.New("job serialization failed err: "), err.Error())
@@ -166,13 +166,13 @@ func Finalization(ctx interface{}) error {
 		return nil
 	}
 
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		//Check if current Node voted
 		_, voted := tracker.CheckIfVoted(context.CurrNodeAddr)
 
 		if !voted {
 			//Create job to check finality
-			job := NewETHCheckFinality(tracker.TrackerName, tracker.State)
+			job := NewETHCheckFinality(tracker.TrackerName, tracker.State, tracker.ChainID)
 
 			err := context.JobStore.SaveJob(job)
 			if err != nil {
@@ -212,7 +212,7 @@ func Cleanup(ctx interface{}) error {
 	}
 
 	//Delete Jobs
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		for state := ethereum.BusyBroadcasting; state <= ethereum.Released; state++ {
 			job, err := context.JobStore.GetJob(tracker.GetJobID(state))
 			if err != nil {
@@ -224,6 +224,10 @@ func Cleanup(ctx interface{}) error {
 				return errors.Wrap(err, "error deleting job from store")
 			}
 		}
+		err := context.JobStore.DeleteResets(tracker.TrackerName.Hex())
+		if err != nil {
+			return errors.Wrap(err, "error deleting job resets from store")
+		}
 	}
 	return nil
 }
@@ -249,7 +253,7 @@ func CleanupFailed(ctx interface{}) error {
 	}
 
 	//Delete Broadcasting Job It its there
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		for state := ethereum.BusyBroadcasting; state <= ethereum.Released; state++ {
 			job, err := context.JobStore.GetJob(tracker.GetJobID(state))
 			if err != nil {
@@ -261,6 +265,10 @@ func CleanupFailed(ctx interface{}) error {
 				return errors.Wrap(err, "error deleting job from store")
 			}
 		}
+		err := context.JobStore.DeleteResets(tracker.TrackerName.Hex())
+		if err != nil {
+			return errors.Wrap(err, "error deleting job resets from store")
+		}
 	}
 
 	return nil
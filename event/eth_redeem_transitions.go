@@ -85,9 +85,9 @@ func Signing(ctx interface{}) error {
 		return err
 	}
 	tracker.State = ethereum.BusyBroadcasting
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 
-		job := NewETHSignRedeem(tracker.TrackerName, ethereum.BusyBroadcasting)
+		job := NewETHSignRedeem(tracker.TrackerName, ethereum.BusyBroadcasting, tracker.ChainID)
 
 		err := context.JobStore.SaveJob(job)
 		if err != nil {
@@ -112,13 +112,13 @@ func VerifyRedeem(ctx interface{}) error {
 		return errors.Wrap(err, tracker.State.String())
 	}
 
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		bjob, err := context.JobStore.GetJob(tracker.GetJobID(ethereum.BusyBroadcasting))
 		if err != nil {
 			return errors.Wrap(err, "failed to get job")
 		}
 		if bjob.IsDone() && !bjob.IsFailed() {
-			job := NewETHVerifyRedeem(tracker.TrackerName, ethereum.BusyFinalizing)
+			job := NewETHVerifyRedeem(tracker.TrackerName, ethereum.BusyFinalizing, tracker.ChainID)
 			err := context.JobStore.SaveJob(job)
 			if err != nil {
 				return errors.Wrap(err, "Failed to save job")
@@ -137,14 +137,14 @@ func RedeemConfirmed(ctx interface{}) error {
 		return errors.New("error casting tracker context")
 	}
 	tracker := context.Tracker
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		if tracker.State == ethereum.BusyFinalizing {
 			bjob, err := context.JobStore.GetJob(tracker.GetJobID(ethereum.BusyBroadcasting))
 			if err != nil {
 				return errors.Wrap(err, "failed to get job")
 			}
 			if bjob.IsDone() && !bjob.IsFailed() {
-				job := NewETHVerifyRedeem(tracker.TrackerName, ethereum.BusyFinalizing)
+				job := NewETHVerifyRedeem(tracker.TrackerName, ethereum.BusyFinalizing, tracker.ChainID)
 				err := context.JobStore.SaveJob(job)
 				if err != nil {
 					return errors.Wrap(err, "Failed to save job")
@@ -165,7 +165,7 @@ func redeemCleanup(ctx interface{}) error {
 	}
 	tracker := context.Tracker
 	//delete the tracker related jobs
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		for state := ethereum.BusyBroadcasting; state <= ethereum.Released; state++ {
 			job, err := context.JobStore.GetJob(tracker.GetJobID(state))
 			if err != nil {
@@ -177,6 +177,10 @@ func redeemCleanup(ctx interface{}) error {
 				return errors.Wrap(err, "error deleting job from store")
 			}
 		}
+		err := context.JobStore.DeleteResets(tracker.TrackerName.Hex())
+		if err != nil {
+			return errors.Wrap(err, "error deleting job resets from store")
+		}
 	}
 	//Delete Tracker
 	context.Logger.Debug("Setting Tracker to succeeded (ethRedeem):", tracker.State.String())
@@ -200,7 +204,7 @@ func redeemCleanupFailed(ctx interface{}) error {
 	}
 	tracker := context.Tracker
 	//delete the tracker related jobs
-	if context.Witnesses.IsETHWitness() {
+	if context.IsWitness() {
 		for state := ethereum.BusyBroadcasting; state <= ethereum.Failed; state++ {
 			job, err := context.JobStore.GetJob(tracker.GetJobID(state))
 			if err != nil {
@@ -214,6 +218,10 @@ func redeemCleanupFailed(ctx interface{}) error {
 				}
 			}
 		}
+		err := context.JobStore.DeleteResets(tracker.TrackerName.Hex())
+		if err != nil {
+			return errors.Wrap(err, "error deleting job resets from store")
+		}
 	}
 	//Delete Tracker
 	context.Logger.Debug("Setting Tracker to Failed (ethRedeem):", tracker.State.String())
@@ -0,0 +1,35 @@
+package event
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/ethereum"
+)
+
+// ResetRetriedJobs drops the jobs of the steps a tracker was stepped back over, once a witness
+// sees the committed retries of the tracker go past the ones it already reset, so that they run again
+func ResetRetriedJobs(ctx *ethereum.TrackerCtx) error {
+	if !ctx.IsWitness() {
+		return nil
+	}
+	tracker := ctx.Tracker
+	name := tracker.TrackerName.Hex()
+	resets, err := ctx.JobStore.GetResets(name)
+	if err != nil {
+		return err
+	}
+	if tracker.Retries <= resets {
+		return nil
+	}
+	for state := tracker.State + 1; state <= ethereum.Released; state++ {
+		job, err := ctx.JobStore.GetJob(tracker.GetJobID(state))
+		if err != nil {
+			continue
+		}
+		err = ctx.JobStore.DeleteJob(job)
+		if err != nil {
+			return errors.Wrap(err, "error deleting job from store")
+		}
+	}
+	return ctx.JobStore.SetResets(name, tracker.Retries)
+}
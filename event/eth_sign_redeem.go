@@ -1,7 +1,7 @@
 package event
 
 import (
-	"fmt"
+	"errors"
 	"math/big"
 	"strconv"
 
@@ -14,22 +14,25 @@ import (
 	"github.com/Oneledger/protocol/storage"
 )
 
-var _ jobs.Job = &JobETHSignRedeem{}
+var _ jobs.Retryable = &JobETHSignRedeem{}
 
 type JobETHSignRedeem struct {
 	TrackerName ethereum.TrackerName
 	JobID       string
 	RetryCount  int
 	Status      jobs.Status
+	ChainID     int64
 	TxHash      *ethereum.TransactionHash
+	err         error
 }
 
-func NewETHSignRedeem(name ethereum.TrackerName, state trackerlib.TrackerState) *JobETHSignRedeem {
+func NewETHSignRedeem(name ethereum.TrackerName, state trackerlib.TrackerState, chainID int64) *JobETHSignRedeem {
 	return &JobETHSignRedeem{
 		TrackerName: name,
 		JobID:       name.String() + storage.DB_PREFIX + strconv.Itoa(int(state)),
 		RetryCount:  0,
 		Status:      0,
+		ChainID:     chainID,
 	}
 }
 
@@ -38,27 +41,35 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	if j.Status == jobs.Completed {
 		return
 	}
+	j.err = nil
 
 	if j.Status == jobs.New {
 		j.Status = jobs.InProgress
 	}
 
 	ethCtx, _ := ctx.(*JobsContext)
-	trackerStore := ethCtx.EthereumTrackers
+	trackerStore := ethCtx.EthereumTrackers.WithChain(j.ChainID)
 	ethCtx.Logger.Debug("Executing Validator Job To Sign Ethereum Smart Contract")
 	tracker, err := trackerStore.WithPrefixType(trackerlib.PrefixOngoing).Get(j.TrackerName)
 	if err != nil {
 		ethCtx.Logger.Error("err trying to deserialize tracker: ", j.TrackerName, err)
 		return
 	}
-	ethconfig := ethCtx.cfg.EthChainDriver
+	ethconfig, err := ethCtx.cfg.EthChainDriver.ForChain(j.ChainID)
+	if err != nil {
+		ethCtx.Logger.Error("err trying to get chain driver config : ", j.GetJobID(), err)
+		j.err = err
+		return
+	}
 	ethoptions := trackerStore.GetOption()
-	cd := new(ethereum.ETHChainDriver)
+	var cd ethereum.OnlineChainDriver
 	redeemAmount := new(big.Int)
+	var nftReq *ethereum.ERC721Request
 	if tracker.Type == trackerlib.ProcessTypeRedeem {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", j.GetJobID(), err, tracker.Type)
+			j.err = err
 			return
 		}
 		reqParams, err := cd.ParseRedeem(tracker.SignedETHTx, ethoptions.ContractABI)
@@ -69,9 +80,10 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 		redeemAmount = reqParams.Amount
 
 	} else if tracker.Type == trackerlib.ProcessTypeRedeemERC {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", j.GetJobID(), err, tracker.Type)
+			j.err = err
 			return
 		}
 		reqParams, err := cd.ParseERC20Redeem(tracker.SignedETHTx, ethoptions.ERCContractABI)
@@ -80,6 +92,23 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 			return
 		}
 		redeemAmount = reqParams.Amount
+	} else if tracker.Type == trackerlib.ProcessTypeRedeemNFT {
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERC721ContractAddress, ethoptions.ERC721ContractABI, ethereum.NFT)
+		if err != nil {
+			ethCtx.Logger.Error("err trying to get ChainDriver : ", j.GetJobID(), err, tracker.Type)
+			j.err = err
+			return
+		}
+		nftReq, err = ethereum.ParseERC721Redeem(tracker.SignedETHTx, ethoptions.ERC721ContractABI)
+		if err != nil {
+			ethCtx.Logger.Error("Error in Parsing token from rawTx (ERC721 Redeem)", j.GetJobID(), err)
+			return
+		}
+	}
+
+	if cd == nil {
+		ethCtx.Logger.Error("no chain driver for tracker type : ", j.GetJobID(), tracker.Type)
+		return
 	}
 
 	rawTx := tracker.SignedETHTx
@@ -102,17 +131,14 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	//Verify Receipt returns
 	txReceipt, err := cd.VerifyReceipt(tx.Hash())
 	if err != nil {
-		ethCtx.Logger.Debug("Error in Getting TX receipt:", err)
-		panic("Shutting down node ,unable to process redeem Transaction")
+		ethCtx.Logger.Error("Error in Getting TX receipt:", j.GetJobID(), err)
+		j.err = err
+		return
 	}
 	if txReceipt == ethereum.Failed {
 		// TX included in uncle block , or TX reverted ( not enough redeem fee)
 		ethCtx.Logger.Debug("Transaction receipt Failed  | Failing Tracker:", err)
-		j.Status = jobs.Failed
-		err := BroadcastReportFinalityETHTx(ctx.(*JobsContext), j.TrackerName, j.JobID, false)
-		if err != nil {
-			panic(fmt.Sprintf("Unable to broadcast failed TX for : %s ", j.JobID))
-		}
+		j.fail(ethCtx, "redeem signature failed on chain")
 		return
 	}
 	if txReceipt == ethereum.NotFound {
@@ -137,10 +163,16 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	*/
 
 	//Checking for confirmation of Vote
-	success, err := cd.HasValidatorSigned(addr, msg.From())
+	var success bool
+	if nftReq != nil {
+		success, err = cd.HasValidatorSignedERC721(addr, nftReq.Token, nftReq.TokenID)
+	} else {
+		success, err = cd.HasValidatorSigned(addr, msg.From())
+	}
 	if err != nil {
 		ethCtx.Logger.Error("Error connecting to HasValidatorSigned function in Smart Contract  :", j.GetJobID(), err)
-		panic("Error connecting to HasValidatorSigned function in Smart Contract ")
+		j.err = err
+		return
 	}
 	//Signature confirmed
 	if success {
@@ -154,21 +186,23 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	}
 
 	//Checking for Status of redeem request (From Ethereum smart contract)
-	status := cd.VerifyRedeem(addr, msg.From())
+	var status ethereum.RedeemStatus
+	if nftReq != nil {
+		status = cd.VerifyERC721Redeem(addr, nftReq.Token, nftReq.TokenID)
+	} else {
+		status = cd.VerifyRedeem(addr, msg.From())
+	}
 	//Ethereum connectivity issue
 	if status == ethereum.ErrorConnecting {
-		ethCtx.Logger.Error("Error connecting to HasValidatorSigned function in Smart Contract  :", j.GetJobID(), err)
-		panic(fmt.Sprintf("Error connecting to HasValidatorSigned function in Smart Contract %s, %s :", j.GetJobID(), err))
+		ethCtx.Logger.Error("Error connecting to VerifyRedeem function in Smart Contract  :", j.GetJobID())
+		j.err = errors.New("unable to connect to the smart contract")
+		return
 	}
 
 	// Redeem request has expired
 	if status == ethereum.Expired && txReceipt == ethereum.Found {
 		ethCtx.Logger.Info("Failing from sign : Redeem Expired")
-		j.Status = jobs.Failed
-		err := BroadcastReportFinalityETHTx(ctx.(*JobsContext), j.TrackerName, j.JobID, false)
-		if err != nil {
-			panic(fmt.Sprintf("Unable to broadcast failed TX for : %s ", j.JobID))
-		}
+		j.fail(ethCtx, "redeem request expired")
 		return
 	}
 	// Status of redeem is Success but USER's Sign has not been confirmed (success is not true yet) = Redeem has been confirmed but this validators vote was reverted .
@@ -180,11 +214,7 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	// Status in not ongoing ,but User Redeem Request is verifiable on etherum = User is sending in an old redeem transaction
 	if status != ethereum.Ongoing && txReceipt == ethereum.Found {
 		ethCtx.Logger.Info("Redeem Request not created by user | Current Status : ", status.String())
-		j.Status = jobs.Failed
-		err := BroadcastReportFinalityETHTx(ctx.(*JobsContext), j.TrackerName, j.JobID, false)
-		if err != nil {
-			panic(fmt.Sprintf("Unable to broadcast failed TX for : %s ", j.JobID))
-		}
+		j.fail(ethCtx, "redeem request not ongoing on chain")
 		return
 	}
 
@@ -192,7 +222,11 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	if j.RetryCount == 0 && txReceipt == ethereum.Found {
 
 		redeemAddr := common.BytesToAddress(tracker.To)
-		tx, err = cd.SignRedeem(addr, redeemAmount, redeemAddr)
+		if nftReq != nil {
+			tx, err = cd.SignERC721Redeem(addr, nftReq.Token, nftReq.TokenID, redeemAddr)
+		} else {
+			tx, err = cd.SignRedeem(addr, redeemAmount, redeemAddr)
+		}
 		if err != nil {
 			ethCtx.Logger.Error("Error in creating signing transaction : ", j.GetJobID(), err)
 			return
@@ -202,14 +236,21 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 		chainid, err := cd.ChainId()
 		if err != nil {
 			ethCtx.Logger.Error("Failed to get chain id ", err)
+			j.err = err
 			return
 		}
 
 		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainid), privkey)
 		privkey = nil
+		if err != nil {
+			ethCtx.Logger.Error("Unable to sign transaction :", j.GetJobID(), err)
+			j.err = err
+			return
+		}
 		_, err = cd.BroadcastTx(signedTx)
 		if err != nil {
 			ethCtx.Logger.Error("Unable to broadcast transaction :", j.GetJobID(), err)
+			j.err = err
 			return
 		}
 		j.RetryCount += 1
@@ -218,6 +259,16 @@ func (j *JobETHSignRedeem) DoMyJob(ctx interface{}) {
 	//j.Status = jobs.Completed
 }
 
+// fail reports the redeem as failed, the job stays to report it again when the report does not go through
+func (j *JobETHSignRedeem) fail(ethCtx *JobsContext, reason string) {
+	err := BroadcastReportFinalityETHTx(ethCtx, j.TrackerName, j.ChainID, j.JobID, false, reason)
+	if err != nil {
+		j.err = err
+		return
+	}
+	j.Status = jobs.Failed
+}
+
 func (j *JobETHSignRedeem) IsDone() bool {
 	return j.Status == jobs.Completed
 }
@@ -233,3 +284,7 @@ func (j *JobETHSignRedeem) GetJobID() string {
 func (j *JobETHSignRedeem) IsFailed() bool {
 	return j.Status == jobs.Failed
 }
+
+func (j *JobETHSignRedeem) AttemptError() error {
+	return j.err
+}
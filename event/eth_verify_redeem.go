@@ -1,7 +1,7 @@
 package event
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 
 	"github.com/Oneledger/protocol/chains/ethereum"
@@ -10,54 +10,76 @@ import (
 	"github.com/Oneledger/protocol/storage"
 )
 
-var _ jobs.Job = &JobETHVerifyRedeem{}
+var _ jobs.Retryable = &JobETHVerifyRedeem{}
 
 type JobETHVerifyRedeem struct {
 	TrackerName ethereum.TrackerName
 	JobID       string
 	RetryCount  int
 	Status      jobs.Status
+	ChainID     int64
+	err         error
 }
 
-func NewETHVerifyRedeem(name ethereum.TrackerName, state trackerlib.TrackerState) *JobETHVerifyRedeem {
+func NewETHVerifyRedeem(name ethereum.TrackerName, state trackerlib.TrackerState, chainID int64) *JobETHVerifyRedeem {
 	return &JobETHVerifyRedeem{
 		TrackerName: name,
 		JobID:       name.String() + storage.DB_PREFIX + strconv.Itoa(int(state)),
 		RetryCount:  0,
 		Status:      0,
+		ChainID:     chainID,
 	}
 }
 
 func (job *JobETHVerifyRedeem) DoMyJob(ctx interface{}) {
-	job.RetryCount += 1
+	job.err = nil
 	if job.Status == jobs.New {
 		job.Status = jobs.InProgress
 	}
 	ethCtx, _ := ctx.(*JobsContext)
-	trackerStore := ethCtx.EthereumTrackers
+	trackerStore := ethCtx.EthereumTrackers.WithChain(job.ChainID)
 	ethCtx.Logger.Debug("Executing Validator JOB to verify from ethereum smart contract")
 	tracker, err := trackerStore.WithPrefixType(trackerlib.PrefixOngoing).Get(job.TrackerName)
 	if err != nil {
 		ethCtx.Logger.Error("Unable to get Tracker", job.JobID)
 		return
 	}
-	ethconfig := ethCtx.cfg.EthChainDriver
+	ethconfig, err := ethCtx.cfg.EthChainDriver.ForChain(job.ChainID)
+	if err != nil {
+		ethCtx.Logger.Error("err trying to get chain driver config : ", job.GetJobID(), err)
+		job.err = err
+		return
+	}
 	ethoptions := trackerStore.GetOption()
-	cd := new(ethereum.ETHChainDriver)
+	var cd ethereum.OnlineChainDriver
 	if tracker.Type == trackerlib.ProcessTypeRedeem {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ContractAddress, ethoptions.ContractABI, ethereum.ETH)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
 			return
 		}
 	} else if tracker.Type == trackerlib.ProcessTypeRedeemERC {
-		cd, err = ethereum.NewChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERCContractAddress, ethoptions.ERCContractABI, ethereum.ERC)
 		if err != nil {
 			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
+			return
+		}
+	} else if tracker.Type == trackerlib.ProcessTypeRedeemNFT {
+		cd, err = ethereum.GetChainDriver(ethconfig, ethCtx.Logger, ethoptions.ERC721ContractAddress, ethoptions.ERC721ContractABI, ethereum.NFT)
+		if err != nil {
+			ethCtx.Logger.Error("err trying to get ChainDriver : ", job.GetJobID(), err, tracker.Type)
+			job.err = err
 			return
 		}
 	}
 
+	if cd == nil {
+		ethCtx.Logger.Error("no chain driver for tracker type : ", job.GetJobID(), tracker.Type)
+		return
+	}
+
 	tx, err := cd.DecodeTransaction(tracker.SignedETHTx)
 	if err != nil {
 		ethCtx.Logger.Error("Unable to decode transaction")
@@ -71,18 +93,29 @@ func (job *JobETHVerifyRedeem) DoMyJob(ctx interface{}) {
 	}
 
 	addr := ethCtx.GetValidatorETHAddress()
-	status := cd.VerifyRedeem(addr, msg.From())
+	var status ethereum.RedeemStatus
+	if tracker.Type == trackerlib.ProcessTypeRedeemNFT {
+		req, err := ethereum.ParseERC721Redeem(tracker.SignedETHTx, ethoptions.ERC721ContractABI)
+		if err != nil {
+			ethCtx.Logger.Error("Error in Parsing token from rawTx (ERC721 Redeem)", job.GetJobID(), err)
+			return
+		}
+		status = cd.VerifyERC721Redeem(addr, req.Token, req.TokenID)
+	} else {
+		status = cd.VerifyRedeem(addr, msg.From())
+	}
 	if status == ethereum.ErrorConnecting {
 		ethCtx.Logger.Error("Error connecting to HasValidatorSigned function in Smart Contract  :", job.JobID, err)
-		panic("Error connecting  to Smart Contract ")
+		job.err = errors.New("unable to connect to the smart contract")
+		return
 	}
 	if status == ethereum.Expired {
-		job.Status = jobs.Failed
-		err := BroadcastReportFinalityETHTx(ctx.(*JobsContext), job.TrackerName, job.JobID, false)
+		err := BroadcastReportFinalityETHTx(ctx.(*JobsContext), job.TrackerName, job.ChainID, job.JobID, false, "redeem request expired")
 		if err != nil {
-			panic(fmt.Sprintf("Unable to broadcast failed TX for : %s %s", job.JobID, err))
+			job.err = err
+			return
 		}
-
+		job.Status = jobs.Failed
 		return
 	}
 	if status == ethereum.Ongoing {
@@ -94,9 +127,10 @@ func (job *JobETHVerifyRedeem) DoMyJob(ctx interface{}) {
 		if index < 0 {
 			return
 		}
-		err := BroadcastReportFinalityETHTx(ethCtx, job.TrackerName, job.JobID, true)
+		err := BroadcastReportFinalityETHTx(ethCtx, job.TrackerName, job.ChainID, job.JobID, true, "")
 		if err != nil {
-			panic(fmt.Sprintf("Unable to broadcast success TX for : %s ", job.JobID))
+			job.err = err
+			return
 		}
 		job.Status = jobs.Completed
 		return
@@ -118,3 +152,7 @@ func (job *JobETHVerifyRedeem) GetJobID() string {
 func (job *JobETHVerifyRedeem) IsFailed() bool {
 	return job.Status == jobs.Failed
 }
+
+func (job *JobETHVerifyRedeem) AttemptError() error {
+	return job.err
+}
@@ -0,0 +1,245 @@
+package event
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Oneledger/protocol/chains/ethereum"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var _ jobs.Retryable = &JobETHWitnessRotation{}
+
+// JobETHWitnessRotation votes the validator set of a witness rotation on every bridge contract of
+// the chain, and reports to the chain once all of them took it
+type JobETHWitnessRotation struct {
+	Chain      chain.Type
+	ChainID    int64
+	Epoch      int64
+	Round      int64
+	Validators []common.Address
+	JobID      string
+	RetryCount int
+	Status     jobs.Status
+	// vote sent to each bridge contract, the zero hash when none was sent yet
+	Contracts []common.Address
+	TxHashes  []ethereum.TransactionHash
+	err       error
+}
+
+func NewETHWitnessRotation(c chain.Type, chainID int64, epoch int64, round int64, validators []common.Address) *JobETHWitnessRotation {
+	return &JobETHWitnessRotation{
+		Chain:      c,
+		ChainID:    chainID,
+		Epoch:      epoch,
+		Round:      round,
+		Validators: validators,
+		JobID: "rotation" + storage.DB_PREFIX + strconv.FormatInt(epoch, 10) +
+			storage.DB_PREFIX + strconv.FormatInt(round, 10),
+		RetryCount: 0,
+		Status:     0,
+	}
+}
+
+// ScheduleWitnessRotation adds the job of this node for the pending witness rotation of a chain,
+// when the node is one of the witnesses that have to sign it. The jobs of rotations that were
+// superseded, cancelled or ran out of their window stop voting on the contracts
+func ScheduleWitnessRotation(js *jobs.JobStore, witnesses *identity.WitnessStore, c chain.Type, chainID int64,
+	myValAddr keys.Address, height int64, logger *log.Logger) {
+	rotation, err := witnesses.GetPendingRotation(c)
+	if err != nil {
+		logger.Error("failed to get witness rotation", c, err)
+		return
+	}
+	if rotation != nil && rotation.IsExpired(height) {
+		rotation = nil
+	}
+	stopStaleRotationJobs(js, rotation, logger)
+	if rotation == nil || !rotation.IsSigner(myValAddr) {
+		return
+	}
+	validators, err := rotation.ETHAddresses()
+	if err != nil {
+		logger.Error("invalid witness rotation", c, err)
+		return
+	}
+	job := NewETHWitnessRotation(c, chainID, rotation.Epoch, rotation.Round, validators)
+	exists, err := js.JobExists(job.GetJobID())
+	if err != nil || exists {
+		return
+	}
+	err = js.SaveJob(job)
+	if err != nil {
+		logger.Error("failed to save witness rotation job", c, err)
+	}
+}
+
+func stopStaleRotationJobs(js *jobs.JobStore, rotation *identity.WitnessRotation, logger *log.Logger) {
+	stale := make([]*JobETHWitnessRotation, 0)
+	js.Iterate(func(job jobs.Job) {
+		j, ok := job.(*JobETHWitnessRotation)
+		if !ok || j.IsDone() || j.IsFailed() {
+			return
+		}
+		if rotation == nil || j.Epoch != rotation.Epoch || j.Round != rotation.Round {
+			stale = append(stale, j)
+		}
+	})
+	for _, j := range stale {
+		j.Status = jobs.Failed
+		err := js.SaveJob(j)
+		if err != nil {
+			logger.Error("failed to stop witness rotation job", j.GetJobID(), err)
+		}
+	}
+}
+
+func (j *JobETHWitnessRotation) DoMyJob(ctx interface{}) {
+
+	if j.Status == jobs.Completed || j.Status == jobs.Failed {
+		return
+	}
+
+	if j.Status == jobs.New {
+		j.Status = jobs.InProgress
+	}
+	j.err = nil
+
+	ethCtx, _ := ctx.(*JobsContext)
+	ethCtx.Logger.Debug("Executing Validator Job To Rotate Witnesses", j.Chain, "epoch", j.Epoch)
+	ethconfig, err := ethCtx.cfg.EthChainDriver.ForChain(j.ChainID)
+	if err != nil {
+		ethCtx.Logger.Error("err trying to get chain driver config : ", j.GetJobID(), err)
+		j.err = err
+		return
+	}
+	ethoptions := ethCtx.EthereumTrackers.WithChain(j.ChainID).GetOption()
+
+	done := true
+	for _, bc := range ethoptions.BridgeContracts() {
+		cd, err := ethereum.GetChainDriver(ethconfig, ethCtx.Logger, bc.Address, bc.ABI, bc.Type)
+		if err != nil {
+			ethCtx.Logger.Error("err trying to get ChainDriver : ", j.GetJobID(), err, bc.Address.Hex())
+			j.err = err
+			return
+		}
+
+		epoch, err := cd.ValidatorEpoch()
+		if err != nil {
+			ethCtx.Logger.Error("Error connecting to validatorEpoch function in Smart Contract :", j.GetJobID(), err)
+			j.err = err
+			return
+		}
+		if epoch >= j.Epoch {
+			continue
+		}
+		done = false
+
+		txHash := j.sentTo(bc.Address)
+		if txHash != (ethereum.TransactionHash{}) {
+			txReceipt, err := cd.VerifyReceipt(txHash)
+			if err != nil {
+				ethCtx.Logger.Error("Error in Getting TX receipt:", j.GetJobID(), err)
+				j.err = err
+				return
+			}
+			// the vote is mined or on its way, the contract moves once enough validators voted
+			if txReceipt != ethereum.Failed {
+				continue
+			}
+			// the vote is sent again, a vote that keeps failing runs the job out of its retries
+			ethCtx.Logger.Error("Validator set update failed on contract", bc.Address.Hex(), j.GetJobID())
+			j.err = errors.New("validator set update failed on contract " + bc.Address.Hex())
+		}
+
+		tx, err := cd.UpdateValidators(ethCtx.GetValidatorETHAddress(), j.Epoch, j.Validators)
+		if err != nil {
+			ethCtx.Logger.Error("Error in creating validator set update transaction : ", j.GetJobID(), err)
+			j.err = err
+			return
+		}
+		chainid, err := cd.ChainId()
+		if err != nil {
+			ethCtx.Logger.Error("Failed to get chain id ", err)
+			j.err = err
+			return
+		}
+		privkey := ethCtx.GetValidatorETHPrivKey()
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainid), privkey)
+		privkey = nil
+		if err != nil {
+			ethCtx.Logger.Error("Unable to sign validator set update :", j.GetJobID(), err)
+			j.err = err
+			return
+		}
+		txHash, err = cd.BroadcastTx(signedTx)
+		if err != nil {
+			ethCtx.Logger.Error("Unable to broadcast transaction :", j.GetJobID(), err)
+			j.err = err
+			return
+		}
+		j.setSent(bc.Address, txHash)
+		ethCtx.Logger.Debug("Validator Set Update Broadcasted | contract :", bc.Address.Hex(), "| epoch :", j.Epoch, "| ETH ", ethCtx.GetValidatorETHAddress().Hex())
+	}
+	if !done {
+		return
+	}
+
+	// the vote is refused once the other witnesses confirmed the rotation, the scheduler stops the
+	// job then
+	err = BroadcastRotateWitnessTx(ethCtx, j.Chain, j.Epoch, j.Round, j.JobID)
+	if err != nil {
+		ethCtx.Logger.Error("Unable to report witness rotation :", j.GetJobID(), err)
+		j.err = err
+		return
+	}
+	j.Status = jobs.Completed
+}
+
+func (j *JobETHWitnessRotation) sentTo(contract common.Address) ethereum.TransactionHash {
+	for i, c := range j.Contracts {
+		if c == contract {
+			return j.TxHashes[i]
+		}
+	}
+	return ethereum.TransactionHash{}
+}
+
+func (j *JobETHWitnessRotation) setSent(contract common.Address, txHash ethereum.TransactionHash) {
+	for i, c := range j.Contracts {
+		if c == contract {
+			j.TxHashes[i] = txHash
+			return
+		}
+	}
+	j.Contracts = append(j.Contracts, contract)
+	j.TxHashes = append(j.TxHashes, txHash)
+}
+
+func (j *JobETHWitnessRotation) IsDone() bool {
+	return j.Status == jobs.Completed
+}
+
+func (j *JobETHWitnessRotation) GetType() string {
+	return JobTypeETHWitnessRotation
+}
+
+func (j *JobETHWitnessRotation) GetJobID() string {
+	return j.JobID
+}
+
+func (j *JobETHWitnessRotation) IsFailed() bool {
+	return j.Status == jobs.Failed
+}
+
+func (j *JobETHWitnessRotation) AttemptError() error {
+	return j.err
+}
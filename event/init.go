@@ -1,6 +1,9 @@
 package event
 
 import (
+	"time"
+
+	"github.com/Oneledger/protocol/data/jobs"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/utils/transition"
 )
@@ -20,6 +23,8 @@ const (
 	JobTypeETHSignRedeem    = "ethsignredeem"
 	JobTypeETHVerifyRedeem  = "verifyredeem"
 
+	JobTypeETHWitnessRotation = "ethWitnessRotation"
+
 	JobTypeGOVCheckVotes       = "govCheckVotes"
 	JobTypeGOVFinalizeProposal = "govFinalizeProposal"
 
@@ -34,6 +39,20 @@ func init() {
 	serialize.RegisterConcrete(new(JobETHCheckFinality), "eth_cf")
 	serialize.RegisterConcrete(new(JobETHSignRedeem), "eth_sign")
 	serialize.RegisterConcrete(new(JobETHVerifyRedeem), "eth_verify")
+	serialize.RegisterConcrete(new(JobETHWitnessRotation), "eth_rotation")
 	serialize.RegisterConcrete(new(JobGovCheckVotes), "gov_check")
 	serialize.RegisterConcrete(new(JobGovFinalizeProposal), "gov_finalize")
+
+	// the ethereum jobs ride out the outages of the ethereum node for a while before they give up
+	ethRetryPolicy := jobs.RetryPolicy{
+		MaxAttempts: 30,
+		Backoff:     10 * time.Second,
+		MaxBackoff:  10 * time.Minute,
+		Jitter:      0.2,
+		Deadline:    12 * time.Hour,
+	}
+	for _, jobType := range []string{JobTypeETHBroadcast, JobTypeETHCheckfinalty, JobTypeETHSignRedeem,
+		JobTypeETHVerifyRedeem, JobTypeETHWitnessRotation} {
+		jobs.SetRetryPolicy(jobType, ethRetryPolicy)
+	}
 }
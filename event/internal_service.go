@@ -6,11 +6,13 @@ package event
 
 import (
 	"github.com/Oneledger/protocol/action"
+	action_bridge "github.com/Oneledger/protocol/action/bridge"
 	"github.com/Oneledger/protocol/action/eth"
 	gov_action "github.com/Oneledger/protocol/action/governance"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/consensus"
+	"github.com/Oneledger/protocol/data/chain"
 	ethereum2 "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/log"
@@ -20,6 +22,7 @@ import (
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 type Service struct {
@@ -29,7 +32,12 @@ type Service struct {
 	router action.Router
 
 	//only support local client for broadcasting internal txs
-	tmrpc *tmclient.Local
+	tmrpc broadcaster
+}
+
+// broadcaster is the part of the consensus client internal txs are broadcast through
+type broadcaster interface {
+	BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
 }
 
 func NewService(ctx node.Context, logger *log.Logger, router action.Router, tmnode *consensus.Node) *Service {
@@ -103,9 +111,9 @@ func (svc Service) InternalBroadcast(request InternalBroadcastRequest, reply *Br
 }
 
 //^TODO Replace error with InternalBroadcastStatus
-func BroadcastReportFinalityETHTx(ethCtx *JobsContext, trackerName ethereum.TrackerName, jobID string, success bool) error {
+func BroadcastReportFinalityETHTx(ethCtx *JobsContext, trackerName ethereum.TrackerName, chainID int64, jobID string, success bool, reason string) error {
 
-	trackerStore := ethCtx.EthereumTrackers
+	trackerStore := ethCtx.EthereumTrackers.WithChain(chainID)
 	tracker, err := trackerStore.QueryAllStores(trackerName)
 	if err != nil {
 		return err
@@ -124,6 +132,8 @@ func BroadcastReportFinalityETHTx(ethCtx *JobsContext, trackerName ethereum.Trac
 		ValidatorAddress: ethCtx.ValidatorAddress,
 		VoteIndex:        index,
 		Success:          success,
+		ChainID:          chainID,
+		Reason:           reason,
 	}
 
 	txData, err := reportFailed.Marshal()
@@ -152,6 +162,46 @@ func BroadcastReportFinalityETHTx(ethCtx *JobsContext, trackerName ethereum.Trac
 	return nil
 }
 
+// BroadcastRotateWitnessTx votes that the bridge contracts of a chain took the witness set of the
+// pending rotation
+func BroadcastRotateWitnessTx(ethCtx *JobsContext, c chain.Type, epoch int64, round int64, jobID string) error {
+
+	rotate := &action_bridge.RotateWitness{
+		Witness: ethCtx.ValidatorAddress,
+		Chain:   c,
+		Epoch:   epoch,
+		Round:   round,
+	}
+
+	txData, err := rotate.Marshal()
+	if err != nil {
+		ethCtx.Logger.Error("Error while preparing witness rotation txn ", jobID, err)
+		return err
+	}
+	uuidNew, _ := uuid.NewUUID()
+	internalRotateTx := action.RawTx{
+		Type: action.BRIDGE_ROTATE_WITNESS,
+		Data: txData,
+		Fee:  action.Fee{},
+		Memo: jobID + uuidNew.String(),
+	}
+
+	req := InternalBroadcastRequest{
+		RawTx: internalRotateTx,
+	}
+	rep := BroadcastReply{}
+	err = ethCtx.Service.InternalBroadcast(req, &rep)
+
+	if err != nil || !rep.OK {
+		ethCtx.Logger.Error("Error while broadcasting witness rotation transaction ", jobID, err, rep.Log)
+		if err == nil {
+			err = errors.New(rep.Log)
+		}
+		return err
+	}
+	return nil
+}
+
 func BroadcastGovExpireVotesTx(jobCtx *JobsContext, proposalID governance.ProposalID, jobID string) error {
 
 	expireVotes := &gov_action.ExpireVotes{
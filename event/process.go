@@ -47,6 +47,9 @@ func (j *JobBus) Start(ctx *JobsContext) error {
 				DeleteCompletedJobs(j.ctx, j.store.WithChain(chain.BITCOIN))
 			case <-tickerEth.C:
 				ProcessAllJobs(j.ctx, j.store.WithChain(chain.ETHEREUM))
+				for _, evmChain := range j.ctx.EthereumTrackers.GetRegistry().Chains {
+					ProcessAllJobs(j.ctx, j.store.WithChain(chain.EVMType(evmChain.ChainID)))
+				}
 			case <-tickerOlt.C:
 				ProcessAllJobs(j.ctx, j.store.WithChain(chain.ONELEDGER))
 				DeleteCompletedJobs(j.ctx, j.store.WithChain(chain.ONELEDGER))
@@ -69,28 +72,68 @@ func (j *JobBus) Close() error {
 
 type JobProcess func(job jobs.Job) jobs.Job
 
+// ProcessAllJobs attempts the jobs of a chain that are due. A job whose attempt panics or reports an
+// error is retried with the retry policy of its type, and dead-lettered once out of retries
 func ProcessAllJobs(ctx *JobsContext, js *jobs.JobStore) {
 
+	now := time.Now()
 	RangeJobs(js, func(job jobs.Job) jobs.Job {
 
-		//ctx.Logger.Info("Trying to do job : ", job.GetType())
+		if js.IsDead(job.GetJobID()) {
+			return job
+		}
+		attempts, err := js.GetAttempts(job.GetJobID())
+		if err != nil {
+			ctx.Logger.Error("err getting job attempts", job.GetJobID(), err)
+			return job
+		}
+		if !attempts.Due(now) {
+			return job
+		}
 
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					ctx.Logger.Info("panic in job: ", job.GetJobID())
-					ctx.Logger.Info(r)
-					debug.PrintStack()
-					panic(r)
+		//ctx.Logger.Info("Trying to do job : ", job.GetType())
+		err = attemptJob(ctx, job)
+		if err == nil {
+			if attempts != nil {
+				err = js.ClearAttempts(job.GetJobID())
+				if err != nil {
+					ctx.Logger.Error("err clearing job attempts", job.GetJobID(), err)
 				}
-			}()
-			job.DoMyJob(ctx)
-		}()
+			}
+			return job
+		}
 
+		attempts, dead, err := js.FailAttempt(job, err, now)
+		if err != nil {
+			ctx.Logger.Error("err recording failed job attempt", job.GetJobID(), err)
+			return job
+		}
+		if dead {
+			ctx.Logger.Error("job dead-lettered after", attempts.Failed, "failed attempts:", job.GetJobID(), attempts.LastError)
+		} else {
+			ctx.Logger.Info("job attempt", attempts.Failed, "failed, retrying at", attempts.NextAttempt, ":", job.GetJobID(), attempts.LastError)
+		}
 		return job
 	})
 }
 
+// attemptJob runs a job once, a panic counts as a failed attempt
+func attemptJob(ctx *JobsContext, job jobs.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger.Error("panic in job: ", job.GetJobID(), r)
+			debug.PrintStack()
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	job.DoMyJob(ctx)
+
+	if retryable, ok := job.(jobs.Retryable); ok {
+		return retryable.AttemptError()
+	}
+	return nil
+}
+
 func RangeJobs(js *jobs.JobStore, pro JobProcess) {
 
 	jobkeys := make([]string, 0, 20)
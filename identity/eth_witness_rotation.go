@@ -0,0 +1,288 @@
+package identity
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// RotationWindow is the number of blocks the current witnesses have to update the contracts to the
+// set of a rotation, the votes of the rotation are refused after it
+const RotationWindow int64 = 100800
+
+// WitnessRotation moves the witnesses of a bridged chain to a new set. The bridge contracts keep
+// their own validators, so the current witnesses first update them to the new set. The chain keeps
+// the current witnesses signing redeems until a quorum of them confirmed the contracts took it
+type WitnessRotation struct {
+	Chain     chain.Type `json:"chain"`
+	Epoch     int64      `json:"epoch"`
+	Witnesses []Witness  `json:"witnesses"`
+	// Signers are the witnesses when the rotation started, they update the contracts and vote on it
+	Signers []keys.Address `json:"signers"`
+	Votes   []keys.Address `json:"votes"`
+	// Round goes up every time a new set supersedes the pending one for the same epoch
+	Round     int64  `json:"round"`
+	Started   int64  `json:"started"`
+	Expires   int64  `json:"expires"`
+	Confirmed int64  `json:"confirmed"`
+	Cancelled int64  `json:"cancelled"`
+	Reason    string `json:"reason"`
+}
+
+// IsPending tells whether the rotation still waits for the contracts to be updated
+func (r *WitnessRotation) IsPending() bool {
+	return r.Confirmed == 0 && r.Cancelled == 0
+}
+
+// IsExpired tells whether the window of the rotation is over at a height
+func (r *WitnessRotation) IsExpired(height int64) bool {
+	return r.Expires > 0 && height > r.Expires
+}
+
+func (r *WitnessRotation) Quorum() int {
+	return len(r.Signers)*2/3 + 1
+}
+
+func (r *WitnessRotation) IsSigner(addr keys.Address) bool {
+	for _, s := range r.Signers {
+		if s.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *WitnessRotation) HasVoted(addr keys.Address) bool {
+	for _, v := range r.Votes {
+		if v.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ETHAddresses returns the addresses the new witnesses sign with on the evm chain
+func (r *WitnessRotation) ETHAddresses() ([]common.Address, error) {
+	addrs := make([]common.Address, 0, len(r.Witnesses))
+	for _, w := range r.Witnesses {
+		pubkey, err := crypto.DecompressPubkey(w.ECDSAPubKey.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ecdsa key of witness %s", w.Address.String())
+		}
+		addrs = append(addrs, crypto.PubkeyToAddress(*pubkey))
+	}
+	return addrs, nil
+}
+
+func (r *WitnessRotation) contains(addr keys.Address) bool {
+	for _, w := range r.Witnesses {
+		if w.Address.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ws *WitnessStore) witnessKey(chain chain.Type, addr keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(ws.prefix) + chain.String() + storage.DB_PREFIX + string(addr))
+}
+
+func (ws *WitnessStore) rotationKey(chain chain.Type) storage.StoreKey {
+	return storage.StoreKey(string(ws.prefix) + "rotation" + storage.DB_PREFIX + chain.String())
+}
+
+// GetRotation returns the last rotation of the witnesses of a chain, nil when they never rotated
+func (ws *WitnessStore) GetRotation(chain chain.Type) (*WitnessRotation, error) {
+	data, err := ws.store.Get(ws.rotationKey(chain))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	rotation := &WitnessRotation{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(data, rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize witness rotation")
+	}
+	return rotation, nil
+}
+
+// GetPendingRotation returns the rotation of the witnesses of a chain waiting for the contracts, if any
+func (ws *WitnessStore) GetPendingRotation(chain chain.Type) (*WitnessRotation, error) {
+	rotation, err := ws.GetRotation(chain)
+	if err != nil || rotation == nil || !rotation.IsPending() {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// GetEpoch returns the epoch of the witness set of a chain, it goes up by one on every confirmed rotation
+func (ws *WitnessStore) GetEpoch(chain chain.Type) (int64, error) {
+	rotation, err := ws.GetRotation(chain)
+	if err != nil || rotation == nil {
+		return 0, err
+	}
+	if rotation.Confirmed == 0 {
+		return rotation.Epoch - 1, nil
+	}
+	return rotation.Epoch, nil
+}
+
+func (ws *WitnessStore) setRotation(rotation *WitnessRotation) error {
+	data, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(rotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize witness rotation")
+	}
+	return ws.store.Set(ws.rotationKey(rotation.Chain), data)
+}
+
+// StartRotation asks the current witnesses of a chain to move the contracts to a new witness set.
+// A new set supersedes the pending rotation: it keeps its epoch, drops its votes and starts a new
+// round and window, the contracts take whichever set of the epoch a quorum voted first
+func (ws *WitnessStore) StartRotation(chain chain.Type, witnesses []Witness, height int64, reason string) (*WitnessRotation, error) {
+	if len(witnesses) == 0 {
+		return nil, errors.New("empty witness set")
+	}
+	seen := make(map[string]bool)
+	for _, w := range witnesses {
+		if err := w.Address.Err(); err != nil {
+			return nil, errors.Wrap(err, "invalid witness address")
+		}
+		if seen[string(w.Address)] {
+			return nil, errors.Errorf("witness %s more than once in the set", w.Address.String())
+		}
+		seen[string(w.Address)] = true
+	}
+
+	signers, err := ws.GetWitnessAddresses(chain)
+	if err != nil {
+		return nil, err
+	}
+	if len(signers) == 0 {
+		return nil, errors.Errorf("%s has no witnesses to rotate from", chain)
+	}
+	last, err := ws.GetRotation(chain)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := ws.GetEpoch(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &WitnessRotation{
+		Chain:     chain,
+		Epoch:     epoch + 1,
+		Witnesses: witnesses,
+		Signers:   signers,
+		Votes:     []keys.Address{},
+		Started:   height,
+		Expires:   height + RotationWindow,
+		Reason:    reason,
+	}
+	// the rounds of an epoch keep counting after a cancel, the jobs of the witnesses go by them
+	if last != nil && last.Epoch == rotation.Epoch {
+		rotation.Round = last.Round + 1
+	}
+	_, err = rotation.ETHAddresses()
+	if err != nil {
+		return nil, err
+	}
+	return rotation, ws.setRotation(rotation)
+}
+
+// PendingWitnesses returns the witness set a chain moves to, the set of the pending rotation if
+// any and the current witnesses otherwise
+func (ws *WitnessStore) PendingWitnesses(chain chain.Type) ([]Witness, error) {
+	pending, err := ws.GetPendingRotation(chain)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return append([]Witness{}, pending.Witnesses...), nil
+	}
+	witnesses := make([]Witness, 0)
+	ws.Iterate(chain, func(addr keys.Address, witness *Witness) bool {
+		witnesses = append(witnesses, *witness)
+		return false
+	})
+	return witnesses, nil
+}
+
+// CancelRotation drops the pending rotation of a chain, the current witnesses stay
+func (ws *WitnessStore) CancelRotation(chain chain.Type, height int64, reason string) (*WitnessRotation, error) {
+	rotation, err := ws.GetPendingRotation(chain)
+	if err != nil {
+		return nil, err
+	}
+	if rotation == nil {
+		return nil, errors.Errorf("no witness rotation of %s pending", chain)
+	}
+	rotation.Cancelled = height
+	rotation.Reason = reason
+	return rotation, ws.setRotation(rotation)
+}
+
+// VoteRotation adds the vote of a witness that the contracts took the new set of the pending
+// rotation. The new set replaces the current one once a quorum of the signers voted
+func (ws *WitnessStore) VoteRotation(chain chain.Type, witness keys.Address, epoch int64, round int64, height int64) (*WitnessRotation, error) {
+	rotation, err := ws.GetPendingRotation(chain)
+	if err != nil {
+		return nil, err
+	}
+	if rotation == nil || rotation.Epoch != epoch {
+		return nil, errors.Errorf("no witness rotation to epoch %d of %s pending", epoch, chain)
+	}
+	// a vote for a superseded set tells nothing about the pending one
+	if rotation.Round != round {
+		return nil, errors.Errorf("witness rotation to epoch %d of %s is at round %d", epoch, chain, rotation.Round)
+	}
+	if rotation.IsExpired(height) {
+		return nil, errors.Errorf("witness rotation to epoch %d of %s expired at height %d", epoch, chain, rotation.Expires)
+	}
+	if !rotation.IsSigner(witness) {
+		return nil, errors.Errorf("%s is not a signer of the witness rotation of %s", witness.String(), chain)
+	}
+	if !rotation.HasVoted(witness) {
+		rotation.Votes = append(rotation.Votes, witness)
+	}
+	if len(rotation.Votes) >= rotation.Quorum() {
+		err = ws.applyRotation(rotation)
+		if err != nil {
+			return nil, err
+		}
+		rotation.Confirmed = height
+	}
+	return rotation, ws.setRotation(rotation)
+}
+
+// applyRotation swaps the witnesses of the chain for the new set
+func (ws *WitnessStore) applyRotation(rotation *WitnessRotation) error {
+	for _, addr := range rotation.Signers {
+		if rotation.contains(addr) {
+			continue
+		}
+		_, err := ws.store.Delete(ws.witnessKey(rotation.Chain, addr))
+		if err != nil {
+			return errors.Wrap(err, "failed to remove witness")
+		}
+	}
+	for i := range rotation.Witnesses {
+		w := rotation.Witnesses[i]
+		// the consensus key may have been rotated while the contracts were updated
+		if current, err := ws.Get(rotation.Chain, w.Address); err == nil {
+			w.PubKey = current.PubKey
+		}
+		err := ws.store.Set(ws.witnessKey(rotation.Chain, w.Address), w.Bytes())
+		if err != nil {
+			return errors.Wrap(err, "failed to add witness")
+		}
+	}
+	return nil
+}
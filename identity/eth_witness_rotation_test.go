@@ -0,0 +1,155 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+func newRotationWitness(t *testing.T, addr keys.Address, name string) Witness {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return Witness{
+		Address:     addr,
+		ECDSAPubKey: keys.PublicKey{KeyType: keys.ETHSECP, Data: crypto.CompressPubkey(&key.PublicKey)},
+		Name:        name,
+	}
+}
+
+func TestWitnessStore_Rotation(t *testing.T) {
+	ws := setupEthWitnessStore()
+	addrs := setupInitialWitness(ws)
+
+	epoch, err := ws.GetEpoch(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), epoch)
+
+	_, err = ws.StartRotation(chain.ETHEREUM, []Witness{}, 2, "empty")
+	assert.Error(t, err)
+	_, err = ws.StartRotation(chain.ETHEREUM, []Witness{{Address: addrs[2], Name: "no ecdsa key"}}, 2, "no key")
+	assert.Error(t, err)
+
+	witnesses := []Witness{
+		newRotationWitness(t, addrs[1], "test_node1"),
+		newRotationWitness(t, addrs[2], "test_node2"),
+	}
+	rotation, err := ws.StartRotation(chain.ETHEREUM, witnesses, 2, "governance")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rotation.Epoch)
+	assert.Equal(t, 2, rotation.Quorum())
+	ws.store.Commit()
+
+	rotation, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 0, 3)
+	require.NoError(t, err)
+	ws.store.Commit()
+
+	// a new set supersedes the pending one for the same epoch, its votes start over
+	rotation, err = ws.StartRotation(chain.ETHEREUM, witnesses, 3, "again")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rotation.Epoch)
+	assert.Equal(t, int64(1), rotation.Round)
+	assert.Empty(t, rotation.Votes)
+	assert.Equal(t, 3+RotationWindow, rotation.Expires)
+	ws.store.Commit()
+
+	// only the witnesses of the current set vote, for the pending round
+	_, err = ws.VoteRotation(chain.ETHEREUM, addrs[2], 1, 1, 4)
+	assert.Error(t, err)
+	_, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 2, 1, 4)
+	assert.Error(t, err)
+	_, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 0, 4)
+	assert.Error(t, err)
+
+	rotation, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 1, 4)
+	require.NoError(t, err)
+	assert.True(t, rotation.IsPending())
+	rotation, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 1, 4)
+	require.NoError(t, err)
+	assert.True(t, rotation.IsPending())
+	ws.store.Commit()
+
+	// the current set signs until the quorum confirmed the contracts took the new one
+	current, err := ws.GetWitnessAddresses(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, []keys.Address{addrs[0], addrs[1]}, current)
+
+	rotation, err = ws.VoteRotation(chain.ETHEREUM, addrs[1], 1, 1, 5)
+	require.NoError(t, err)
+	assert.False(t, rotation.IsPending())
+	assert.Equal(t, int64(5), rotation.Confirmed)
+	ws.store.Commit()
+
+	current, err = ws.GetWitnessAddresses(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, []keys.Address{addrs[1], addrs[2]}, current)
+	w, err := ws.Get(chain.ETHEREUM, addrs[1])
+	require.NoError(t, err)
+	assert.Equal(t, witnesses[0].ECDSAPubKey, w.ECDSAPubKey)
+
+	epoch, err = ws.GetEpoch(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), epoch)
+	pending, err := ws.GetPendingRotation(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Nil(t, pending)
+
+	rotation, err = ws.StartRotation(chain.ETHEREUM, witnesses[1:], 6, "next")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rotation.Epoch)
+	assert.Equal(t, int64(0), rotation.Round)
+}
+
+func TestWitnessStore_RotationCancelAndExpiry(t *testing.T) {
+	ws := setupEthWitnessStore()
+	addrs := setupInitialWitness(ws)
+	witnesses := []Witness{
+		newRotationWitness(t, addrs[1], "test_node1"),
+		newRotationWitness(t, addrs[2], "test_node2"),
+	}
+
+	_, err := ws.CancelRotation(chain.ETHEREUM, 2, "nothing pending")
+	assert.Error(t, err)
+
+	_, err = ws.StartRotation(chain.ETHEREUM, witnesses, 2, "governance")
+	require.NoError(t, err)
+	ws.store.Commit()
+	pending, err := ws.PendingWitnesses(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, witnesses, pending)
+
+	rotation, err := ws.CancelRotation(chain.ETHEREUM, 3, "governance")
+	require.NoError(t, err)
+	assert.False(t, rotation.IsPending())
+	ws.store.Commit()
+
+	// the current set stays at its epoch and the chain moves to it again
+	epoch, err := ws.GetEpoch(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), epoch)
+	pending, err = ws.PendingWitnesses(chain.ETHEREUM)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, addrs[0], pending[0].Address)
+	_, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 0, 4)
+	assert.Error(t, err)
+
+	// the next rotation of the epoch takes the next round
+	rotation, err = ws.StartRotation(chain.ETHEREUM, witnesses, 5, "governance")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rotation.Epoch)
+	assert.Equal(t, int64(1), rotation.Round)
+	ws.store.Commit()
+
+	// votes are refused once the window is over
+	assert.False(t, rotation.IsExpired(rotation.Expires))
+	assert.True(t, rotation.IsExpired(rotation.Expires+1))
+	_, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 1, rotation.Expires+1)
+	assert.Error(t, err)
+	_, err = ws.VoteRotation(chain.ETHEREUM, addrs[0], 1, 1, rotation.Expires)
+	assert.NoError(t, err)
+}
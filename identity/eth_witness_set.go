@@ -9,6 +9,9 @@ import (
 
 var isETHWitness bool
 
+// consensus address of this node, kept to tell whether it witnesses chains added after start
+var nodeWitnessAddress keys.Address
+
 type WitnessStore struct {
 	prefix []byte
 	store  *storage.State
@@ -28,6 +31,7 @@ func (ws *WitnessStore) WithState(state *storage.State) *WitnessStore {
 
 func (ws *WitnessStore) Init(chain chain.Type, nodeValidatorAddress keys.Address) {
 	isETHWitness = ws.Exists(chain, nodeValidatorAddress)
+	nodeWitnessAddress = nodeValidatorAddress
 }
 
 func (ws *WitnessStore) Get(chain chain.Type, addr keys.Address) (*Witness, error) {
@@ -50,9 +54,10 @@ func (ws *WitnessStore) Exists(chain chain.Type, addr keys.Address) bool {
 }
 
 func (ws *WitnessStore) Iterate(chain chain.Type, fn func(addr keys.Address, witness *Witness) bool) (stopped bool) {
+	chainPrefix := storage.StoreKey(string(ws.prefix) + chain.String() + storage.DB_PREFIX)
 	return ws.store.IterateRange(
-		ws.prefix,
-		storage.Rangefix(string(ws.prefix)),
+		chainPrefix,
+		storage.Rangefix(string(chainPrefix)),
 		true,
 		func(key, value []byte) bool {
 			witness, err := (&Witness{}).FromBytes(value)
@@ -60,8 +65,7 @@ func (ws *WitnessStore) Iterate(chain chain.Type, fn func(addr keys.Address, wit
 				logger.Error("failed to deserialize witness")
 				return false
 			}
-			prefix_len := len(storage.StoreKey(string(ws.prefix) + chain.String() + storage.DB_PREFIX))
-			addr := key[prefix_len:]
+			addr := key[len(chainPrefix):]
 			return fn(addr, witness)
 		},
 	)
@@ -82,6 +86,14 @@ func (ws *WitnessStore) IsETHWitness() bool {
 	return isETHWitness
 }
 
+// This node is a witness of the given chain or not, the witness sets change with the rotations
+func (ws *WitnessStore) IsChainWitness(chain chain.Type) bool {
+	if len(nodeWitnessAddress) == 0 {
+		return !chain.IsEVM() && isETHWitness
+	}
+	return ws.Exists(chain, nodeWitnessAddress)
+}
+
 func (ws *WitnessStore) IsWitnessAddress(chain chain.Type, addr keys.Address) bool {
 	key := storage.StoreKey(string(ws.prefix) + chain.String() + storage.DB_PREFIX + string(addr))
 	return ws.store.Exists(key)
@@ -109,3 +121,20 @@ func (ws *WitnessStore) AddWitness(chain chain.Type, apply Stake) error {
 
 	return nil
 }
+
+// Update the consensus key of an existing witness after a validator key rotation
+func (ws *WitnessStore) UpdatePubKey(chain chain.Type, addr keys.Address, pubKey keys.PublicKey) error {
+	witness, err := ws.Get(chain, addr)
+	if err != nil {
+		return err
+	}
+	witness.PubKey = pubKey
+
+	vkey := storage.StoreKey(string(ws.prefix) + chain.String() + storage.DB_PREFIX + string(witness.Address))
+	err = ws.store.Set(vkey, witness.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to update witness pubkey")
+	}
+
+	return nil
+}
@@ -154,3 +154,24 @@ func TestEthWitnessStore_AddWitness(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, keys.Address(addr), witness.Address)
 }
+
+func TestEthWitnessStore_ChainWitnesses(t *testing.T) {
+	ws := setupEthWitnessStore()
+	addrs := setupInitialWitness(ws)
+	polygon := chain.EVMType(137)
+	err := ws.AddWitness(polygon, Stake{ValidatorAddress: addrs[2], StakeAddress: addrs[2], Name: "test_node2"})
+	assert.NoError(t, err)
+	ws.store.Commit()
+
+	ethWitnesses, err := ws.GetWitnessAddresses(chain.ETHEREUM)
+	assert.NoError(t, err)
+	assert.Len(t, ethWitnesses, 2)
+	polygonWitnesses, err := ws.GetWitnessAddresses(polygon)
+	assert.NoError(t, err)
+	assert.Equal(t, []keys.Address{addrs[2]}, polygonWitnesses)
+
+	ws.Init(chain.ETHEREUM, addrs[2])
+	assert.False(t, ws.IsChainWitness(chain.ETHEREUM))
+	assert.True(t, ws.IsChainWitness(polygon))
+	assert.False(t, ws.IsChainWitness(chain.EVMType(56)))
+}
@@ -14,13 +14,14 @@ import (
 )
 
 type Validator struct {
-	Address      keys.Address   `json:"address"`
-	StakeAddress keys.Address   `json:"stakeAddress"`
-	PubKey       keys.PublicKey `json:"pubKey"`
-	ECDSAPubKey  keys.PublicKey `json:"ecdsaPubkey"`
-	Power        int64          `json:"power"`
-	Name         string         `json:"name"`
-	Staking      balance.Amount `json:"staking"`
+	Address      keys.Address     `json:"address"`
+	StakeAddress keys.Address     `json:"stakeAddress"`
+	PubKey       keys.PublicKey   `json:"pubKey"`
+	ECDSAPubKey  keys.PublicKey   `json:"ecdsaPubkey"`
+	Power        int64            `json:"power"`
+	Name         string           `json:"name"`
+	Staking      balance.Amount   `json:"staking"`
+	Profile      ValidatorProfile `json:"profile"`
 }
 
 func NewValidator(address keys.Address, stakeAddress keys.Address, pubKey keys.PublicKey, ecdsaPubKey keys.PublicKey, amount balance.Amount, name string) *Validator {
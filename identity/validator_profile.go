@@ -0,0 +1,143 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	MaxValidatorNameLength    = 70
+	MaxValidatorWebsiteLength = 140
+	MaxValidatorContactLength = 140
+	MaxValidatorDetailsLength = 280
+	MaxValidatorLogoLength    = 140
+)
+
+// ValidatorProfile is the public description of a validator, set by its stake address
+type ValidatorProfile struct {
+	Website string `json:"website"`
+	Contact string `json:"contact"`
+	Details string `json:"details"`
+	Logo    string `json:"logo"`
+}
+
+func (p ValidatorProfile) Validate() error {
+	if len(p.Website) > MaxValidatorWebsiteLength {
+		return fmt.Errorf("website longer than %d", MaxValidatorWebsiteLength)
+	}
+	if len(p.Contact) > MaxValidatorContactLength {
+		return fmt.Errorf("contact longer than %d", MaxValidatorContactLength)
+	}
+	if len(p.Details) > MaxValidatorDetailsLength {
+		return fmt.Errorf("details longer than %d", MaxValidatorDetailsLength)
+	}
+	if len(p.Logo) > MaxValidatorLogoLength {
+		return fmt.Errorf("logo longer than %d", MaxValidatorLogoLength)
+	}
+	return nil
+}
+
+func ValidateValidatorName(name string) error {
+	if len(name) > MaxValidatorNameLength {
+		return fmt.Errorf("name longer than %d", MaxValidatorNameLength)
+	}
+	return nil
+}
+
+// ProfileRecord is the name and profile of a validator as set at a height
+type ProfileRecord struct {
+	Height  int64            `json:"height"`
+	Name    string           `json:"name"`
+	Profile ValidatorProfile `json:"profile"`
+}
+
+func (pr *ProfileRecord) Bytes() []byte {
+	value, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(pr)
+	if err != nil {
+		logger.Error("profile record not serializable", err)
+		return []byte{}
+	}
+	return value
+}
+
+func (pr *ProfileRecord) FromBytes(msg []byte) (*ProfileRecord, error) {
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(msg, pr)
+	if err != nil {
+		logger.Error("failed to deserialize profile record from bytes", err)
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (vs *ValidatorStore) getProfileHistoryPrefix(addr keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(vs.prefixProfile) + addr.String() + storage.DB_PREFIX)
+}
+
+func (vs *ValidatorStore) getProfileHistoryKey(addr keys.Address, height int64) storage.StoreKey {
+	// zero padded so the records iterate in height order
+	return storage.StoreKey(string(vs.getProfileHistoryPrefix(addr)) + fmt.Sprintf("%019d", height))
+}
+
+// HandleEditValidator updates the name and profile of a validator and keeps a record of the change at the height
+func (vs *ValidatorStore) HandleEditValidator(addr keys.Address, name string, profile ValidatorProfile, height int64) error {
+	if err := ValidateValidatorName(name); err != nil {
+		return err
+	}
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	validator, err := vs.Get(addr)
+	if err != nil {
+		return err
+	}
+	validator.Name = name
+	validator.Profile = profile
+
+	err = vs.set(*validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to set validator profile")
+	}
+
+	record := &ProfileRecord{
+		Height:  height,
+		Name:    name,
+		Profile: profile,
+	}
+	err = vs.store.Set(vs.getProfileHistoryKey(addr, height), record.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set profile history")
+	}
+	return nil
+}
+
+// GetProfileHistory returns the committed profile changes of a validator, oldest first
+func (vs *ValidatorStore) GetProfileHistory(addr keys.Address) ([]ProfileRecord, error) {
+	records := make([]ProfileRecord, 0)
+	prefix := vs.getProfileHistoryPrefix(addr)
+
+	var err error
+	vs.store.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			record := &ProfileRecord{}
+			record, err = record.FromBytes(value)
+			if err != nil {
+				return true
+			}
+			records = append(records, *record)
+			return false
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize profile history")
+	}
+	return records, nil
+}
@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorProfile_Validate(t *testing.T) {
+	assert.NoError(t, ValidatorProfile{Website: "https://oneledger.io"}.Validate())
+	assert.Error(t, ValidatorProfile{Details: strings.Repeat("a", MaxValidatorDetailsLength+1)}.Validate())
+	assert.Error(t, ValidateValidatorName(strings.Repeat("a", MaxValidatorNameLength+1)))
+}
+
+func TestValidatorStore_HandleEditValidator(t *testing.T) {
+	t.Run("edit profile twice, should keep both changes in history", func(t *testing.T) {
+		vs, addr, _ := setupForKeyRotation()
+
+		first := ValidatorProfile{Website: "https://first.io", Contact: "first@oneledger.io"}
+		assert.NoError(t, vs.HandleEditValidator(addr, "first", first, 5))
+		vs.store.Commit()
+
+		second := ValidatorProfile{Website: "https://second.io", Details: "second validator"}
+		assert.NoError(t, vs.HandleEditValidator(addr, "second", second, 12))
+		vs.store.Commit()
+
+		validator, err := vs.Get(addr)
+		assert.NoError(t, err)
+		assert.Equal(t, "second", validator.Name)
+		assert.Equal(t, second, validator.Profile)
+
+		history, err := vs.GetProfileHistory(addr)
+		assert.NoError(t, err)
+		if assert.Len(t, history, 2) {
+			assert.Equal(t, int64(5), history[0].Height)
+			assert.Equal(t, first, history[0].Profile)
+			assert.Equal(t, int64(12), history[1].Height)
+			assert.Equal(t, "second", history[1].Name)
+		}
+	})
+	t.Run("edit with an oversized field, should return an error", func(t *testing.T) {
+		vs, addr, _ := setupForKeyRotation()
+
+		profile := ValidatorProfile{Logo: strings.Repeat("a", MaxValidatorLogoLength+1)}
+		assert.Error(t, vs.HandleEditValidator(addr, "test_name", profile, 5))
+	})
+	t.Run("edit an unknown validator, should return an error", func(t *testing.T) {
+		vs := setup()
+		addr, _ := generateConsensusKey()
+
+		assert.Error(t, vs.HandleEditValidator(addr, "test_name", ValidatorProfile{}, 5))
+	})
+}
@@ -25,6 +25,8 @@ import (
 type ValidatorStore struct {
 	prefix              []byte
 	prefixPurge         []byte
+	prefixRotation      []byte
+	prefixProfile       []byte
 	store               *storage.State
 	proposer            keys.Address
 	queue               ValidatorQueue
@@ -38,11 +40,13 @@ type ValidatorStore struct {
 	pendingEvents       []types.Event
 }
 
-func NewValidatorStore(prefix string, prefixPurge string, state *storage.State) *ValidatorStore {
+func NewValidatorStore(prefix string, prefixPurge string, prefixRotation string, prefixProfile string, state *storage.State) *ValidatorStore {
 	// TODO: get the genesis validators when start the node
 	return &ValidatorStore{
 		prefix:              storage.Prefix(prefix),
 		prefixPurge:         storage.Prefix(prefixPurge),
+		prefixRotation:      storage.Prefix(prefixRotation),
+		prefixProfile:       storage.Prefix(prefixProfile),
 		store:               state,
 		proposer:            []byte(nil),
 		queue:               ValidatorQueue{PriorityQueue: make(utils.PriorityQueue, 0, 100)},
@@ -163,7 +167,7 @@ func (vs *ValidatorStore) Setup(req types.RequestBeginBlock, nodeValidatorAddres
 	}
 
 	vs.fetchPostponedUnstakes()
-	vs.InitValidatorQueue(nodeValidatorAddress)
+	vs.InitValidatorQueue(vs.ResolveConsensusAddress(nodeValidatorAddress))
 	vs.cacheActiveValidators(req.LastCommitInfo)
 
 	return def
@@ -263,6 +267,10 @@ func (vs *ValidatorStore) IsValidatorAddress(addr keys.Address) bool {
 func (vs *ValidatorStore) HandleStake(apply Stake, updateStakeAddress bool, height int64) error {
 	validator := &Validator{}
 	if !vs.Exists(apply.ValidatorAddress) {
+		// the address belongs to the consensus key of a rotated validator
+		if owner := vs.ResolveConsensusAddress(apply.ValidatorAddress); !owner.Equal(apply.ValidatorAddress) {
+			return errors.New("consensus key already in use")
+		}
 		validator = NewValidator(
 			apply.ValidatorAddress,
 			apply.StakeAddress,
@@ -271,6 +279,13 @@ func (vs *ValidatorStore) HandleStake(apply Stake, updateStakeAddress bool, heig
 			apply.Amount,
 			apply.Name,
 		)
+		// restored from a state dump with a rotated consensus key, keep resolving it
+		if h, err := apply.Pubkey.GetHandler(); err == nil && !h.Address().Equal(apply.ValidatorAddress) {
+			err = vs.store.Set(vs.getRotationConsensusKey(h.Address()), apply.ValidatorAddress.Bytes())
+			if err != nil {
+				return errors.Wrap(err, "failed to set consensus address")
+			}
+		}
 		// push the new validator to queue
 	} else {
 		v, err := vs.Get(apply.ValidatorAddress)
@@ -312,8 +327,9 @@ func calculatePower(stake balance.Amount) int64 {
 func makingslash(vs *ValidatorStore, evidences []types.Evidence) []Validator {
 	remove := make([]Validator, 0)
 	for _, evidence := range evidences {
-		if vs.store.Exists(evidence.Validator.Address) {
-			value, _ := vs.store.Get(evidence.Validator.GetAddress())
+		addr := storage.StoreKey(vs.ResolveConsensusAddress(evidence.Validator.Address))
+		if vs.store.Exists(addr) {
+			value, _ := vs.store.Get(addr)
 			if value == nil {
 				logger.Error("failed to get validator from store", evidence.Validator.Address)
 			}
@@ -409,7 +425,7 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 
 	if height > 1 || (len(vs.byzantine) > 0) {
 		// map for non top-power validators
-		nonTopValidators := make(map[string]types.PubKey)
+		nonTopValidators := make(map[string]keys.PublicKey)
 
 		// collect top-power validators
 		cnt := int64(0)
@@ -470,6 +486,11 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 				}
 			}
 
+			// remove the old key of a rotated validator
+			if update := vs.applyKeyRotation(validator.Address, height); update != nil {
+				validatorUpdates = append(validatorUpdates, *update)
+			}
+
 			// append to update list
 			if updateTendermint {
 				logger.Detailf("Validator for update ready: %s - with power: %d\n", addrHuman, validator.Power)
@@ -478,7 +499,7 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 					Power:  validator.Power,
 				})
 			} else {
-				nonTopValidators[addrHuman] = validator.PubKey.GetABCIPubKey()
+				nonTopValidators[addrHuman] = validator.PubKey
 			}
 
 			//distribute the fee for validators
@@ -502,12 +523,19 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 		}
 		sort.Strings(keysLA)
 
-		for _, addr := range keysLA {
+		for _, consensusAddr := range keysLA {
+			addr := string(vs.ResolveConsensusAddress(keys.Address(consensusAddr)))
 			addrHuman := keys.Address(addr).Humanize()
-			pub, ok := nonTopValidators[addrHuman]
+			pubKey, ok := nonTopValidators[addrHuman]
 			if !ok {
 				continue
 			}
+			// tendermint only knows the key it reported, skip keys replaced by a rotation
+			h, err := pubKey.GetHandler()
+			if err != nil || !bytes.Equal(h.Address(), []byte(consensusAddr)) {
+				continue
+			}
+			pub := pubKey.GetABCIPubKey()
 			// get last purge height
 			purgeHeight, err := vs.GetLastPurgeHeight(keys.Address(addr))
 			if err != nil {
@@ -520,7 +548,7 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 			}
 
 			// add to validator update list
-			logger.Infof("Validator for purge ready: %s - with power: %d\n", addrHuman, vs.lastActive[addr])
+			logger.Infof("Validator for purge ready: %s - with power: %d\n", addrHuman, vs.lastActive[consensusAddr])
 			validatorUpdates = append(validatorUpdates, types.ValidatorUpdate{
 				PubKey: pub,
 				Power:  0,
@@ -67,7 +67,7 @@ func setUpCtx() *ValidatorContext {
 	delegators := delegation.NewDelegationStore("tst", cs)
 	evidenceStore := evidence.NewEvidenceStore("tes", cs)
 	govern := governance.NewStore("tg", cs)
-	validators := NewValidatorStore("tv", "purged", cs)
+	validators := NewValidatorStore("tv", "purged", "rotation", "profile", cs)
 
 	evidenceOption := evidence.Options{
 		MinVotesRequired: 2,
@@ -95,7 +95,7 @@ func setUpCtx() *ValidatorContext {
 		General:           governance.ProposalOption{},
 		BountyProgramAddr: "0lt581891d8411faaa15bfd3020b8bd78942cb74ecb",
 	}
-	govern.SetFeeOption(*feeOpt)
+	govern.SetFeeOption(feeOpt)
 	govern.SetEvidenceOptions(evidenceOption)
 	govern.WithHeight(0).SetAllLUH()
 	govern.SetProposalOptions(pOpt)
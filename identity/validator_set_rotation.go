@@ -0,0 +1,205 @@
+package identity
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	rotationPendingKey   = "pending"
+	rotationConsensusKey = "consensus"
+)
+
+// KeyRotation records a consensus key swap that has not yet been reported to tendermint
+type KeyRotation struct {
+	ValidatorAddress keys.Address   `json:"validatorAddress"`
+	OldPubKey        keys.PublicKey `json:"oldPubKey"`
+	NewPubKey        keys.PublicKey `json:"newPubKey"`
+	Height           int64          `json:"height"`
+}
+
+func (kr *KeyRotation) Bytes() []byte {
+	value, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(kr)
+	if err != nil {
+		logger.Error("key rotation not serializable", err)
+		return []byte{}
+	}
+	return value
+}
+
+func (kr *KeyRotation) FromBytes(msg []byte) (*KeyRotation, error) {
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(msg, kr)
+	if err != nil {
+		logger.Error("failed to deserialize key rotation from bytes", err)
+		return nil, err
+	}
+	return kr, nil
+}
+
+func (vs *ValidatorStore) getRotationPendingKey(addr keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(vs.prefixRotation) + rotationPendingKey + storage.DB_PREFIX + string(addr))
+}
+
+func (vs *ValidatorStore) getRotationConsensusKey(consensusAddr keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(vs.prefixRotation) + rotationConsensusKey + storage.DB_PREFIX + string(consensusAddr))
+}
+
+// GetPendingRotation returns the consensus key rotation waiting for the end block update, nil if none
+func (vs *ValidatorStore) GetPendingRotation(addr keys.Address) (*KeyRotation, error) {
+	dat, _ := vs.store.Get(vs.getRotationPendingKey(addr))
+	if len(dat) == 0 {
+		return nil, nil
+	}
+	rotation, err := (&KeyRotation{}).FromBytes(dat)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize key rotation")
+	}
+	return rotation, nil
+}
+
+// ResolveConsensusAddress maps the address of a consensus key seen by tendermint
+// to the address the validator was registered with at its first stake
+func (vs *ValidatorStore) ResolveConsensusAddress(consensusAddr keys.Address) keys.Address {
+	dat, _ := vs.store.Get(vs.getRotationConsensusKey(consensusAddr))
+	if len(dat) == 0 {
+		return consensusAddr
+	}
+	return keys.Address(dat)
+}
+
+// IsConsensusKeyInUse checks if the consensus key is already taken by a validator other than the given one
+func (vs *ValidatorStore) IsConsensusKeyInUse(pubKey keys.PublicKey, validatorAddr keys.Address) bool {
+	h, err := pubKey.GetHandler()
+	if err != nil {
+		return true
+	}
+	consensusAddr := h.Address()
+
+	owner := vs.ResolveConsensusAddress(consensusAddr)
+	if !bytes.Equal(owner, consensusAddr) {
+		return !owner.Equal(validatorAddr)
+	}
+	return vs.Exists(consensusAddr) && !consensusAddr.Equal(validatorAddr)
+}
+
+// HandleKeyRotation swaps the consensus key of a validator, the old key is removed from tendermint
+// in the end block update of the next block
+func (vs *ValidatorStore) HandleKeyRotation(addr keys.Address, newPubKey keys.PublicKey, height int64) error {
+	validator, err := vs.Get(addr)
+	if err != nil {
+		return errors.Wrap(err, "error deserialize validator")
+	}
+
+	pending, err := vs.GetPendingRotation(addr)
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		return errors.New("previous key rotation not yet applied")
+	}
+
+	if validator.PubKey.Equal(newPubKey) {
+		return errors.New("new consensus key is the same as the current one")
+	}
+	if vs.IsConsensusKeyInUse(newPubKey, addr) {
+		return errors.New("consensus key already in use")
+	}
+
+	oldHandler, err := validator.PubKey.GetHandler()
+	if err != nil {
+		return errors.Wrap(err, "invalid current consensus key")
+	}
+	newHandler, err := newPubKey.GetHandler()
+	if err != nil {
+		return errors.Wrap(err, "invalid new consensus key")
+	}
+
+	rotation := &KeyRotation{
+		ValidatorAddress: addr,
+		OldPubKey:        validator.PubKey,
+		NewPubKey:        newPubKey,
+		Height:           height,
+	}
+	err = vs.store.Set(vs.getRotationPendingKey(addr), rotation.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set key rotation")
+	}
+
+	// both keys keep resolving to the validator, votes signed with the old key
+	// are still reported by tendermint until the update is applied
+	for _, consensusAddr := range []keys.Address{oldHandler.Address(), newHandler.Address()} {
+		err = vs.store.Set(vs.getRotationConsensusKey(consensusAddr), addr.Bytes())
+		if err != nil {
+			return errors.Wrap(err, "failed to set consensus address")
+		}
+	}
+
+	validator.PubKey = newPubKey
+	err = vs.set(*validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to set validator for key rotation")
+	}
+
+	logger.Infof("consensus key rotated, validator: %s, new consensus address: %s", addr.Humanize(), newHandler.Address().Humanize())
+	return nil
+}
+
+// HandleECDSAKeyRotation swaps the ECDSA key of a validator
+func (vs *ValidatorStore) HandleECDSAKeyRotation(addr keys.Address, newECDSAPubKey keys.PublicKey) error {
+	validator, err := vs.Get(addr)
+	if err != nil {
+		return errors.Wrap(err, "error deserialize validator")
+	}
+	if validator.ECDSAPubKey.Equal(newECDSAPubKey) {
+		return errors.New("new ecdsa key is the same as the current one")
+	}
+
+	validator.ECDSAPubKey = newECDSAPubKey
+	err = vs.set(*validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to set validator for ecdsa key rotation")
+	}
+	return nil
+}
+
+// pop the pending rotation of the validator and make the power 0 update for the old key,
+// only if tendermint still has the old key in the last commit
+func (vs *ValidatorStore) applyKeyRotation(addr keys.Address, height int64) (update *types.ValidatorUpdate) {
+	rotation, err := vs.GetPendingRotation(addr)
+	if err != nil {
+		logger.Errorf("failed to get key rotation, validator: %s", addr.Humanize())
+		return nil
+	}
+	// rotations made in this block are reported in the next one, when the validator data is versioned
+	if rotation == nil || rotation.Height >= height {
+		return nil
+	}
+
+	_, err = vs.store.Delete(vs.getRotationPendingKey(addr))
+	if err != nil {
+		logger.Errorf("failed to delete key rotation, validator: %s", addr.Humanize())
+	}
+
+	h, err := rotation.OldPubKey.GetHandler()
+	if err != nil {
+		return nil
+	}
+	oldAddr := string(h.Address())
+	if _, ok := vs.lastActive[oldAddr]; !ok {
+		return nil
+	}
+	// the old key is removed here, it must not be picked by the purge
+	delete(vs.lastActive, oldAddr)
+
+	logger.Infof("Validator old key for removal ready: %s\n", addr.Humanize())
+	return &types.ValidatorUpdate{
+		PubKey: rotation.OldPubKey.GetABCIPubKey(),
+		Power:  0,
+	}
+}
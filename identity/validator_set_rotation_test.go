@@ -0,0 +1,118 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+func generateConsensusKey() (keys.Address, keys.PublicKey) {
+	pub := ed25519.GenPrivKey().PubKey()
+	pubKey, _ := keys.GetPublicKeyFromBytes(pub.Bytes()[5:], keys.ED25519)
+	return keys.Address(pub.Address()), pubKey
+}
+
+func setupForKeyRotation() (*ValidatorStore, keys.Address, keys.PublicKey) {
+	vs := setup()
+	addr, pubKey := generateConsensusKey()
+	stake := Stake{
+		ValidatorAddress: addr,
+		StakeAddress:     addr,
+		Pubkey:           pubKey,
+		Name:             "test_name",
+		Amount:           *balance.NewAmountFromInt(100),
+	}
+	_ = vs.HandleStake(stake, false, 0)
+	vs.store.Commit()
+	return vs, addr, pubKey
+}
+
+func TestValidatorStore_HandleKeyRotation(t *testing.T) {
+	t.Run("rotate to a new key, should resolve both keys to the validator", func(t *testing.T) {
+		vs, addr, oldPubKey := setupForKeyRotation()
+		newAddr, newPubKey := generateConsensusKey()
+
+		assert.NoError(t, vs.HandleKeyRotation(addr, newPubKey, 5))
+
+		validator, err := vs.Get(addr)
+		assert.NoError(t, err)
+		assert.True(t, validator.PubKey.Equal(newPubKey))
+		assert.Equal(t, addr, vs.ResolveConsensusAddress(newAddr))
+		assert.Equal(t, addr, vs.ResolveConsensusAddress(addr))
+
+		rotation, err := vs.GetPendingRotation(addr)
+		assert.NoError(t, err)
+		if assert.NotNil(t, rotation) {
+			assert.True(t, rotation.OldPubKey.Equal(oldPubKey))
+			assert.Equal(t, int64(5), rotation.Height)
+		}
+	})
+	t.Run("rotate twice before the update, should return an error", func(t *testing.T) {
+		vs, addr, _ := setupForKeyRotation()
+		_, newPubKey := generateConsensusKey()
+		_, nextPubKey := generateConsensusKey()
+
+		assert.NoError(t, vs.HandleKeyRotation(addr, newPubKey, 5))
+		assert.Error(t, vs.HandleKeyRotation(addr, nextPubKey, 5))
+	})
+	t.Run("rotate to a key used by another validator, should return an error", func(t *testing.T) {
+		vs, addr, _ := setupForKeyRotation()
+		otherAddr, otherPubKey := generateConsensusKey()
+		_ = vs.HandleStake(Stake{
+			ValidatorAddress: otherAddr,
+			StakeAddress:     otherAddr,
+			Pubkey:           otherPubKey,
+			Amount:           *balance.NewAmountFromInt(100),
+		}, false, 0)
+		vs.store.Commit()
+
+		assert.Error(t, vs.HandleKeyRotation(addr, otherPubKey, 5))
+	})
+	t.Run("stake a new validator with a rotated key, should return an error", func(t *testing.T) {
+		vs, addr, _ := setupForKeyRotation()
+		newAddr, newPubKey := generateConsensusKey()
+		assert.NoError(t, vs.HandleKeyRotation(addr, newPubKey, 5))
+		vs.store.Commit()
+
+		err := vs.HandleStake(Stake{
+			ValidatorAddress: newAddr,
+			StakeAddress:     newAddr,
+			Pubkey:           newPubKey,
+			Amount:           *balance.NewAmountFromInt(100),
+		}, false, 10)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidatorStore_applyKeyRotation(t *testing.T) {
+	vs, addr, oldPubKey := setupForKeyRotation()
+	_, newPubKey := generateConsensusKey()
+	assert.NoError(t, vs.HandleKeyRotation(addr, newPubKey, 5))
+	vs.store.Commit()
+
+	// tendermint still reports the old key
+	vs.cacheActiveValidators(types.LastCommitInfo{
+		Votes: []types.VoteInfo{{Validator: types.Validator{Address: addr, Power: 100}}},
+	})
+
+	// nothing to apply in the block of the rotation
+	assert.Nil(t, vs.applyKeyRotation(addr, 5))
+
+	update := vs.applyKeyRotation(addr, 6)
+	if assert.NotNil(t, update) {
+		assert.Equal(t, oldPubKey.GetABCIPubKey(), update.PubKey)
+		assert.Equal(t, int64(0), update.Power)
+	}
+	_, ok := vs.lastActive[string(addr)]
+	assert.False(t, ok)
+
+	vs.store.Commit()
+	rotation, err := vs.GetPendingRotation(addr)
+	assert.NoError(t, err)
+	assert.Nil(t, rotation)
+}
@@ -36,7 +36,7 @@ func setup() *ValidatorStore {
 
 	db := db.NewDB("test", db.MemDBBackend, "")
 	cs := storage.NewState(storage.NewChainState("balance", db))
-	vs := NewValidatorStore("v", "purged", cs)
+	vs := NewValidatorStore("v", "purged", "rotation", "profile", cs)
 	return vs
 }
 
@@ -91,63 +91,59 @@ func (r rpcAuthHandler) ServeHTTP(respW http.ResponseWriter, req *http.Request)
 
 func (r *rpcAuthHandler) Authorized(respW http.ResponseWriter, req *http.Request) bool {
 	if r.cfg != nil && r.cfg.Node.Auth.RPCPrivateKey != "" {
-		respErr := ""
-		defer func() {
-			if respErr != "" {
-				http.Error(respW, respErr, 401)
-			}
-		}()
-
 		respW.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
 		token := req.Header.Get("Authorization")
 
-		data := base58.Decode(token)
-		if len(data) <= 20 {
-			respErr = "invalid token"
-			return false
-		}
-		signData := data[:20]
-		signature := data[20:]
-
-		var keyData []byte
-		//Get Private key for signature verification.
-		keyData, err := base64.StdEncoding.DecodeString(r.cfg.Node.Auth.RPCPrivateKey)
+		err := VerifyToken(r.cfg.Node.Auth.RPCPrivateKey, token)
 		if err != nil {
-			respErr = err.Error()
+			http.Error(respW, err.Error(), 401)
 			return false
 		}
+	}
 
-		privateKey, err := keys.GetPrivateKeyFromBytes(keyData, keys.ED25519)
-		if err != nil {
-			respErr = err.Error()
-			return false
-		}
+	return true
+}
 
-		//Private key Handler
-		privateKeyHandler, err := privateKey.GetHandler()
-		if err != nil {
-			respErr = err.Error()
-			return false
-		}
+// VerifyToken checks a token given to the owner at /token against the RPC private key it was signed with
+func VerifyToken(rpcPrivateKey string, token string) error {
+	data := base58.Decode(token)
+	if len(data) <= 20 {
+		return errors.New("invalid token")
+	}
+	signData := data[:20]
+	signature := data[20:]
 
-		//Get public key from private key handler
-		pubKey := privateKeyHandler.PubKey()
-		pubKeyHandler, err := pubKey.GetHandler()
-		if err != nil {
-			respErr = err.Error()
-			return false
-		}
+	//Get Private key for signature verification.
+	keyData, err := base64.StdEncoding.DecodeString(rpcPrivateKey)
+	if err != nil {
+		return err
+	}
 
-		//Verify Message with public key
-		verified := pubKeyHandler.VerifyBytes(signData, signature)
+	privateKey, err := keys.GetPrivateKeyFromBytes(keyData, keys.ED25519)
+	if err != nil {
+		return err
+	}
 
-		if !verified {
-			respErr = "not authorized"
-			return false
-		}
+	//Private key Handler
+	privateKeyHandler, err := privateKey.GetHandler()
+	if err != nil {
+		return err
 	}
 
-	return true
+	//Get public key from private key handler
+	pubKey := privateKeyHandler.PubKey()
+	pubKeyHandler, err := pubKey.GetHandler()
+	if err != nil {
+		return err
+	}
+
+	//Verify Message with public key
+	verified := pubKeyHandler.VerifyBytes(signData, signature)
+
+	if !verified {
+		return errors.New("not authorized")
+	}
+	return nil
 }
 
 // Prepare injects all the data necessary for serving over the specified URL.
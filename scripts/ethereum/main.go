@@ -482,6 +482,7 @@ func redeem() []byte {
 		rawTxBytes2,
 		action.Amount{Currency: olt.Name, Value: *balance.NewAmountFromInt(10000000000)},
 		400000,
+		0,
 	}
 
 	reply := &se.OLTReply{}
@@ -711,6 +712,7 @@ func erc20Redeem() {
 		rawTxBytes2,
 		action.Amount{Currency: olt.Name, Value: *balance.NewAmountFromInt(10000000000)},
 		400000,
+		0,
 	}
 
 	reply := &se.OLTReply{}
@@ -92,7 +92,7 @@ func (svc *Service) validateAndSignTx(req client.BroadcastRequest) ([]byte, erro
 
 	handler := svc.router.Handler(tx.Type)
 	ctx := action.NewContext(svc.router, nil, nil, nil, nil, svc.currencies,
-		svc.feePool, svc.validators, nil, svc.domains, svc.delegators, svc.netwkDelegators, svc.evidenceStore, svc.trackers, nil, nil, nil, svc.logger,
+		svc.feePool, svc.validators, nil, svc.domains, svc.delegators, svc.netwkDelegators, svc.evidenceStore, svc.trackers, nil, nil, nil, nil, nil, svc.logger,
 		svc.proposalMaster, svc.rewardMaster, svc.govern, svc.extStores, svc.govUpdate, svc.stateDB)
 
 	_, err = handler.Validate(ctx, signedTx)
@@ -125,7 +125,7 @@ func (svc *Service) validateAndMtSignTx(req client.BroadcastMtSigRequest) ([]byt
 
 	handler := svc.router.Handler(tx.Type)
 	ctx := action.NewContext(svc.router, nil, nil, nil, nil, svc.currencies,
-		svc.feePool, svc.validators, nil, svc.domains, svc.delegators, svc.netwkDelegators, svc.evidenceStore, svc.trackers, nil, nil, nil, svc.logger,
+		svc.feePool, svc.validators, nil, svc.domains, svc.delegators, svc.netwkDelegators, svc.evidenceStore, svc.trackers, nil, nil, nil, nil, nil, svc.logger,
 		svc.proposalMaster, svc.rewardMaster, svc.govern, svc.extStores, svc.govUpdate, svc.stateDB)
 
 	_, err = handler.Validate(ctx, signedTx)
@@ -8,7 +8,6 @@ import (
 	"bytes"
 	"encoding/hex"
 
-	"github.com/blockcypher/gobcy"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -33,34 +32,40 @@ func (s *Service) PrepareLock(args client.BTCLockPrepareRequest, reply *client.B
 
 	cfg := s.trackerStore.GetConfig()
 
-	cd := bitcoin.NewChainDriver(cfg.BlockCypherToken)
-
-	btc := gobcy.API{cfg.BlockCypherToken, "btc", cfg.BlockCypherChainType}
+	backend, err := cfg.Backend()
+	if err != nil {
+		s.logger.Error("error getting bitcoin backend", err)
+		return codes.ErrBTCReadingTxn
+	}
+	cd := bitcoin.NewChainDriver(backend)
 
 	cdInput := make([]bitcoin.InputTransaction, 0, len(args.Inputs))
 	var totalInput int64 = 0
 
 	for _, input := range args.Inputs {
-		tx, err := btc.GetTX(input.Hash, nil)
+		hashh, err := chainhash.NewHashFromStr(input.Hash)
+		if err != nil {
+			return codes.ErrBadBTCTxn.Wrap(err)
+		}
+
+		out, err := backend.GetOutput(hashh, input.Index)
+		if err == bitcoin.ErrOutputNotFound {
+
+			s.logger.Error("source is not spendable", err)
+			return codes.ErrBTCNotSpendable
+		}
 		if err != nil {
 			s.logger.Error("error in getting txn from bitcoin network", err)
 			return codes.ErrBTCReadingTxn
 		}
 
-		if tx.Confirmations < MINIMUM_CONFIRMATIONS_REQ {
+		if out.Confirmations < MINIMUM_CONFIRMATIONS_REQ {
 
 			s.logger.Error("not enough txn confirmations", err)
 			return codes.ErrBTCNotEnoughConfirmations
 		}
 
-		if tx.Outputs[input.Index].SpentBy != "" {
-
-			s.logger.Error("source is not spendable", err)
-			return codes.ErrBTCNotSpendable
-		}
-
-		hashh, _ := chainhash.NewHashFromStr(tx.Hash)
-		inputAmount := int64(tx.Outputs[input.Index].Value)
+		inputAmount := out.Value
 		totalInput += inputAmount
 
 		cdInput = append(cdInput, bitcoin.InputTransaction{hashh, input.Index, inputAmount})
@@ -85,8 +90,17 @@ func (s *Service) PrepareLock(args client.BTCLockPrepareRequest, reply *client.B
 		return codes.ErrBadBTCAddress.Wrap(err)
 	}
 
-	txnBytes, err := cd.PrepareLockNew(tracker.CurrentTxId, 0, tracker.CurrentBalance,
-		cdInput, args.FeeRate, args.AmountSatoshi, returnAddressBytes, tracker.ProcessLockScriptAddress)
+	feeRate := args.FeeRate
+	if feeRate == 0 {
+		// let the backend pick the rate when the wallet doesn't
+		feeRate, err = backend.EstimateFeeRate(bitcoin.DefaultFeeTarget)
+		if err != nil || feeRate < bitcoin.MinFeeRate || feeRate > bitcoin.MaxFeeRate {
+			feeRate = bitcoin.DefaultFeeRate
+		}
+	}
+
+	txnBytes, err := cd.PrepareLockNew(tracker.CurrentTxId, 0, tracker.CurrentBalance, tracker.CurrentLockScriptAddress,
+		cdInput, feeRate, args.AmountSatoshi, returnAddressBytes, tracker.ProcessLockScriptAddress)
 	if err != nil {
 		return codes.ErrBadBTCTxn.Wrap(err)
 	}
@@ -126,7 +140,7 @@ func (s *Service) AddUserSignatureAndProcessLock(args client.BTCLockRequest, rep
 		// if this is first lock for tracker, then all inputs must be signed
 
 		for i := range newBTCTx.TxIn {
-			if len(newBTCTx.TxIn[i].SignatureScript) == 0 {
+			if !bitcoin.InputSigned(newBTCTx.TxIn[i]) {
 
 				s.logger.Error("all user sources for lock are not signed")
 				return codes.ErrBadBTCTxn
@@ -149,7 +163,7 @@ func (s *Service) AddUserSignatureAndProcessLock(args client.BTCLockRequest, rep
 				continue
 			}
 
-			if len(newBTCTx.TxIn[i].SignatureScript) == 0 {
+			if !bitcoin.InputSigned(newBTCTx.TxIn[i]) {
 
 				s.logger.Error("all user sources for lock are not signed")
 				return codes.ErrBadBTCTxn
@@ -157,7 +171,13 @@ func (s *Service) AddUserSignatureAndProcessLock(args client.BTCLockRequest, rep
 		}
 	}
 
-	if !bitcoin.ValidateLock(newBTCTx, cfg.BlockCypherToken, cfg.BlockCypherChainType,
+	backend, err := cfg.Backend()
+	if err != nil {
+		s.logger.Error("error getting bitcoin backend", err)
+		return codes.ErrBTCReadingTxn
+	}
+
+	if !bitcoin.ValidateLock(newBTCTx, backend,
 		tracker.ProcessLockScriptAddress, tracker.CurrentBalance, totalLockAmount, isFirstLock) {
 
 		return codes.ErrBadBTCTxn
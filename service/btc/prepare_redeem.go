@@ -24,7 +24,12 @@ func (s *Service) PrepareRedeem(args client.BTCRedeemRequest, reply *client.BTCR
 
 	cfg := s.trackerStore.GetConfig()
 
-	cd := bitcoin.NewChainDriver(cfg.BlockCypherToken)
+	backend, err := cfg.Backend()
+	if err != nil {
+		s.logger.Error("error getting bitcoin backend", err)
+		return codes.ErrBTCReadingTxn
+	}
+	cd := bitcoin.NewChainDriver(backend)
 
 	//tracker, err := s.trackerStore.Get("tracker_1")
 	tracker, err := s.trackerStore.GetTrackerForRedeem()
@@ -49,7 +54,7 @@ func (s *Service) PrepareRedeem(args client.BTCRedeemRequest, reply *client.BTCR
 	}
 
 	fmt.Printf("%#v \n", tracker)
-	txnBytes := cd.PrepareRedeemNew(tracker.CurrentTxId, 0, tracker.CurrentBalance,
+	txnBytes := cd.PrepareRedeemNew(tracker.CurrentTxId, 0, tracker.CurrentBalance, tracker.CurrentLockScriptAddress,
 		btcAddr, args.Amount, args.FeesBTC, tracker.ProcessLockScriptAddress)
 
 	fmt.Println(hex.EncodeToString(txnBytes))
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 
 	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/status_codes"
 )
 
@@ -20,6 +21,7 @@ func (s *Service) GetTracker(args client.BTCGetTrackerRequest, reply *client.BTC
 
 	b, _ := json.MarshalIndent(tracker, "", "	")
 	reply.TrackerData = string(b)
+	reply.BridgePaused = s.bridge.IsPaused(chain.BITCOIN)
 
 	return nil
 }
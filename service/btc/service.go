@@ -9,6 +9,7 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 )
@@ -26,6 +27,7 @@ type Service struct {
 
 	validators   *identity.ValidatorStore
 	trackerStore *bitcoin.TrackerStore
+	bridge       *bridge.Store
 }
 
 func NewService(
@@ -35,6 +37,7 @@ func NewService(
 	nodeCtx node.Context,
 	validators *identity.ValidatorStore,
 	trackerStore *bitcoin.TrackerStore,
+	bridgeStore *bridge.Store,
 	logger *log.Logger,
 ) *Service {
 
@@ -44,6 +47,7 @@ func NewService(
 		accounts:     accounts,
 		validators:   validators,
 		trackerStore: trackerStore,
+		bridge:       bridgeStore,
 		logger:       logger,
 	}
 }
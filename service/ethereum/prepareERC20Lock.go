@@ -11,8 +11,9 @@ import (
 
 func (svc *Service) PrepareOLTERC20Lock(req *OLTERC20LockRequest, out *OLTReply) error {
 	erc20lock := eth.ERC20Lock{
-		Locker: req.Address,
-		ETHTxn: req.RawTx,
+		Locker:  req.Address,
+		ETHTxn:  req.RawTx,
+		ChainID: req.ChainID,
 	}
 
 	data, err := erc20lock.Marshal()
@@ -12,9 +12,10 @@ import (
 func (svc *Service) CreateRawExtERC20Redeem(req RedeemRequest, out *OLTReply) error {
 
 	redeemERC20 := eth.ERC20Redeem{
-		Owner:  req.UserOLTaddress,
-		To:     req.UserETHaddress,
-		ETHTxn: req.ETHTxn,
+		Owner:   req.UserOLTaddress,
+		To:      req.UserETHaddress,
+		ETHTxn:  req.ETHTxn,
+		ChainID: req.ChainID,
 	}
 
 	data, err := redeemERC20.Marshal()
@@ -0,0 +1,84 @@
+package ethereum
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/eth"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+func (svc *Service) CreateRawExtERC721Lock(req OLTERC721LockRequest, out *OLTReply) error {
+	lock := eth.ERC721Lock{
+		Locker:   req.Address,
+		ETHTxn:   req.RawTx,
+		TokenURI: req.TokenURI,
+		ChainID:  req.ChainID,
+	}
+	data, err := lock.Marshal()
+	if err != nil {
+		svc.logger.Error(codes.ErrUnmarshaling.ErrorMsg())
+		return codes.ErrUnmarshaling
+	}
+	return createRawTx(action.ERC721_LOCK, data, req.Fee, req.Gas, out)
+}
+
+func (svc *Service) CreateRawExtERC721Redeem(req RedeemRequest, out *OLTReply) error {
+	redeem := eth.ERC721Redeem{
+		Owner:   req.UserOLTaddress,
+		To:      req.UserETHaddress,
+		ETHTxn:  req.ETHTxn,
+		ChainID: req.ChainID,
+	}
+	data, err := redeem.Marshal()
+	if err != nil {
+		svc.logger.Error(codes.ErrUnmarshaling.ErrorMsg())
+		return codes.ErrUnmarshaling
+	}
+	return createRawTx(action.ERC721_REDEEM, data, req.Fee, req.Gas, out)
+}
+
+func (svc *Service) CreateRawNFTTransfer(req NFTTransferRequest, out *OLTReply) error {
+	transfer := eth.NFTTransfer{
+		From:     req.From,
+		To:       req.To,
+		ChainID:  req.ChainID,
+		Contract: req.Contract,
+		TokenID:  req.TokenID,
+	}
+	data, err := transfer.Marshal()
+	if err != nil {
+		svc.logger.Error(codes.ErrUnmarshaling.ErrorMsg())
+		return codes.ErrUnmarshaling
+	}
+	return createRawTx(action.NFT_TRANSFER, data, req.Fee, req.Gas, out)
+}
+
+// ListNFTs returns the bridged ERC721 tokens held by an address
+func (svc *Service) ListNFTs(req ListNFTsRequest, out *ListNFTsReply) error {
+	*out = ListNFTsReply{
+		NFTs:   svc.nfts.GetOwned(req.Owner),
+		Height: svc.nfts.State.Version(),
+	}
+	return nil
+}
+
+func createRawTx(txType action.Type, data []byte, userfee action.Amount, gas int64, out *OLTReply) error {
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type: txType,
+		Data: data,
+		Fee:  action.Fee{Price: userfee, Gas: gas},
+		Memo: uuidNew.String(),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return action.ErrUnserializable
+	}
+	*out = OLTReply{
+		RawTX: packet,
+	}
+	return nil
+}
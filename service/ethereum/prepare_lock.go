@@ -19,7 +19,7 @@ import (
 
 func (svc *Service) CreateRawExtLock(req OLTLockRequest, out *OLTReply) error {
 
-	packets, err := createRawLock(req.Address, req.RawTx, req.Fee, req.Gas)
+	packets, err := createRawLock(req.Address, req.RawTx, req.ChainID, req.Fee, req.Gas)
 	if err != nil {
 		svc.logger.Error(err, codes.ErrPreparingOLTLock.ErrorMsg())
 		return codes.ErrPreparingOLTLock
@@ -34,12 +34,13 @@ func (svc *Service) CreateRawExtLock(req OLTLockRequest, out *OLTReply) error {
 // Helper Function to create Lock ,and send back unsigned OLT transaction
 // Data Field is Lock struct (Tx.data.ETHTxn)
 
-func createRawLock(locker action.Address, rawTx []byte, userfee action.Amount, gas int64) ([]byte, error) {
+func createRawLock(locker action.Address, rawTx []byte, chainID int64, userfee action.Amount, gas int64) ([]byte, error) {
 	// First accept the rawTx
 	//tracker := tracker.NewTracker(common.BytesToHash(rawTx))
 	lock := eth.Lock{
-		Locker: locker,
-		ETHTxn: rawTx,
+		Locker:  locker,
+		ETHTxn:  rawTx,
+		ChainID: chainID,
 	}
 
 	data, err := lock.Marshal()
@@ -67,7 +68,7 @@ func createRawLock(locker action.Address, rawTx []byte, userfee action.Amount, g
 // Wallet signs and then calls onlinelock
 func (svc *Service) GetRawLockTX(req ETHLockRequest, out *ETHLockRawTX) error {
 	opt := svc.trackers.GetOption()
-	cd, err := ethereum.NewChainDriver(svc.config, svc.logger, opt.ContractAddress, opt.ContractABI, ethereum.ERC)
+	cd, err := ethereum.GetChainDriver(svc.config, svc.logger, opt.ContractAddress, opt.ContractABI, ethereum.ERC)
 	if err != nil {
 		return errors.Wrap(err, "GetRawLockTx")
 	}
@@ -12,9 +12,10 @@ import (
 func (svc *Service) CreateRawExtRedeem(req RedeemRequest, out *OLTReply) error {
 
 	redeem := eth.Redeem{
-		Owner:  req.UserOLTaddress,
-		To:     req.UserETHaddress,
-		ETHTxn: req.ETHTxn,
+		Owner:   req.UserOLTaddress,
+		To:      req.UserETHaddress,
+		ETHTxn:  req.ETHTxn,
+		ChainID: req.ChainID,
 	}
 
 	data, err := redeem.Marshal()
@@ -10,6 +10,7 @@ import (
 	chain "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/bridge"
 	ethTracker "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/identity"
@@ -28,6 +29,8 @@ type Service struct {
 	nodeContext node.Context
 	validators  *identity.ValidatorStore
 	trackers    *ethTracker.TrackerStore
+	nfts        *ethTracker.NFTStore
+	bridge      *bridge.Store
 }
 
 // Returns a new Service, should be passed as an RPC handler
@@ -39,6 +42,8 @@ func NewService(
 	nodeCtx node.Context,
 	validators *identity.ValidatorStore,
 	trackerStore *ethTracker.TrackerStore,
+	nftStore *ethTracker.NFTStore,
+	bridgeStore *bridge.Store,
 
 	logger *log.Logger,
 ) *Service {
@@ -50,6 +55,8 @@ func NewService(
 		accounts:    accounts,
 		validators:  validators,
 		trackers:    trackerStore,
+		nfts:        nftStore,
+		bridge:      bridgeStore,
 		logger:      logger,
 	}
 }
@@ -62,6 +69,7 @@ type OLTLockRequest struct {
 	Address keys.Address
 	Fee     action.Amount `json:"fee"`
 	Gas     int64         `json:"gas"`
+	ChainID int64         `json:"chainId"`
 }
 
 type OLTERC20LockRequest struct {
@@ -69,6 +77,7 @@ type OLTERC20LockRequest struct {
 	Address keys.Address
 	Fee     action.Amount `json:"fee"`
 	Gas     int64         `json:"gas"`
+	ChainID int64         `json:"chainId"`
 }
 
 type OLTReply struct {
@@ -81,6 +90,7 @@ type RedeemRequest struct {
 	ETHTxn         []byte         `json:"ethTxn"`
 	Fee            action.Amount  `json:"fee"`
 	Gas            int64          `json:"gas"`
+	ChainID        int64          `json:"chainId"`
 }
 
 type OLTERC20RedeemRequest struct {
@@ -89,6 +99,35 @@ type OLTERC20RedeemRequest struct {
 	ETHTxn         []byte         `json:"ethTxn"`
 	Fee            action.Amount  `json:"fee"`
 	Gas            int64          `json:"gas"`
+	ChainID        int64          `json:"chainId"`
+}
+
+type OLTERC721LockRequest struct {
+	RawTx    []byte `json:"rawTx"`
+	Address  keys.Address
+	TokenURI string        `json:"tokenUri"`
+	Fee      action.Amount `json:"fee"`
+	Gas      int64         `json:"gas"`
+	ChainID  int64         `json:"chainId"`
+}
+
+type NFTTransferRequest struct {
+	From     action.Address `json:"from"`
+	To       action.Address `json:"to"`
+	Contract common.Address `json:"contract"`
+	TokenID  string         `json:"tokenId"`
+	Fee      action.Amount  `json:"fee"`
+	Gas      int64          `json:"gas"`
+	ChainID  int64          `json:"chainId"`
+}
+
+type ListNFTsRequest struct {
+	Owner keys.Address `json:"owner"`
+}
+
+type ListNFTsReply struct {
+	NFTs   []ethTracker.NFT `json:"nfts"`
+	Height int64            `json:"height"`
 }
 
 type ETHLockRequest struct {
@@ -120,8 +159,11 @@ type BalanceReply struct {
 
 type TrackerStatusRequest struct {
 	TrackerName chain.TrackerName `json:"trackerName"`
+	ChainID     int64             `json:"chainId"`
 }
 
 type TrackerStatusReply struct {
 	Status string `json:"status"`
+	// BridgePaused tells whether the bridge of the chain of the tracker refuses new locks and redeems
+	BridgePaused bool `json:"bridgePaused"`
 }
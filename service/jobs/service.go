@@ -0,0 +1,165 @@
+package jobs
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/log"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// Service lets the node operator look into the validator jobs of the node, and requeue or cancel
+// the ones the job bus gave up on
+type Service struct {
+	jobs   *jobs.JobStore
+	logger *log.Logger
+}
+
+func NewService(store *jobs.JobStore, logger *log.Logger) *Service {
+	return &Service{
+		jobs:   store,
+		logger: logger,
+	}
+}
+
+func Name() string {
+	return "jobs"
+}
+
+func (svc *Service) ListJobs(req client.ListJobsRequest, reply *client.ListJobsReply) error {
+	chainType, err := chain.TypeFromName(req.Chain)
+	if err != nil {
+		return errors.Wrap(err, req.Chain)
+	}
+
+	infos := make([]client.JobInfo, 0)
+	if req.Dead {
+		svc.jobs.IterateDeadLetters(chainType, func(letter *jobs.DeadLetter) {
+			infos = append(infos, deadJobInfo(letter))
+		})
+	} else {
+		svc.jobs.IterateChain(chainType, func(job jobs.Job) {
+			info, err := svc.jobInfo(chainType, job)
+			if err != nil {
+				svc.logger.Error("error getting job", job.GetJobID(), err)
+				return
+			}
+			infos = append(infos, info)
+		})
+	}
+
+	*reply = client.ListJobsReply{
+		Jobs: infos,
+	}
+	return nil
+}
+
+func (svc *Service) GetJob(req client.JobRequest, reply *client.JobReply) error {
+	chainType, err := chain.TypeFromName(req.Chain)
+	if err != nil {
+		return errors.Wrap(err, req.Chain)
+	}
+
+	job, err := svc.jobs.GetChainJob(chainType, req.JobID)
+	if err != nil {
+		return codes.ErrGettingJob.Wrap(err)
+	}
+	info, err := svc.jobInfo(chainType, job)
+	if err != nil {
+		svc.logger.Error("error getting job", req.JobID, err)
+		return codes.ErrGettingJob.Wrap(err)
+	}
+
+	*reply = client.JobReply{
+		Job: info,
+	}
+	return nil
+}
+
+// RequeueJob takes a job out of the dead-letter queue, the job bus attempts it again on its next round
+func (svc *Service) RequeueJob(req client.JobRequest, reply *client.JobReply) error {
+	chainType, err := chain.TypeFromName(req.Chain)
+	if err != nil {
+		return errors.Wrap(err, req.Chain)
+	}
+	letter, err := svc.jobs.GetDeadLetter(chainType, req.JobID)
+	if err != nil {
+		return codes.ErrGettingJob.Wrap(err)
+	}
+	err = svc.jobs.Requeue(chainType, req.JobID)
+	if err != nil {
+		svc.logger.Error("error requeuing job", req.JobID, err)
+		return codes.ErrUpdatingJob.Wrap(err)
+	}
+	svc.logger.Info("job requeued", chainType, req.JobID)
+
+	info := deadJobInfo(letter)
+	info.State = "pending"
+	info.Attempts = nil
+	info.Dead = nil
+	*reply = client.JobReply{
+		Job: info,
+	}
+	return nil
+}
+
+// CancelJob drops a job of the node, whether the job bus gave up on it or not
+func (svc *Service) CancelJob(req client.JobRequest, reply *client.JobReply) error {
+	chainType, err := chain.TypeFromName(req.Chain)
+	if err != nil {
+		return errors.Wrap(err, req.Chain)
+	}
+	err = svc.jobs.Cancel(chainType, req.JobID)
+	if err != nil {
+		svc.logger.Error("error cancelling job", req.JobID, err)
+		return codes.ErrUpdatingJob.Wrap(err)
+	}
+	svc.logger.Info("job cancelled", chainType, req.JobID)
+
+	*reply = client.JobReply{
+		Job: client.JobInfo{JobID: req.JobID, State: "cancelled"},
+	}
+	return nil
+}
+
+func (svc *Service) jobInfo(c chain.Type, job jobs.Job) (client.JobInfo, error) {
+	info := client.JobInfo{
+		JobID: job.GetJobID(),
+		Type:  job.GetType(),
+		State: "pending",
+		Job:   job,
+	}
+	letter, err := svc.jobs.GetDeadLetter(c, job.GetJobID())
+	if err == nil {
+		return deadJobInfo(letter), nil
+	}
+	attempts, err := svc.jobs.GetChainAttempts(c, job.GetJobID())
+	if err != nil {
+		return info, err
+	}
+	info.Attempts = attempts
+	switch {
+	case job.IsDone():
+		info.State = "done"
+	case job.IsFailed():
+		info.State = "failed"
+	case attempts != nil:
+		info.State = "retrying"
+	}
+	return info, nil
+}
+
+func deadJobInfo(letter *jobs.DeadLetter) client.JobInfo {
+	attempts := letter.Attempts
+	dead := letter.Dead
+	return client.JobInfo{
+		JobID:    attempts.JobID,
+		Type:     attempts.Type,
+		State:    "dead",
+		Attempts: &attempts,
+		Dead:     &dead,
+		Job:      letter.Job,
+	}
+}
@@ -18,17 +18,20 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/delegation"
 	ethTracker "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/evidence"
 	"github.com/Oneledger/protocol/data/evm"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/jobs"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/service/broadcast"
 	"github.com/Oneledger/protocol/service/btc"
 	"github.com/Oneledger/protocol/service/ethereum"
+	jobsvc "github.com/Oneledger/protocol/service/jobs"
 	nodesvc "github.com/Oneledger/protocol/service/node"
 	"github.com/Oneledger/protocol/service/owner"
 	"github.com/Oneledger/protocol/service/query"
@@ -49,6 +52,9 @@ type Context struct {
 	WitnessSet      *identity.WitnessStore
 	Trackers        *bitcoin.TrackerStore
 	EthTrackers     *ethTracker.TrackerStore
+	NFTs            *ethTracker.NFTStore
+	Bridge          *bridge.Store
+	JobStore        *jobs.JobStore
 	// configurations
 	Cfg                   config.Server
 	Currencies            *balance.CurrencySet
@@ -84,10 +90,11 @@ func NewMap(ctx *Context) (Map, error) {
 		nodesvc.Name(): nodesvc.NewService(ctx.NodeContext, &ctx.Cfg, ctx.Logger),
 		owner.Name():   owner.NewService(ctx.Accounts, ctx.Logger),
 		query.Name(): query.NewService(ctx.Services, ctx.Balances, ctx.Currencies, ctx.ValidatorSet, ctx.WitnessSet, ctx.Domains, ctx.Delegators, ctx.NetwkDelegators, ctx.EvidenceStore,
-			ctx.Govern, ctx.FeePool, ctx.ProposalMaster, ctx.RewardMaster, ctx.Logger, ctx.TxTypes, ctx.Contracts, ctx.AccountKeeper),
+			ctx.Govern, ctx.FeePool, ctx.ProposalMaster, ctx.RewardMaster, ctx.Logger, ctx.TxTypes, ctx.Contracts, ctx.AccountKeeper, ctx.Bridge),
 		tx.Name():       tx.NewService(ctx.Balances, ctx.Router, ctx.Accounts, ctx.ValidatorSet, ctx.Govern, ctx.Delegators, ctx.EvidenceStore, ctx.FeePool.GetOpt(), ctx.NodeContext, ctx.Logger),
-		btc.Name():      btc.NewService(ctx.Balances, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.Trackers, ctx.Logger),
-		ethereum.Name(): ethereum.NewService(ctx.Cfg.EthChainDriver, ctx.Router, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.EthTrackers, ctx.Logger),
+		btc.Name():      btc.NewService(ctx.Balances, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.Trackers, ctx.Bridge, ctx.Logger),
+		jobsvc.Name():   jobsvc.NewService(ctx.JobStore, ctx.Logger),
+		ethereum.Name(): ethereum.NewService(ctx.Cfg.EthChainDriver, ctx.Router, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.EthTrackers, ctx.NFTs, ctx.Bridge, ctx.Logger),
 	}
 
 	serviceMap := Map{}
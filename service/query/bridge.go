@@ -0,0 +1,92 @@
+package query
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bridge"
+	"github.com/Oneledger/protocol/data/chain"
+)
+
+// GetBridgeStatus returns the circuit breaker of a bridged chain, with the usage of the flow limits
+// of the bridged assets over their windows
+func (svc *Service) GetBridgeStatus(req client.BridgeStatusRequest, reply *client.BridgeStatusReply) error {
+	chainType, err := chain.TypeFromName(req.Chain)
+	if err != nil {
+		return errors.Wrap(err, req.Chain)
+	}
+	pause, err := svc.bridge.GetPause(chainType)
+	if err != nil {
+		svc.logger.Error("error getting bridge pause", err)
+		return err
+	}
+	opt, err := svc.governance.GetBridgeOptions()
+	if err != nil {
+		return err
+	}
+
+	height := svc.bridge.State.Version()
+	limits := make([]client.BridgeLimitUsage, 0, len(opt.Limits))
+	for _, limit := range opt.Limits {
+		inflow, err := svc.bridge.Usage(limit, bridge.Inflow, height)
+		if err != nil {
+			return err
+		}
+		outflow, err := svc.bridge.Usage(limit, bridge.Outflow, height)
+		if err != nil {
+			return err
+		}
+		limits = append(limits, client.BridgeLimitUsage{
+			Limit:   limit,
+			Inflow:  *balance.NewAmountFromBigInt(inflow),
+			Outflow: *balance.NewAmountFromBigInt(outflow),
+		})
+	}
+
+	*reply = client.BridgeStatusReply{
+		Pause:  *pause,
+		Limits: limits,
+		Height: height,
+	}
+	return nil
+}
+
+// GetTrackerHistory returns the history of a tracker of a bridge, it is kept once the tracker is cleaned up
+func (svc *Service) GetTrackerHistory(req client.TrackerHistoryRequest, reply *client.TrackerHistoryReply) error {
+	chainType, err := chain.TypeFromName(req.Chain)
+	if err != nil {
+		return errors.Wrap(err, req.Chain)
+	}
+	history, err := svc.bridge.GetHistory(chainType, req.Name)
+	if err != nil {
+		return err
+	}
+
+	*reply = client.TrackerHistoryReply{
+		History: *history,
+		Height:  svc.bridge.State.Version(),
+	}
+	return nil
+}
+
+// ListTrackers lists the trackers of the bridges by owner, state and the heights they were active between
+func (svc *Service) ListTrackers(req client.ListTrackersRequest, reply *client.ListTrackersReply) error {
+	chainType := chain.Type(-1)
+	if req.Chain != "" {
+		var err error
+		chainType, err = chain.TypeFromName(req.Chain)
+		if err != nil {
+			return errors.Wrap(err, req.Chain)
+		}
+	}
+	if req.ToHeight > 0 && req.FromHeight > req.ToHeight {
+		return errors.New("from height is over to height")
+	}
+
+	*reply = client.ListTrackersReply{
+		Trackers: svc.bridge.FilterHistory(chainType, req.Owner, req.State, req.FromHeight, req.ToHeight),
+		Height:   svc.bridge.State.Version(),
+	}
+	return nil
+}
@@ -118,6 +118,14 @@ func (svc *Service) GetGovernanceOptionsForHeight(req client.GovernanceOptionsRe
 	if err != nil {
 		return err
 	}
+	evmChains, err := svc.governance.GetEVMChainRegistry()
+	if err != nil {
+		return err
+	}
+	bridgeOpt, err := svc.governance.GetBridgeOptions()
+	if err != nil {
+		return err
+	}
 	btcOpt, err := svc.governance.GetBTCChainDriverOption()
 	if err != nil {
 		return err
@@ -174,7 +182,9 @@ func (svc *Service) GetGovernanceOptionsForHeight(req client.GovernanceOptionsRe
 		GovOptions: governance.GovernanceState{
 			FeeOption:       *feeOpt,
 			ETHCDOption:     *ethOpt,
+			EVMChains:       *evmChains,
 			BTCCDOption:     *btcOpt,
+			BridgeOptions:   *bridgeOpt,
 			ONSOptions:      *onsOpt,
 			PropOptions:     *propOpt,
 			RewardOptions:   *rewardOpt,
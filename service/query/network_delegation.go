@@ -261,11 +261,17 @@ func (svc *Service) GetDelegRewards(req client.GetDelegRewardsRequest, resp *cli
 		}
 	}
 
+	autoCompound, err := svc.netwkDelegators.Rewards.GetAutoCompound(req.Delegator)
+	if err != nil {
+		return err
+	}
+
 	*resp = client.GetDelegRewardsReply{
 		Balance: *balance,
 		Pending: pending.Rewards,
 		//Matured: *matured,
-		Height: height,
+		AutoCompound: autoCompound,
+		Height:       height,
 	}
 	return nil
 }
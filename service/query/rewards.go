@@ -1,9 +1,15 @@
 package query
 
 import (
+	"time"
+
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
+	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
+	"github.com/Oneledger/protocol/data/rewards"
+	codes "github.com/Oneledger/protocol/status_codes"
 )
 
 func (svc *Service) ListRewardsForValidator(req client.RewardsRequest, resp *client.ListRewardsReply) error {
@@ -99,3 +105,79 @@ func (svc *Service) GetTotalRewards(_ client.ListRewardsRequest, reply *client.R
 
 	return nil
 }
+
+func (svc *Service) getRewardSchedule() (*rewards.Options, *rewards.Schedule, error) {
+	options, err := svc.governance.GetRewardOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+	schedule, err := svc.rewardMaster.RewardCm.GetSchedule(options, time.Now().UTC())
+	if err != nil {
+		return nil, nil, err
+	}
+	return options, schedule, nil
+}
+
+// GetRewardSchedule returns the block reward issuance schedule currently in effect
+func (svc *Service) GetRewardSchedule(_ client.ListRewardsRequest, reply *client.RewardScheduleReply) error {
+	options, schedule, err := svc.getRewardSchedule()
+	if err != nil {
+		svc.logger.Error("error getting reward schedule", err)
+		return governance.ErrGetRewardOptions
+	}
+
+	*reply = client.RewardScheduleReply{
+		Schedule:                 *schedule,
+		BurnoutRate:              options.BurnoutRate,
+		BlockSpeedCalculateCycle: options.BlockSpeedCalculateCycle,
+		Height:                   svc.rewardMaster.Reward.GetState().Version(),
+	}
+	return nil
+}
+
+// GetProjectedAPR estimates the yearly return of validators and network delegators from the current issuance
+func (svc *Service) GetProjectedAPR(_ client.ListRewardsRequest, reply *client.ProjectedAPRReply) error {
+	options, schedule, err := svc.getRewardSchedule()
+	if err != nil {
+		svc.logger.Error("error getting reward schedule", err)
+		return governance.ErrGetRewardOptions
+	}
+
+	validators, err := svc.validators.GetValidatorSet()
+	if err != nil {
+		svc.logger.Error("error listing validators")
+		return codes.ErrListValidators
+	}
+	vMap := svc.evidenceStore.GetValidatorMap()
+	validatorStake := balance.NewAmount(0)
+	for _, v := range validators {
+		if vMap[v.Address.String()] {
+			validatorStake = validatorStake.Plus(v.Staking)
+		}
+	}
+
+	curr, ok := svc.currencies.GetCurrencyByName(options.RewardCurrency)
+	if !ok {
+		return codes.ErrGettingBalance
+	}
+	poolAddr, err := svc.governance.GetPoolByName(governance.POOL_DELEGATION)
+	if err != nil {
+		return codes.ErrGettingBalance
+	}
+	pool, err := svc.balances.GetBalanceForCurr(poolAddr, &curr)
+	if err != nil {
+		return codes.ErrGettingBalance
+	}
+
+	validatorAPR, delegatorAPR := rewards.ProjectAPR(&schedule.AnnualIssuance, validatorStake, pool.Amount, netwkDeleg.COMMISSION_PERCENTAGE)
+
+	*reply = client.ProjectedAPRReply{
+		ValidatorAPR:    validatorAPR.Text('f', 4),
+		DelegatorAPR:    delegatorAPR.Text('f', 4),
+		AnnualIssuance:  schedule.AnnualIssuance,
+		ValidatorStake:  *validatorStake,
+		DelegationStake: *pool.Amount,
+		Height:          svc.rewardMaster.Reward.GetState().Version(),
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	nwd "github.com/Oneledger/protocol/action/network_delegation"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/utils"
+)
+
+// most heights a single statement can cover, each height reads a block and its results
+const maxStatementHeights = 10000
+
+// GetRewardStatement itemizes the reward income and outgoings of an address over a height range.
+// Reward amounts come from the reward stores' versioned state, the tx driven entries and fees
+// from the blocks of the range.
+func (svc *Service) GetRewardStatement(req client.RewardStatementRequest, reply *client.RewardStatementReply) error {
+	if err := req.Address.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
+
+	height := svc.rewardMaster.Reward.GetState().Version()
+	if req.To == 0 {
+		req.To = height
+	}
+	if req.From < 1 || req.From > req.To {
+		return errors.Errorf("invalid height range %d to %d", req.From, req.To)
+	}
+	if req.To > height {
+		return errors.Errorf("height %d is beyond the latest height %d", req.To, height)
+	}
+	if req.To-req.From+1 > maxStatementHeights {
+		return errors.Errorf("height range should not cover more than %d heights", maxStatementHeights)
+	}
+	// amounts are the difference of state between a height and the one before
+	for h := req.From - 1; h <= req.To; h++ {
+		if h > 0 && !svc.rewardMaster.Reward.GetState().VersionExists(h) {
+			return errors.Errorf("state at height %d is pruned", h)
+		}
+	}
+
+	entries := make([]client.StatementEntry, 0)
+	totals := make(map[string]balance.Amount)
+	for h := req.From; h <= req.To; h++ {
+		heightEntries, err := svc.statementAtHeight(req.Address, h)
+		if err != nil {
+			return errors.Wrapf(err, "statement at height %d", h)
+		}
+		for _, entry := range heightEntries {
+			total := totals[entry.Type]
+			totals[entry.Type] = *total.Plus(entry.Amount)
+		}
+		entries = append(entries, heightEntries...)
+	}
+
+	*reply = client.RewardStatementReply{
+		Address: req.Address,
+		From:    req.From,
+		To:      req.To,
+		Entries: entries,
+		Totals:  totals,
+		Height:  height,
+	}
+	return nil
+}
+
+func (svc *Service) statementAtHeight(addr keys.Address, h int64) ([]client.StatementEntry, error) {
+	block := svc.ext.Block(h)
+	if block == nil || block.Block == nil {
+		return nil, errors.New("failed to get block")
+	}
+	results, err := svc.ext.BlockResults(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block results")
+	}
+
+	entries := make([]client.StatementEntry, 0)
+	newEntry := func(entryType string, amount balance.Amount, txHash string) {
+		entries = append(entries, client.StatementEntry{
+			Height: h,
+			Time:   block.Block.Time,
+			Type:   entryType,
+			Amount: amount,
+			TxHash: txHash,
+		})
+	}
+
+	// validator rewards, the rewards of a block are added to its interval in BeginBlock
+	earnedBefore, err := svc.rewardMaster.Reward.GetVersionedWithHeight(h-1, addr, h)
+	if err != nil {
+		return nil, err
+	}
+	earnedAfter, err := svc.rewardMaster.Reward.GetVersionedWithHeight(h, addr, h)
+	if err != nil {
+		return nil, err
+	}
+	if earned, err := earnedAfter.Minus(*earnedBefore); err == nil && !earned.Equals(*balance.NewAmount(0)) {
+		newEntry(client.StatementBlockReward, *earned, "")
+	}
+
+	withdrawnBefore, err := svc.rewardMaster.RewardCm.GetVersionedWithdrawnRewards(h-1, addr)
+	if err != nil {
+		return nil, err
+	}
+	withdrawnAfter, err := svc.rewardMaster.RewardCm.GetVersionedWithdrawnRewards(h, addr)
+	if err != nil {
+		return nil, err
+	}
+	if withdrawn, err := withdrawnAfter.Minus(*withdrawnBefore); err == nil && !withdrawn.Equals(*balance.NewAmount(0)) {
+		newEntry(client.StatementRewardsWithdrawal, *withdrawn, "")
+	}
+
+	// auto compounded delegation rewards
+	deducted := balance.NewAmount(0)
+	compoundKey := "deleg_rewards_compound_" + addr.String()
+	for _, event := range results.BeginBlockEvents {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != compoundKey {
+				continue
+			}
+			amt, err := balance.NewAmountFromString(string(attr.Value), 10)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid auto compound event")
+			}
+			deducted = deducted.Plus(*amt)
+			newEntry(client.StatementRewardsCompound, *amt, "")
+		}
+	}
+
+	// tx driven entries, only the txs that went through are kept in state
+	for i, rawTx := range block.Block.Txs {
+		if i >= len(results.TxsResults) || results.TxsResults[i].Code != abciTypes.CodeTypeOK {
+			continue
+		}
+		txHash := strings.ToUpper(fmt.Sprintf("%x", utils.GetTransactionHash(rawTx)))
+		tx := &action.SignedTx{}
+		err := serialize.GetSerializer(serialize.NETWORK).Deserialize(rawTx, tx)
+		if err != nil || len(tx.Signatures) == 0 {
+			continue
+		}
+
+		switch tx.Type {
+		case action.REWARDS_WITHDRAW_NETWORK_DELEGATE:
+			withdraw := nwd.Withdraw{}
+			if err := withdraw.Unmarshal(tx.Data); err == nil && withdraw.Delegator.Equal(addr) {
+				deducted = deducted.Plus(withdraw.Amount.Value)
+				newEntry(client.StatementRewardsWithdrawal, withdraw.Amount.Value, txHash)
+			}
+		case action.REWARDS_REINVEST_NETWORK_DELEGATE:
+			invest := nwd.Reinvest{}
+			if err := invest.Unmarshal(tx.Data); err == nil && invest.Delegator.Equal(addr) {
+				deducted = deducted.Plus(invest.Amount.Value)
+				newEntry(client.StatementRewardsReinvest, invest.Amount.Value, txHash)
+			}
+		}
+
+		// fee is charged to the first signer
+		handler, err := tx.Signatures[0].Signer.GetHandler()
+		if err != nil || !handler.Address().Equal(addr) {
+			continue
+		}
+		fee := tx.Fee.Price.Value.BigInt()
+		fee.Mul(fee, balance.NewAmount(results.TxsResults[i].GasUsed).BigInt())
+		newEntry(client.StatementFee, *balance.NewAmountFromBigInt(fee), txHash)
+	}
+
+	// delegation rewards credited in BeginBlock, what left the rewards balance in the block is added back
+	balanceBefore, err := svc.netwkDelegators.Rewards.GetVersionedRewardsBalance(h-1, addr)
+	if err != nil {
+		return nil, err
+	}
+	balanceAfter, err := svc.netwkDelegators.Rewards.GetVersionedRewardsBalance(h, addr)
+	if err != nil {
+		return nil, err
+	}
+	if earned, err := balanceAfter.Plus(*deducted).Minus(*balanceBefore); err == nil && !earned.Equals(*balance.NewAmount(0)) {
+		newEntry(client.StatementDelegationReward, *earned, "")
+	}
+
+	return entries, nil
+}
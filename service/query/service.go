@@ -11,6 +11,7 @@ import (
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bridge"
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/evidence"
 	"github.com/Oneledger/protocol/data/fees"
@@ -42,6 +43,7 @@ type Service struct {
 	txTypes         *[]action.TxTypeDescribe
 	contracts       *evm.ContractStore
 	accountKeeper   balance.AccountKeeper
+	bridge          *bridge.Store
 }
 
 func Name() string {
@@ -50,7 +52,7 @@ func Name() string {
 
 func NewService(ctx client.ExtServiceContext, balances *balance.Store, currencies *balance.CurrencySet, validators *identity.ValidatorStore, witnesses *identity.WitnessStore,
 	domains *ons.DomainStore, delegators *delegation.DelegationStore, netwkDelegators *netwkDeleg.MasterStore, evidenceStore *evidence.EvidenceStore, govern *governance.Store, feePool *fees.Store, proposalMaster *governance.ProposalMasterStore, rewardMaster *rewards.RewardMasterStore, logger *log.Logger, txTypes *[]action.TxTypeDescribe,
-	contracts *evm.ContractStore, accountKeeper balance.AccountKeeper,
+	contracts *evm.ContractStore, accountKeeper balance.AccountKeeper, bridgeStore *bridge.Store,
 ) *Service {
 	service := &Service{
 		name:            "query",
@@ -72,6 +74,7 @@ func NewService(ctx client.ExtServiceContext, balances *balance.Store, currencie
 		governance:      govern,
 		contracts:       contracts,
 		accountKeeper:   accountKeeper,
+		bridge:          bridgeStore,
 	}
 	return service
 }
@@ -140,6 +143,25 @@ func (svc *Service) ListValidators(_ client.ListValidatorsRequest, reply *client
 	return nil
 }
 
+// ValidatorProfileHistory returns the profile changes of a validator
+func (svc *Service) ValidatorProfileHistory(req client.ValidatorProfileHistoryRequest, reply *client.ValidatorProfileHistoryReply) error {
+	if err := req.Address.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
+
+	history, err := svc.validators.GetProfileHistory(req.Address)
+	if err != nil {
+		svc.logger.Error("error getting validator profile history", err)
+		return codes.ErrListValidators
+	}
+
+	*reply = client.ValidatorProfileHistoryReply{
+		History: history,
+		Height:  svc.balances.State.Version(),
+	}
+	return nil
+}
+
 // ListWitnesses returns a list of all witness
 func (svc *Service) ListWitnesses(req client.ListWitnessesRequest, reply *client.ListWitnessesReply) error {
 	witnesses, err := svc.witnesses.GetWitnessAddresses(req.ChainType)
@@ -147,9 +169,21 @@ func (svc *Service) ListWitnesses(req client.ListWitnessesRequest, reply *client
 		svc.logger.Error("error listing witnesses")
 		return codes.ErrListWitnesses
 	}
+	epoch, err := svc.witnesses.GetEpoch(req.ChainType)
+	if err != nil {
+		svc.logger.Error("error getting witness epoch", err)
+		return codes.ErrListWitnesses
+	}
+	rotation, err := svc.witnesses.GetPendingRotation(req.ChainType)
+	if err != nil {
+		svc.logger.Error("error getting witness rotation", err)
+		return codes.ErrListWitnesses
+	}
 
 	*reply = client.ListWitnessesReply{
 		Witnesses: witnesses,
+		Epoch:     epoch,
+		Rotation:  rotation,
 		Height:    svc.balances.State.Version(),
 	}
 	return nil
@@ -285,8 +319,14 @@ func (svc *Service) DelegationStatus(req client.DelegationStatusRequest, resp *c
 }
 
 func (svc *Service) FeeOptions(_ struct{}, reply *client.FeeOptionsReply) error {
+	blockFee, err := svc.feePool.GetBlockFee()
+	if err != nil {
+		return governance.ErrGetFeeOptions
+	}
 	*reply = client.FeeOptionsReply{
 		FeeOption: *svc.feePool.GetOpt(),
+		BaseFee:   svc.feePool.GetOpt().BaseFee(),
+		LastBlock: blockFee,
 	}
 	return nil
 }
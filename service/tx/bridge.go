@@ -0,0 +1,62 @@
+package tx
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/eth"
+	"github.com/Oneledger/protocol/client"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// RetryTracker creates the retry of a stuck evm tracker signed by this node, its validator has to be
+// a witness of the chain of the tracker
+func (s *Service) RetryTracker(args client.RetryTrackerRequest, reply *client.RetryTrackerReply) error {
+	hPub, err := s.nodeContext.ValidatorPubKey().GetHandler()
+	if err != nil {
+		s.logger.Error("error get public key handler", err)
+		return codes.ErrLoadingNodeKey
+	}
+	address := hPub.Address()
+
+	hPri, err := s.nodeContext.PrivVal().GetHandler()
+	if err != nil {
+		s.logger.Error("error get private key handler", err)
+		return codes.ErrLoadingNodeKey
+	}
+
+	if len(common.FromHex(args.TrackerName)) != common.HashLength {
+		return errors.Errorf("invalid tracker name %s", args.TrackerName)
+	}
+	retry := eth.Retry{
+		Witness:     address,
+		TrackerName: common.HexToHash(args.TrackerName),
+		ChainID:     args.ChainID,
+		Reason:      args.Reason,
+	}
+
+	data, err := retry.Marshal()
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := action.RawTx{
+		Type: action.ETH_RETRY,
+		Data: data,
+		Fee:  action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo: uuidNew.String(),
+	}
+
+	// witness signs Tx
+	rawData := tx.RawBytes()
+	signed, err := hPri.Sign(rawData)
+	if err != nil {
+		return errors.Wrap(err, "error signing retry")
+	}
+
+	*reply = client.RetryTrackerReply{RawTx: rawData, Signature: action.Signature{Signed: signed, Signer: hPri.PubKey()}}
+	return nil
+}
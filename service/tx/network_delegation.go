@@ -141,6 +141,39 @@ func (s *Service) ReinvestDelegRewards(args client.ReinvestDelegRewardsRequest,
 	return nil
 }
 
+func (s *Service) AutoCompoundDelegRewards(args client.AutoCompoundDelegRewardsRequest, reply *client.CreateTxReply) error {
+	compound := nwd.AutoCompound{
+		Delegator: args.Delegator,
+		Enable:    args.Enable,
+		MinAmount: args.MinAmount,
+	}
+
+	data, err := compound.Marshal()
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	feeAmount := s.feeOpt.MinFee()
+	tx := &action.RawTx{
+		Type: action.AUTO_COMPOUND_NETWORK_DELEGATE,
+		Data: data,
+		Fee: action.Fee{
+			Price: action.Amount{Currency: "OLT", Value: *feeAmount.Amount},
+			Gas:   20000,
+		},
+		Memo: uuidNew.String(),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
 // below is removed since finalize withdraw rewards logic is moved to block beginner, OLP-1266
 //func (s *Service) FinalizeDelegRewards(args client.FinalizeRewardsRequest, reply *client.CreateTxReply) error {
 //
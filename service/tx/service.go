@@ -540,3 +540,111 @@ func (svc *Service) Withdraw(args client.WithdrawRequest, reply *client.Withdraw
 
 	return nil
 }
+
+func (svc *Service) RotateKey(args client.RotateKeyRequest, reply *client.RotateKeyReply) error {
+	// default to the validator running this node
+	validatorAddress := args.ValidatorAddress
+	if len(validatorAddress) == 0 {
+		validatorAddress = svc.validators.ResolveConsensusAddress(svc.nodeContext.ValidatorAddress())
+	}
+
+	svc.logger.Infof("Validator - %s, delegator - %s, rotate key\n", validatorAddress, args.StakeAddress)
+
+	rotate := staking.RotateKey{
+		ValidatorAddress: validatorAddress,
+		StakeAddress:     args.StakeAddress,
+		NewPubKey:        args.NewPubKey,
+		NewECDSAPubKey:   args.NewECDSAPubKey,
+	}
+
+	data, err := rotate.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing RotateKey object", err)
+		return codes.ErrSerialization
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	feeAmount := svc.feeOpt.MinFee()
+
+	tx := &action.RawTx{
+		Type: action.ROTATE_KEY,
+		Data: data,
+		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo: uuidNew.String(),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing rotate key transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.RotateKeyReply{RawTx: packet}
+
+	return nil
+}
+
+func (svc *Service) EditValidator(args client.EditValidatorRequest, reply *client.EditValidatorReply) error {
+	// default to the validator running this node
+	validatorAddress := args.ValidatorAddress
+	if len(validatorAddress) == 0 {
+		validatorAddress = svc.validators.ResolveConsensusAddress(svc.nodeContext.ValidatorAddress())
+	}
+
+	validator, err := svc.validators.Get(validatorAddress)
+	if err != nil {
+		svc.logger.Errorf("validator for address %s not found\n", validatorAddress)
+		return codes.ErrBadAddress
+	}
+
+	svc.logger.Infof("Validator - %s, delegator - %s, edit validator\n", validatorAddress, args.StakeAddress)
+
+	// only overwrite the fields given in the request
+	edit := staking.EditValidator{
+		ValidatorAddress: validatorAddress,
+		StakeAddress:     args.StakeAddress,
+		Name:             validator.Name,
+		Profile:          validator.Profile,
+	}
+	if args.Name != nil {
+		edit.Name = *args.Name
+	}
+	if args.Website != nil {
+		edit.Profile.Website = *args.Website
+	}
+	if args.Contact != nil {
+		edit.Profile.Contact = *args.Contact
+	}
+	if args.Details != nil {
+		edit.Profile.Details = *args.Details
+	}
+	if args.Logo != nil {
+		edit.Profile.Logo = *args.Logo
+	}
+
+	data, err := edit.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing EditValidator object", err)
+		return codes.ErrSerialization
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	feeAmount := svc.feeOpt.MinFee()
+
+	tx := &action.RawTx{
+		Type: action.EDIT_VALIDATOR,
+		Data: data,
+		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo: uuidNew.String(),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing edit validator transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.EditValidatorReply{RawTx: packet}
+
+	return nil
+}
@@ -39,6 +39,8 @@ const (
 	InternalErrorListWitnesses              = 100610
 	InternalErrorGettingProposal            = 100611
 	InternalErrorGettingBidConv             = 100612
+	InternalErrorGettingJob                 = 100613
+	InternalErrorUpdatingJob                = 100614
 
 	ONSError                        = 1007
 	ONSErrDomainMissing             = 100701
@@ -107,11 +109,29 @@ const (
 	ETHTrackerNotFoundOngoing = 600102
 	ETHTrackerExists          = 600103
 	ETHTrackerUnabletoSet     = 600104
+	ETHNFTNotFound            = 600105
+	ETHNFTExists              = 600106
+	ETHNFTNotOwner            = 600107
+	ETHNFTRedeeming           = 600108
+	ETHTrackerNotWitness      = 600109
+	ETHTrackerNotRetriable    = 600110
+
+	//Bridge
+	BridgeErrPaused           = 600601
+	BridgeErrLimitExceeded    = 600602
+	BridgeErrTxLimitExceeded  = 600603
+	BridgeErrInvalidOptions   = 600604
+	BridgeErrNotPauseVoter    = 600605
+	BridgeErrUnableToSetPause = 600606
+	BridgeErrNotRotationVoter = 600607
+	BridgeErrUnableToRotate   = 600608
 
 	// Staking
 	DelgErr                     = 6003
 	DelgErrStakeAddressInUse    = 600301
 	DelgErrStakeAddressMismatch = 600302
+	DelgErrInvalidKeyRotation   = 600303
+	DelgErrInvalidProfile       = 600304
 
 	NetDelgErr                              = 6005
 	NetDelgErrGettingActiveDelgAmount       = 600501
@@ -124,6 +144,7 @@ const (
 	NetDelgErrFinalizingDelgRewards         = 600508
 	NetDelgErrAddingWithdrawAmountToBalance = 600509
 	NetDelgErrReinvest                      = 600510
+	NetDelgErrAutoCompound                  = 600511
 
 	GovErr                                = 7001
 	GovErrGetProposalOptions              = 700101
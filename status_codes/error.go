@@ -72,6 +72,9 @@ var (
 	ErrTrackerBusy     = ProtocolError{InternalErrorTrackerBusy, "tracker busy"}
 	ErrTrackerBalance  = ProtocolError{InternalErrorTrackerInsufficientBalance, "insufficient balance in tracker"}
 
+	ErrGettingJob  = ProtocolError{InternalErrorGettingJob, "error getting job"}
+	ErrUpdatingJob = ProtocolError{InternalErrorUpdatingJob, "error updating job"}
+
 	// Query errors
 	ErrBadAddress      = ProtocolError{IncorrectAddress, "address incorrect"}
 	ErrGettingBalance  = ProtocolError{InternalErrorGettingBalance, "error  getting balance"}
@@ -68,6 +68,9 @@ type ChainStateRotationSetting struct {
 	// cycles = 1 : only keep one of latest every
 	// cycles = 0 : keep every "every"
 	cycles int64
+
+	// "archive" : keep every version
+	archive bool
 }
 
 // NewChainState generates a new ChainState object
@@ -97,6 +100,7 @@ func (state *ChainState) SetupRotation(chainStateRotationCfg config.ChainStateRo
 	state.ChainStateRotation.recent = chainStateRotationCfg.Recent
 	state.ChainStateRotation.every = chainStateRotationCfg.Every
 	state.ChainStateRotation.cycles = chainStateRotationCfg.Cycles
+	state.ChainStateRotation.archive = chainStateRotationCfg.Archive
 	return nil
 
 }
@@ -191,7 +195,7 @@ func (state *ChainState) Commit() ([]byte, int64) {
 
 	release := state.LastVersion - state.ChainStateRotation.recent
 
-	if release > 0 {
+	if release > 0 && !state.ChainStateRotation.archive {
 		if state.ChainStateRotation.every == 0 || release%state.ChainStateRotation.every != 0 {
 			err := state.Delivered.DeleteVersion(release)
 			if err != nil {
@@ -211,6 +215,22 @@ func (state *ChainState) Commit() ([]byte, int64) {
 	return hash, version
 }
 
+// IsArchive tells if every version is kept
+func (state *ChainState) IsArchive() bool {
+	return state.ChainStateRotation.archive
+}
+
+// checkVersion returns ErrStatePruned for a committed version that is not kept anymore
+func (state *ChainState) checkVersion(version int64) error {
+	if state.Delivered.VersionExists(version) {
+		return nil
+	}
+	if version > 0 && version < state.Delivered.Version() {
+		return ErrStatePruned
+	}
+	return iavl.ErrVersionDoesNotExist
+}
+
 func (state *ChainState) LoadVersion(version int64) (int64, error) {
 	return state.Delivered.LoadVersion(version)
 }
@@ -202,3 +202,41 @@ func TestChainState_Rotation(t *testing.T) {
 	assert.Equal(t, correct, counter, "These should be equal")
 
 }
+
+func TestChainState_Archive(t *testing.T) {
+	rotation := config.ChainStateRotationCfg{
+		Recent: int64(2),
+		Every:  int64(0),
+		Cycles: int64(0),
+	}
+
+	pruned := NewChainState("Pruned", db.NewDB("pruned", db.MemDBBackend, ""))
+	assert.NoError(t, pruned.SetupRotation(rotation))
+	rotation.Archive = true
+	archive := NewChainState("Archive", db.NewDB("archive", db.MemDBBackend, ""))
+	assert.NoError(t, archive.SetupRotation(rotation))
+	assert.True(t, archive.IsArchive())
+
+	for i := 1; i <= 10; i++ {
+		for _, state := range []*ChainState{pruned, archive} {
+			state.Delivered.Set(StoreKey("key"), []byte(strconv.Itoa(i)))
+			state.Commit()
+		}
+	}
+
+	// the archive keeps every version
+	for i := int64(1); i <= 10; i++ {
+		state, err := NewStateAt(archive, i)
+		assert.NoError(t, err)
+		value, _ := state.Get(StoreKey("key"))
+		assert.Equal(t, strconv.Itoa(int(i)), string(value))
+	}
+
+	// the old versions are pruned otherwise
+	_, err := NewStateAt(pruned, 1)
+	assert.Equal(t, ErrStatePruned, err)
+	_, err = NewState(pruned).GetAtHeight(1, StoreKey("key"))
+	assert.Equal(t, ErrStatePruned, err)
+	assert.NoError(t, NewState(pruned).CheckVersion(10))
+	assert.NotEqual(t, ErrStatePruned, NewState(pruned).CheckVersion(11))
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// GetWithProofAtHeight returns the value of a key at the version, with a proof of it, or of its
+// absence when there is no value, against the root hash of the version. The proof is an encoded
+// merkle.ProofOp of an iavl value or absence op, see VerifyProof
+func (s *State) GetWithProofAtHeight(version int64, key StoreKey) ([]byte, []byte, error) {
+	t, err := s.cs.Delivered.GetImmutable(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, proof, err := t.GetWithProof(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var op merkle.ProofOp
+	if value == nil {
+		op = iavl.NewAbsenceOp(key, proof).ProofOp()
+	} else {
+		op = iavl.NewValueOp(key, proof).ProofOp()
+	}
+	dat, err := op.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, dat, nil
+}
+
+// VerifyProof checks a proof given by GetWithProofAtHeight against the root hash of the state, the
+// app hash of the block after the version. An empty value checks the absence of the key
+func VerifyProof(root []byte, key StoreKey, value []byte, proof []byte) error {
+	op := merkle.ProofOp{}
+	err := op.Unmarshal(proof)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode proof")
+	}
+	if !bytes.Equal(op.Key, key) {
+		return errors.Errorf("proof is of key %X, not %X", op.Key, []byte(key))
+	}
+
+	var (
+		operator merkle.ProofOperator
+		args     [][]byte
+	)
+	if len(value) == 0 {
+		operator, err = iavl.AbsenceOpDecoder(op)
+	} else {
+		operator, err = iavl.ValueOpDecoder(op)
+		args = [][]byte{value}
+	}
+	if err != nil {
+		return err
+	}
+	hashes, err := operator.Run(args)
+	if err != nil {
+		return err
+	}
+	if len(hashes) != 1 || !bytes.Equal(hashes[0], root) {
+		return errors.Errorf("proof does not match root hash %X", root)
+	}
+	return nil
+}
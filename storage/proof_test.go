@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyProof(t *testing.T) {
+	state := NewState(NewChainState("test", getCacheDB()))
+	for _, k := range keys {
+		if len(k) > 0 {
+			state.Set(StoreKey(k), testcase[k])
+		}
+	}
+	state.Set(StoreKey("present"), []byte("value"))
+	root, version := state.Commit()
+
+	t.Run("value", func(t *testing.T) {
+		value, proof, err := state.GetWithProofAtHeight(version, StoreKey("present"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), value)
+		assert.NoError(t, VerifyProof(root, StoreKey("present"), value, proof))
+
+		assert.Error(t, VerifyProof(root, StoreKey("present"), []byte("other"), proof))
+		assert.Error(t, VerifyProof(root, StoreKey("other"), value, proof))
+		assert.Error(t, VerifyProof([]byte("root"), StoreKey("present"), value, proof))
+	})
+
+	t.Run("absence", func(t *testing.T) {
+		value, proof, err := state.GetWithProofAtHeight(version, StoreKey("absent"))
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+		assert.NoError(t, VerifyProof(root, StoreKey("absent"), nil, proof))
+
+		assert.Error(t, VerifyProof(root, StoreKey("absent"), []byte("value"), proof))
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, _, err := state.GetWithProofAtHeight(version+1, StoreKey("present"))
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,8 @@ package storage
 import (
 	"bytes"
 	"sync"
+
+	"github.com/tendermint/iavl"
 )
 
 var _ Store = &State{}
@@ -14,6 +16,9 @@ type State struct {
 	gc        GasCalculator
 	txSession Session
 	mux       sync.RWMutex
+
+	// snapshot of a committed version the state reads from instead of the chain state, it is read-only
+	snapshot *iavl.ImmutableTree
 }
 
 func NewState(state *ChainState) *State {
@@ -24,12 +29,29 @@ func NewState(state *ChainState) *State {
 	}
 }
 
+// NewStateAt returns a state on the committed version of the chain state, its changes stay in the
+// cache and are never written to the chain state
+func NewStateAt(state *ChainState, version int64) (*State, error) {
+	err := state.checkVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := state.Delivered.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	s := NewState(state)
+	s.snapshot = snapshot
+	return s, nil
+}
+
 func (s *State) WithGas(gc GasCalculator) *State {
 	gs := NewGasStore(s.cache, gc)
 	return &State{
-		cs:    s.cs,
-		cache: gs,
-		gc:    gc,
+		cs:       s.cs,
+		cache:    gs,
+		gc:       gc,
+		snapshot: s.snapshot,
 	}
 }
 
@@ -71,13 +93,24 @@ func (s *State) DiscardTxSession() {
 }
 
 func (s State) Version() int64 {
+	if s.snapshot != nil {
+		return s.snapshot.Version()
+	}
 	return s.cs.Version
 }
 
 func (s State) RootHash() []byte {
+	if s.snapshot != nil {
+		return s.snapshot.Hash()
+	}
 	return s.cs.Hash
 }
 
+// ReadOnly tells if the state is on a committed version, see NewStateAt
+func (s *State) ReadOnly() bool {
+	return s.snapshot != nil
+}
+
 func (s *State) DumpState() {
 	s.cache.DumpState()
 }
@@ -88,7 +121,7 @@ func (s *State) Get(key StoreKey) ([]byte, error) {
 		result, err := s.txSession.Get(key)
 		if err == nil {
 			// if got result, return directly
-			return result, err
+			return notDeleted(result), err
 		}
 	}
 
@@ -96,13 +129,25 @@ func (s *State) Get(key StoreKey) ([]byte, error) {
 	result, err := s.cache.Get(key)
 	if err == nil {
 		// if got result, return directly
-		return result, err
+		return notDeleted(result), err
 	}
 
+	if s.snapshot != nil {
+		_, value := s.snapshot.Get(key)
+		return value, nil
+	}
 	// if didn't get result in cache, get from ChainState
 	return s.cs.Get(key)
 }
 
+// notDeleted hides the tombstone of a key deleted in the cache, it is read as a missing key
+func notDeleted(value []byte) []byte {
+	if bytes.Equal(value, []byte(TOMBSTONE)) {
+		return nil
+	}
+	return value
+}
+
 func (s *State) Set(key StoreKey, value []byte) error {
 	if s.txSession != nil {
 		return s.txSession.Set(key, value)
@@ -124,6 +169,9 @@ func (s *State) Exists(key StoreKey) bool {
 
 	// check existence in cache, because it's cheaper
 	exist := s.cache.Exists(key)
+	if !exist && s.snapshot != nil {
+		return s.snapshot.Has(key)
+	}
 	if !exist {
 		// if not existed in cache, check ChainState
 		return s.cs.Exists(key)
@@ -150,7 +198,11 @@ func (s *State) GetIterable() Iterable {
 
 func (s *State) Iterate(fn func(key []byte, value []byte) bool) (stopped bool) {
 	keys := make([]StoreKey, 0, 100)
-	s.cs.Iterate(func(key, value []byte) bool {
+	iterate := s.cs.Iterate
+	if s.snapshot != nil {
+		iterate = s.snapshot.Iterate
+	}
+	iterate(func(key, value []byte) bool {
 		keys = append(keys, key)
 		return false
 	})
@@ -170,7 +222,11 @@ func (s *State) Iterate(fn func(key []byte, value []byte) bool) (stopped bool) {
 
 func (s *State) IterateRange(start, end []byte, ascending bool, fn func(key, value []byte) bool) (stop bool) {
 	keys := make([]StoreKey, 0, 100)
-	s.cs.IterateRange(start, end, ascending, func(key, value []byte) bool {
+	iterateRange := s.cs.IterateRange
+	if s.snapshot != nil {
+		iterateRange = s.snapshot.IterateRange
+	}
+	iterateRange(start, end, ascending, func(key, value []byte) bool {
 		keys = append(keys, key)
 		return false
 	})
@@ -189,6 +245,9 @@ func (s *State) IterateRange(start, end []byte, ascending bool, fn func(key, val
 }
 
 func (s State) Write() bool {
+	if s.snapshot != nil {
+		return false
+	}
 	s.mux.Lock()
 	defer s.mux.Unlock()
 	s.cache.GetIterable().Iterate(func(key []byte, value []byte) bool {
@@ -204,6 +263,9 @@ func (s State) Write() bool {
 }
 
 func (s *State) Commit() (hash []byte, version int64) {
+	if s.snapshot != nil {
+		return s.snapshot.Hash(), s.snapshot.Version()
+	}
 
 	s.Write()
 	s.cache = NewSessionedDirectStorage(SESSION_CACHE, "state")
@@ -233,6 +295,10 @@ func (s *State) ConsumeContractGas(gas Gas) bool {
 }
 
 func (s *State) GetAtHeight(version int64, key StoreKey) ([]byte, error) {
+	err := s.cs.checkVersion(version)
+	if err != nil {
+		return []byte{}, err
+	}
 	t, err := s.cs.Delivered.GetImmutable(version)
 	if err != nil {
 		return []byte{}, err
@@ -246,6 +312,17 @@ func (s *State) GetVersioned(version int64, key StoreKey) []byte {
 	return value
 }
 
+// VersionExists tells if the state at the version is still kept, older versions may be pruned
+func (s *State) VersionExists(version int64) bool {
+	return s.cs.Delivered.VersionExists(version)
+}
+
+// CheckVersion returns ErrStatePruned if the state at the version is not kept anymore, every
+// version is kept in archive mode
+func (s *State) CheckVersion(version int64) error {
+	return s.cs.checkVersion(version)
+}
+
 func (s *State) GetPrevious(num int64, key StoreKey) []byte {
 
 	ver := s.cs.Version
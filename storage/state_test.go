@@ -7,6 +7,8 @@ import (
 
 	"github.com/magiconair/properties/assert"
 	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
 )
 
 var testcase = make(map[string][]byte)
@@ -115,6 +117,37 @@ func TestState_CommitTxSession(t *testing.T) {
 	assert.Equal(t, hash2, hash, "hash should match")
 	assert.Equal(t, version2, version, "version should match")
 }
+
+func TestNewStateAt(t *testing.T) {
+	cs := NewChainState("test", getCacheDB())
+	_ = cs.SetupRotation(config.ChainStateRotationCfg{Recent: 2})
+	state := NewState(cs)
+
+	state.Set([]byte("key"), []byte("first"))
+	_, version := state.Commit()
+	state.Set([]byte("key"), []byte("second"))
+	state.Commit()
+
+	past, err := NewStateAt(cs, version)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, past.ReadOnly(), true)
+	assert.Equal(t, past.Version(), version)
+
+	value, _ := past.Get([]byte("key"))
+	assert.Equal(t, value, []byte("first"))
+
+	// changes are kept in the cache only
+	past.Set([]byte("key"), []byte("third"))
+	value, _ = past.Get([]byte("key"))
+	assert.Equal(t, value, []byte("third"))
+	past.Commit()
+	value, _ = cs.Get([]byte("key"))
+	assert.Equal(t, value, []byte("second"))
+
+	_, err = NewStateAt(cs, version+10)
+	assert.Equal(t, err != nil, true)
+}
+
 func getCacheDB() db.DB {
 	return db.NewDB("test", db.MemDBBackend, "")
 }
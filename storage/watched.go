@@ -0,0 +1,61 @@
+package storage
+
+var _ SessionedDirectStorage = &watchedStore{}
+
+// watchedStore writes through to a state, calling back with the current value of a key before
+// it is changed
+type watchedStore struct {
+	state *State
+	watch func(key StoreKey, prev []byte)
+}
+
+// NewWatchedState returns a state reading and writing through to the state, watch is called with
+// the current value of every key before it is set or deleted so the change can be undone
+func NewWatchedState(state *State, watch func(key StoreKey, prev []byte)) *State {
+	return &State{
+		cs:       state.cs,
+		cache:    &watchedStore{state: state, watch: watch},
+		gc:       state.gc,
+		snapshot: state.snapshot,
+	}
+}
+
+func (w *watchedStore) Get(key StoreKey) ([]byte, error) {
+	return w.state.Get(key)
+}
+
+func (w *watchedStore) Set(key StoreKey, value []byte) error {
+	prev, err := w.state.Get(key)
+	if err != nil {
+		return err
+	}
+	w.watch(key, prev)
+	return w.state.Set(key, value)
+}
+
+func (w *watchedStore) Exists(key StoreKey) bool {
+	return w.state.Exists(key)
+}
+
+func (w *watchedStore) Delete(key StoreKey) (bool, error) {
+	prev, err := w.state.Get(key)
+	if err != nil {
+		return false, err
+	}
+	w.watch(key, prev)
+	return w.state.Delete(key)
+}
+
+func (w *watchedStore) GetIterable() Iterable {
+	return w.state
+}
+
+func (w *watchedStore) BeginSession() Session {
+	panic("no tx session in watched state")
+}
+
+func (w *watchedStore) Close() {}
+
+func (w *watchedStore) DumpState() {
+	w.state.DumpState()
+}
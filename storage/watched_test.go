@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWatchedState(t *testing.T) {
+	state := NewState(NewChainState("test", getCacheDB()))
+	_ = state.Set(StoreKey("a"), []byte("1"))
+
+	prevs := make(map[string][]byte)
+	watched := NewWatchedState(state, func(key StoreKey, prev []byte) {
+		prevs[string(key)] = prev
+	})
+
+	assert.NoError(t, watched.Set(StoreKey("a"), []byte("2")))
+	assert.NoError(t, watched.Set(StoreKey("b"), []byte("3")))
+	_, err := watched.Delete(StoreKey("a"))
+	assert.NoError(t, err)
+
+	// the writes go through to the state
+	value, _ := state.Get(StoreKey("b"))
+	assert.Equal(t, []byte("3"), value)
+	assert.True(t, watched.Exists(StoreKey("b")))
+
+	// the values before the writes are watched
+	assert.Equal(t, []byte("2"), prevs["a"])
+	assert.Nil(t, prevs["b"])
+}
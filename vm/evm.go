@@ -110,6 +110,8 @@ type EVMTransaction struct {
 
 	// debug olvm
 	debug bool
+	// tracer of the execution, used by the debug api
+	tracer ethvm.Tracer
 }
 
 func NewEVMTransaction(stateDB *CommitStateDB, gaspool *ethcore.GasPool, header *abci.Header, from keys.Address, to *keys.Address, nonce uint64, value *big.Int, data []byte, accessList *ethtypes.AccessList, gas uint64, gasPrice *big.Int, isSimulation bool) *EVMTransaction {
@@ -133,10 +135,15 @@ func (etx *EVMTransaction) SetVMDebug(debug bool) {
 	etx.debug = debug
 }
 
+// SetTracer sets a tracer to capture the execution steps of the transaction
+func (etx *EVMTransaction) SetTracer(tracer ethvm.Tracer) {
+	etx.tracer = tracer
+}
+
 func (etx *EVMTransaction) NewEVM() *ethvm.EVM {
 	blockCtx := ethvm.BlockContext{
 		CanTransfer: ethcore.CanTransfer,
-		Transfer:    ethcore.Transfer,
+		Transfer:    nativeTransfer,
 		GetHash:     GetHashFn(etx.stateDB, etx.header),
 		Coinbase:    ethcmn.BytesToAddress(etx.header.ProposerAddress),
 		GasLimit:    etx.gaspool.Gas(),
@@ -149,7 +156,10 @@ func (etx *EVMTransaction) NewEVM() *ethvm.EVM {
 	vmConfig := ethvm.Config{
 		ExtraEips: make([]int, 0),
 	}
-	if etx.debug {
+	if etx.tracer != nil {
+		vmConfig.Debug = true
+		vmConfig.Tracer = etx.tracer
+	} else if etx.debug {
 		vmConfig.Debug = true
 		vmConfig.Tracer = ethvm.NewMarkdownLogger(&ethvm.LogConfig{
 			Debug:     true,
@@ -209,7 +219,10 @@ func (etx *EVMTransaction) GasPrice() *big.Int {
 }
 
 func (etx *EVMTransaction) Apply() (*ExecutionResult, error) {
-	executionResult, err := ApplyMessage(etx.NewEVM(), etx, etx.gaspool)
+	evm := etx.NewEVM()
+	defer beginNative(evm, etx.stateDB, etx.header)()
+
+	executionResult, err := ApplyMessage(evm, etx, etx.gaspool)
 
 	if !etx.IsFake() {
 		// Ensure any modifications are committed to the state
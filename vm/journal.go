@@ -3,6 +3,7 @@ package vm
 import (
 	"math/big"
 
+	"github.com/Oneledger/protocol/storage"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 )
 
@@ -204,6 +205,13 @@ type (
 		address *ethcmn.Address
 		slot    *ethcmn.Hash
 	}
+
+	// Changes to the native state by native contracts.
+	nativeChange struct {
+		state *storage.State
+		key   storage.StoreKey
+		prev  []byte
+	}
 )
 
 func (ch createObjectChange) revert(s *CommitStateDB) {
@@ -376,3 +384,15 @@ func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
 func (ch accessListAddSlotChange) dirtied() *ethcmn.Address {
 	return nil
 }
+
+func (ch nativeChange) revert(s *CommitStateDB) {
+	if ch.prev == nil {
+		_, _ = ch.state.Delete(ch.key)
+		return
+	}
+	_ = ch.state.Set(ch.key, ch.prev)
+}
+
+func (ch nativeChange) dirtied() *ethcmn.Address {
+	return nil
+}
@@ -0,0 +1,263 @@
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/Oneledger/protocol/storage"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+var ErrNoNativeEnv = errors.New("native contract called outside of a transaction")
+
+// NativeEnv is what a native contract runs against. Writes to its State are journaled in the
+// StateDB so they are rolled back with the call when it reverts
+type NativeEnv struct {
+	StateDB  *CommitStateDB
+	State    *storage.State
+	Header   *abci.Header
+	Contract ethcmn.Address
+	Caller   ethcmn.Address
+	Value    *big.Int
+	// ReadOnly is set when the call is static, made by a STATICCALL or from the frame of one, or
+	// when it is not a CALL (DELEGATECALL or CALLCODE) and the caller is unknown. The state must
+	// not be changed
+	ReadOnly bool
+}
+
+// NativeContract is a precompiled contract at a reserved address with access to the native stores
+// of the chain
+type NativeContract interface {
+	RequiredGas(input []byte) uint64
+	Run(env *NativeEnv, input []byte) ([]byte, error)
+}
+
+// nativeCall is the caller and value of a CALL to a native contract
+type nativeCall struct {
+	caller ethcmn.Address
+	value  *big.Int
+}
+
+// nativeExecution is the evm of the transaction a goroutine applies
+type nativeExecution struct {
+	evm     *ethvm.EVM
+	stateDB *CommitStateDB
+	header  *abci.Header
+	// call is taken from the state db when the gas of a contract is checked, right before it runs
+	call *nativeCall
+}
+
+var (
+	// nativeContracts are registered while the node starts, they are only read once transactions
+	// are applied
+	nativeContracts = make(map[ethcmn.Address]NativeContract)
+
+	// nativeExecutions are the executions by the id of the goroutine applying them. Precompiled
+	// contracts of go-ethereum are global and get no context, the goroutine is what links the run
+	// of a contract to the evm calling it
+	nativeExecutions sync.Map
+
+	// nativeCode gives the code of the native contracts that are not precompiled contracts
+	nativeCode func(address ethcmn.Address) []byte
+
+	// nativeBlock is the height the native contracts activate at, 0 keeps them disabled
+	nativeBlock int64
+)
+
+func init() {
+	// the static flag of a call is read from the interpreter, see isStatic. TestNative_GoEthereumVersion
+	// pins the go-ethereum it was checked against
+	field, ok := reflect.TypeOf(ethvm.EVMInterpreter{}).FieldByName("readOnly")
+	if !ok || field.Type.Kind() != reflect.Bool {
+		panic("go-ethereum interpreter has no read only flag")
+	}
+}
+
+// RegisterNativeContract adds the native contract to the precompiled contracts at the address, it
+// is done once while the node starts, before any transaction is applied
+func RegisterNativeContract(address ethcmn.Address, contract NativeContract) {
+	if _, ok := ethvm.PrecompiledContractsBerlin[address]; !ok {
+		ethvm.PrecompiledAddressesBerlin = append(ethvm.PrecompiledAddressesBerlin, address)
+	}
+	ethvm.PrecompiledContractsBerlin[address] = &nativePrecompile{address: address, contract: contract}
+	nativeContracts[address] = contract
+}
+
+// SetNativeBlock sets the fork height of the native contracts from the genesis while the node
+// starts. Below it their addresses are empty accounts, as they were before the native contracts
+func SetNativeBlock(height int64) {
+	nativeBlock = height
+}
+
+// IsNativeActive tells if the native contracts run at the height
+func IsNativeActive(height int64) bool {
+	return nativeBlock != 0 && nativeBlock <= height
+}
+
+// activePrecompiles returns the precompiled contracts warmed in the access list of a transaction at
+// the height, the native contracts are only in it once they are active
+func activePrecompiles(rules ethparams.Rules, height int64) []ethcmn.Address {
+	precompiles := ethvm.ActivePrecompiles(rules)
+	if IsNativeActive(height) {
+		return precompiles
+	}
+	active := make([]ethcmn.Address, 0, len(precompiles))
+	for _, address := range precompiles {
+		if !IsNativeContract(address) {
+			active = append(active, address)
+		}
+	}
+	return active
+}
+
+// SetNativeCode sets what gives the code of the native contracts that are not precompiled
+// contracts, like the ones of currencies added as the chain runs. It is set while the node starts
+func SetNativeCode(code func(address ethcmn.Address) []byte) {
+	nativeCode = code
+}
+
+// NativeCode returns the code of the native contract at the address, nil if there is none
+func NativeCode(address ethcmn.Address) []byte {
+	if nativeCode == nil {
+		return nil
+	}
+	return nativeCode(address)
+}
+
+// nativeCode returns the code of the native contract at the address when the native contracts are
+// active at the height the state db runs at, the one of the transaction applied on the goroutine or
+// the last block outside of a transaction
+func (s *CommitStateDB) nativeCode(address ethcmn.Address) []byte {
+	if nativeCode == nil {
+		return nil
+	}
+	var height int64
+	if exec := currentNative(); exec != nil {
+		height = exec.header.Height
+	} else if s.blockStore != nil {
+		height = s.blockStore.Height()
+	}
+	if !IsNativeActive(height) {
+		return nil
+	}
+	return nativeCode(address)
+}
+
+// IsNativeContract tells if a native contract is registered at the address
+func IsNativeContract(address ethcmn.Address) bool {
+	_, ok := nativeContracts[address]
+	return ok
+}
+
+// beginNative sets the evm as the one native contracts run for on the goroutine, the returned func
+// ends it
+func beginNative(evm *ethvm.EVM, stateDB *CommitStateDB, header *abci.Header) func() {
+	if len(nativeContracts) == 0 {
+		return func() {}
+	}
+	id := goroutineID()
+	nativeExecutions.Store(id, &nativeExecution{evm: evm, stateDB: stateDB, header: header})
+	return func() {
+		nativeExecutions.Delete(id)
+	}
+}
+
+// currentNative returns the execution of the goroutine, nil outside of a transaction
+func currentNative() *nativeExecution {
+	exec, ok := nativeExecutions.Load(goroutineID())
+	if !ok {
+		return nil
+	}
+	return exec.(*nativeExecution)
+}
+
+// goroutineID returns the id of the running goroutine, the runtime only gives it in the header of
+// the stack trace
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = bytes.TrimPrefix(buf[:runtime.Stack(buf, false)], []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
+// isStatic tells if the evm runs a static call. go-ethereum gives no context to precompiled
+// contracts so the flag is read from the interpreter, it is set from a STATICCALL until it returns
+func isStatic(evm *ethvm.EVM) bool {
+	return reflect.ValueOf(evm.Interpreter()).Elem().FieldByName("readOnly").Bool()
+}
+
+// nativeTransfer records the caller and value of a CALL to a native contract in the state db, the
+// transfer is only done for a CALL
+func nativeTransfer(db ethvm.StateDB, sender, recipient ethcmn.Address, amount *big.Int) {
+	ethcore.Transfer(db, sender, recipient, amount)
+	if stateDB, ok := db.(*CommitStateDB); ok && IsNativeContract(recipient) {
+		stateDB.nativeCall = &nativeCall{caller: sender, value: amount}
+	}
+}
+
+var _ ethvm.PrecompiledContract = (*nativePrecompile)(nil)
+
+// nativePrecompile runs a native contract as a precompiled contract of go-ethereum
+type nativePrecompile struct {
+	address  ethcmn.Address
+	contract NativeContract
+}
+
+func (p *nativePrecompile) RequiredGas(input []byte) uint64 {
+	exec := currentNative()
+	if exec != nil {
+		exec.call, exec.stateDB.nativeCall = exec.stateDB.nativeCall, nil
+		if !IsNativeActive(exec.header.Height) {
+			return 0
+		}
+	}
+	return p.contract.RequiredGas(input)
+}
+
+func (p *nativePrecompile) Run(input []byte) ([]byte, error) {
+	exec := currentNative()
+	if exec == nil {
+		return nil, ErrNoNativeEnv
+	}
+	// before the fork a call to the address runs no code, like a call to an empty account
+	if !IsNativeActive(exec.header.Height) {
+		exec.call = nil
+		return nil, nil
+	}
+	call := exec.call
+	exec.call = nil
+
+	stateDB := exec.stateDB
+	state := stateDB.contractStore.State
+	env := &NativeEnv{
+		StateDB:  stateDB,
+		Header:   exec.header,
+		Contract: p.address,
+		Value:    new(big.Int),
+		ReadOnly: call == nil || isStatic(exec.evm),
+		State: storage.NewWatchedState(state, func(key storage.StoreKey, prev []byte) {
+			stateDB.journal.append(nativeChange{
+				state: state,
+				key:   append(storage.StoreKey{}, key...),
+				prev:  prev,
+			})
+		}),
+	}
+	if call != nil {
+		env.Caller = call.caller
+		env.Value = call.value
+	}
+	return p.contract.Run(env, input)
+}
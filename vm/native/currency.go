@@ -0,0 +1,87 @@
+package native
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+const currencyABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"},{"name":"currency","type":"string"}],"outputs":[{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"currency","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+// newCurrencyContract gives access to the balances of the native currencies. OLT is the value of
+// the evm, it can be read but is moved with the value of calls
+func newCurrencyContract(currencies *balance.CurrencySet) vm.NativeContract {
+	return newContract(currencyABI, map[string]*method{
+		"balanceOf": {gas: ReadGas, run: balanceOf},
+		"transfer":  {gas: 2*WriteGas + 2*ReadGas, write: true, run: transfer},
+	}, currencies)
+}
+
+func getCurrency(stores *Stores, name string) (balance.Currency, error) {
+	currency, ok := stores.Currencies.GetCurrencyByName(name)
+	if !ok {
+		return currency, fmt.Errorf("currency %s not found", name)
+	}
+	return currency, nil
+}
+
+// balanceOf returns the balance of the account in the currency
+func balanceOf(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	account := args[0].(ethcmn.Address)
+	currency, err := getCurrency(stores, args[1].(string))
+	if err != nil {
+		return nil, err
+	}
+	// OLT balances of the accounts touched by the transaction are in the state db
+	if currency.Name == "OLT" {
+		return []interface{}{env.StateDB.GetBalance(account)}, nil
+	}
+	coin, err := stores.Balances.GetBalanceForCurr(keys.Address(account.Bytes()), &currency)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{coin.Amount.BigInt()}, nil
+}
+
+// transfer moves an amount of a currency other than OLT from the caller to the address
+func transfer(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	to := args[0].(ethcmn.Address)
+	currency, err := getCurrency(stores, args[1].(string))
+	if err != nil {
+		return nil, err
+	}
+	amount := args[2].(*big.Int)
+
+	if currency.Name == "OLT" {
+		return nil, errors.New("OLT is transferred with the value of a call")
+	}
+	err = moveCoins(env, stores, currency, env.Caller, to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
+
+// moveCoins moves an amount of a currency between the balances of two addresses, the Transfer
+// event is logged by the token of the currency
+func moveCoins(env *vm.NativeEnv, stores *Stores, currency balance.Currency, from, to ethcmn.Address, amount *big.Int) error {
+	coin := currency.NewCoinFromAmount(*balance.NewAmountFromBigInt(amount))
+	err := stores.Balances.MinusFromAddress(keys.Address(from.Bytes()), coin)
+	if err != nil {
+		return balance.ErrInsufficientBalance
+	}
+	err = stores.Balances.AddToAddress(keys.Address(to.Bytes()), coin)
+	if err != nil {
+		return err
+	}
+	addLog(env, TokenAddress(currency.Name), transferEvent, from, to, amount)
+	return nil
+}
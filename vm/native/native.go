@@ -0,0 +1,191 @@
+package native
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/delegation"
+	"github.com/Oneledger/protocol/data/evidence"
+	"github.com/Oneledger/protocol/data/governance"
+	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Reserved addresses of the native contracts
+var (
+	ONSAddress      = ethcmn.HexToAddress("0x0000000000000000000000000000000000001000")
+	CurrencyAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001001")
+	StakingAddress  = ethcmn.HexToAddress("0x0000000000000000000000000000000000001002")
+	TokensAddress   = ethcmn.HexToAddress("0x0000000000000000000000000000000000001003")
+)
+
+// Gas of the native contract calls, a read of a native store is priced as a cold storage read
+// and a write as a storage write
+const (
+	ReadGas  uint64 = 2_600
+	WriteGas uint64 = 20_000
+)
+
+var (
+	ErrWriteProtection = errors.New("write protection")
+	ErrNotPayable      = errors.New("method is not payable")
+	ErrUnknownMethod   = errors.New("unknown method")
+
+	// selector of the Error(string) revert reason
+	revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+)
+
+// Stores are the native stores the contracts run against, they are separate from the stores of
+// the app and built on the state of every call
+type Stores struct {
+	Balances        *balance.Store
+	Currencies      *balance.CurrencySet
+	Domains         *ons.DomainStore
+	Validators      *identity.ValidatorStore
+	Delegators      *delegation.DelegationStore
+	NetwkDelegators *netwkDeleg.MasterStore
+	EvidenceStore   *evidence.EvidenceStore
+	GovernanceStore *governance.Store
+	Witnesses       *identity.WitnessStore
+}
+
+// NewStores returns the stores on the state under the prefixes of the stores of the app, the calls
+// of transactions applied at the same time don't share them
+func NewStores(state *storage.State, currencies *balance.CurrencySet) *Stores {
+	return &Stores{
+		Balances:        balance.NewStore("b", state),
+		Currencies:      currencies,
+		Domains:         ons.NewDomainStore("d", state),
+		Validators:      identity.NewValidatorStore("v", "purged", "rotation", "profile", state),
+		Delegators:      delegation.NewDelegationStore("st", state),
+		NetwkDelegators: netwkDeleg.NewMasterStore("deleg", "delegRwz", state),
+		EvidenceStore:   evidence.NewEvidenceStore("es", state),
+		GovernanceStore: governance.NewStore("g", state),
+		Witnesses:       identity.NewWitnessStore("w", state),
+	}
+}
+
+// actionContext returns the context the actions of the app run against for the stores and the
+// header of the call, a native method doing what a tx does shares its routine with the tx
+func (stores *Stores) actionContext(env *vm.NativeEnv) *action.Context {
+	return &action.Context{
+		Header:          env.Header,
+		Balances:        stores.Balances,
+		Currencies:      stores.Currencies,
+		Validators:      stores.Validators,
+		Witnesses:       stores.Witnesses,
+		Delegators:      stores.Delegators,
+		NetwkDelegators: stores.NetwkDelegators,
+		EvidenceStore:   stores.EvidenceStore,
+		GovernanceStore: stores.GovernanceStore,
+		Domains:         stores.Domains,
+	}
+}
+
+// EnableNative registers the native contracts in the vm while the node starts. The tokens of the
+// currencies are code calling the tokens contract, so the currencies added later have one too
+func EnableNative(currencies *balance.CurrencySet) {
+	vm.RegisterNativeContract(ONSAddress, newONSContract(currencies))
+	vm.RegisterNativeContract(CurrencyAddress, newCurrencyContract(currencies))
+	vm.RegisterNativeContract(StakingAddress, newStakingContract(currencies))
+
+	index := newTokenIndex(currencies)
+	vm.RegisterNativeContract(TokensAddress, newTokensContract(index))
+	vm.SetNativeCode(index.code)
+}
+
+// method of a native contract, it gets the unpacked inputs and returns the outputs to pack
+type method struct {
+	gas     uint64
+	write   bool
+	payable bool
+	run     func(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error)
+}
+
+// contract dispatches the calls to its methods by their abi selector
+type contract struct {
+	abi        abi.ABI
+	methods    map[string]*method
+	currencies *balance.CurrencySet
+}
+
+func newContract(definition string, methods map[string]*method, currencies *balance.CurrencySet) *contract {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid native contract abi: %s", err))
+	}
+	for name := range methods {
+		if _, ok := parsed.Methods[name]; !ok {
+			panic(fmt.Sprintf("native contract method %s not in abi", name))
+		}
+	}
+	return &contract{
+		abi:        parsed,
+		methods:    methods,
+		currencies: currencies,
+	}
+}
+
+func (c *contract) RequiredGas(input []byte) uint64 {
+	if len(input) < 4 {
+		return ReadGas
+	}
+	m, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return ReadGas
+	}
+	return c.methods[m.Name].gas
+}
+
+func (c *contract) Run(env *vm.NativeEnv, input []byte) ([]byte, error) {
+	if len(input) < 4 {
+		return revert(ErrUnknownMethod)
+	}
+	m, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return revert(ErrUnknownMethod)
+	}
+	def := c.methods[m.Name]
+	if def.write && env.ReadOnly {
+		return revert(ErrWriteProtection)
+	}
+	if !def.payable && env.Value.Sign() > 0 {
+		return revert(ErrNotPayable)
+	}
+	args, err := m.Inputs.Unpack(input[4:])
+	if err != nil {
+		return revert(err)
+	}
+	outputs, err := def.run(env, NewStores(env.State, c.currencies), args)
+	if err != nil {
+		return revert(err)
+	}
+	return m.Outputs.Pack(outputs...)
+}
+
+// revert returns the error as the reason of a revert, the remaining gas is given back to the caller
+func revert(err error) ([]byte, error) {
+	stringType, _ := abi.NewType("string", "", nil)
+	reason, _ := abi.Arguments{{Type: stringType}}.Pack(err.Error())
+	return append(append([]byte{}, revertSelector...), reason...), ethvm.ErrExecutionReverted
+}
+
+// takeValue moves the OLT sent with the call out of the contract, it is then up to the method
+func takeValue(env *vm.NativeEnv) *big.Int {
+	value := new(big.Int).Set(env.Value)
+	if value.Sign() > 0 {
+		env.StateDB.SubBalance(env.Contract, value)
+	}
+	return value
+}
@@ -0,0 +1,481 @@
+package native
+
+import (
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	btcchain "github.com/Oneledger/protocol/chains/bitcoin"
+	ethchain "github.com/Oneledger/protocol/chains/ethereum"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/delegation"
+	"github.com/Oneledger/protocol/data/evm"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	db "github.com/tendermint/tm-db"
+)
+
+var (
+	olt = balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+	btc = balance.Currency{Id: 1, Name: "BTC", Chain: chain.BITCOIN, Decimal: 8, Unit: "satoshi"}
+
+	caller   = ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	receiver = ethcmn.HexToAddress("0x1000000000000000000000000000000000000002")
+)
+
+type testContext struct {
+	state    *storage.State
+	stores   *Stores
+	stateDB  *vm.CommitStateDB
+	header   *abci.Header
+	currency abi.ABI
+	staking  abi.ABI
+	token    abi.ABI
+}
+
+func setup(t *testing.T) *testContext {
+	state := storage.NewState(storage.NewChainState("native", db.NewDB("test", db.MemDBBackend, "")))
+	currencies := balance.NewCurrencySet()
+	assert.NoError(t, currencies.Register(olt))
+	assert.NoError(t, currencies.Register(btc))
+
+	stores := NewStores(state, currencies)
+	EnableNative(currencies)
+	vm.SetNativeBlock(1)
+
+	logger := log.NewLoggerWithPrefix(os.Stdout, "native").WithLevel(log.Fatal)
+	stateDB := vm.NewCommitStateDB(
+		evm.NewContractStore(state),
+		balance.NewNesterAccountKeeper(state, balance.NewStore("b", state), currencies),
+		logger,
+	)
+	currencyABI, err := abi.JSON(strings.NewReader(currencyABI))
+	assert.NoError(t, err)
+	stakingABI, err := abi.JSON(strings.NewReader(stakingABI))
+	assert.NoError(t, err)
+	tokenABI, err := abi.JSON(strings.NewReader(tokenABI))
+	assert.NoError(t, err)
+
+	return &testContext{
+		state:   state,
+		stores:  stores,
+		stateDB: stateDB,
+		header: &abci.Header{
+			Height:  2,
+			Time:    time.Now(),
+			ChainID: "test-1",
+		},
+		currency: currencyABI,
+		staking:  stakingABI,
+		token:    tokenABI,
+	}
+}
+
+func (ctx *testContext) call(t *testing.T, to ethcmn.Address, data []byte) *vm.ExecutionResult {
+	return ctx.callFrom(t, caller, to, data, big.NewInt(0))
+}
+
+func (ctx *testContext) callFrom(t *testing.T, from, to ethcmn.Address, data []byte, value *big.Int) *vm.ExecutionResult {
+	toAddr := keys.Address(to.Bytes())
+	result, err := vm.NewEVMTransaction(
+		ctx.stateDB, new(ethcore.GasPool).AddGas(vm.SimulationBlockGasLimit), ctx.header,
+		from.Bytes(), &toAddr, 0, value, data, nil, 1_000_000, big.NewInt(0), true,
+	).Apply()
+	assert.NoError(t, err)
+	return result
+}
+
+func (ctx *testContext) btcBalance(t *testing.T, address ethcmn.Address) *big.Int {
+	coin, err := ctx.stores.Balances.GetBalanceForCurr(keys.Address(address.Bytes()), &btc)
+	assert.NoError(t, err)
+	return coin.Amount.BigInt()
+}
+
+func TestCurrency_Transfer(t *testing.T) {
+	ctx := setup(t)
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address(caller.Bytes()), btc.NewCoinFromInt(10)))
+
+	data, err := ctx.currency.Pack("transfer", receiver, "BTC", big.NewInt(400))
+	assert.NoError(t, err)
+
+	snapshot := ctx.stateDB.Snapshot()
+	result := ctx.call(t, CurrencyAddress, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), ctx.btcBalance(t, receiver))
+
+	data, err = ctx.currency.Pack("balanceOf", receiver, "BTC")
+	assert.NoError(t, err)
+	result = ctx.call(t, CurrencyAddress, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), new(big.Int).SetBytes(result.ReturnData))
+
+	// reverting the state db rolls back the native change
+	ctx.stateDB.RevertToSnapshot(snapshot)
+	assert.Equal(t, big.NewInt(0), ctx.btcBalance(t, receiver))
+	assert.Equal(t, btc.NewCoinFromInt(10).Amount.BigInt(), ctx.btcBalance(t, caller))
+}
+
+func TestCurrency_TransferFailures(t *testing.T) {
+	ctx := setup(t)
+
+	// not enough funds
+	data, err := ctx.currency.Pack("transfer", receiver, "BTC", big.NewInt(1))
+	assert.NoError(t, err)
+	result := ctx.call(t, CurrencyAddress, data)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, balance.ErrInsufficientBalance.Error(), revertReason(t, result.ReturnData))
+
+	// OLT is moved with the value of calls
+	data, err = ctx.currency.Pack("transfer", receiver, "OLT", big.NewInt(1))
+	assert.NoError(t, err)
+	result = ctx.call(t, CurrencyAddress, data)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+
+	// unknown method
+	result = ctx.call(t, CurrencyAddress, []byte{1, 2, 3, 4})
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrUnknownMethod.Error(), revertReason(t, result.ReturnData))
+}
+
+func (ctx *testContext) setStakingOptions(t *testing.T) {
+	govern := ctx.stores.GovernanceStore.WithHeight(0)
+	assert.NoError(t, govern.SetStakingOptions(delegation.Options{MaturityTime: 10}))
+	assert.NoError(t, govern.SetLUH(governance.LAST_UPDATE_HEIGHT_STAKING))
+}
+
+func TestStaking_Stake(t *testing.T) {
+	ctx := setup(t)
+	ctx.setStakingOptions(t)
+	validator := ethcmn.HexToAddress("0x1000000000000000000000000000000000000003")
+	assert.NoError(t, ctx.stores.Validators.Set(identity.Validator{
+		Address:      keys.Address(validator.Bytes()),
+		StakeAddress: keys.Address(caller.Bytes()),
+		Staking:      *balance.NewAmount(0),
+	}))
+	ctx.stateDB.AddBalance(caller, big.NewInt(1000))
+
+	stake, err := ctx.staking.Pack("stake", validator)
+	assert.NoError(t, err)
+	result := ctx.callFrom(t, caller, StakingAddress, stake, big.NewInt(600))
+	assert.NoError(t, result.Err)
+
+	assert.Equal(t, "400", ctx.stateDB.GetBalance(caller).String())
+	assert.Equal(t, "0", ctx.stateDB.GetBalance(StakingAddress).String())
+
+	data, err := ctx.staking.Pack("delegation", validator, caller)
+	assert.NoError(t, err)
+	result = ctx.call(t, StakingAddress, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(600), new(big.Int).SetBytes(result.ReturnData))
+
+	v, err := ctx.stores.Validators.Get(keys.Address(validator.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(600), v.Staking.BigInt())
+
+	// only the stake address of the validator stakes to it, the value goes back with the revert
+	ctx.stateDB.AddBalance(receiver, big.NewInt(1000))
+	result = ctx.callFrom(t, receiver, StakingAddress, stake, big.NewInt(100))
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, "1000", ctx.stateDB.GetBalance(receiver).String())
+}
+
+func TestStaking_StakeJoinsWitnesses(t *testing.T) {
+	ctx := setup(t)
+	ctx.setStakingOptions(t)
+	witness := keys.Address(ethcmn.HexToAddress("0x1000000000000000000000000000000000000004").Bytes())
+	assert.NoError(t, ctx.stores.Witnesses.AddWitness(chain.ETHEREUM, identity.Stake{
+		ValidatorAddress: witness,
+		ECDSAPubKey:      newECDSAPubKey(),
+		Name:             "witness",
+	}))
+	ctx.state.Commit()
+
+	validator := ethcmn.HexToAddress("0x1000000000000000000000000000000000000003")
+	ecdsaPubKey := newECDSAPubKey()
+	assert.NoError(t, ctx.stores.Validators.Set(identity.Validator{
+		Address:      keys.Address(validator.Bytes()),
+		StakeAddress: keys.Address(caller.Bytes()),
+		ECDSAPubKey:  ecdsaPubKey,
+		Name:         "validator",
+		Staking:      *balance.NewAmount(0),
+	}))
+	ctx.stateDB.AddBalance(caller, big.NewInt(1000))
+
+	// a stake through the contract makes the validator join the witnesses as a stake tx does
+	stake, err := ctx.staking.Pack("stake", validator)
+	assert.NoError(t, err)
+	result := ctx.callFrom(t, caller, StakingAddress, stake, big.NewInt(600))
+	assert.NoError(t, result.Err)
+
+	rotation, err := ctx.stores.Witnesses.GetPendingRotation(chain.ETHEREUM)
+	assert.NoError(t, err)
+	if assert.NotNil(t, rotation) {
+		assert.Equal(t, []keys.Address{witness}, rotation.Signers)
+		assert.Len(t, rotation.Witnesses, 2)
+		assert.Equal(t, ecdsaPubKey, rotation.Witnesses[1].ECDSAPubKey)
+	}
+}
+
+func TestNative_BeforeFork(t *testing.T) {
+	ctx := setup(t)
+	vm.SetNativeBlock(ctx.header.Height + 1)
+	defer vm.SetNativeBlock(1)
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address(caller.Bytes()), btc.NewCoinFromInt(10)))
+
+	// the address runs no code before the fork, like an empty account
+	data, err := ctx.currency.Pack("transfer", receiver, "BTC", big.NewInt(400))
+	assert.NoError(t, err)
+	result := ctx.call(t, CurrencyAddress, data)
+	assert.NoError(t, result.Err)
+	assert.Empty(t, result.ReturnData)
+	assert.Equal(t, big.NewInt(0), ctx.btcBalance(t, receiver))
+
+	// nor have the tokens code
+	assert.Zero(t, ctx.codeSize(t, TokenAddress("BTC")))
+	result = ctx.call(t, TokenAddress("BTC"), data)
+	assert.NoError(t, result.Err)
+	assert.Empty(t, result.ReturnData)
+
+	ctx.header.Height++
+	result = ctx.call(t, CurrencyAddress, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), ctx.btcBalance(t, receiver))
+}
+
+func TestToken_Transfer(t *testing.T) {
+	ctx := setup(t)
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address(caller.Bytes()), btc.NewCoinFromInt(10)))
+	token := TokenAddress("BTC")
+
+	data, err := ctx.token.Pack("decimals")
+	assert.NoError(t, err)
+	result := ctx.call(t, token, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(8), new(big.Int).SetBytes(result.ReturnData))
+
+	data, err = ctx.token.Pack("transfer", receiver, big.NewInt(400))
+	assert.NoError(t, err)
+	result = ctx.call(t, token, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), ctx.btcBalance(t, receiver))
+
+	data, err = ctx.token.Pack("balanceOf", receiver)
+	assert.NoError(t, err)
+	result = ctx.call(t, token, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), new(big.Int).SetBytes(result.ReturnData))
+
+	// the transfer is logged by the token
+	logs := ctx.stateDB.GetTxLogs()
+	assert.Len(t, logs, 1)
+	assert.Equal(t, token, logs[0].Address)
+	assert.Equal(t, []ethcmn.Hash{transferEvent, caller.Hash(), receiver.Hash()}, logs[0].Topics)
+	assert.Equal(t, big.NewInt(400), new(big.Int).SetBytes(logs[0].Data))
+
+	// a token has code so contracts can call it, OLT has no token
+	assert.NotZero(t, ctx.codeSize(t, token))
+	assert.Nil(t, vm.NativeCode(TokenAddress("OLT")))
+	assert.Zero(t, ctx.codeSize(t, TokenAddress("OLT")))
+}
+
+func TestToken_Calls(t *testing.T) {
+	ctx := setup(t)
+	token := TokenAddress("BTC")
+
+	// the value sent to a token goes back with the revert
+	ctx.stateDB.AddBalance(caller, big.NewInt(1000))
+	data, err := ctx.token.Pack("transfer", receiver, big.NewInt(0))
+	assert.NoError(t, err)
+	result := ctx.callFrom(t, caller, token, data, big.NewInt(100))
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, "1000", ctx.stateDB.GetBalance(caller).String())
+
+	// only the tokens call the tokens contract
+	forged := append(caller.Hash().Bytes(), data...)
+	result = ctx.call(t, TokensAddress, forged)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrNotToken.Error(), revertReason(t, result.ReturnData))
+}
+
+func TestToken_TotalSupply(t *testing.T) {
+	ctx := setup(t)
+	token := TokenAddress("BTC")
+	data, err := ctx.token.Pack("totalSupply")
+	assert.NoError(t, err)
+
+	// the supply is tallied by the bridge of the chain
+	govern := ctx.stores.GovernanceStore.WithHeight(0)
+	assert.NoError(t, govern.SetBTCChainDriverOption(btcchain.ChainDriverOption{TotalSupplyAddr: "btcSupply"}))
+	assert.NoError(t, govern.SetLUH(governance.LAST_UPDATE_HEIGHT_BTC))
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address("btcSupply"), btc.NewCoinFromInt(21)))
+
+	result := ctx.call(t, token, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, btc.NewCoinFromInt(21).Amount.BigInt(), new(big.Int).SetBytes(result.ReturnData))
+
+	// no supply address is set for ethereum
+	assert.NoError(t, ctx.stores.Currencies.Register(balance.Currency{Id: 2, Name: "ETH", Chain: chain.ETHEREUM, Decimal: 18}))
+	assert.NoError(t, govern.SetETHChainDriverOption(ethchain.ChainDriverOption{}))
+	assert.NoError(t, govern.SetLUH(governance.LAST_UPDATE_HEIGHT_ETH))
+	result = ctx.call(t, TokenAddress("ETH"), data)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrNoSupply.Error(), revertReason(t, result.ReturnData))
+}
+
+func TestToken_StaticCall(t *testing.T) {
+	ctx := setup(t)
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address(caller.Bytes()), btc.NewCoinFromInt(10)))
+	token := TokenAddress("BTC")
+	staticProxy := ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+	ctx.stateDB.SetCode(staticProxy, forwarder(ethvm.STATICCALL, token))
+
+	approve, err := ctx.token.Pack("approve", staticProxy, big.NewInt(500))
+	assert.NoError(t, err)
+	result := ctx.call(t, token, approve)
+	assert.NoError(t, result.Err)
+
+	// the writes of a token revert in a STATICCALL
+	transfer, err := ctx.token.Pack("transfer", receiver, big.NewInt(400))
+	assert.NoError(t, err)
+	result = ctx.call(t, staticProxy, transfer)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrWriteProtection.Error(), revertReason(t, result.ReturnData))
+
+	transferFrom, err := ctx.token.Pack("transferFrom", caller, receiver, big.NewInt(400))
+	assert.NoError(t, err)
+	result = ctx.call(t, staticProxy, transferFrom)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrWriteProtection.Error(), revertReason(t, result.ReturnData))
+	assert.Equal(t, big.NewInt(0), ctx.btcBalance(t, receiver))
+
+	// the views are read
+	balanceOf, err := ctx.token.Pack("balanceOf", caller)
+	assert.NoError(t, err)
+	result = ctx.call(t, staticProxy, balanceOf)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, btc.NewCoinFromInt(10).Amount.BigInt(), new(big.Int).SetBytes(result.ReturnData))
+}
+
+func TestToken_TransferFrom(t *testing.T) {
+	ctx := setup(t)
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address(caller.Bytes()), btc.NewCoinFromInt(10)))
+	token := TokenAddress("BTC")
+	spender := ethcmn.HexToAddress("0x1000000000000000000000000000000000000003")
+
+	approve, err := ctx.token.Pack("approve", spender, big.NewInt(500))
+	assert.NoError(t, err)
+	result := ctx.call(t, token, approve)
+	assert.NoError(t, result.Err)
+
+	transferFrom, err := ctx.token.Pack("transferFrom", caller, receiver, big.NewInt(300))
+	assert.NoError(t, err)
+	result = ctx.callFrom(t, spender, token, transferFrom, big.NewInt(0))
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(300), ctx.btcBalance(t, receiver))
+
+	data, err := ctx.token.Pack("allowance", caller, spender)
+	assert.NoError(t, err)
+	result = ctx.call(t, token, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(200), new(big.Int).SetBytes(result.ReturnData))
+
+	// the rest of the allowance is not enough
+	result = ctx.callFrom(t, spender, token, transferFrom, big.NewInt(0))
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrInsufficientAllowance.Error(), revertReason(t, result.ReturnData))
+	assert.Equal(t, big.NewInt(300), ctx.btcBalance(t, receiver))
+}
+
+func TestNative_StaticCall(t *testing.T) {
+	ctx := setup(t)
+	callProxy := ethcmn.HexToAddress("0x2000000000000000000000000000000000000001")
+	staticProxy := ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+	staticToCallProxy := ethcmn.HexToAddress("0x2000000000000000000000000000000000000003")
+	ctx.stateDB.SetCode(callProxy, forwarder(ethvm.CALL, CurrencyAddress))
+	ctx.stateDB.SetCode(staticProxy, forwarder(ethvm.STATICCALL, CurrencyAddress))
+	ctx.stateDB.SetCode(staticToCallProxy, forwarder(ethvm.STATICCALL, callProxy))
+	assert.NoError(t, ctx.stores.Balances.AddToAddress(keys.Address(callProxy.Bytes()), btc.NewCoinFromInt(10)))
+
+	transfer, err := ctx.currency.Pack("transfer", receiver, "BTC", big.NewInt(400))
+	assert.NoError(t, err)
+
+	// a write method can't be reached by a STATICCALL, nor by a CALL from the frame of one
+	result := ctx.call(t, staticProxy, transfer)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrWriteProtection.Error(), revertReason(t, result.ReturnData))
+	result = ctx.call(t, staticToCallProxy, transfer)
+	assert.Equal(t, ethvm.ErrExecutionReverted, result.Err)
+	assert.Equal(t, ErrWriteProtection.Error(), revertReason(t, result.ReturnData))
+	assert.Equal(t, big.NewInt(0), ctx.btcBalance(t, receiver))
+
+	// the proxy is the caller of a CALL
+	result = ctx.call(t, callProxy, transfer)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), ctx.btcBalance(t, receiver))
+
+	// views are read by a STATICCALL
+	data, err := ctx.currency.Pack("balanceOf", receiver, "BTC")
+	assert.NoError(t, err)
+	result = ctx.call(t, staticProxy, data)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(400), new(big.Int).SetBytes(result.ReturnData))
+}
+
+// codeSize returns the code size of the address a contract sees in a transaction
+func (ctx *testContext) codeSize(t *testing.T, address ethcmn.Address) int64 {
+	sizer := ethcmn.HexToAddress("0x2000000000000000000000000000000000000004")
+	code := append([]byte{byte(ethvm.PUSH20)}, address.Bytes()...)
+	code = append(code, byte(ethvm.EXTCODESIZE), byte(ethvm.PUSH1), 0, byte(ethvm.MSTORE),
+		byte(ethvm.PUSH1), 32, byte(ethvm.PUSH1), 0, byte(ethvm.RETURN))
+	ctx.stateDB.SetCode(sizer, code)
+	result := ctx.call(t, sizer, nil)
+	assert.NoError(t, result.Err)
+	return new(big.Int).SetBytes(result.ReturnData).Int64()
+}
+
+// forwarder returns the code of a contract that makes a call of the kind to the target with its
+// input, and returns or reverts with the output of the call
+func forwarder(op ethvm.OpCode, target ethcmn.Address) []byte {
+	code := []byte{
+		byte(ethvm.CALLDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.PUSH1), 0, byte(ethvm.CALLDATACOPY),
+		byte(ethvm.PUSH1), 0, byte(ethvm.PUSH1), 0, byte(ethvm.CALLDATASIZE), byte(ethvm.PUSH1), 0,
+	}
+	if op == ethvm.CALL {
+		code = append(code, byte(ethvm.PUSH1), 0)
+	}
+	code = append(code, byte(ethvm.PUSH20))
+	code = append(code, target.Bytes()...)
+	code = append(code, byte(ethvm.GAS), byte(op),
+		byte(ethvm.RETURNDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.DUP1), byte(ethvm.RETURNDATACOPY))
+	ok := byte(len(code) + 7)
+	return append(code,
+		byte(ethvm.PUSH1), ok, byte(ethvm.JUMPI),
+		byte(ethvm.RETURNDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.REVERT),
+		byte(ethvm.JUMPDEST), byte(ethvm.RETURNDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.RETURN),
+	)
+}
+
+func newECDSAPubKey() keys.PublicKey {
+	pub := secp256k1.GenPrivKey().PubKey().(secp256k1.PubKeySecp256k1)
+	return keys.PublicKey{KeyType: keys.SECP256K1, Data: pub[:]}
+}
+
+func revertReason(t *testing.T, data []byte) string {
+	reason, err := abi.UnpackRevert(data)
+	assert.NoError(t, err)
+	return reason
+}
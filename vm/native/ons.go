@@ -0,0 +1,41 @@
+package native
+
+import (
+	"errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+const onsABI = `[
+	{"type":"function","name":"resolve","stateMutability":"view","inputs":[{"name":"name","type":"string"}],"outputs":[{"name":"account","type":"address"}]}
+]`
+
+// newONSContract resolves the ONS domains to the address of their account
+func newONSContract(currencies *balance.CurrencySet) vm.NativeContract {
+	return newContract(onsABI, map[string]*method{
+		"resolve": {gas: ReadGas, run: resolve},
+	}, currencies)
+}
+
+// resolve returns the account of an active domain, like a domain send does
+func resolve(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	name := ons.GetNameFromString(args[0].(string))
+
+	domain, err := stores.Domains.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsExpired(env.Header.Height) {
+		return nil, errors.New("domain expired")
+	}
+	if !domain.IsActive(env.Header.Height) {
+		return nil, errors.New("domain inactive")
+	}
+	if len(domain.Beneficiary) == 0 {
+		return nil, errors.New("domain account address not set")
+	}
+	return []interface{}{ethcmn.BytesToAddress(domain.Beneficiary)}, nil
+}
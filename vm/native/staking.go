@@ -0,0 +1,140 @@
+package native
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Oneledger/protocol/action/staking"
+	"github.com/Oneledger/protocol/data/balance"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+const stakingABI = `[
+	{"type":"function","name":"validator","stateMutability":"view","inputs":[{"name":"validator","type":"address"}],"outputs":[{"name":"exists","type":"bool"},{"name":"stakeAddress","type":"address"},{"name":"staking","type":"uint256"},{"name":"power","type":"uint256"},{"name":"name","type":"string"},{"name":"frozen","type":"bool"}]},
+	{"type":"function","name":"delegation","stateMutability":"view","inputs":[{"name":"validator","type":"address"},{"name":"delegator","type":"address"}],"outputs":[{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"networkDelegation","stateMutability":"view","inputs":[{"name":"delegator","type":"address"}],"outputs":[{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"stake","stateMutability":"payable","inputs":[{"name":"validator","type":"address"}],"outputs":[{"name":"success","type":"bool"}]},
+	{"type":"function","name":"delegate","stateMutability":"payable","inputs":[],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+// newStakingContract queries the validators and delegations, and stakes or delegates the OLT sent
+// with the call on behalf of the caller
+func newStakingContract(currencies *balance.CurrencySet) vm.NativeContract {
+	return newContract(stakingABI, map[string]*method{
+		"validator":         {gas: 2 * ReadGas, run: getValidator},
+		"delegation":        {gas: ReadGas, run: getDelegation},
+		"networkDelegation": {gas: ReadGas, run: getNetworkDelegation},
+		"stake":             {gas: 4*WriteGas + 4*ReadGas, write: true, payable: true, run: stake},
+		"delegate":          {gas: 2*WriteGas + 2*ReadGas, write: true, payable: true, run: delegate},
+	}, currencies)
+}
+
+func getValidator(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	address := keys.Address(args[0].(ethcmn.Address).Bytes())
+	if !stores.Validators.Exists(address) {
+		return []interface{}{false, ethcmn.Address{}, new(big.Int), new(big.Int), "", false}, nil
+	}
+	validator, err := stores.Validators.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{
+		true,
+		ethcmn.BytesToAddress(validator.StakeAddress),
+		validator.Staking.BigInt(),
+		big.NewInt(validator.Power),
+		validator.Name,
+		stores.EvidenceStore.IsFrozenValidator(address),
+	}, nil
+}
+
+func getDelegation(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	validator := keys.Address(args[0].(ethcmn.Address).Bytes())
+	delegator := keys.Address(args[1].(ethcmn.Address).Bytes())
+	amount, err := stores.Delegators.GetValidatorDelegationAmount(validator, delegator)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{amount.BigInt()}, nil
+}
+
+func getNetworkDelegation(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	delegator := keys.Address(args[0].(ethcmn.Address).Bytes())
+	coin, err := stores.NetwkDelegators.Deleg.WithPrefix(netwkDeleg.ActiveType).Get(delegator)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{coin.Amount.BigInt()}, nil
+}
+
+// stake adds the OLT sent to the stake of a validator, the caller has to be its stake address. It
+// stakes as the stake tx does, a validator it makes eligible joins the witnesses
+func stake(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	validatorAddress := keys.Address(args[0].(ethcmn.Address).Bytes())
+	stakeAddress := keys.Address(env.Caller.Bytes())
+	if env.Value.Sign() <= 0 {
+		return nil, errors.New("no OLT sent to stake")
+	}
+	if !stores.Validators.Exists(validatorAddress) {
+		return nil, errors.New("validator not found")
+	}
+	validator, err := stores.Validators.Get(validatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !validator.StakeAddress.Equal(stakeAddress) {
+		return nil, errors.New("caller is not the stake address of the validator")
+	}
+
+	err = staking.ApplyStake(stores.actionContext(env), identity.Stake{
+		ValidatorAddress: validatorAddress,
+		StakeAddress:     stakeAddress,
+		Pubkey:           validator.PubKey,
+		ECDSAPubKey:      validator.ECDSAPubKey,
+		Name:             validator.Name,
+		Amount:           *balance.NewAmountFromBigInt(env.Value),
+	}, func() error {
+		takeValue(env)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
+
+// delegate adds the OLT sent to the network delegation of the caller, the OLT goes to the pool
+func delegate(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	delegator := keys.Address(env.Caller.Bytes())
+	if env.Value.Sign() <= 0 {
+		return nil, errors.New("no OLT sent to delegate")
+	}
+	currency, err := getCurrency(stores, "OLT")
+	if err != nil {
+		return nil, err
+	}
+	pool, err := stores.GovernanceStore.GetPoolByName(gov.POOL_DELEGATION)
+	if err != nil {
+		return nil, err
+	}
+
+	coin := currency.NewCoinFromAmount(*balance.NewAmountFromBigInt(takeValue(env)))
+	env.StateDB.AddBalance(ethcmn.BytesToAddress(pool), coin.Amount.BigInt())
+
+	deleg := stores.NetwkDelegators.Deleg.WithPrefix(netwkDeleg.ActiveType)
+	current, err := deleg.Get(delegator)
+	if err != nil {
+		return nil, err
+	}
+	delegated := current.Plus(coin)
+	err = deleg.Set(delegator, &delegated)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
@@ -0,0 +1,328 @@
+package native
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const tokenABI = `[
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},
+	{"type":"event","name":"Approval","anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]}
+]`
+
+// allowancePrefix is the prefix of the token allowances in the chain state
+const allowancePrefix = "tokenAllowance"
+
+var (
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+	ErrNotToken              = errors.New("caller is not a token")
+	ErrNoSupply              = errors.New("no supply is kept for the currency")
+
+	transferEvent = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalEvent = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+	// tokenCode is the code of every token, it calls the tokens contract with the caller of the
+	// token before the input and returns or reverts with its output. Value is not taken
+	tokenCode = func() []byte {
+		code := []byte{
+			byte(ethvm.CALLVALUE), byte(ethvm.ISZERO), byte(ethvm.PUSH1), 9, byte(ethvm.JUMPI),
+			byte(ethvm.PUSH1), 0, byte(ethvm.DUP1), byte(ethvm.REVERT),
+			byte(ethvm.JUMPDEST), byte(ethvm.CALLER), byte(ethvm.PUSH1), 0, byte(ethvm.MSTORE),
+			byte(ethvm.CALLDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.PUSH1), 32, byte(ethvm.CALLDATACOPY),
+			byte(ethvm.PUSH1), 0, byte(ethvm.PUSH1), 0, byte(ethvm.CALLDATASIZE), byte(ethvm.PUSH1), 32, byte(ethvm.ADD),
+			byte(ethvm.PUSH1), 0, byte(ethvm.PUSH1), 0, byte(ethvm.PUSH20),
+		}
+		code = append(code, TokensAddress.Bytes()...)
+		code = append(code, byte(ethvm.GAS), byte(ethvm.CALL),
+			byte(ethvm.RETURNDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.DUP1), byte(ethvm.RETURNDATACOPY))
+		ok := byte(len(code) + 7)
+		return append(code,
+			byte(ethvm.PUSH1), ok, byte(ethvm.JUMPI),
+			byte(ethvm.RETURNDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.REVERT),
+			byte(ethvm.JUMPDEST), byte(ethvm.RETURNDATASIZE), byte(ethvm.PUSH1), 0, byte(ethvm.RETURN),
+		)
+	}()
+)
+
+// TokenAddress returns the address of the ERC-20 token of a native currency, it only depends on
+// the name so it is the same on every network
+func TokenAddress(currency string) ethcmn.Address {
+	return ethcmn.BytesToAddress(crypto.Keccak256([]byte("native-currency/" + currency))[12:])
+}
+
+// tokenIndex finds the currencies by the address of their token, currencies are added at genesis
+// and by governance so it is built again when the count of currencies changes. OLT is the value of
+// the evm and has no token
+type tokenIndex struct {
+	currencies *balance.CurrencySet
+
+	mu        sync.RWMutex
+	size      int
+	byAddress map[ethcmn.Address]string
+}
+
+func newTokenIndex(currencies *balance.CurrencySet) *tokenIndex {
+	return &tokenIndex{currencies: currencies, byAddress: make(map[ethcmn.Address]string)}
+}
+
+// currency returns the currency of the token at the address
+func (ti *tokenIndex) currency(address ethcmn.Address) (balance.Currency, bool) {
+	ti.mu.RLock()
+	name, ok := ti.byAddress[address]
+	stale := ti.size != ti.currencies.Len()
+	ti.mu.RUnlock()
+	if stale {
+		ti.mu.Lock()
+		ti.byAddress = make(map[ethcmn.Address]string)
+		for _, currency := range ti.currencies.GetCurrencies() {
+			if currency.Name != "OLT" {
+				ti.byAddress[TokenAddress(currency.Name)] = currency.Name
+			}
+		}
+		ti.size = ti.currencies.Len()
+		name, ok = ti.byAddress[address]
+		ti.mu.Unlock()
+	}
+	if !ok {
+		return balance.Currency{}, false
+	}
+	return ti.currencies.GetCurrencyByName(name)
+}
+
+// code returns the code of the token at the address, nil if there is no token
+func (ti *tokenIndex) code(address ethcmn.Address) []byte {
+	if _, ok := ti.currency(address); !ok {
+		return nil
+	}
+	return tokenCode
+}
+
+// tokens runs the calls of the ERC-20 tokens of the native currencies over the balances of the
+// chain. A token is the code at its address, it calls the contract with its caller first so the
+// currency is the one of the calling token
+type tokens struct {
+	index    *tokenIndex
+	contract *contract
+}
+
+func newTokensContract(index *tokenIndex) vm.NativeContract {
+	t := &tokens{index: index}
+	t.contract = newContract(tokenABI, map[string]*method{
+		"name":         {gas: ReadGas, run: t.getName},
+		"symbol":       {gas: ReadGas, run: t.getName},
+		"decimals":     {gas: ReadGas, run: t.decimals},
+		"totalSupply":  {gas: 2 * ReadGas, run: t.totalSupply},
+		"balanceOf":    {gas: ReadGas, run: t.balanceOf},
+		"allowance":    {gas: ReadGas, run: t.allowance},
+		"transfer":     {gas: 2*WriteGas + 2*ReadGas, write: true, run: t.transfer},
+		"approve":      {gas: WriteGas, write: true, run: t.approve},
+		"transferFrom": {gas: 3*WriteGas + 3*ReadGas, write: true, run: t.transferFrom},
+	}, index.currencies)
+	return t
+}
+
+func (t *tokens) RequiredGas(input []byte) uint64 {
+	if len(input) < ethcmn.HashLength {
+		return ReadGas
+	}
+	return t.contract.RequiredGas(input[ethcmn.HashLength:])
+}
+
+// Run runs the call of a token as one made to the token by the caller it gives
+func (t *tokens) Run(env *vm.NativeEnv, input []byte) ([]byte, error) {
+	if _, ok := t.index.currency(env.Caller); !ok || len(input) < ethcmn.HashLength {
+		return revert(ErrNotToken)
+	}
+	tokenEnv := *env
+	tokenEnv.Contract = env.Caller
+	tokenEnv.Caller = ethcmn.BytesToAddress(input[:ethcmn.HashLength])
+	return t.contract.Run(&tokenEnv, input[ethcmn.HashLength:])
+}
+
+// currency returns the currency of the token the call is made to
+func (t *tokens) currency(env *vm.NativeEnv) (balance.Currency, error) {
+	currency, ok := t.index.currency(env.Contract)
+	if !ok {
+		return currency, ErrNotToken
+	}
+	return currency, nil
+}
+
+func (t *tokens) getName(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{currency.Name}, nil
+}
+
+func (t *tokens) decimals(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{uint8(currency.Decimal)}, nil
+}
+
+// totalSupply returns the amount of the currency minted by the bridge of its chain, it is
+// tallied at the total supply address of the chain
+func (t *tokens) totalSupply(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	var supplyAddr string
+	switch currency.Chain {
+	case chain.ETHEREUM:
+		opt, err := stores.GovernanceStore.GetETHChainDriverOption()
+		if err != nil {
+			return nil, err
+		}
+		supplyAddr = opt.TotalSupplyAddr
+	case chain.BITCOIN:
+		opt, err := stores.GovernanceStore.GetBTCChainDriverOption()
+		if err != nil {
+			return nil, err
+		}
+		supplyAddr = opt.TotalSupplyAddr
+	}
+	if supplyAddr == "" {
+		return nil, ErrNoSupply
+	}
+	coin, err := stores.Balances.GetBalanceForCurr(keys.Address(supplyAddr), &currency)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{coin.Amount.BigInt()}, nil
+}
+
+func (t *tokens) balanceOf(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	return balanceOf(env, stores, []interface{}{args[0], currency.Name})
+}
+
+func (t *tokens) allowance(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := getAllowance(env.State, currency.Name, args[0].(ethcmn.Address), args[1].(ethcmn.Address))
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{amount}, nil
+}
+
+func (t *tokens) transfer(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	err = moveCoins(env, stores, currency, env.Caller, args[0].(ethcmn.Address), args[1].(*big.Int))
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
+
+func (t *tokens) approve(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	spender := args[0].(ethcmn.Address)
+	amount := args[1].(*big.Int)
+	err = setAllowance(env.State, currency.Name, env.Caller, spender, amount)
+	if err != nil {
+		return nil, err
+	}
+	addLog(env, env.Contract, approvalEvent, env.Caller, spender, amount)
+	return []interface{}{true}, nil
+}
+
+// transferFrom moves the amount out of the allowance of the caller, an allowance of the max
+// uint256 is never spent
+func (t *tokens) transferFrom(env *vm.NativeEnv, stores *Stores, args []interface{}) ([]interface{}, error) {
+	from := args[0].(ethcmn.Address)
+	to := args[1].(ethcmn.Address)
+	amount := args[2].(*big.Int)
+
+	currency, err := t.currency(env)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := getAllowance(env.State, currency.Name, from, env.Caller)
+	if err != nil {
+		return nil, err
+	}
+	if allowance.Cmp(amount) < 0 {
+		return nil, ErrInsufficientAllowance
+	}
+	if allowance.Cmp(math.MaxBig256) != 0 {
+		err = setAllowance(env.State, currency.Name, from, env.Caller, new(big.Int).Sub(allowance, amount))
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = moveCoins(env, stores, currency, from, to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
+
+func allowanceKey(name string, owner, spender ethcmn.Address) storage.StoreKey {
+	return storage.StoreKey(allowancePrefix + storage.DB_PREFIX + name + storage.DB_PREFIX +
+		owner.Hex() + storage.DB_PREFIX + spender.Hex())
+}
+
+func getAllowance(state *storage.State, name string, owner, spender ethcmn.Address) (*big.Int, error) {
+	value, err := state.Get(allowanceKey(name, owner, spender))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(value), nil
+}
+
+func setAllowance(state *storage.State, name string, owner, spender ethcmn.Address, amount *big.Int) error {
+	key := allowanceKey(name, owner, spender)
+	if amount.Sign() == 0 {
+		_, err := state.Delete(key)
+		return err
+	}
+	return state.Set(key, amount.Bytes())
+}
+
+// addLog logs an event of a token with two indexed addresses and an amount, like Transfer
+func addLog(env *vm.NativeEnv, contract ethcmn.Address, event ethcmn.Hash, a, b ethcmn.Address, amount *big.Int) {
+	env.StateDB.AddLog(&ethtypes.Log{
+		Address:     contract,
+		Topics:      []ethcmn.Hash{event, a.Hash(), b.Hash()},
+		Data:        ethcmn.BigToHash(amount).Bytes(),
+		BlockNumber: uint64(env.Header.Height),
+	})
+}
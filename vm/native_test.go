@@ -0,0 +1,49 @@
+package vm
+
+import (
+	"runtime/debug"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goEthereumVersion is the go-ethereum the native contracts were checked against. They find their
+// evm by the goroutine and read the static flag from the interpreter, a bump has to check again
+// that precompiled contracts still run on the goroutine applying the transaction and that the
+// interpreter still sets readOnly for the frame of a STATICCALL
+const goEthereumVersion = "v1.10.8"
+
+func TestNative_GoEthereumVersion(t *testing.T) {
+	info, ok := debug.ReadBuildInfo()
+	require.True(t, ok)
+	for _, dep := range info.Deps {
+		if dep.Path != "github.com/ethereum/go-ethereum" {
+			continue
+		}
+		version := dep.Version
+		if dep.Replace != nil {
+			version = dep.Replace.Version
+		}
+		assert.Equal(t, goEthereumVersion, version, "check goroutineID and isStatic against the new go-ethereum")
+		return
+	}
+	t.Fatal("go-ethereum is not in the build")
+}
+
+func TestNative_GoroutineID(t *testing.T) {
+	id := goroutineID()
+	assert.NotZero(t, id)
+	assert.Equal(t, id, goroutineID())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		other := goroutineID()
+		assert.NotZero(t, other)
+		assert.NotEqual(t, id, other)
+	}()
+	wg.Wait()
+}
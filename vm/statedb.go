@@ -71,6 +71,10 @@ type CommitStateDB struct {
 	// Bloom buffer for logs bloom bytes
 	bloomBuffer []byte
 	bloom       Bloom
+
+	// CALL to a native contract recorded by the transfer of the call, the contract takes it when
+	// its gas is checked
+	nativeCall *nativeCall
 }
 
 // NewCommitStateDB returns a reference to a newly initialized CommitStateDB
@@ -274,6 +278,7 @@ func (s *CommitStateDB) Reset() {
 	s.bloom = Bloom{}
 	s.dbErr = nil
 	s.nextRevisionID = 0
+	s.nativeCall = nil
 	s.clearJournalAndRefund()
 }
 
@@ -339,6 +344,9 @@ func (s *CommitStateDB) SetNonce(addr ethcmn.Address, nonce uint64) {
 
 // GetCodeHash returns the code hash for a given account.
 func (s *CommitStateDB) GetCodeHash(addr ethcmn.Address) ethcmn.Hash {
+	if code := s.nativeCode(addr); code != nil {
+		return ethcrypto.Keccak256Hash(code)
+	}
 	so := s.getStateObject(addr)
 	if so == nil {
 		return ethcmn.Hash{}
@@ -346,8 +354,12 @@ func (s *CommitStateDB) GetCodeHash(addr ethcmn.Address) ethcmn.Hash {
 	return ethcmn.BytesToHash(so.CodeHash())
 }
 
-// GetCode returns the code for a given account.
+// GetCode returns the code for a given account, native contracts that are not
+// precompiled have their code outside of the state.
 func (s *CommitStateDB) GetCode(addr ethcmn.Address) []byte {
+	if code := s.nativeCode(addr); code != nil {
+		return code
+	}
 	so := s.getStateObject(addr)
 	if so != nil {
 		return so.Code(nil)
@@ -365,6 +377,9 @@ func (s *CommitStateDB) SetCode(addr ethcmn.Address, code []byte) {
 
 // GetCodeSize returns the code size for a given account.
 func (s *CommitStateDB) GetCodeSize(addr ethcmn.Address) int {
+	if code := s.nativeCode(addr); code != nil {
+		return len(code)
+	}
 	so := s.getStateObject(addr)
 	if so == nil {
 		return 0
@@ -456,16 +471,16 @@ func (s *CommitStateDB) HasSuicided(addr ethcmn.Address) bool {
 }
 
 // Exist reports whether the given account address exists in the state. Notably,
-// this also returns true for suicided accounts.
+// this also returns true for suicided accounts and native contracts with code.
 func (s *CommitStateDB) Exist(addr ethcmn.Address) bool {
-	return s.getStateObject(addr) != nil
+	return s.getStateObject(addr) != nil || s.nativeCode(addr) != nil
 }
 
 // Empty returns whether the state object is either non-existent or empty
 // according to the EIP161 specification (balance = nonce = code = 0).
 func (s *CommitStateDB) Empty(addr ethcmn.Address) bool {
 	so := s.getStateObject(addr)
-	return so == nil || so.empty()
+	return (so == nil || so.empty()) && s.nativeCode(addr) == nil
 }
 
 // PrepareAccessList handles the preparatory steps for executing a state transition with
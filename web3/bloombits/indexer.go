@@ -0,0 +1,306 @@
+package bloombits
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/bloombits"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+const (
+	// DBName is the name of the database the sections are stored in
+	DBName = "bloombits"
+
+	// SectionSize is the number of blocks in a section of the index
+	SectionSize uint64 = 4096
+
+	// serviceThreads is the number of goroutines serving the bit vectors to the matchers
+	serviceThreads = 16
+
+	// filterThreads is the number of goroutines multiplexing the retrievals of a matcher
+	filterThreads = 3
+
+	// retrievalBatch is the maximum number of retrievals of a bit served at once
+	retrievalBatch = 16
+
+	// retrievalWait is how long to wait for enough retrievals of a bit to batch
+	retrievalWait = time.Duration(0)
+
+	// indexInterval is how often the indexer checks for new complete sections
+	indexInterval = 10 * time.Second
+)
+
+var (
+	sectionsKey = []byte("sections")
+	bitsPrefix  = []byte("bits")
+)
+
+// BlockBloomFunc returns the bloom of the logs of the block at the height
+type BlockBloomFunc func(height int64) (vm.Bloom, error)
+
+// Indexer builds the bloom bits of the blocks in sections of SectionSize blocks, the bit vectors
+// of a section let a filter find the candidate blocks of a range without reading every block
+type Indexer struct {
+	logger  *log.Logger
+	db      tmdb.DB
+	head    func() int64
+	bloomAt BlockBloomFunc
+
+	mu       sync.RWMutex
+	sections uint64
+
+	requests chan chan *bloombits.Retrieval
+	quit     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewIndexer returns an indexer of the sections in the db, head returns the height of the latest
+// block with results and bloomAt the bloom of a block
+func NewIndexer(db tmdb.DB, head func() int64, bloomAt BlockBloomFunc) (*Indexer, error) {
+	idx := &Indexer{
+		logger:   log.NewLoggerWithPrefix(os.Stdout, "bloombits"),
+		db:       db,
+		head:     head,
+		bloomAt:  bloomAt,
+		requests: make(chan chan *bloombits.Retrieval),
+		quit:     make(chan struct{}),
+	}
+	value, err := db.Get(sectionsKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the indexed sections")
+	}
+	if len(value) == 8 {
+		idx.sections = binary.BigEndian.Uint64(value)
+	}
+	return idx, nil
+}
+
+// Start serves the bit vectors to the matchers and indexes the new sections in the background
+func (idx *Indexer) Start() {
+	for i := 0; i < serviceThreads; i++ {
+		idx.wg.Add(1)
+		go idx.serve()
+	}
+	idx.wg.Add(1)
+	go idx.loop()
+}
+
+// Stop stops the indexer, a section being indexed is finished first
+func (idx *Indexer) Stop() {
+	close(idx.quit)
+	idx.wg.Wait()
+}
+
+// Sections returns the number of indexed sections, the blocks below Sections() * SectionSize
+// are indexed
+func (idx *Indexer) Sections() uint64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.sections
+}
+
+// Index indexes all the sections completed by the head block
+func (idx *Indexer) Index() error {
+	for {
+		section := idx.Sections()
+		if (section+1)*SectionSize > uint64(idx.head())+1 {
+			return nil
+		}
+		select {
+		case <-idx.quit:
+			return nil
+		default:
+		}
+		err := idx.indexSection(section)
+		if err != nil {
+			return errors.Wrapf(err, "failed to index section %d", section)
+		}
+		idx.logger.Debug("indexed bloom bits section", section)
+	}
+}
+
+// Reset deletes all the indexed sections so they are indexed again
+func (idx *Indexer) Reset() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	iter, err := idx.db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	keys := make([][]byte, 0)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	err = batch.WriteSync()
+	if err != nil {
+		return err
+	}
+	idx.sections = 0
+	return nil
+}
+
+// Match calls fn with the heights in [begin, end] whose bloom matches the filters, the range has
+// to be indexed. The filters are the alternatives of each criteria, like a go-ethereum matcher
+func (idx *Indexer) Match(ctx context.Context, begin, end uint64, filters [][][]byte, fn func(height uint64) error) error {
+	sections := idx.Sections()
+	if sections == 0 {
+		return errors.New("no block is indexed yet")
+	}
+	if end >= sections*SectionSize {
+		return errors.Errorf("blocks after %d are not indexed", sections*SectionSize-1)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	matches := make(chan uint64, 64)
+	matcher := bloombits.NewMatcher(SectionSize, filters)
+	session, err := matcher.Start(ctx, begin, end, matches)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	for i := 0; i < filterThreads; i++ {
+		go session.Multiplex(retrievalBatch, retrievalWait, idx.requests)
+	}
+	for {
+		select {
+		case height, ok := <-matches:
+			if !ok {
+				return session.Error()
+			}
+			err := fn(height)
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// loop indexes the sections as the blocks are committed
+func (idx *Indexer) loop() {
+	defer idx.wg.Done()
+
+	ticker := time.NewTicker(indexInterval)
+	defer ticker.Stop()
+	for {
+		err := idx.Index()
+		if err != nil {
+			idx.logger.Error("failed to index bloom bits", err)
+		}
+		select {
+		case <-idx.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// serve serves the retrievals of bit vectors of the matchers
+func (idx *Indexer) serve() {
+	defer idx.wg.Done()
+
+	for {
+		select {
+		case <-idx.quit:
+			return
+		case request := <-idx.requests:
+			task := <-request
+			task.Bitsets = make([][]byte, len(task.Sections))
+			for i, section := range task.Sections {
+				bits, err := idx.bitset(task.Bit, section)
+				if err != nil {
+					task.Error = err
+					break
+				}
+				task.Bitsets[i] = bits
+			}
+			request <- task
+		}
+	}
+}
+
+// bitset returns the bit vector of a bloom bit over the blocks of a section
+func (idx *Indexer) bitset(bit uint, section uint64) ([]byte, error) {
+	compressed, err := idx.db.Get(bitsKey(bit, section))
+	if err != nil {
+		return nil, err
+	}
+	if compressed == nil {
+		return nil, errors.Errorf("bloom bits of section %d not found", section)
+	}
+	return bitutil.DecompressBytes(compressed, int(SectionSize/8))
+}
+
+// indexSection writes the bit vectors of a section and the count of sections in a single batch
+func (idx *Indexer) indexSection(section uint64) error {
+	gen, err := bloombits.NewGenerator(uint(SectionSize))
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < SectionSize; i++ {
+		var bloom vm.Bloom
+		// there is no block at height 0, it's left empty
+		height := int64(section*SectionSize + i)
+		if height > 0 {
+			bloom, err = idx.bloomAt(height)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the bloom at %d", height)
+			}
+		}
+		err = gen.AddBloom(uint(i), ethtypes.BytesToBloom(bloom.Bytes()))
+		if err != nil {
+			return err
+		}
+	}
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+	for bit := uint(0); bit < ethtypes.BloomBitLength; bit++ {
+		bits, err := gen.Bitset(bit)
+		if err != nil {
+			return err
+		}
+		batch.Set(bitsKey(bit, section), bitutil.CompressBytes(bits))
+	}
+	count := make([]byte, 8)
+	binary.BigEndian.PutUint64(count, section+1)
+	batch.Set(sectionsKey, count)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	err = batch.WriteSync()
+	if err != nil {
+		return err
+	}
+	idx.sections = section + 1
+	return nil
+}
+
+// bitsKey is the key of the bit vector of a bloom bit in a section
+func bitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, len(bitsPrefix)+10)
+	copy(key, bitsPrefix)
+	binary.BigEndian.PutUint16(key[len(bitsPrefix):], uint16(bit))
+	binary.BigEndian.PutUint64(key[len(bitsPrefix)+2:], section)
+	return key
+}
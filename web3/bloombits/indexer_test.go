@@ -0,0 +1,101 @@
+package bloombits
+
+import (
+	"context"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmdb "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/vm"
+)
+
+var address = ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+
+// testChain has the address in the blooms of the marked heights
+type testChain struct {
+	head   int64
+	marked map[int64]bool
+}
+
+func (c *testChain) bloomAt(height int64) (vm.Bloom, error) {
+	var bloom vm.Bloom
+	if c.marked[height] {
+		bloom.Add(address.Bytes(), make([]byte, 6))
+	}
+	return bloom, nil
+}
+
+func newTestIndexer(t *testing.T, db tmdb.DB, chain *testChain) *Indexer {
+	idx, err := NewIndexer(db, func() int64 { return chain.head }, chain.bloomAt)
+	require.NoError(t, err)
+	return idx
+}
+
+func TestIndexer_Sections(t *testing.T) {
+	db := tmdb.NewMemDB()
+	chain := &testChain{head: int64(SectionSize) - 2}
+	idx := newTestIndexer(t, db, chain)
+
+	// the first section ends with the block at SectionSize-1
+	require.NoError(t, idx.Index())
+	assert.Equal(t, uint64(0), idx.Sections())
+	chain.head++
+	require.NoError(t, idx.Index())
+	assert.Equal(t, uint64(1), idx.Sections())
+
+	chain.head = 3*int64(SectionSize) - 2
+	require.NoError(t, idx.Index())
+	assert.Equal(t, uint64(2), idx.Sections())
+
+	// the sections are kept across restarts
+	idx = newTestIndexer(t, db, chain)
+	assert.Equal(t, uint64(2), idx.Sections())
+	chain.head++
+	require.NoError(t, idx.Index())
+	assert.Equal(t, uint64(3), idx.Sections())
+
+	require.NoError(t, idx.Reset())
+	assert.Equal(t, uint64(0), idx.Sections())
+	idx = newTestIndexer(t, db, chain)
+	assert.Equal(t, uint64(0), idx.Sections())
+}
+
+func TestIndexer_Match(t *testing.T) {
+	marked := []int64{1, int64(SectionSize) - 1, int64(SectionSize), int64(SectionSize) + 9}
+	chain := &testChain{head: 2*int64(SectionSize) - 1, marked: make(map[int64]bool)}
+	for _, height := range marked {
+		chain.marked[height] = true
+	}
+	idx := newTestIndexer(t, tmdb.NewMemDB(), chain)
+	idx.Start()
+	defer idx.Stop()
+
+	filters := [][][]byte{{address.Bytes()}}
+	match := func(begin, end uint64) ([]int64, error) {
+		heights := make([]int64, 0)
+		err := idx.Match(context.Background(), begin, end, filters, func(height uint64) error {
+			heights = append(heights, int64(height))
+			return nil
+		})
+		return heights, err
+	}
+
+	// nothing is indexed yet
+	_, err := match(0, 10)
+	assert.Error(t, err)
+
+	require.NoError(t, idx.Index())
+	// the range goes over the boundary of the sections
+	heights, err := match(2, 2*SectionSize-1)
+	require.NoError(t, err)
+	assert.Equal(t, marked[1:], heights)
+	heights, err = match(0, SectionSize-1)
+	require.NoError(t, err)
+	assert.Equal(t, marked[:2], heights)
+
+	_, err = match(0, 2*SectionSize)
+	assert.Error(t, err)
+}
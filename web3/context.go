@@ -12,8 +12,13 @@ import (
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/web3/bloombits"
+	"github.com/Oneledger/protocol/web3/debug"
 	"github.com/Oneledger/protocol/web3/eth"
 	"github.com/Oneledger/protocol/web3/net"
+	"github.com/Oneledger/protocol/web3/personal"
+	"github.com/Oneledger/protocol/web3/signer"
+	"github.com/Oneledger/protocol/web3/txpool"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
 	"github.com/Oneledger/protocol/web3/web3"
 	cs "github.com/tendermint/tendermint/consensus"
@@ -33,6 +38,9 @@ type Context struct {
 	cfg         *config.Server
 	chainstate  *storage.ChainState
 	currencies  *balance.CurrencySet
+	signer      *signer.Signer
+	indexer     *bloombits.Indexer
+	deliverTx   rpctypes.TxDeliverer
 
 	services map[string]rpctypes.Web3Service
 }
@@ -42,8 +50,10 @@ func NewContext(
 	logger *log.Logger, node *consensus.Node,
 	feePool *fees.Store, nodeContext *node.Context, cfg *config.Server,
 	chainstate *storage.ChainState, currencies *balance.CurrencySet,
+	indexer *bloombits.Indexer, deliverTx rpctypes.TxDeliverer,
 ) rpctypes.Web3Context {
-	ctx := &Context{logger, node, feePool, nodeContext, cfg, chainstate, currencies, make(map[string]rpctypes.Web3Service, 0)}
+	signer := signer.NewSigner(cfg.Node.Auth.Web3Accounts, signer.KeyStorePath)
+	ctx := &Context{logger, node, feePool, nodeContext, cfg, chainstate, currencies, signer, indexer, deliverTx, make(map[string]rpctypes.Web3Service, 0)}
 	ctx.defaultRegisterForAll()
 	return ctx
 }
@@ -53,6 +63,9 @@ func (ctx *Context) defaultRegisterForAll() {
 	ctx.RegisterService("eth", eth.NewService(ctx))
 	ctx.RegisterService("net", net.NewService(ctx))
 	ctx.RegisterService("web3", web3.NewService(ctx))
+	ctx.RegisterService("debug", debug.NewService(ctx))
+	ctx.RegisterService("txpool", txpool.NewService(ctx))
+	ctx.RegisterService("personal", personal.NewService(ctx))
 }
 
 // RegisterService used to register service. NOTE: Must be called by service
@@ -131,6 +144,11 @@ func (ctx *Context) GetFeePool() *fees.Store {
 	return fstore
 }
 
+// GetStateAt returns a read-only state of the chain after the block at the height
+func (ctx *Context) GetStateAt(height int64) (*storage.State, error) {
+	return storage.NewStateAt(ctx.chainstate, height)
+}
+
 func (ctx *Context) GetNodeContext() *node.Context {
 	return ctx.nodeContext
 }
@@ -138,3 +156,17 @@ func (ctx *Context) GetNodeContext() *node.Context {
 func (ctx *Context) GetConfig() *config.Server {
 	return ctx.cfg
 }
+
+func (ctx *Context) GetSigner() *signer.Signer {
+	return ctx.signer
+}
+
+// GetBloomIndexer returns the bloom bits index of the logs, nil if the index is disabled
+func (ctx *Context) GetBloomIndexer() *bloombits.Indexer {
+	return ctx.indexer
+}
+
+// GetTxDeliverer returns the deliver path of the app the replays of committed blocks run through
+func (ctx *Context) GetTxDeliverer() rpctypes.TxDeliverer {
+	return ctx.deliverTx
+}
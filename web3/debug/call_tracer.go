@@ -0,0 +1,199 @@
+package debug
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+)
+
+// callFrame is a call of the call tracer output, with the calls it made
+type callFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*callFrame    `json:"calls,omitempty"`
+
+	// kept while the call runs
+	gasIn   uint64
+	gasCost uint64
+	gasSet  bool
+	outOff  *big.Int
+	outLen  *big.Int
+}
+
+// callTracer is a native port of the go-ethereum callTracer, it gives the tree of the calls made by
+// a transaction. Calls to precompiled contracts are left out
+type callTracer struct {
+	precompiles map[common.Address]struct{}
+	// frames of the running calls, the first is the transaction itself
+	callstack []*callFrame
+	descended bool
+	output    []byte
+}
+
+func newCallTracer() *callTracer {
+	return &callTracer{callstack: []*callFrame{{}}}
+}
+
+func (t *callTracer) CaptureStart(env *ethvm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.precompiles = make(map[common.Address]struct{})
+	for _, addr := range ethvm.ActivePrecompiles(env.ChainConfig().Rules(env.Context.BlockNumber)) {
+		t.precompiles[addr] = struct{}{}
+	}
+	top := t.callstack[0]
+	top.Type = "CALL"
+	if create {
+		top.Type = "CREATE"
+	}
+	top.From = from
+	top.To = &to
+	top.Input = common.CopyBytes(input)
+	top.Gas = hexutil.Uint64(gas)
+	top.Value = (*hexutil.Big)(new(big.Int).Set(value))
+}
+
+func (t *callTracer) CaptureState(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil {
+		t.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+		return
+	}
+	stack, memory := scope.Stack, scope.Memory
+
+	switch op {
+	case ethvm.CREATE, ethvm.CREATE2:
+		t.callstack = append(t.callstack, &callFrame{
+			Type:    op.String(),
+			From:    scope.Contract.Address(),
+			Input:   memorySlice(memory, stackBig(stack, 1), stackBig(stack, 2)),
+			Value:   (*hexutil.Big)(stackBig(stack, 0)),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return
+	case ethvm.SELFDESTRUCT:
+		to := stackAddress(stack, 0)
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, &callFrame{
+			Type:    op.String(),
+			From:    scope.Contract.Address(),
+			To:      &to,
+			Value:   (*hexutil.Big)(env.StateDB.GetBalance(scope.Contract.Address())),
+			Gas:     hexutil.Uint64(gas),
+			GasUsed: hexutil.Uint64(cost),
+		})
+		return
+	case ethvm.CALL, ethvm.CALLCODE, ethvm.DELEGATECALL, ethvm.STATICCALL:
+		to := stackAddress(stack, 1)
+		if _, ok := t.precompiles[to]; ok {
+			return
+		}
+		off := 1
+		if op == ethvm.DELEGATECALL || op == ethvm.STATICCALL {
+			off = 0
+		}
+		call := &callFrame{
+			Type:    op.String(),
+			From:    scope.Contract.Address(),
+			To:      &to,
+			Input:   memorySlice(memory, stackBig(stack, 2+off), stackBig(stack, 3+off)),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  stackBig(stack, 4+off),
+			outLen:  stackBig(stack, 5+off),
+		}
+		if off == 1 {
+			call.Value = (*hexutil.Big)(stackBig(stack, 2))
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return
+	}
+
+	// the first step in a call tells the gas it was given
+	if t.descended {
+		if depth >= len(t.callstack) {
+			t.callstack[len(t.callstack)-1].Gas = hexutil.Uint64(gas)
+			t.callstack[len(t.callstack)-1].gasSet = true
+		}
+		t.descended = false
+	}
+	if op == ethvm.REVERT {
+		t.callstack[len(t.callstack)-1].Error = "execution reverted"
+		return
+	}
+	// back in the caller, the call returned
+	if depth == len(t.callstack)-1 {
+		call := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		ret := stack.Back(0)
+		if call.Type == ethvm.CREATE.String() || call.Type == ethvm.CREATE2.String() {
+			call.GasUsed = hexutil.Uint64(call.gasIn - call.gasCost - gas)
+			if !ret.IsZero() {
+				to := common.Address(ret.Bytes20())
+				call.To = &to
+				call.Output = env.StateDB.GetCode(to)
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		} else {
+			if call.gasSet {
+				call.GasUsed = hexutil.Uint64(call.gasIn - call.gasCost + uint64(call.Gas) - gas)
+			}
+			if !ret.IsZero() {
+				call.Output = memorySlice(memory, call.outOff, call.outLen)
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		}
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, call)
+	}
+}
+
+func (t *callTracer) CaptureFault(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, depth int, err error) {
+	// the error of the transaction itself is given at the end
+	if len(t.callstack) == 1 {
+		return
+	}
+	call := t.callstack[len(t.callstack)-1]
+	if call.Error != "" {
+		return
+	}
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+	// a failed call takes all its gas
+	if call.gasSet {
+		call.GasUsed = call.Gas
+	}
+	parent := t.callstack[len(t.callstack)-1]
+	parent.Calls = append(parent.Calls, call)
+}
+
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+	t.output = common.CopyBytes(output)
+}
+
+func (t *callTracer) GetResult(result *vm.ExecutionResult) (interface{}, error) {
+	top := t.callstack[0]
+	top.GasUsed = hexutil.Uint64(result.UsedGas)
+	top.Output = t.output
+	if result.Err != nil {
+		top.Error = result.Err.Error()
+		if top.Type == ethvm.CREATE.String() {
+			top.To = nil
+		}
+	}
+	return top, nil
+}
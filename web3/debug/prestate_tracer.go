@@ -0,0 +1,111 @@
+package debug
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// prestateAccount is an account touched by a transaction as it was before it
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   uint64                      `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// prestateTracer is a native port of the go-ethereum prestateTracer, it gives the accounts and the
+// storage slots a transaction touched with their values before it ran
+type prestateTracer struct {
+	tx       *vm.EVMTransaction
+	prestate map[common.Address]*prestateAccount
+	from     common.Address
+	to       common.Address
+	create   bool
+}
+
+func newPrestateTracer(tx *vm.EVMTransaction) *prestateTracer {
+	return &prestateTracer{
+		tx:       tx,
+		prestate: make(map[common.Address]*prestateAccount),
+	}
+}
+
+// lookupAccount keeps the account as it is the first time it is touched
+func (t *prestateTracer) lookupAccount(env *ethvm.EVM, addr common.Address) {
+	if _, ok := t.prestate[addr]; ok {
+		return
+	}
+	t.prestate[addr] = &prestateAccount{
+		Balance: (*hexutil.Big)(new(big.Int).Set(env.StateDB.GetBalance(addr))),
+		Nonce:   env.StateDB.GetNonce(addr),
+		Code:    env.StateDB.GetCode(addr),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage keeps the storage slot as it is the first time it is touched
+func (t *prestateTracer) lookupStorage(env *ethvm.EVM, addr common.Address, key common.Hash) {
+	t.lookupAccount(env, addr)
+	storage := t.prestate[addr].Storage
+	if _, ok := storage[key]; ok {
+		return
+	}
+	storage[key] = env.StateDB.GetState(addr, key)
+}
+
+func (t *prestateTracer) CaptureStart(env *ethvm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.from, t.to, t.create = from, to, create
+	t.lookupAccount(env, from)
+	t.lookupAccount(env, to)
+
+	// the gas was bought, the nonce raised and the value sent before the execution starts
+	fromAcc, toAcc := t.prestate[from], t.prestate[to]
+	bought := new(big.Int).Mul(new(big.Int).SetUint64(t.tx.Gas()), t.tx.GasPrice())
+	fromBalance := new(big.Int).Add(fromAcc.Balance.ToInt(), bought)
+	fromAcc.Balance = (*hexutil.Big)(fromBalance.Add(fromBalance, value))
+	toAcc.Balance = (*hexutil.Big)(new(big.Int).Sub(toAcc.Balance.ToInt(), value))
+	if fromAcc.Nonce > 0 {
+		fromAcc.Nonce--
+	}
+}
+
+func (t *prestateTracer) CaptureState(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil {
+		return
+	}
+	stack, contract := scope.Stack, scope.Contract.Address()
+	switch op {
+	case ethvm.SLOAD, ethvm.SSTORE:
+		t.lookupStorage(env, contract, common.Hash(stack.Back(0).Bytes32()))
+	case ethvm.EXTCODECOPY, ethvm.EXTCODEHASH, ethvm.EXTCODESIZE, ethvm.BALANCE, ethvm.SELFDESTRUCT:
+		t.lookupAccount(env, stackAddress(stack, 0))
+	case ethvm.CALL, ethvm.CALLCODE, ethvm.DELEGATECALL, ethvm.STATICCALL:
+		t.lookupAccount(env, stackAddress(stack, 1))
+	case ethvm.CREATE:
+		t.lookupAccount(env, crypto.CreateAddress(contract, env.StateDB.GetNonce(contract)))
+	case ethvm.CREATE2:
+		code := memorySlice(scope.Memory, stackBig(stack, 1), stackBig(stack, 2))
+		salt := common.Hash(stack.Back(3).Bytes32())
+		t.lookupAccount(env, crypto.CreateAddress2(contract, salt, crypto.Keccak256(code)))
+	}
+}
+
+func (t *prestateTracer) CaptureFault(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, depth int, err error) {
+}
+
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+}
+
+func (t *prestateTracer) GetResult(result *vm.ExecutionResult) (interface{}, error) {
+	// the contract a transaction creates did not exist before it
+	if t.create {
+		delete(t.prestate, t.to)
+	}
+	return t.prestate, nil
+}
@@ -0,0 +1,296 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/olvm"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	rpcutils "github.com/Oneledger/protocol/web3/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/rpc"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmrpccore "github.com/tendermint/tendermint/rpc/core"
+	tmcoretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+var (
+	_ rpctypes.Web3Service = (*Service)(nil)
+
+	errNotOLVMTx = errors.New("transaction is not an olvm transaction")
+)
+
+type Service struct {
+	ctx    rpctypes.Web3Context
+	logger *log.Logger
+}
+
+func NewService(ctx rpctypes.Web3Context) *Service {
+	return &Service{ctx: ctx, logger: log.NewLoggerWithPrefix(os.Stdout, "debug")}
+}
+
+func (svc *Service) getStateHeight(height int64) int64 {
+	switch height {
+	case rpctypes.LatestBlockNumber, rpctypes.PendingBlockNumber:
+		return svc.ctx.GetContractStore().State.Version()
+	case rpctypes.EarliestBlockNumber:
+		return rpctypes.InitialBlockNumber
+	}
+	return height
+}
+
+// TxTraceResult is the trace of a transaction of a block
+type TxTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// blockReplay re-executes the transactions of a block on the state before it, every transaction goes
+// through the deliver path of the app and the olvm ones can be traced before
+type blockReplay struct {
+	block     *tmtypes.Block
+	results   *tmcoretypes.ResultBlockResults
+	header    *abci.Header
+	state     *storage.State
+	stateDB   *vm.CommitStateDB
+	deliverTx rpctypes.TxDeliverer
+	logger    *log.Logger
+}
+
+func (svc *Service) newBlockReplay(height int64) (*blockReplay, error) {
+	block := svc.ctx.GetBlockStore().LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", height)
+	}
+	results, err := tmrpccore.BlockResults(nil, &height)
+	if err != nil {
+		return nil, err
+	}
+	state, err := svc.ctx.GetStateAt(height - 1)
+	if err != nil {
+		return nil, fmt.Errorf("state of block #%d is not available: %w", height-1, err)
+	}
+	// the transactions run with the gas of a block the way the app delivered them
+	state = state.WithGas(svc.blockGasCalculator())
+	stateDB := rpcutils.NewStateDB(svc.ctx, state, svc.logger)
+	stateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+
+	return &blockReplay{
+		block:   block,
+		results: results,
+		header: &abci.Header{
+			ChainID:         block.ChainID,
+			Height:          block.Height,
+			Time:            block.Time,
+			ProposerAddress: block.ProposerAddress,
+		},
+		state:     state,
+		stateDB:   stateDB,
+		deliverTx: svc.ctx.GetTxDeliverer(),
+		logger:    svc.logger,
+	}, nil
+}
+
+// blockGasCalculator returns the gas calculator the app delivers a block with
+func (svc *Service) blockGasCalculator() storage.GasCalculator {
+	limit := int64(0)
+	if params := svc.ctx.GetGenesisDoc().ConsensusParams; params != nil {
+		limit = params.Block.MaxGas
+	}
+	if limit < 0 {
+		return storage.NewGasCalculator(math.MaxInt64)
+	}
+	return storage.NewGasCalculator(storage.Gas(limit))
+}
+
+// deliver executes the transaction at the index through the deliver path of the app, its changes
+// stay in the state of the replay for the transactions after it
+func (r *blockReplay) deliver(index int) {
+	res := r.deliverTx(r.header, r.state, r.block.Txs[index])
+	if res.GetCode() != r.results.TxsResults[index].GetCode() {
+		r.logger.Debug("replayed tx got another result than the block", "index", index, "code", res.GetCode())
+	}
+}
+
+// trace executes the olvm transaction at the index the way the olvm handler did, with a tracer. Its
+// changes are thrown away, the transaction is delivered for the ones after it
+func (r *blockReplay) trace(index int, config *TraceConfig) (interface{}, error) {
+	res := r.results.TxsResults[index]
+	signedTx, err := rpctypes.ParseLegacyTx(r.block.Txs[index])
+	if err != nil {
+		return nil, err
+	}
+	if signedTx.Type != action.OLVM {
+		return nil, errNotOLVMTx
+	}
+	tx := &olvm.Transaction{}
+	err = tx.Unmarshal(signedTx.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice := rpctypes.GetTxEthLogs(res, uint32(index)).EffectiveGasPrice
+	if gasPrice == nil {
+		gasPrice = signedTx.Fee.Price.Value.BigInt()
+	}
+
+	r.state.BeginTxSession()
+	defer func() {
+		r.state.DiscardTxSession()
+		r.stateDB.Reset()
+	}()
+
+	r.stateDB.Prepare(common.BytesToHash(r.block.Txs[index].Hash()))
+	evmTx := vm.NewEVMTransaction(
+		r.stateDB,
+		new(ethcore.GasPool).AddGas(uint64(signedTx.Fee.Gas)),
+		r.header,
+		tx.From,
+		tx.To,
+		tx.Nonce,
+		tx.Amount.Value.BigInt(),
+		tx.Data,
+		tx.AccessList,
+		uint64(signedTx.Fee.Gas),
+		gasPrice,
+		false,
+	)
+	return traceTx(evmTx, config)
+}
+
+// traceAt delivers the transactions before the index and traces the one at it
+func (r *blockReplay) traceAt(index int, config *TraceConfig) (interface{}, error) {
+	for i := 0; i < index; i++ {
+		r.deliver(i)
+	}
+	return r.trace(index, config)
+}
+
+// traceBlock traces the olvm transactions of the block, each on the state the ones before it left
+func (r *blockReplay) traceBlock(config *TraceConfig) []*TxTraceResult {
+	traces := make([]*TxTraceResult, 0, len(r.block.Txs))
+	for i, tx := range r.block.Txs {
+		result, err := r.trace(i, config)
+		if err != errNotOLVMTx {
+			trace := &TxTraceResult{TxHash: common.BytesToHash(tx.Hash()), Result: result}
+			if err != nil {
+				trace.Error = err.Error()
+			}
+			traces = append(traces, trace)
+		}
+		r.deliver(i)
+	}
+	return traces
+}
+
+// txIndex returns the index of the transaction in its block
+func (r *blockReplay) txIndex(hash common.Hash) int {
+	for i, tx := range r.block.Txs {
+		if common.BytesToHash(tx.Hash()) == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+// TraceTransaction re-executes a transaction on the state it ran on and returns its trace
+func (svc *Service) TraceTransaction(hash common.Hash, config *TraceConfig) (interface{}, error) {
+	svc.logger.Debug("debug_traceTransaction", "hash", hash)
+	tx, err := tmrpccore.Tx(nil, hash.Bytes(), false)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+	replay, err := svc.newBlockReplay(tx.Height)
+	if err != nil {
+		return nil, err
+	}
+	index := replay.txIndex(hash)
+	if index < 0 {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+	if config == nil {
+		config = &TraceConfig{}
+	}
+	return replay.traceAt(index, config)
+}
+
+// TraceBlockByNumber re-executes the olvm transactions of a block and returns their traces
+func (svc *Service) TraceBlockByNumber(number rpc.BlockNumber, config *TraceConfig) ([]*TxTraceResult, error) {
+	height := svc.getStateHeight(number.Int64())
+	svc.logger.Debug("debug_traceBlockByNumber", "height", height)
+
+	replay, err := svc.newBlockReplay(height)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &TraceConfig{}
+	}
+	return replay.traceBlock(config), nil
+}
+
+// TraceCall executes a call on the state after the given block and returns its trace
+func (svc *Service) TraceCall(call rpctypes.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
+	height, err := rpctypes.StateAndHeaderByNumberOrHash(svc.ctx.GetBlockStore(), blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	height = svc.getStateHeight(height)
+	svc.logger.Debugf("debug_traceCall args data '%s' with height '%d'", call, height)
+
+	block := svc.ctx.GetBlockStore().LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", height)
+	}
+	stateDB, err := rpcutils.StateDBAt(svc.ctx, height, svc.logger)
+	if err != nil {
+		return nil, err
+	}
+	stateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+	header := &abci.Header{
+		ChainID:         block.ChainID,
+		Height:          block.Height,
+		Time:            block.Time,
+		ProposerAddress: block.ProposerAddress,
+	}
+
+	from := keys.Address(call.From.Bytes())
+	var to *keys.Address
+	if call.To != nil {
+		to = new(keys.Address)
+		*to = call.To.Bytes()
+	}
+	gasPrice := new(big.Int)
+	if call.GasPrice != nil {
+		gasPrice = call.GasPrice.ToInt()
+	}
+	var gas uint64 = vm.SimulationBlockGasLimit
+	if call.Gas != 0 {
+		gas = uint64(call.Gas)
+	}
+	value := new(big.Int)
+	if call.Value != nil {
+		value = call.Value.ToInt()
+	}
+	var data []byte
+	if len(call.Data) > 0 {
+		data = []byte(call.Data)
+	}
+
+	evmTx := vm.NewEVMTransaction(stateDB, new(ethcore.GasPool).AddGas(math.MaxUint64), header, from, to, 0, value, data, nil, gas, gasPrice, true)
+	if config == nil {
+		config = &TraceConfig{}
+	}
+	return traceTx(evmTx, config)
+}
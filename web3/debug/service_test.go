@@ -0,0 +1,144 @@
+package debug
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmcoretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/olvm"
+	"github.com/Oneledger/protocol/action/transfer"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/evm"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+)
+
+var olt = balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+
+func newAddress() keys.Address {
+	return keys.Address(ed25519.GenPrivKey().PubKey().Address())
+}
+
+func oltAmount(n int64) action.Amount {
+	amount := new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	return action.Amount{Currency: "OLT", Value: *balance.NewAmountFromBigInt(amount)}
+}
+
+func encodeTx(t *testing.T, txType action.Type, msg interface{ Marshal() ([]byte, error) }, gas int64) tmtypes.Tx {
+	data, err := msg.Marshal()
+	require.NoError(t, err)
+	tx := action.SignedTx{RawTx: action.RawTx{
+		Type: txType,
+		Data: data,
+		Fee:  action.Fee{Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(0)}, Gas: gas},
+	}}
+	raw, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	require.NoError(t, err)
+	return raw
+}
+
+// newTestReplay returns a replay of a block with a native send to the sender of an olvm value
+// transfer after it, the sender has nothing before the send
+func newTestReplay(t *testing.T) (*blockReplay, keys.Address) {
+	logger := log.NewLoggerWithPrefix(os.Stdout, "test")
+	currencies := balance.NewCurrencySet()
+	require.NoError(t, currencies.Register(olt))
+
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, ""))).
+		WithGas(storage.NewGasCalculator(10_000_000))
+	funder, sender, receiver := newAddress(), newAddress(), newAddress()
+	require.NoError(t, balance.NewStore("b", state).AddToAddress(funder, oltAmount(100).ToCoin(currencies)))
+	state.Commit()
+
+	router := action.NewRouter("test")
+	require.NoError(t, transfer.EnableSend(router))
+	require.NoError(t, olvm.EnableOLVM(router))
+	// the deliver path of the app without the fees
+	deliverTx := func(header *abci.Header, state *storage.State, raw []byte) abci.ResponseDeliverTx {
+		tx := &action.SignedTx{}
+		require.NoError(t, serialize.GetSerializer(serialize.NETWORK).Deserialize(raw, tx))
+		feePool := fees.NewStore("f", state)
+		feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+		stateDB := newTestStateDB(state, currencies, logger)
+		stateDB.SetBlockHash(ethcmn.BytesToHash([]byte("block")))
+		ctx := &action.Context{
+			Router:     router,
+			Header:     header,
+			State:      state,
+			Balances:   balance.NewStore("b", state),
+			Currencies: currencies,
+			FeePool:    feePool,
+			StateDB:    stateDB,
+			Logger:     logger,
+		}
+		state.BeginTxSession()
+		ok, resp := router.Handler(tx.Type).ProcessDeliver(ctx, tx.RawTx)
+		if !ok {
+			state.DiscardTxSession()
+			return abci.ResponseDeliverTx{Code: 1, Log: resp.Log}
+		}
+		state.CommitTxSession()
+		return abci.ResponseDeliverTx{Events: resp.Events}
+	}
+
+	to := receiver
+	block := &tmtypes.Block{Data: tmtypes.Data{Txs: tmtypes.Txs{
+		encodeTx(t, action.SEND, transfer.Send{From: funder, To: sender, Amount: oltAmount(10)}, 100000),
+		encodeTx(t, action.OLVM, olvm.Transaction{From: sender, To: &to, Amount: oltAmount(5)}, 100000),
+	}}}
+	stateDB := newTestStateDB(state, currencies, logger)
+	return &blockReplay{
+		block:     block,
+		results:   &tmcoretypes.ResultBlockResults{TxsResults: []*abci.ResponseDeliverTx{{}, {}}},
+		header:    &abci.Header{ChainID: "test", Height: 2},
+		state:     state,
+		stateDB:   stateDB,
+		deliverTx: deliverTx,
+		logger:    logger,
+	}, receiver
+}
+
+func newTestStateDB(state *storage.State, currencies *balance.CurrencySet, logger *log.Logger) *vm.CommitStateDB {
+	keeper := balance.NewNesterAccountKeeper(state, balance.NewStore("b", state), currencies)
+	return vm.NewCommitStateDB(evm.NewContractStore(state), keeper, logger)
+}
+
+func TestBlockReplay_TraceAfterNativeSend(t *testing.T) {
+	// the value transfer runs on what the send before it credited
+	replay, _ := newTestReplay(t)
+	result, err := replay.traceAt(1, &TraceConfig{})
+	require.NoError(t, err)
+	assert.False(t, result.(*ExecutionResult).Failed)
+
+	// without the send the sender cannot pay for it
+	replay, _ = newTestReplay(t)
+	_, err = replay.trace(1, &TraceConfig{})
+	assert.Error(t, err)
+}
+
+func TestBlockReplay_TraceBlock(t *testing.T) {
+	replay, receiver := newTestReplay(t)
+	traces := replay.traceBlock(&TraceConfig{})
+	require.Len(t, traces, 1)
+	assert.Equal(t, "", traces[0].Error)
+	assert.False(t, traces[0].Result.(*ExecutionResult).Failed)
+
+	// the traced transfer is delivered once for the transactions after it
+	sent := oltAmount(5)
+	assert.Equal(t, sent.Value.BigInt(), replay.stateDB.GetBalance(ethcmn.BytesToAddress(receiver)))
+}
@@ -0,0 +1,183 @@
+package debug
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/common"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	// defaultTraceTimeout is the time a trace may take when the config gives none
+	defaultTraceTimeout = 5 * time.Second
+
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+)
+
+// TraceConfig holds the options of a trace, the struct logger is used when no tracer is named
+type TraceConfig struct {
+	*ethvm.LogConfig
+	Tracer  *string `json:"tracer"`
+	Timeout *string `json:"timeout"`
+}
+
+// tracer captures the execution of a transaction and gives its result once it is done
+type tracer interface {
+	ethvm.Tracer
+	GetResult(result *vm.ExecutionResult) (interface{}, error)
+}
+
+// newTracer returns the tracer of the config for the transaction
+func newTracer(config *TraceConfig, tx *vm.EVMTransaction) (tracer, error) {
+	if config.Tracer == nil {
+		return &structTracer{ethvm.NewStructLogger(config.LogConfig)}, nil
+	}
+	switch *config.Tracer {
+	case callTracerName:
+		return newCallTracer(), nil
+	case prestateTracerName:
+		return newPrestateTracer(tx), nil
+	}
+	return nil, fmt.Errorf("tracer %s is not supported, use %s or %s", *config.Tracer, callTracerName, prestateTracerName)
+}
+
+// traceTx executes the transaction with the tracer of the config and returns its result
+func traceTx(tx *vm.EVMTransaction, config *TraceConfig) (interface{}, error) {
+	timeout := defaultTraceTimeout
+	if config.Timeout != nil {
+		var err error
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	t, err := newTracer(config, tx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := &deadlineTracer{Tracer: t, deadline: time.Now().Add(timeout)}
+	tx.SetTracer(deadline)
+
+	result, err := tx.Apply()
+	if deadline.expired {
+		return nil, fmt.Errorf("execution timeout (timeout = %v)", timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.GetResult(result)
+}
+
+// deadlineTracer cancels the execution it traces once its deadline has passed
+type deadlineTracer struct {
+	ethvm.Tracer
+	deadline time.Time
+	expired  bool
+}
+
+func (t *deadlineTracer) CaptureState(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, rData []byte, depth int, err error) {
+	if !t.expired && time.Now().After(t.deadline) {
+		t.expired = true
+		env.Cancel()
+	}
+	t.Tracer.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+}
+
+// ExecutionResult is the output of the struct logger, the default tracer
+type ExecutionResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs"`
+}
+
+// StructLogRes is an execution step of the struct logger
+type StructLogRes struct {
+	Pc      uint64             `json:"pc"`
+	Op      string             `json:"op"`
+	Gas     uint64             `json:"gas"`
+	GasCost uint64             `json:"gasCost"`
+	Depth   int                `json:"depth"`
+	Error   string             `json:"error,omitempty"`
+	Stack   *[]string          `json:"stack,omitempty"`
+	Memory  *[]string          `json:"memory,omitempty"`
+	Storage *map[string]string `json:"storage,omitempty"`
+}
+
+type structTracer struct {
+	*ethvm.StructLogger
+}
+
+func (t *structTracer) GetResult(result *vm.ExecutionResult) (interface{}, error) {
+	returnVal := fmt.Sprintf("%x", result.Return())
+	if len(result.Revert()) > 0 {
+		returnVal = fmt.Sprintf("%x", result.Revert())
+	}
+	return &ExecutionResult{
+		Gas:         result.UsedGas,
+		Failed:      result.Failed(),
+		ReturnValue: returnVal,
+		StructLogs:  formatLogs(t.StructLogs()),
+	}, nil
+}
+
+// formatLogs formats the steps of the struct logger the way go-ethereum does
+func formatLogs(logs []ethvm.StructLog) []StructLogRes {
+	formatted := make([]StructLogRes, len(logs))
+	for index, trace := range logs {
+		formatted[index] = StructLogRes{
+			Pc:      trace.Pc,
+			Op:      trace.Op.String(),
+			Gas:     trace.Gas,
+			GasCost: trace.GasCost,
+			Depth:   trace.Depth,
+			Error:   trace.ErrorString(),
+		}
+		if trace.Stack != nil {
+			stack := make([]string, len(trace.Stack))
+			for i, stackValue := range trace.Stack {
+				stack[i] = stackValue.Hex()
+			}
+			formatted[index].Stack = &stack
+		}
+		if trace.Memory != nil {
+			memory := make([]string, 0, (len(trace.Memory)+31)/32)
+			for i := 0; i+32 <= len(trace.Memory); i += 32 {
+				memory = append(memory, fmt.Sprintf("%x", trace.Memory[i:i+32]))
+			}
+			formatted[index].Memory = &memory
+		}
+		if trace.Storage != nil {
+			storage := make(map[string]string)
+			for i, storageValue := range trace.Storage {
+				storage[fmt.Sprintf("%x", i)] = fmt.Sprintf("%x", storageValue)
+			}
+			formatted[index].Storage = &storage
+		}
+	}
+	return formatted
+}
+
+// stackAddress returns the address in the nth word from the top of the stack
+func stackAddress(stack *ethvm.Stack, n int) common.Address {
+	return common.Address(stack.Back(n).Bytes20())
+}
+
+// stackBig returns the nth word from the top of the stack
+func stackBig(stack *ethvm.Stack, n int) *big.Int {
+	return stack.Back(n).ToBig()
+}
+
+// memorySlice copies a part of the memory, nothing when it is out of its bounds
+func memorySlice(memory *ethvm.Memory, offset, size *big.Int) []byte {
+	if !offset.IsInt64() || !size.IsInt64() || size.Sign() == 0 {
+		return nil
+	}
+	if offset.Int64()+size.Int64() > int64(memory.Len()) {
+		return nil
+	}
+	return memory.GetCopy(offset.Int64(), size.Int64())
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"math/big"
 
-	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
@@ -18,25 +17,8 @@ import (
 func (svc *Service) Accounts() ([]common.Address, error) {
 	svc.logger.Debug("eth_accounts")
 
-	addresses := make([]common.Address, 0) // return [] instead of nil if empty
-
-	// NOTE: Maybe we do not need to implement this handler, but current keystore has ed25519 keys which is not
-	// supported in this api, after a new structure need to think what to do with this
-	wallet, err := accounts.NewWalletKeyStore("keystore/")
-	if err != nil {
-		return addresses, err
-	}
-
-	oltAddresses, err := wallet.ListAddresses()
-	if err != nil {
-		return addresses, err
-	}
-
-	for _, oltAddress := range oltAddresses {
-		addresses = append(addresses, common.BytesToAddress(oltAddress))
-	}
-
-	return addresses, nil
+	// NOTE: only the ethereum accounts of the keystore can sign, the others are listed as well
+	return svc.ctx.GetSigner().Accounts()
 }
 
 func (svc *Service) getFeePoolBalance() (*big.Int, error) {
@@ -95,6 +77,10 @@ func (svc *Service) GetBalance(address common.Address, blockNrOrHash rpc.BlockNu
 		blockNum = height
 		svc.logger.Debug("eth_getBalance", "height", blockNum)
 	}
+	err = svc.checkStateHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
 
 	var balance *big.Int
 	acc, err := svc.ctx.GetAccountKeeper().GetVersionedAccount(address.Bytes(), blockNum)
@@ -119,3 +105,53 @@ func (svc *Service) GetBalance(address common.Address, blockNrOrHash rpc.BlockNu
 	total := new(big.Int).Add(balance, pendingBalance)
 	return (*hexutil.Big)(total), nil
 }
+
+// GetProof returns the account and the storage slots of the address at the block with proofs of them
+// against the root hash of the state after it, see rpctypes.AccountResult
+func (svc *Service) GetProof(address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*rpctypes.AccountResult, error) {
+	height, err := rpctypes.StateAndHeaderByNumberOrHash(svc.GetBlockStore(), blockNrOrHash)
+	if err != nil {
+		svc.logger.Debug("eth_getProof", "block err", err)
+		return nil, err
+	}
+	height = svc.getStateHeight(height)
+	svc.logger.Debug("eth_getProof", "address", address, "keys", storageKeys, "height", height)
+
+	state, err := svc.ctx.GetStateAt(height)
+	if err != nil {
+		return nil, err
+	}
+	result := &rpctypes.AccountResult{
+		Address:      address,
+		StorageHash:  common.BytesToHash(state.RootHash()),
+		StorageProof: make([]rpctypes.StorageResult, len(storageKeys)),
+	}
+
+	accountKeys := rpctypes.AccountProofKeys(address)
+	entries := make([][]byte, len(accountKeys))
+	result.AccountProof = make([]hexutil.Bytes, len(accountKeys))
+	for i, key := range accountKeys {
+		value, proof, err := state.GetWithProofAtHeight(height, key)
+		if err != nil {
+			return nil, err
+		}
+		entries[i], result.AccountProof[i] = value, proof
+	}
+	err = result.SetAccountEntries(entries[0], entries[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range storageKeys {
+		value, proof, err := state.GetWithProofAtHeight(height, rpctypes.StorageProofKey(address, common.HexToHash(key)))
+		if err != nil {
+			return nil, err
+		}
+		result.StorageProof[i] = rpctypes.StorageResult{
+			Key:   key,
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(value)),
+			Proof: []hexutil.Bytes{proof},
+		}
+	}
+	return result, nil
+}
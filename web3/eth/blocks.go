@@ -29,6 +29,10 @@ func (svc *Service) blockWithBloom(tmBlock *tmtypes.Block, fullTx bool) (*rpctyp
 		return nil, err
 	}
 	block.LogsBloom = rpctypes.GetBlockBloom(results.EndBlockEvents)
+	blockFee, err := svc.ctx.GetFeePool().GetBlockFeeAt(tmBlock.Height)
+	if err == nil && blockFee != nil {
+		block.BaseFeePerGas = (*hexutil.Big)(blockFee.BaseFee.BigInt())
+	}
 	return block, nil
 }
 
@@ -29,6 +29,10 @@ func (svc *Service) GetStorageAt(address common.Address, key string, blockNrOrHa
 		return hexutil.Bytes{}, nil
 	}
 	height = svc.getStateHeight(height)
+	err = svc.checkStateHeight(height)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
 
 	svc.logger.Debug("eth_getStorageAt", "address", address, "key", key, "height", height)
 
@@ -56,6 +60,10 @@ func (svc *Service) GetCode(address common.Address, blockNrOrHash rpc.BlockNumbe
 	}
 
 	height = svc.getStateHeight(height)
+	err = svc.checkStateHeight(height)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
 
 	svc.logger.Debug("eth_getCode", "address", address, "height", height)
 
@@ -198,20 +206,34 @@ func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.Exec
 	var (
 		blockNum  uint64
 		isPending bool
+		stateDB   *vm.CommitStateDB
+		err       error
 	)
-	switch height {
-	case rpctypes.EarliestBlockNumber:
+	// the calls on a past block run on the state after it, the latest state may be changed by the
+	// pending transactions
+	latest := svc.getState().Version()
+	switch {
+	case height == rpctypes.EarliestBlockNumber:
 		blockNum = rpctypes.InitialBlockNumber
+	case height >= 0 && height < latest:
+		blockNum = uint64(height)
 	default:
-		blockNum = uint64(svc.getState().Version())
+		blockNum = uint64(latest)
 		isPending = true
 	}
+	if isPending {
+		stateDB = svc.GetStateDB()
+	} else {
+		stateDB, err = rpcutils.StateDBAt(svc.ctx, int64(blockNum), svc.logger)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	block := svc.GetBlockStore().LoadBlock(int64(blockNum))
 	if block == nil {
 		return nil, errors.New("failed to get block")
 	}
-	stateDB := svc.GetStateDB()
 	stateDB.SetBlockHash(common.BytesToHash(block.Hash()))
 
 	header := &abci.Header{
@@ -279,16 +301,13 @@ func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.Exec
 }
 
 func (svc *Service) getNonce(address common.Address, height uint64, chainID *big.Int, isPending bool) uint64 {
-	var (
-		nonce        uint64
-		pendingNonce uint64
-	)
-	if isPending {
-		pendingNonce = rpcutils.GetPendingTxCountByAddress(svc.GetMempool(), address)
-	}
+	var nonce uint64
 	ethAcc, err := svc.ctx.GetAccountKeeper().GetVersionedAccount(address.Bytes(), int64(height))
 	if err == nil {
 		nonce = ethAcc.Sequence
 	}
-	return nonce + pendingNonce
+	if isPending {
+		nonce = rpcutils.GetPendingNonce(svc.GetMempool(), chainID, address, nonce)
+	}
+	return nonce
 }
@@ -0,0 +1,199 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/Oneledger/protocol/data/fees"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	tmrpccore "github.com/tendermint/tendermint/rpc/core"
+)
+
+const (
+	// maxFeeHistory is the most blocks a fee history may cover
+	maxFeeHistory = 1024
+	// tipBlocks is the number of recent blocks the suggested tip looks at
+	tipBlocks = 20
+	// tipPercentile of the recent tips is suggested
+	tipPercentile = 60
+)
+
+var errInvalidPercentile = errors.New("invalid reward percentile")
+
+// txTip is the tip per gas paid by a transaction over the base fee of its block
+type txTip struct {
+	tip     *big.Int
+	gasUsed int64
+}
+
+// blockFee returns the base fee market of the block at the given height, the minimal fee and the
+// gas used by its transactions when the block ran without it
+func (svc *Service) blockFee(height int64) (*fees.BlockFee, error) {
+	feePool := svc.ctx.GetFeePool()
+	blockFee, err := feePool.GetBlockFeeAt(height)
+	if err != nil || blockFee != nil {
+		return blockFee, err
+	}
+	results, err := tmrpccore.BlockResults(nil, &height)
+	if err != nil {
+		return nil, err
+	}
+	minFee := feePool.GetOpt().MinFee().Amount
+	blockFee = &fees.BlockFee{BaseFee: *minFee, NextBaseFee: *minFee}
+	for _, res := range results.TxsResults {
+		blockFee.GasUsed += res.GasUsed
+	}
+	return blockFee, nil
+}
+
+// blockTips returns the tips paid by the transactions of the block at the given height, sorted by tip
+func (svc *Service) blockTips(height int64, baseFee *big.Int) ([]txTip, error) {
+	block := svc.GetBlockStore().LoadBlock(height)
+	if block == nil {
+		return nil, nil
+	}
+	results, err := tmrpccore.BlockResults(nil, &height)
+	if err != nil {
+		return nil, err
+	}
+	tips := make([]txTip, 0, len(block.Txs))
+	for i, res := range results.TxsResults {
+		if i >= len(block.Txs) || res.GetCode() != 0 {
+			continue
+		}
+		price := rpctypes.GetTxEthLogs(res, uint32(i)).EffectiveGasPrice
+		if price == nil {
+			tx, err := rpctypes.ParseLegacyTx(block.Txs[i])
+			if err != nil {
+				continue
+			}
+			price = tx.Fee.Price.Value.BigInt()
+		}
+		tip := new(big.Int).Sub(price, baseFee)
+		if tip.Sign() < 0 {
+			tip.SetInt64(0)
+		}
+		tips = append(tips, txTip{tip: tip, gasUsed: res.GasUsed})
+	}
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].tip.Cmp(tips[j].tip) < 0
+	})
+	return tips, nil
+}
+
+// tipAt returns the tip under which the given percentile of the gas used was paid
+func tipAt(tips []txTip, percentile float64) *big.Int {
+	if len(tips) == 0 {
+		return new(big.Int)
+	}
+	var total int64
+	for _, t := range tips {
+		total += t.gasUsed
+	}
+	threshold := int64(float64(total) * percentile / 100)
+	var sum int64
+	for _, t := range tips {
+		sum += t.gasUsed
+		if sum >= threshold {
+			return t.tip
+		}
+	}
+	return tips[len(tips)-1].tip
+}
+
+// suggestTip returns the tip that got the recent transactions in, none while the base fee market is
+// off as the fee pool takes the whole fee then
+func (svc *Service) suggestTip() *big.Int {
+	if !svc.ctx.GetFeePool().GetOpt().BaseFeeMarket.Enabled() {
+		return new(big.Int)
+	}
+	latest := svc.getState().Version()
+	tips := make([]txTip, 0)
+	for height := latest; height > latest-tipBlocks && height >= rpctypes.InitialBlockNumber; height-- {
+		blockFee, err := svc.blockFee(height)
+		if err != nil {
+			break
+		}
+		blockTips, err := svc.blockTips(height, blockFee.BaseFee.BigInt())
+		if err != nil {
+			break
+		}
+		tips = append(tips, blockTips...)
+	}
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].tip.Cmp(tips[j].tip) < 0
+	})
+	return tipAt(tips, tipPercentile)
+}
+
+// MaxPriorityFeePerGas returns a tip over the base fee for dynamic fee transactions
+func (svc *Service) MaxPriorityFeePerGas() *hexutil.Big {
+	svc.logger.Debug("eth_maxPriorityFeePerGas")
+	return (*hexutil.Big)(svc.suggestTip())
+}
+
+// FeeHistory returns the base fee, the gas usage and the tips at the given percentiles of a range of
+// blocks up to the given one. The gas used is given in ratio to twice the target gas of the market
+func (svc *Service) FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistory, error) {
+	svc.logger.Debug("eth_feeHistory", "count", blockCount, "last", lastBlock, "percentiles", rewardPercentiles)
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, errInvalidPercentile
+		}
+	}
+
+	latest := svc.getState().Version()
+	last := int64(lastBlock)
+	if last < 0 || last > latest {
+		last = latest
+	}
+	count := int64(blockCount)
+	if count > maxFeeHistory {
+		count = maxFeeHistory
+	}
+	if count > last {
+		count = last
+	}
+	oldest := last - count + 1
+
+	capacity := 2 * svc.ctx.GetFeePool().GetOpt().BaseFeeMarket.TargetGas
+	history := &rpctypes.FeeHistory{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldest)),
+		BaseFee:      make([]*hexutil.Big, 0, count+1),
+		GasUsedRatio: make([]float64, 0, count),
+	}
+	if len(rewardPercentiles) > 0 {
+		history.Reward = make([][]*hexutil.Big, 0, count)
+	}
+	for height := oldest; height <= last; height++ {
+		blockFee, err := svc.blockFee(height)
+		if err != nil {
+			return nil, err
+		}
+		history.BaseFee = append(history.BaseFee, (*hexutil.Big)(blockFee.BaseFee.BigInt()))
+		ratio := float64(0)
+		if capacity > 0 {
+			ratio = float64(blockFee.GasUsed) / float64(capacity)
+		}
+		history.GasUsedRatio = append(history.GasUsedRatio, ratio)
+
+		if len(rewardPercentiles) > 0 {
+			tips, err := svc.blockTips(height, blockFee.BaseFee.BigInt())
+			if err != nil {
+				return nil, err
+			}
+			reward := make([]*hexutil.Big, len(rewardPercentiles))
+			for i, p := range rewardPercentiles {
+				reward[i] = (*hexutil.Big)(tipAt(tips, p))
+			}
+			history.Reward = append(history.Reward, reward)
+		}
+		if height == last {
+			history.BaseFee = append(history.BaseFee, (*hexutil.Big)(blockFee.NextBaseFee.BigInt()))
+		}
+	}
+	return history, nil
+}
@@ -7,7 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/web3/bloombits"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -36,6 +38,8 @@ type filter struct {
 // information related to the Ethereum protocol such as blocks, transactions and logs.
 type PublicFilterAPI struct {
 	blockStore *store.BlockStore
+	indexer    *bloombits.Indexer
+	cfg        *config.FilterConfig
 	logger     *log.Logger
 	events     *EventSystem
 	filtersMu  sync.Mutex
@@ -43,9 +47,11 @@ type PublicFilterAPI struct {
 }
 
 // NewPublicFilterAPI returns a new PublicFilterAPI instance.
-func NewPublicFilterAPI(blockStore *store.BlockStore, eventBus *tmtypes.EventBus) *PublicFilterAPI {
+func NewPublicFilterAPI(blockStore *store.BlockStore, eventBus *tmtypes.EventBus, indexer *bloombits.Indexer, cfg *config.FilterConfig) *PublicFilterAPI {
 	api := &PublicFilterAPI{
 		blockStore: blockStore,
+		indexer:    indexer,
+		cfg:        cfg,
 		logger:     log.NewLoggerWithPrefix(os.Stdout, "eth-filters"),
 		filters:    make(map[rpc.ID]*filter),
 		events:     NewEventSystem(eventBus),
@@ -406,7 +412,7 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit filters.FilterCrit
 			end = crit.ToBlock.Int64()
 		}
 		// Construct the range filter
-		filter = NewRangeFilter(api.blockStore, begin, end, crit.Addresses, crit.Topics)
+		filter = api.newRangeFilter(begin, end, crit.Addresses, crit.Topics)
 	}
 
 	// Run the filter and return all the logs
@@ -418,6 +424,13 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit filters.FilterCrit
 	return returnLogs(logs), nil
 }
 
+// newRangeFilter creates a range filter over the indexed bloom bits, limited to the max range
+func (api *PublicFilterAPI) newRangeFilter(begin, end int64, addresses []common.Address, topics [][]common.Hash) *Filter {
+	filter := NewRangeFilter(api.blockStore, api.indexer, begin, end, addresses, topics)
+	filter.maxRange = api.cfg.MaxBlockRange
+	return filter
+}
+
 // UninstallFilter removes the filter with the given filter id.
 //
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_uninstallfilter
@@ -468,7 +481,7 @@ func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*et
 			end = f.crit.ToBlock.Int64()
 		}
 		// Construct the range filter
-		filter = NewRangeFilter(api.blockStore, begin, end, f.crit.Addresses, f.crit.Topics)
+		filter = api.newRangeFilter(begin, end, f.crit.Addresses, f.crit.Topics)
 	}
 	// Run the filter and return all the logs
 	logs, err := filter.Logs()
@@ -1,10 +1,13 @@
 package filters
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 	"os"
 
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/web3/bloombits"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -24,6 +27,10 @@ type Filter struct {
 
 	block      common.Hash // Block hash if filtering a single block
 	begin, end int64       // Range interval if filtering multiple blocks
+
+	indexer  *bloombits.Indexer // Bloom bits of the indexed sections, nil if not indexed
+	matchers [][][]byte         // Criteria of the range as bloombits filters
+	maxRange int64              // Maximum number of blocks of the range, 0 for no limit
 }
 
 // NewBlockFilter creates a new filter which directly inspects the contents of
@@ -38,7 +45,7 @@ func NewBlockFilter(blockStore *store.BlockStore, block common.Hash, addresses [
 
 // NewRangeFilter creates a new filter which uses a bloom filter on blocks to
 // figure out whether a particular block is interesting or not.
-func NewRangeFilter(blockStore *store.BlockStore, begin, end int64, addresses []common.Address, topics [][]common.Hash) *Filter {
+func NewRangeFilter(blockStore *store.BlockStore, indexer *bloombits.Indexer, begin, end int64, addresses []common.Address, topics [][]common.Hash) *Filter {
 	// Flatten the address and topic filter clauses into a single bloombits filter
 	// system. Since the bloombits are not positional, nil topics are permitted,
 	// which get flattened into a nil byte slice.
@@ -61,6 +68,8 @@ func NewRangeFilter(blockStore *store.BlockStore, begin, end int64, addresses []
 	filter := newFilter(blockStore, addresses, topics)
 	filter.begin = begin
 	filter.end = end
+	filter.indexer = indexer
+	filter.matchers = filters
 	filter.logger.Debug("NewRangeFilter", "begin", filter.begin, "end", filter.end)
 	return filter
 }
@@ -79,7 +88,10 @@ func newFilter(blockStore *store.BlockStore, addresses []common.Address, topics
 // BloomStatus returns the BloomBitsBlocks and the number of processed sections maintained
 // by the chain indexer.
 func (f *Filter) BloomStatus() (uint64, uint64) {
-	return 4096, 0
+	if f.indexer == nil {
+		return bloombits.SectionSize, 0
+	}
+	return bloombits.SectionSize, f.indexer.Sections()
 }
 
 // Logs searches the blockchain for matching log entries, returning all from the
@@ -110,8 +122,25 @@ func (f *Filter) Logs() ([]*ethtypes.Log, error) {
 	if f.end == -1 {
 		f.end = int64(head)
 	}
+	if f.maxRange > 0 && f.end-f.begin+1 > f.maxRange {
+		return nil, fmt.Errorf("block range %d-%d exceeds the limit of %d blocks", f.begin, f.end, f.maxRange)
+	}
 	// Gather all indexed logs, and finish with non indexed ones
 	logs := []*ethtypes.Log{}
+	if f.indexer != nil {
+		indexed := int64(f.indexer.Sections()*bloombits.SectionSize) - 1
+		if f.begin <= indexed {
+			end := indexed
+			if f.end < end {
+				end = f.end
+			}
+			found, err := f.indexedLogs(context.Background(), end)
+			logs = append(logs, found...)
+			if err != nil {
+				return logs, err
+			}
+		}
+	}
 	for i := f.begin; i <= f.end; i++ {
 		blockResults, err := tmrpccore.BlockResults(nil, &i)
 		if err != nil {
@@ -121,6 +150,10 @@ func (f *Filter) Logs() ([]*ethtypes.Log, error) {
 		if len(blockResults.TxsResults) == 0 {
 			continue
 		}
+		bloom := rpctypes.GetBlockBloom(blockResults.EndBlockEvents)
+		if !bloomFilter(bloom, f.addresses, f.topics) {
+			continue
+		}
 
 		logsMatched := f.checkMatches(blockResults.TxsResults)
 		logs = append(logs, logsMatched...)
@@ -129,6 +162,31 @@ func (f *Filter) Logs() ([]*ethtypes.Log, error) {
 	return logs, nil
 }
 
+// indexedLogs returns the logs matching the filter criteria in the indexed blocks up to end, the
+// bloom bits give the candidate blocks so only those are read. The start of the filter is moved
+// after the blocks searched.
+func (f *Filter) indexedLogs(ctx context.Context, end int64) ([]*ethtypes.Log, error) {
+	logs := []*ethtypes.Log{}
+	err := f.indexer.Match(ctx, uint64(f.begin), uint64(end), f.matchers, func(number uint64) error {
+		// there is no block at height 0
+		if number == 0 {
+			return nil
+		}
+		height := int64(number)
+		blockResults, err := tmrpccore.BlockResults(nil, &height)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, f.checkMatches(blockResults.TxsResults)...)
+		return nil
+	})
+	if err != nil {
+		return logs, err
+	}
+	f.begin = end + 1
+	return logs, nil
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(blockResults *tmcoretypes.ResultBlockResults) (logs []*types.Log, err error) {
 	f.logger.Debug("blockLogs", "block height", blockResults.Height)
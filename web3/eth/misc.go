@@ -68,9 +68,9 @@ func (svc *Service) Hashrate() hexutil.Uint64 {
 	return 0
 }
 
-// GasPrice returns the current gas price
+// GasPrice returns the current gas price, the base fee with the suggested tip
 func (svc *Service) GasPrice() *hexutil.Big {
 	svc.logger.Debug("eth_gasPrice")
-	out := svc.ctx.GetFeePool().GetOpt().MinFee().Amount.BigInt()
+	out := new(big.Int).Add(svc.ctx.GetFeePool().GetOpt().BaseFee().Amount.BigInt(), svc.suggestTip())
 	return (*hexutil.Big)(out)
 }
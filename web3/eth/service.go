@@ -1,6 +1,7 @@
 package eth
 
 import (
+	"fmt"
 	"os"
 	"sync"
 
@@ -28,7 +29,7 @@ func NewService(ctx rpctypes.Web3Context) *Service {
 	return &Service{
 		ctx:       ctx,
 		logger:    log.NewLoggerWithPrefix(os.Stdout, "eth"),
-		filterAPI: rpcfilters.NewPublicFilterAPI(ctx.GetBlockStore(), ctx.GetEventBus()),
+		filterAPI: rpcfilters.NewPublicFilterAPI(ctx.GetBlockStore(), ctx.GetEventBus(), ctx.GetBloomIndexer(), ctx.GetConfig().API.Filter()),
 	}
 }
 
@@ -54,6 +55,16 @@ func (svc *Service) getStateHeight(height int64) int64 {
 	return height
 }
 
+// checkStateHeight returns storage.ErrStatePruned if the state after the block at the height is not
+// kept anymore, the node keeps the state of every block in archive mode
+func (svc *Service) checkStateHeight(height int64) error {
+	err := svc.getState().CheckVersion(height)
+	if err != nil {
+		return fmt.Errorf("state of block #%d is not available: %w", height, err)
+	}
+	return nil
+}
+
 func (svc *Service) GetStateDB() *vm.CommitStateDB {
 	stateDB := vm.NewCommitStateDB(svc.ctx.GetContractStore(), svc.ctx.GetAccountKeeper(), svc.logger)
 	stateDB.SetBlockStore(svc.ctx.GetBlockStore())
@@ -0,0 +1,144 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// setTxDefaults fills the arguments left out, the nonce after the pending transactions of the sender,
+// the suggested fees and the estimated gas
+func (svc *Service) setTxDefaults(args *rpctypes.SendTxArgs) error {
+	chainID, err := svc.ChainId()
+	if err != nil {
+		return err
+	}
+	if args.ChainID == nil {
+		args.ChainID = &chainID
+	} else if args.ChainID.ToInt().Cmp(chainID.ToInt()) != 0 {
+		return fmt.Errorf("chainId does not match node's (have=%v, want=%v)", args.ChainID, &chainID)
+	}
+
+	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
+		return errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	}
+	if args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
+		if args.MaxPriorityFeePerGas == nil {
+			args.MaxPriorityFeePerGas = (*hexutil.Big)(svc.suggestTip())
+		}
+		if args.MaxFeePerGas == nil {
+			// room for the base fee to double before the transaction gets in
+			baseFee := svc.ctx.GetFeePool().GetOpt().BaseFee().Amount.BigInt()
+			feeCap := new(big.Int).Add(args.MaxPriorityFeePerGas.ToInt(), new(big.Int).Mul(baseFee, big.NewInt(2)))
+			args.MaxFeePerGas = (*hexutil.Big)(feeCap)
+		}
+		if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
+			return fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
+		}
+	} else if args.GasPrice == nil {
+		args.GasPrice = svc.GasPrice()
+	}
+
+	if args.Value == nil {
+		args.Value = new(hexutil.Big)
+	}
+	if args.Nonce == nil {
+		nonce := hexutil.Uint64(svc.getNonce(args.From, uint64(svc.getState().Version()), chainID.ToInt(), true))
+		args.Nonce = &nonce
+	}
+	if args.Data != nil && args.Input != nil && !bytes.Equal(*args.Data, *args.Input) {
+		return errors.New(`both "data" and "input" are set and not equal. Please use "input" to pass transaction call data`)
+	}
+	if args.To == nil && len(args.GetData()) == 0 {
+		return errors.New(`contract creation without any data provided`)
+	}
+
+	if args.Gas == nil {
+		gasPrice := args.GasPrice
+		if gasPrice == nil {
+			gasPrice = args.MaxFeePerGas
+		}
+		estimated, err := svc.EstimateGas(rpctypes.CallArgs{
+			From:     args.From,
+			To:       args.To,
+			GasPrice: gasPrice,
+			Value:    args.Value,
+			Data:     args.GetData(),
+		})
+		if err != nil {
+			return err
+		}
+		args.Gas = &estimated
+	}
+	return nil
+}
+
+// SendTransaction signs the transaction with an unlocked account of the node and submits it
+func (svc *Service) SendTransaction(ctx context.Context, args rpctypes.SendTxArgs) (common.Hash, error) {
+	svc.logger.Debug("eth_sendTransaction", "from", args.From)
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := svc.setTxDefaults(&args); err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := signer.SignTx(args.From, args.ToTransaction(), args.ChainID.ToInt())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return svc.submitTransaction(tx)
+}
+
+// Sign signs the data with an unlocked account of the node, prefixed as an ethereum signed message
+// (EIP-191). The V of the signature is 27 or 28
+func (svc *Service) Sign(ctx context.Context, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	svc.logger.Debug("eth_sign", "address", address)
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return nil, err
+	}
+	signature, err := signer.SignHash(address, accounts.TextHash(data))
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// SignTypedData_v4 signs the typed data with an unlocked account of the node (EIP-712). The V of the
+// signature is 27 or 28
+func (svc *Service) SignTypedData_v4(ctx context.Context, address common.Address, typedData core.TypedData) (hexutil.Bytes, error) {
+	svc.logger.Debug("eth_signTypedData_v4", "address", address)
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return nil, err
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, err
+	}
+	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
+	signature, err := signer.SignHash(address, crypto.Keccak256(rawData))
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
@@ -41,6 +41,10 @@ func (svc *Service) GetTransactionCount(address common.Address, blockNrOrHash rp
 
 	// getting actual block
 	blockNum := svc.getStateHeight(height)
+	err = svc.checkStateHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
 
 	var txLen uint64
 	ethAcc, err := svc.ctx.GetAccountKeeper().GetVersionedAccount(address.Bytes(), blockNum)
@@ -50,7 +54,11 @@ func (svc *Service) GetTransactionCount(address common.Address, blockNrOrHash rp
 
 	// for pending
 	if height == rpctypes.PendingBlockNumber {
-		txLen += rpcutils.GetPendingTxCountByAddress(svc.GetMempool(), address)
+		chainID, err := svc.ChainId()
+		if err != nil {
+			return nil, err
+		}
+		txLen = rpcutils.GetPendingNonce(svc.GetMempool(), chainID.ToInt(), address, txLen)
 	}
 	n := hexutil.Uint64(txLen)
 	return &n, nil
@@ -113,9 +121,10 @@ func (svc *Service) GetTransactionReceipt(hash common.Hash) (*rpctypes.Transacti
 	}
 
 	var (
-		contractAddress *common.Address
-		bloom           = vm.BytesToBloom(make([]byte, 6))
-		logs            = make([]*ethtypes.Log, 0)
+		contractAddress   *common.Address
+		bloom             = vm.BytesToBloom(make([]byte, 6))
+		logs              = make([]*ethtypes.Log, 0)
+		effectiveGasPrice = oneTx.GasPrice
 	)
 	// Set status codes based on tx result
 	status := ethtypes.ReceiptStatusSuccessful
@@ -124,6 +133,9 @@ func (svc *Service) GetTransactionReceipt(hash common.Hash) (*rpctypes.Transacti
 	} else {
 		logReceipt := rpctypes.GetTxEthLogs(&tx.TxResult, tx.Index)
 		status = logReceipt.Status
+		if logReceipt.EffectiveGasPrice != nil {
+			effectiveGasPrice = (*hexutil.Big)(logReceipt.EffectiveGasPrice)
+		}
 
 		if status == ethtypes.ReceiptStatusSuccessful {
 			if oneTx.To == nil {
@@ -142,6 +154,8 @@ func (svc *Service) GetTransactionReceipt(hash common.Hash) (*rpctypes.Transacti
 		TransactionHash:   oneTx.Hash,
 		ContractAddress:   contractAddress,
 		GasUsed:           hexutil.Uint64(tx.TxResult.GasUsed),
+		EffectiveGasPrice: effectiveGasPrice,
+		Type:              oneTx.Type,
 		BlockHash:         *oneTx.BlockHash,
 		BlockNumber:       *oneTx.BlockNumber,
 		TransactionIndex:  *oneTx.TransactionIndex,
@@ -243,8 +257,10 @@ func (svc *Service) submitTransaction(tx *ethtypes.Transaction) (common.Hash, er
 		return common.Hash{}, err
 	}
 
-	if tx.Type() != ethtypes.LegacyTxType {
-		return common.Hash{}, errors.New("only legacy transactions allowed over RPC")
+	switch tx.Type() {
+	case ethtypes.LegacyTxType, ethtypes.AccessListTxType, ethtypes.DynamicFeeTxType:
+	default:
+		return common.Hash{}, ethtypes.ErrTxTypeNotSupported
 	}
 
 	if !tx.Protected() {
@@ -253,7 +269,7 @@ func (svc *Service) submitTransaction(tx *ethtypes.Transaction) (common.Hash, er
 	}
 
 	// Print a log with full tx details for manual investigations and interventions
-	signer := ethtypes.NewEIP155Signer(tx.ChainId())
+	signer := ethtypes.NewLondonSigner(tx.ChainId())
 	from, err := ethtypes.Sender(signer, tx)
 	if err != nil {
 		return common.Hash{}, err
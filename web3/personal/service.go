@@ -0,0 +1,94 @@
+package personal
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"time"
+
+	"github.com/Oneledger/protocol/log"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// defaultUnlockDuration is how long an account stays unlocked when no duration is given
+const defaultUnlockDuration = 300 * time.Second
+
+var _ rpctypes.Web3Service = (*Service)(nil)
+
+type Service struct {
+	ctx    rpctypes.Web3Context
+	logger *log.Logger
+}
+
+func NewService(ctx rpctypes.Web3Context) *Service {
+	return &Service{ctx: ctx, logger: log.NewLoggerWithPrefix(os.Stdout, "personal")}
+}
+
+// ListAccounts returns the addresses of the accounts of the node keystore
+func (svc *Service) ListAccounts(ctx context.Context) ([]common.Address, error) {
+	svc.logger.Debug("personal_listAccounts")
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return nil, err
+	}
+	return signer.Accounts()
+}
+
+// NewAccount creates an ethereum account in the node keystore, encrypted with the password
+func (svc *Service) NewAccount(ctx context.Context, password string) (common.Address, error) {
+	svc.logger.Debug("personal_newAccount")
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return common.Address{}, err
+	}
+	if password == "" {
+		return common.Address{}, errors.New("password is required")
+	}
+	address, err := signer.NewAccount(password)
+	if err != nil {
+		return common.Address{}, err
+	}
+	svc.logger.Info("Created account", "address", address.Hex())
+	return address, nil
+}
+
+// UnlockAccount unlocks the account with its password for the duration in seconds, 300 seconds when
+// none is given and until it is locked with a duration of 0
+func (svc *Service) UnlockAccount(ctx context.Context, address common.Address, password string, duration *uint64) (bool, error) {
+	svc.logger.Debug("personal_unlockAccount", "address", address)
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return false, err
+	}
+
+	const max = uint64(time.Duration(math.MaxInt64) / time.Second)
+	d := defaultUnlockDuration
+	if duration != nil {
+		if *duration > max {
+			return false, errors.New("unlock duration too large")
+		}
+		d = time.Duration(*duration) * time.Second
+	}
+	err := signer.Unlock(address, password, d)
+	if err != nil {
+		svc.logger.Warn("Failed account unlock attempt", "address", address, "err", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// LockAccount locks the account, it tells whether the account was unlocked
+func (svc *Service) LockAccount(ctx context.Context, address common.Address) (bool, error) {
+	svc.logger.Debug("personal_lockAccount", "address", address)
+
+	signer := svc.ctx.GetSigner()
+	if err := signer.Authorize(ctx); err != nil {
+		return false, err
+	}
+	return signer.Lock(address), nil
+}
@@ -0,0 +1,102 @@
+package personal
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/Oneledger/protocol/web3/signer"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+)
+
+type testContext struct {
+	rpctypes.Web3Context
+	signer *signer.Signer
+}
+
+func (ctx *testContext) GetSigner() *signer.Signer {
+	return ctx.signer
+}
+
+// ownerContexts returns the context of a request of the owner and of one without its token
+func ownerContexts() (context.Context, context.Context) {
+	key := ed25519.GenPrivKey()
+	data := make([]byte, 20)
+	signature, _ := key.Sign(data)
+	rpcPrivateKey := base64.StdEncoding.EncodeToString(key[:])
+
+	contextOf := func(token string) context.Context {
+		var ctx context.Context
+		handler := signer.OwnerHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		}), rpcPrivateKey)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Authorization", token)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return ctx
+	}
+	return contextOf(base58.Encode(append(data, signature...))), contextOf("")
+}
+
+func TestService_Authorization(t *testing.T) {
+	svc := NewService(&testContext{signer: signer.NewSigner(true, t.TempDir())})
+	_, stranger := ownerContexts()
+
+	_, err := svc.ListAccounts(stranger)
+	assert.Equal(t, signer.ErrNotAuthorized, err)
+	_, err = svc.NewAccount(stranger, "secret")
+	assert.Equal(t, signer.ErrNotAuthorized, err)
+	_, err = svc.UnlockAccount(stranger, [20]byte{}, "secret", nil)
+	assert.Equal(t, signer.ErrNotAuthorized, err)
+	_, err = svc.LockAccount(stranger, [20]byte{})
+	assert.Equal(t, signer.ErrNotAuthorized, err)
+
+	owner, _ := ownerContexts()
+	disabled := NewService(&testContext{signer: signer.NewSigner(false, t.TempDir())})
+	_, err = disabled.ListAccounts(owner)
+	assert.Equal(t, signer.ErrDisabled, err)
+}
+
+func TestService_Accounts(t *testing.T) {
+	svc := NewService(&testContext{signer: signer.NewSigner(true, t.TempDir())})
+	owner, _ := ownerContexts()
+
+	_, err := svc.NewAccount(owner, "")
+	assert.Error(t, err)
+	address, err := svc.NewAccount(owner, "secret")
+	require.NoError(t, err)
+	accounts, err := svc.ListAccounts(owner)
+	require.NoError(t, err)
+	assert.Contains(t, accounts, address)
+
+	ok, err := svc.UnlockAccount(owner, address, "wrong", nil)
+	assert.Error(t, err)
+	assert.False(t, ok)
+	tooLong := uint64(math.MaxUint64)
+	_, err = svc.UnlockAccount(owner, address, "secret", &tooLong)
+	assert.Error(t, err)
+
+	forGood := uint64(0)
+	ok, err = svc.UnlockAccount(owner, address, "secret", &forGood)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = svc.ctx.GetSigner().SignHash(address, make([]byte, 32))
+	assert.NoError(t, err)
+
+	ok, err = svc.LockAccount(owner, address)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = svc.ctx.GetSigner().SignHash(address, make([]byte, 32))
+	assert.Equal(t, signer.ErrLocked, err)
+	ok, err = svc.LockAccount(owner, address)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
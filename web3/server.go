@@ -8,6 +8,7 @@ import (
 
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/web3/signer"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
 
 	"github.com/ethereum/go-ethereum/node"
@@ -60,6 +61,13 @@ func (s *Server) start(rpcInfo interface{}, apis map[string]rpctypes.Web3Service
 		availableAPINames = rpcCfg.API
 		keepAlive = rpcCfg.KeepAlive
 		handler = node.NewHTTPHandlerStack(rpcSrv, s.cfg.API.HTTPConfig.CORSDomain, s.cfg.API.HTTPConfig.VHosts)
+		if s.cfg.Node.Auth.Web3Accounts {
+			// node accounts are used over HTTP only, with the token of the owner
+			if s.cfg.Node.Auth.RPCPrivateKey == "" {
+				return signer.ErrNoOwnerKey
+			}
+			handler = signer.OwnerHandler(handler, s.cfg.Node.Auth.RPCPrivateKey)
+		}
 	case *config.WSConfig:
 		name = "WS"
 		uri = fmt.Sprintf("%s:%d", rpcCfg.Addr, rpcCfg.Port)
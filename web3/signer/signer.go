@@ -0,0 +1,214 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeyStorePath is the keystore of the node the accounts are kept in
+const KeyStorePath = "keystore/"
+
+var (
+	ErrDisabled      = errors.New("node accounts are disabled")
+	ErrNotAuthorized = errors.New("node accounts need the owner token")
+	ErrLocked        = errors.New("account is locked")
+	ErrNoOwnerKey    = errors.New("node accounts need the rpc private key to tell the owner")
+)
+
+type ownerKey struct{}
+
+// OwnerHandler marks the requests carrying the token of the owner in their Authorization header, only
+// those can use the node accounts. No request is the owner's when there is no RPC private key
+func OwnerHandler(next http.Handler, rpcPrivateKey string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rpcPrivateKey != "" && rpc.VerifyToken(rpcPrivateKey, r.Header.Get("Authorization")) == nil {
+			r = r.WithContext(context.WithValue(r.Context(), ownerKey{}, true))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// unlockedAccount is the key of an unlocked account, a zero expiry keeps it until it is locked
+type unlockedAccount struct {
+	key    *ecdsa.PrivateKey
+	expiry time.Time
+}
+
+// Signer signs with the ethereum accounts of the node keystore. An account is locked, it has to be
+// unlocked with its passphrase for a while before it signs
+type Signer struct {
+	enabled      bool
+	keyStorePath string
+
+	mu       sync.Mutex
+	unlocked map[common.Address]*unlockedAccount
+}
+
+func NewSigner(enabled bool, keyStorePath string) *Signer {
+	return &Signer{
+		enabled:      enabled,
+		keyStorePath: keyStorePath,
+		unlocked:     make(map[common.Address]*unlockedAccount),
+	}
+}
+
+// Authorize checks the node accounts are enabled and the request of the context is the owner's
+func (s *Signer) Authorize(ctx context.Context) error {
+	if !s.enabled {
+		return ErrDisabled
+	}
+	if owner, _ := ctx.Value(ownerKey{}).(bool); !owner {
+		return ErrNotAuthorized
+	}
+	return nil
+}
+
+// Accounts lists the addresses of the accounts in the keystore
+func (s *Signer) Accounts() ([]common.Address, error) {
+	addresses := make([]common.Address, 0)
+
+	wallet, err := accounts.NewWalletKeyStore(s.keyStorePath)
+	if err != nil {
+		return addresses, err
+	}
+	oltAddresses, err := wallet.ListAddresses()
+	if err != nil {
+		return addresses, err
+	}
+	for _, oltAddress := range oltAddresses {
+		addresses = append(addresses, common.BytesToAddress(oltAddress))
+	}
+	return addresses, nil
+}
+
+// NewAccount creates an ethereum account in the keystore, encrypted with the passphrase
+func (s *Signer) NewAccount(passphrase string) (common.Address, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+	privKey, err := keys.GetPrivateKeyFromBytes(crypto.FromECDSA(key), keys.ETHSECP)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pubKey, err := keys.GetPublicKeyFromBytes(crypto.CompressPubkey(&key.PublicKey), keys.ETHSECP)
+	if err != nil {
+		return common.Address{}, err
+	}
+	ct, err := chain.TypeFromName("OneLedger")
+	if err != nil {
+		return common.Address{}, err
+	}
+	acc, err := accounts.NewAccount(ct, "", &privKey, &pubKey)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	wallet, err := accounts.NewWalletKeyStore(s.keyStorePath)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !wallet.Open(acc.Address(), passphrase) {
+		return common.Address{}, errors.New("failed to open the keystore")
+	}
+	defer wallet.Close()
+
+	err = wallet.Add(acc)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(acc.Address()), nil
+}
+
+// Unlock decrypts the key of the account with its passphrase and keeps it for the duration, for
+// good with a zero duration
+func (s *Signer) Unlock(address common.Address, passphrase string, duration time.Duration) error {
+	addr := keys.Address(address.Bytes())
+	wallet, err := accounts.NewWalletKeyStore(s.keyStorePath)
+	if err != nil {
+		return err
+	}
+	if !wallet.KeyExists(addr) {
+		return fmt.Errorf("account %s not found", address.Hex())
+	}
+	if !wallet.Open(addr, passphrase) {
+		return errors.New("could not decrypt key with given passphrase")
+	}
+	defer wallet.Close()
+
+	acc, err := wallet.GetAccount(addr)
+	if err != nil {
+		return err
+	}
+	if acc.PrivateKey == nil || acc.PrivateKey.Keytype != keys.ETHSECP {
+		return fmt.Errorf("account %s has no ethereum key", address.Hex())
+	}
+
+	unlocked := &unlockedAccount{key: keys.ETHSECP256K1TOECDSA(acc.PrivateKey.Data)}
+	if duration > 0 {
+		unlocked.expiry = time.Now().Add(duration)
+	}
+	s.mu.Lock()
+	s.unlocked[address] = unlocked
+	s.mu.Unlock()
+	return nil
+}
+
+// Lock drops the key of the account, it tells whether the account was unlocked
+func (s *Signer) Lock(address common.Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.unlocked[address]
+	delete(s.unlocked, address)
+	return ok
+}
+
+// key returns the key of the account while it is unlocked
+func (s *Signer) key(address common.Address) (*ecdsa.PrivateKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlocked, ok := s.unlocked[address]
+	if !ok {
+		return nil, ErrLocked
+	}
+	if !unlocked.expiry.IsZero() && time.Now().After(unlocked.expiry) {
+		delete(s.unlocked, address)
+		return nil, ErrLocked
+	}
+	return unlocked.key, nil
+}
+
+// SignHash signs the hash with the account, the signature is in the [R || S || V] format with a V
+// of 0 or 1
+func (s *Signer) SignHash(address common.Address, hash []byte) ([]byte, error) {
+	key, err := s.key(address)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, key)
+}
+
+// SignTx signs the transaction with the account for the chain
+func (s *Signer) SignTx(address common.Address, tx *ethtypes.Transaction, chainID *big.Int) (*ethtypes.Transaction, error) {
+	key, err := s.key(address)
+	if err != nil {
+		return nil, err
+	}
+	return ethtypes.SignTx(tx, ethtypes.NewLondonSigner(chainID), key)
+}
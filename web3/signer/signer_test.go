@@ -0,0 +1,113 @@
+package signer
+
+import (
+	"context"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// newRPCKey returns an rpc private key and a token of the owner signed with it
+func newRPCKey() (string, string) {
+	key := ed25519.GenPrivKey()
+	data := make([]byte, 20)
+	signature, _ := key.Sign(data)
+	return base64.StdEncoding.EncodeToString(key[:]), base58.Encode(append(data, signature...))
+}
+
+// requestContext returns the context a request with the token gets through the owner handler
+func requestContext(rpcPrivateKey, token string) context.Context {
+	var ctx context.Context
+	handler := OwnerHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}), rpcPrivateKey)
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	return ctx
+}
+
+func TestSigner_Authorize(t *testing.T) {
+	rpcKey, token := newRPCKey()
+	_, otherToken := newRPCKey()
+	s := NewSigner(true, t.TempDir())
+
+	assert.NoError(t, s.Authorize(requestContext(rpcKey, token)))
+	assert.Equal(t, ErrNotAuthorized, s.Authorize(requestContext(rpcKey, "")))
+	assert.Equal(t, ErrNotAuthorized, s.Authorize(requestContext(rpcKey, otherToken)))
+	// without an rpc private key nobody is the owner
+	assert.Equal(t, ErrNotAuthorized, s.Authorize(requestContext("", "")))
+	assert.Equal(t, ErrNotAuthorized, s.Authorize(requestContext("", token)))
+
+	assert.Equal(t, ErrDisabled, NewSigner(false, t.TempDir()).Authorize(requestContext(rpcKey, token)))
+}
+
+func TestSigner_UnlockExpiry(t *testing.T) {
+	s := NewSigner(true, t.TempDir())
+	address, err := s.NewAccount("secret")
+	require.NoError(t, err)
+	accounts, err := s.Accounts()
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{address}, accounts)
+
+	hash := crypto.Keccak256([]byte("message"))
+	_, err = s.SignHash(address, hash)
+	assert.Equal(t, ErrLocked, err)
+
+	assert.Error(t, s.Unlock(address, "wrong", 0))
+	assert.Error(t, s.Unlock(common.HexToAddress("0x01"), "secret", 0))
+
+	require.NoError(t, s.Unlock(address, "secret", 50*time.Millisecond))
+	_, err = s.SignHash(address, hash)
+	assert.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = s.SignHash(address, hash)
+	assert.Equal(t, ErrLocked, err)
+	assert.False(t, s.Lock(address))
+
+	// a zero duration keeps the account unlocked until it is locked
+	require.NoError(t, s.Unlock(address, "secret", 0))
+	_, err = s.SignHash(address, hash)
+	assert.NoError(t, err)
+	assert.True(t, s.Lock(address))
+	_, err = s.SignHash(address, hash)
+	assert.Equal(t, ErrLocked, err)
+}
+
+func TestSigner_Sign(t *testing.T) {
+	s := NewSigner(true, t.TempDir())
+	address, err := s.NewAccount("secret")
+	require.NoError(t, err)
+	require.NoError(t, s.Unlock(address, "secret", 0))
+
+	hash := crypto.Keccak256([]byte("message"))
+	signature, err := s.SignHash(address, hash)
+	require.NoError(t, err)
+	require.Len(t, signature, 65)
+	pubKey, err := crypto.SigToPub(hash, signature)
+	require.NoError(t, err)
+	assert.Equal(t, address, crypto.PubkeyToAddress(*pubKey))
+
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x01")
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{ChainID: chainID, Nonce: 3, To: &to, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), Value: big.NewInt(7)})
+	signedTx, err := s.SignTx(address, tx, chainID)
+	require.NoError(t, err)
+	sender, err := ethtypes.Sender(ethtypes.NewLondonSigner(chainID), signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+}
@@ -0,0 +1,132 @@
+package txpool
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/utils"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	rpcutils "github.com/Oneledger/protocol/web3/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ rpctypes.Web3Service = (*Service)(nil)
+
+type Service struct {
+	ctx    rpctypes.Web3Context
+	logger *log.Logger
+}
+
+func NewService(ctx rpctypes.Web3Context) *Service {
+	return &Service{ctx: ctx, logger: log.NewLoggerWithPrefix(os.Stdout, "txpool")}
+}
+
+// getPool sorts out the mempool against the nonces of the senders in the latest state
+func (svc *Service) getPool() *rpcutils.TxPool {
+	chainID := utils.HashToBigInt(svc.ctx.GetGenesisDoc().ChainID)
+	height := svc.ctx.GetContractStore().State.Version()
+	keeper := svc.ctx.GetAccountKeeper()
+	return rpcutils.GetTxPool(svc.ctx.GetMempool(), chainID, func(address common.Address) uint64 {
+		ethAcc, err := keeper.GetVersionedAccount(address.Bytes(), height)
+		if err != nil {
+			return 0
+		}
+		return ethAcc.Sequence
+	})
+}
+
+// Content returns the transactions of the mempool, the olvm ones by sender and nonce, the native
+// ones by sender and hash
+func (svc *Service) Content() map[string]map[string]map[string]interface{} {
+	svc.logger.Debug("txpool_content")
+	pool := svc.getPool()
+
+	content := map[string]map[string]map[string]interface{}{
+		"pending": make(map[string]map[string]interface{}),
+		"queued":  make(map[string]map[string]interface{}),
+		"native":  make(map[string]map[string]interface{}),
+	}
+	for name, txsByFrom := range map[string]map[common.Address][]*rpctypes.Transaction{
+		"pending": pool.Pending,
+		"queued":  pool.Queued,
+	} {
+		for from, txs := range txsByFrom {
+			dump := make(map[string]interface{})
+			for _, tx := range txs {
+				dump[strconv.FormatUint(uint64(tx.Nonce), 10)] = tx
+			}
+			content[name][from.Hex()] = dump
+		}
+	}
+	for from, txs := range pool.Native {
+		dump := make(map[string]interface{})
+		for _, tx := range txs {
+			dump[tx.Hash.Hex()] = tx
+		}
+		content["native"][from.Hex()] = dump
+	}
+	return content
+}
+
+// Inspect returns a summary of the transactions of the mempool, laid out as Content
+func (svc *Service) Inspect() map[string]map[string]map[string]string {
+	svc.logger.Debug("txpool_inspect")
+	pool := svc.getPool()
+
+	content := map[string]map[string]map[string]string{
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
+		"native":  make(map[string]map[string]string),
+	}
+	format := func(tx *rpctypes.Transaction) string {
+		if tx.To != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value.ToInt(), uint64(tx.Gas), tx.GasPrice.ToInt())
+		}
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value.ToInt(), uint64(tx.Gas), tx.GasPrice.ToInt())
+	}
+	for name, txsByFrom := range map[string]map[common.Address][]*rpctypes.Transaction{
+		"pending": pool.Pending,
+		"queued":  pool.Queued,
+	} {
+		for from, txs := range txsByFrom {
+			dump := make(map[string]string)
+			for _, tx := range txs {
+				dump[strconv.FormatUint(uint64(tx.Nonce), 10)] = format(tx)
+			}
+			content[name][from.Hex()] = dump
+		}
+	}
+	for from, txs := range pool.Native {
+		dump := make(map[string]string)
+		for _, tx := range txs {
+			dump[tx.Hash.Hex()] = fmt.Sprintf("%s: %v gas × %v wei", tx.Type, uint64(tx.Gas), tx.GasPrice.ToInt())
+		}
+		content["native"][from.Hex()] = dump
+	}
+	return content
+}
+
+// Status returns the number of pending and queued transactions of the mempool, native transactions
+// are counted as pending
+func (svc *Service) Status() map[string]hexutil.Uint {
+	svc.logger.Debug("txpool_status")
+	pool := svc.getPool()
+
+	var pending, queued int
+	for _, txs := range pool.Pending {
+		pending += len(txs)
+	}
+	for _, txs := range pool.Native {
+		pending += len(txs)
+	}
+	for _, txs := range pool.Queued {
+		queued += len(txs)
+	}
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(pending),
+		"queued":  hexutil.Uint(queued),
+	}
+}
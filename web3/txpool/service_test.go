@@ -0,0 +1,192 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/mempool"
+	tmtypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/olvm"
+	"github.com/Oneledger/protocol/action/transfer"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/evm"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	rpcutils "github.com/Oneledger/protocol/web3/utils"
+)
+
+// testMempool hands out the transactions it was given, in order
+type testMempool struct {
+	mempool.Mempool
+	txs tmtypes.Txs
+}
+
+func (m *testMempool) ReapMaxTxs(max int) tmtypes.Txs {
+	if max < 0 || max > len(m.txs) {
+		return m.txs
+	}
+	return m.txs[:max]
+}
+
+// testKeeper gives the nonces of the senders in the state
+type testKeeper struct {
+	balance.AccountKeeper
+	nonces map[common.Address]uint64
+}
+
+func (k *testKeeper) GetVersionedAccount(addr keys.Address, height int64) (*balance.EthAccount, error) {
+	return &balance.EthAccount{Address: addr, Sequence: k.nonces[common.BytesToAddress(addr)]}, nil
+}
+
+type testContext struct {
+	rpctypes.Web3Context
+	mempool *testMempool
+	keeper  *testKeeper
+}
+
+func (ctx *testContext) GetGenesisDoc() *tmtypes.GenesisDoc {
+	return &tmtypes.GenesisDoc{ChainID: "test"}
+}
+
+func (ctx *testContext) GetMempool() mempool.Mempool {
+	return ctx.mempool
+}
+
+func (ctx *testContext) GetAccountKeeper() balance.AccountKeeper {
+	return ctx.keeper
+}
+
+func (ctx *testContext) GetContractStore() *evm.ContractStore {
+	return evm.NewContractStore(storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, ""))))
+}
+
+type testSender struct {
+	pubKey keys.PublicKey
+	addr   common.Address
+}
+
+func newTestSender() testSender {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	return testSender{
+		pubKey: keys.PublicKey{KeyType: keys.ED25519, Data: pubKey.Bytes()[5:]},
+		addr:   common.BytesToAddress(pubKey.Address()),
+	}
+}
+
+func (s testSender) signedTx(t *testing.T, txType action.Type, msg interface{ Marshal() ([]byte, error) }) tmtypes.Tx {
+	data, err := msg.Marshal()
+	require.NoError(t, err)
+	tx := action.SignedTx{
+		RawTx: action.RawTx{
+			Type: txType,
+			Data: data,
+			Fee:  action.Fee{Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(1)}, Gas: 21000},
+		},
+		Signatures: []action.Signature{{Signer: s.pubKey, Signed: make([]byte, 65)}},
+	}
+	raw, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	require.NoError(t, err)
+	return raw
+}
+
+func (s testSender) olvmTx(t *testing.T, nonce uint64) tmtypes.Tx {
+	to := keys.Address(common.HexToAddress("0x01").Bytes())
+	return s.signedTx(t, action.OLVM, olvm.Transaction{
+		Nonce:   nonce,
+		From:    s.addr.Bytes(),
+		To:      &to,
+		Amount:  action.Amount{Currency: "OLT", Value: *balance.NewAmount(7)},
+		ChainID: big.NewInt(1),
+	})
+}
+
+func (s testSender) sendTx(t *testing.T) tmtypes.Tx {
+	return s.signedTx(t, action.SEND, transfer.Send{
+		From:   s.addr.Bytes(),
+		To:     s.addr.Bytes(),
+		Amount: action.Amount{Currency: "OLT", Value: *balance.NewAmount(7)},
+	})
+}
+
+// newTestService returns a service on a mempool where the first sender, at nonce 1 in the state, has
+// its nonces 1 and 2 pending and 4 queued, and the second one has a native send
+func newTestService(t *testing.T) (*Service, testSender, testSender) {
+	alice, bob := newTestSender(), newTestSender()
+	ctx := &testContext{
+		mempool: &testMempool{txs: tmtypes.Txs{
+			alice.olvmTx(t, 2),
+			bob.sendTx(t),
+			alice.olvmTx(t, 1),
+			alice.olvmTx(t, 4),
+		}},
+		keeper: &testKeeper{nonces: map[common.Address]uint64{alice.addr: 1}},
+	}
+	return NewService(ctx), alice, bob
+}
+
+func TestService_Content(t *testing.T) {
+	svc, alice, bob := newTestService(t)
+
+	content := svc.Content()
+	require.Contains(t, content["pending"], alice.addr.Hex())
+	pending := content["pending"][alice.addr.Hex()]
+	assert.Len(t, pending, 2)
+	assert.Equal(t, hexutil.Uint64(1), pending["1"].(*rpctypes.Transaction).Nonce)
+	assert.Equal(t, alice.addr, pending["2"].(*rpctypes.Transaction).From)
+
+	require.Contains(t, content["queued"], alice.addr.Hex())
+	assert.Len(t, content["queued"][alice.addr.Hex()], 1)
+	assert.Contains(t, content["queued"][alice.addr.Hex()], "4")
+
+	require.Contains(t, content["native"], bob.addr.Hex())
+	native := content["native"][bob.addr.Hex()]
+	require.Len(t, native, 1)
+	for hash, tx := range native {
+		assert.Equal(t, hash, tx.(*rpctypes.NativeTransaction).Hash.Hex())
+		assert.Equal(t, action.SEND.String(), tx.(*rpctypes.NativeTransaction).Type)
+	}
+}
+
+func TestService_Inspect(t *testing.T) {
+	svc, alice, bob := newTestService(t)
+
+	inspect := svc.Inspect()
+	to := common.HexToAddress("0x01").Hex()
+	assert.Equal(t, to+": 7 wei + 21000 gas × 1 wei", inspect["pending"][alice.addr.Hex()]["1"])
+	assert.Equal(t, to+": 7 wei + 21000 gas × 1 wei", inspect["queued"][alice.addr.Hex()]["4"])
+	require.Len(t, inspect["native"][bob.addr.Hex()], 1)
+	for _, summary := range inspect["native"][bob.addr.Hex()] {
+		assert.Equal(t, action.SEND.String()+": 21000 gas × 1 wei", summary)
+	}
+}
+
+func TestService_Status(t *testing.T) {
+	svc, _, _ := newTestService(t)
+
+	status := svc.Status()
+	assert.Equal(t, hexutil.Uint(3), status["pending"])
+	assert.Equal(t, hexutil.Uint(1), status["queued"])
+}
+
+func TestGetPendingNonce(t *testing.T) {
+	svc, alice, bob := newTestService(t)
+	mem := svc.ctx.GetMempool()
+	chainID := big.NewInt(1)
+
+	// the nonces in the mempool after the one of the state are taken, up to the first gap
+	assert.Equal(t, uint64(3), rpcutils.GetPendingNonce(mem, chainID, alice.addr, 1))
+	assert.Equal(t, uint64(5), rpcutils.GetPendingNonce(mem, chainID, alice.addr, 4))
+	// native transactions have no nonce
+	assert.Equal(t, uint64(0), rpcutils.GetPendingNonce(mem, chainID, bob.addr, 0))
+	assert.Equal(t, uint64(9), rpcutils.GetPendingNonce(mem, chainID, newTestSender().addr, 9))
+}
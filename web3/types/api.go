@@ -8,7 +8,11 @@ import (
 	"github.com/Oneledger/protocol/data/evm"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
 	"github.com/Oneledger/protocol/vm"
+	"github.com/Oneledger/protocol/web3/bloombits"
+	"github.com/Oneledger/protocol/web3/signer"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
@@ -25,6 +29,10 @@ type EthService interface {
 	GetStateDB() *vm.CommitStateDB
 }
 
+// TxDeliverer delivers a transaction of a committed block again through the deliver path of the app,
+// on a state of the caller. The changes stay in the state and are never committed
+type TxDeliverer func(header *abci.Header, state *storage.State, tx []byte) abci.ResponseDeliverTx
+
 // Web3Context interface to define required elements for the API Context
 type Web3Context interface {
 	// propagation structures
@@ -39,8 +47,12 @@ type Web3Context interface {
 	GetContractStore() *evm.ContractStore
 	GetAccountKeeper() balance.AccountKeeper
 	GetFeePool() *fees.Store
+	GetStateAt(height int64) (*storage.State, error)
 	GetNodeContext() *node.Context
 	GetConfig() *config.Server
+	GetSigner() *signer.Signer
+	GetBloomIndexer() *bloombits.Indexer
+	GetTxDeliverer() TxDeliverer
 
 	// service registry
 	RegisterService(name string, srv Web3Service)
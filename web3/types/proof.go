@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/evm"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// balanceStorePrefix is the prefix of the balance store the account keeper keeps the balances in
+const balanceStorePrefix = "b"
+
+// AccountResult is the eth_getProof result (EIP-1186). Instead of merkle patricia trie nodes every
+// proof is an encoded iavl proof op of a single key against the root hash of the state, see
+// storage.VerifyProof. The account is kept under two keys, its account entry and its balance entry,
+// proven in this order in AccountProof, with their values as they are kept in AccountEntries
+type AccountResult struct {
+	Address        common.Address  `json:"address"`
+	AccountProof   []hexutil.Bytes `json:"accountProof"`
+	AccountEntries []hexutil.Bytes `json:"accountEntries"`
+	Balance        *hexutil.Big    `json:"balance"`
+	CodeHash       common.Hash     `json:"codeHash"`
+	Nonce          hexutil.Uint64  `json:"nonce"`
+	// root hash of the state after the block, it is the app hash of the next block header
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// StorageResult is the proof of a storage slot of a contract, a slot with no value is proven absent
+type StorageResult struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// AccountProofKeys returns the keys of the account of the address in the state, its account entry
+// and its balance entry
+func AccountProofKeys(address common.Address) []storage.StoreKey {
+	addr := keys.Address(address.Bytes())
+	return []storage.StoreKey{
+		balance.AccountStoreKey(addr),
+		balance.NewStore(balanceStorePrefix, nil).BuildKey(addr, nil),
+	}
+}
+
+// StorageProofKey returns the key of a storage slot of a contract in the state
+func StorageProofKey(address common.Address, key common.Hash) storage.StoreKey {
+	prefixKey := utils.GetStorageByAddressKey(address, key.Bytes())
+	return evm.NewContractStore(nil).GetStoreKey(evm.AddressStoragePrefix(address), prefixKey.Bytes())
+}
+
+// StorageProofValue returns the value a storage slot is kept with in the state, none for an empty slot
+func StorageProofValue(value *big.Int) []byte {
+	if value == nil || value.Sign() == 0 {
+		return nil
+	}
+	return common.BigToHash(value).Bytes()
+}
+
+// SetAccountEntries sets the entries of the account and the fields they hold
+func (r *AccountResult) SetAccountEntries(account, amount []byte) error {
+	r.AccountEntries = []hexutil.Bytes{account, amount}
+	r.Nonce = 0
+	r.CodeHash = ethcrypto.Keccak256Hash(nil)
+	r.Balance = (*hexutil.Big)(new(big.Int))
+
+	if len(account) > 0 {
+		ea := &balance.EthAccount{}
+		err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(account, ea)
+		if err != nil {
+			return err
+		}
+		r.Nonce = hexutil.Uint64(ea.Sequence)
+		r.CodeHash = common.BytesToHash(ea.CodeHash)
+	}
+	if len(amount) > 0 {
+		amt := balance.NewAmount(0)
+		err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(amount, amt)
+		if err != nil {
+			return err
+		}
+		r.Balance = (*hexutil.Big)(amt.BigInt())
+	}
+	return nil
+}
+
+// Verify checks the proofs of the account and of its storage slots against the root hash of the state,
+// taken from the app hash of the header after the block the proof was asked at, and that the fields
+// of the account are the ones its proven entries hold
+func (r *AccountResult) Verify(root []byte) error {
+	accountKeys := AccountProofKeys(r.Address)
+	if len(r.AccountProof) != len(accountKeys) || len(r.AccountEntries) != len(accountKeys) {
+		return fmt.Errorf("account proof needs %d entries", len(accountKeys))
+	}
+	for i, key := range accountKeys {
+		err := storage.VerifyProof(root, key, r.AccountEntries[i], r.AccountProof[i])
+		if err != nil {
+			return fmt.Errorf("invalid account proof: %w", err)
+		}
+	}
+
+	proven := &AccountResult{}
+	err := proven.SetAccountEntries(r.AccountEntries[0], r.AccountEntries[1])
+	if err != nil {
+		return err
+	}
+	if proven.Nonce != r.Nonce || proven.CodeHash != r.CodeHash || proven.Balance.ToInt().Cmp(r.Balance.ToInt()) != 0 {
+		return fmt.Errorf("account %s does not match its proven entries", r.Address)
+	}
+
+	for _, slot := range r.StorageProof {
+		if len(slot.Proof) != 1 {
+			return fmt.Errorf("storage proof of %s needs 1 entry", slot.Key)
+		}
+		key := StorageProofKey(r.Address, common.HexToHash(slot.Key))
+		err := storage.VerifyProof(root, key, StorageProofValue(slot.Value.ToInt()), slot.Proof[0])
+		if err != nil {
+			return fmt.Errorf("invalid storage proof of %s: %w", slot.Key, err)
+		}
+	}
+	return nil
+}
@@ -27,6 +27,78 @@ type CallArgs struct {
 	Data     hexutil.Bytes   `json:"data"`
 }
 
+// SendTxArgs represents the arguments to send a transaction signed by an account of the node.
+type SendTxArgs struct {
+	From                 common.Address       `json:"from"`
+	To                   *common.Address      `json:"to"`
+	Gas                  *hexutil.Uint64      `json:"gas"`
+	GasPrice             *hexutil.Big         `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big         `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big         `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big         `json:"value"`
+	Nonce                *hexutil.Uint64      `json:"nonce"`
+	Data                 *hexutil.Bytes       `json:"data"`
+	Input                *hexutil.Bytes       `json:"input"`
+	AccessList           *ethtypes.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big         `json:"chainId,omitempty"`
+}
+
+// GetData returns the input of the transaction, "input" is preferred over "data"
+func (args *SendTxArgs) GetData() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+// ToTransaction builds the transaction of the arguments once their defaults are set, a dynamic fee
+// transaction when fee caps are given, else an access list or a legacy transaction
+func (args *SendTxArgs) ToTransaction() *ethtypes.Transaction {
+	var data ethtypes.TxData
+	switch {
+	case args.MaxFeePerGas != nil:
+		al := ethtypes.AccessList{}
+		if args.AccessList != nil {
+			al = *args.AccessList
+		}
+		data = &ethtypes.DynamicFeeTx{
+			To:         args.To,
+			ChainID:    (*big.Int)(args.ChainID),
+			Nonce:      uint64(*args.Nonce),
+			Gas:        uint64(*args.Gas),
+			GasFeeCap:  (*big.Int)(args.MaxFeePerGas),
+			GasTipCap:  (*big.Int)(args.MaxPriorityFeePerGas),
+			Value:      (*big.Int)(args.Value),
+			Data:       args.GetData(),
+			AccessList: al,
+		}
+	case args.AccessList != nil:
+		data = &ethtypes.AccessListTx{
+			To:         args.To,
+			ChainID:    (*big.Int)(args.ChainID),
+			Nonce:      uint64(*args.Nonce),
+			Gas:        uint64(*args.Gas),
+			GasPrice:   (*big.Int)(args.GasPrice),
+			Value:      (*big.Int)(args.Value),
+			Data:       args.GetData(),
+			AccessList: *args.AccessList,
+		}
+	default:
+		data = &ethtypes.LegacyTx{
+			To:       args.To,
+			Nonce:    uint64(*args.Nonce),
+			Gas:      uint64(*args.Gas),
+			GasPrice: (*big.Int)(args.GasPrice),
+			Value:    (*big.Int)(args.Value),
+			Data:     args.GetData(),
+		}
+	}
+	return ethtypes.NewTx(data)
+}
+
 // Header represents a block header in the Ethereum blockchain.
 type Header struct {
 	ParentHash  common.Hash         `json:"parentHash"       gencodec:"required"`
@@ -70,6 +142,7 @@ type Block struct {
 	Uncles           []common.Hash       `json:"uncles"`
 	ReceiptsRoot     common.Hash         `json:"receiptsRoot"`
 	Transactions     []interface{}       `json:"transactions"`
+	BaseFeePerGas    *hexutil.Big        `json:"baseFeePerGas,omitempty"`
 }
 
 // Transaction represents a transaction returned to RPC clients.
@@ -88,6 +161,23 @@ type Transaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *common.Hash    `json:"r"`
 	S                *common.Hash    `json:"s"`
+
+	// EIP-2718 typed transaction fields
+	Type       hexutil.Uint64       `json:"type"`
+	ChainID    *hexutil.Big         `json:"chainId,omitempty"`
+	GasFeeCap  *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	GasTipCap  *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList *ethtypes.AccessList `json:"accessList,omitempty"`
+}
+
+// NativeTransaction represents a pending native OneLedger transaction returned to RPC clients.
+type NativeTransaction struct {
+	Hash     common.Hash    `json:"hash"`
+	Type     string         `json:"type"`
+	From     common.Address `json:"from"`
+	Gas      hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big   `json:"gasPrice"`
+	Memo     string         `json:"memo"`
 }
 
 // TransactionReceipt represents a mined transaction returned to RPC clients.
@@ -104,6 +194,10 @@ type TransactionReceipt struct {
 	ContractAddress *common.Address `json:"contractAddress"`
 	GasUsed         hexutil.Uint64  `json:"gasUsed"`
 
+	// price per gas the sender paid, and the EIP-2718 type of the transaction
+	EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+	Type              hexutil.Uint64 `json:"type"`
+
 	// Inclusion information: These fields provide information about the inclusion of the
 	// transaction corresponding to this receipt.
 	BlockHash        common.Hash    `json:"blockHash"`
@@ -114,3 +208,11 @@ type TransactionReceipt struct {
 	From common.Address  `json:"from"`
 	To   *common.Address `json:"to"`
 }
+
+// FeeHistory represents the fee market of a range of blocks returned to RPC clients.
+type FeeHistory struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
@@ -47,6 +47,8 @@ type LogReceipt struct {
 	Logs            []*ethtypes.Log
 	Status          uint64
 	ContractAddress *common.Address
+	// nil for the transactions run before the price was logged
+	EffectiveGasPrice *big.Int
 }
 
 // GetTxEthLogs substract logs from deliver response
@@ -73,6 +75,8 @@ func GetTxEthLogs(res *abci.ResponseDeliverTx, txIndex uint32) *LogReceipt {
 				if status, err := strconv.Atoi(string(attr.Value)); err == nil {
 					lr.Status = uint64(status)
 				}
+			} else if bytes.Equal(attr.Key, []byte("tx.gasPrice")) {
+				lr.EffectiveGasPrice = new(big.Int).SetBytes(attr.Value)
 			}
 		}
 	}
@@ -108,6 +112,10 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 			Amount  *action.Amount `json:"amount"`
 			Data    []byte         `json:"data"`
 			Nonce   uint64         `json:"nonce"`
+			// typed transactions
+			TxType     int64                `json:"type"`
+			AccessList *ethtypes.AccessList `json:"accessList"`
+			GasTipCap  *big.Int             `json:"gasTipCap"`
 		}{}
 		nonce       hexutil.Uint64
 		blockNumber *hexutil.Big
@@ -157,6 +165,10 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 	}
 
 	err = jsonSerializer.Deserialize(lTx.Data, &unpackedData)
+	if err == nil && unpackedData.TxType != ethtypes.LegacyTxType && v != nil {
+		// typed transactions carry the bare recovery id
+		v.Sub(v, big.NewInt(27))
+	}
 	if err == nil {
 		if unpackedData.To != nil {
 			to = new(common.Address)
@@ -174,7 +186,7 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 		nonce = hexutil.Uint64(unpackedData.Nonce)
 	}
 
-	return &Transaction{
+	ethTx := &Transaction{
 		BlockHash:        blockHash,
 		BlockNumber:      blockNumber,
 		From:             from,
@@ -189,7 +201,20 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 		V:                (*hexutil.Big)(v),
 		R:                r,
 		S:                s,
-	}, nil
+		Type:             hexutil.Uint64(unpackedData.TxType),
+	}
+	if unpackedData.TxType != ethtypes.LegacyTxType {
+		ethTx.ChainID = (*hexutil.Big)(unpackedData.ChainID)
+		ethTx.AccessList = unpackedData.AccessList
+		if ethTx.AccessList == nil {
+			ethTx.AccessList = &ethtypes.AccessList{}
+		}
+	}
+	if unpackedData.TxType == ethtypes.DynamicFeeTxType {
+		ethTx.GasFeeCap = ethTx.GasPrice
+		ethTx.GasTipCap = (*hexutil.Big)(unpackedData.GasTipCap)
+	}
+	return ethTx, nil
 }
 
 // ParseLegacyTx is used to parse the signed tx for old OneLedger tx types
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+)
+
+// NewStateDB returns a state db of the context on the state
+func NewStateDB(ctx rpctypes.Web3Context, state *storage.State, logger *log.Logger) *vm.CommitStateDB {
+	stateDB := vm.NewCommitStateDB(ctx.GetContractStore(), ctx.GetAccountKeeper(), logger)
+	stateDB.WithState(state)
+	stateDB.SetBlockStore(ctx.GetBlockStore())
+	return stateDB
+}
+
+// StateDBAt returns a state db on the state after the block at the height, its changes are never committed
+func StateDBAt(ctx rpctypes.Web3Context, height int64, logger *log.Logger) (*vm.CommitStateDB, error) {
+	state, err := ctx.GetStateAt(height)
+	if err != nil {
+		return nil, fmt.Errorf("state of block #%d is not available: %w", height, err)
+	}
+	return NewStateDB(ctx, state, logger), nil
+}
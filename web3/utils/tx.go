@@ -11,34 +11,13 @@ import (
 	"github.com/tendermint/tendermint/mempool"
 )
 
-// GetPendingTxCountByAddress is used to get pending tx count (nonce) for user address
-// NOTE: Working right now only with legacy tx
-func GetPendingTxCountByAddress(mem mempool.Mempool, address common.Address) (total uint64) {
-	for _, tx := range mem.ReapMaxTxs(50) {
-		lTx, err := rpctypes.ParseLegacyTx(tx)
-		if err != nil {
-			// means tx is not legacy and we need to check is tx is ethereum
-			// TODO: Add ethereum tx check when it will be released
-			continue
-		}
-		// This is only for legacy tx
-		for _, sig := range lTx.Signatures {
-			pubKeyHandler, err := sig.Signer.GetHandler()
-			if err != nil {
-				continue
-			}
-			// match if signer is a user
-			if pubKeyHandler.Address().Equal(address.Bytes()) {
-				total++
-			}
-		}
-	}
-	return
-}
+// MaxPoolTxs is the number of transactions of the mempool the web3 services look at, the first ones
+// in mempool order
+const MaxPoolTxs = 5000
 
 // GetPendingTx search for tx in pool
 func GetPendingTx(mem mempool.Mempool, hash common.Hash, chainID *big.Int) (*rpctypes.Transaction, error) {
-	for _, uTx := range mem.ReapMaxTxs(50) {
+	for _, uTx := range mem.ReapMaxTxs(MaxPoolTxs) {
 		if bytes.Equal(uTx.Hash(), hash.Bytes()) {
 			return rpctypes.LegacyRawBlockAndTxToEthTx(nil, &uTx, chainID, nil)
 		}
@@ -49,7 +28,7 @@ func GetPendingTx(mem mempool.Mempool, hash common.Hash, chainID *big.Int) (*rpc
 // GetPendingTransactions search for txs in pool
 func GetPendingTxs(mem mempool.Mempool, chainID *big.Int) ([]*rpctypes.Transaction, error) {
 	transactions := make([]*rpctypes.Transaction, 0, 50)
-	for _, uTx := range mem.ReapMaxTxs(50) {
+	for _, uTx := range mem.ReapMaxTxs(MaxPoolTxs) {
 		tx, err := rpctypes.LegacyRawBlockAndTxToEthTx(nil, &uTx, chainID, nil)
 		if err != nil {
 			continue
@@ -61,7 +40,7 @@ func GetPendingTxs(mem mempool.Mempool, chainID *big.Int) ([]*rpctypes.Transacti
 
 // GetPendingTxsWithCallback search for txs in pool and return in callback form
 func GetPendingTxsWithCallback(mem mempool.Mempool, chainID *big.Int, callback func(tx *rpctypes.Transaction) bool) error {
-	for _, uTx := range mem.ReapMaxTxs(50) {
+	for _, uTx := range mem.ReapMaxTxs(MaxPoolTxs) {
 		tx, err := rpctypes.LegacyRawBlockAndTxToEthTx(nil, &uTx, chainID, nil)
 		if err != nil {
 			continue
@@ -10,6 +10,7 @@ import (
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/utils"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -17,16 +18,22 @@ import (
 var (
 	jsonSerializer = serialize.GetSerializer(serialize.NETWORK)
 	big8           = big.NewInt(8)
+	big27          = big.NewInt(27)
 )
 
 func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
 	chainId := tx.ChainId()
 
-	signer := ethtypes.NewEIP155Signer(chainId)
-	chainIdMul := new(big.Int).Mul(chainId, big.NewInt(2))
+	signer := ethtypes.NewLondonSigner(chainId)
 	V, R, S := tx.RawSignatureValues()
-	V = new(big.Int).Sub(V, chainIdMul)
-	V.Sub(V, big8)
+	if tx.Type() == ethtypes.LegacyTxType {
+		chainIdMul := new(big.Int).Mul(chainId, big.NewInt(2))
+		V = new(big.Int).Sub(V, chainIdMul)
+		V.Sub(V, big8)
+	} else {
+		// typed transactions carry the bare recovery id
+		V = new(big.Int).Add(V, big27)
+	}
 
 	sighash := signer.Hash(tx)
 	pub, err := utils.RecoverPlain(sighash, R, S, V, true)
@@ -45,9 +52,9 @@ func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
 
 	sigs := []action.Signature{{Signer: pubKey, Signed: utils.ToUncompressedSig(R, S, V)}}
 
-	// fee setting
+	// fee setting, the fee cap for dynamic fee transactions
 	gas := int64(tx.Gas())
-	gasPrice := tx.GasPrice()
+	gasPrice := tx.GasFeeCap()
 	price := action.NewAmount(action.DEFAULT_CURRENCY, balance.Amount(*gasPrice))
 	fee := action.Fee{Price: *price, Gas: gas}
 
@@ -66,6 +73,20 @@ func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
 		Data:    tx.Data(),
 		Nonce:   tx.Nonce(),
 		ChainID: tx.ChainId(),
+		TxType:  int64(tx.Type()),
+	}
+	if tx.Type() != ethtypes.LegacyTxType {
+		accessList := tx.AccessList()
+		for i := range accessList {
+			// storage keys are required when the list is decoded back from json
+			if accessList[i].StorageKeys == nil {
+				accessList[i].StorageKeys = []ethcmn.Hash{}
+			}
+		}
+		msg.AccessList = &accessList
+	}
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		msg.GasTipCap = tx.GasTipCap()
 	}
 	data, err := msg.Marshal()
 	if err != nil {
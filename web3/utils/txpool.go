@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/Oneledger/protocol/action"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/tendermint/tendermint/mempool"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// TxPool is the content of the mempool by sender. The olvm transactions of a sender are pending when
+// their nonces follow its nonce in the state, the others wait for a gap to be filled and are queued.
+// Native transactions have no nonce, they are all pending
+type TxPool struct {
+	Pending map[common.Address][]*rpctypes.Transaction
+	Queued  map[common.Address][]*rpctypes.Transaction
+	Native  map[common.Address][]*rpctypes.NativeTransaction
+}
+
+// txSender returns the sender of a transaction, the signer of its first signature
+func txSender(lTx *action.SignedTx) (common.Address, bool) {
+	if len(lTx.Signatures) == 0 {
+		return common.Address{}, false
+	}
+	pubKeyHandler, err := lTx.Signatures[0].Signer.GetHandler()
+	if err != nil {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(pubKeyHandler.Address().Bytes()), true
+}
+
+// iteratePoolTxs calls back with every transaction of the mempool that could be parsed, of the sender
+// only when one is given. The eth form is set only for olvm transactions
+func iteratePoolTxs(mem mempool.Mempool, chainID *big.Int, sender *common.Address, callback func(uTx tmtypes.Tx, lTx *action.SignedTx, tx *rpctypes.Transaction)) {
+	for _, uTx := range mem.ReapMaxTxs(MaxPoolTxs) {
+		lTx, err := rpctypes.ParseLegacyTx(uTx)
+		if err != nil {
+			continue
+		}
+		if sender != nil {
+			if from, ok := txSender(lTx); !ok || from != *sender {
+				continue
+			}
+		}
+		var tx *rpctypes.Transaction
+		if lTx.Type == action.OLVM {
+			tx, err = rpctypes.LegacyRawBlockAndTxToEthTx(nil, &uTx, chainID, nil)
+			if err != nil {
+				continue
+			}
+		}
+		callback(uTx, lTx, tx)
+	}
+}
+
+// GetTxPool sorts out the transactions of the mempool, nonceOf gives the nonce of a sender in the state
+func GetTxPool(mem mempool.Mempool, chainID *big.Int, nonceOf func(address common.Address) uint64) *TxPool {
+	pool := &TxPool{
+		Pending: make(map[common.Address][]*rpctypes.Transaction),
+		Queued:  make(map[common.Address][]*rpctypes.Transaction),
+		Native:  make(map[common.Address][]*rpctypes.NativeTransaction),
+	}
+
+	senders := make(map[common.Address][]*rpctypes.Transaction)
+	iteratePoolTxs(mem, chainID, nil, func(uTx tmtypes.Tx, lTx *action.SignedTx, tx *rpctypes.Transaction) {
+		if tx != nil {
+			senders[tx.From] = append(senders[tx.From], tx)
+			return
+		}
+		nTx := &rpctypes.NativeTransaction{
+			Hash:     common.BytesToHash(uTx.Hash()),
+			Type:     lTx.Type.String(),
+			Gas:      hexutil.Uint64(lTx.Fee.Gas),
+			GasPrice: (*hexutil.Big)(&lTx.Fee.Price.Value),
+			Memo:     lTx.Memo,
+		}
+		nTx.From, _ = txSender(lTx)
+		pool.Native[nTx.From] = append(pool.Native[nTx.From], nTx)
+	})
+
+	for from, txs := range senders {
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].Nonce < txs[j].Nonce
+		})
+		next := nonceOf(from)
+		for _, tx := range txs {
+			if uint64(tx.Nonce) == next {
+				pool.Pending[from] = append(pool.Pending[from], tx)
+				next++
+				continue
+			}
+			pool.Queued[from] = append(pool.Queued[from], tx)
+		}
+	}
+	return pool
+}
+
+// GetPendingNonce returns the nonce of the next transaction of the address, the nonce in the state
+// raised by the pending olvm transactions of the address in the mempool
+func GetPendingNonce(mem mempool.Mempool, chainID *big.Int, address common.Address, nonce uint64) uint64 {
+	nonces := make(map[uint64]struct{})
+	iteratePoolTxs(mem, chainID, &address, func(_ tmtypes.Tx, _ *action.SignedTx, tx *rpctypes.Transaction) {
+		if tx != nil {
+			nonces[uint64(tx.Nonce)] = struct{}{}
+		}
+	})
+	for {
+		if _, ok := nonces[nonce]; !ok {
+			return nonce
+		}
+		nonce++
+	}
+}